@@ -2,10 +2,16 @@ module github.com/96solutions/neurography
 
 go 1.22
 
-require go.uber.org/mock v0.4.0
+require (
+	github.com/fsnotify/fsnotify v1.8.0
+	github.com/mattn/go-sqlite3 v1.14.22
+	go.uber.org/mock v0.4.0
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	golang.org/x/mod v0.11.0 // indirect
-	golang.org/x/sys v0.1.0 // indirect
-	golang.org/x/tools v0.2.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/tools v0.7.0 // indirect
 )
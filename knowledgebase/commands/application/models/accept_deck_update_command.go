@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+// AcceptDeckUpdateCommand represents input of the accept models.DeckUpdate usecase,
+// SourceID is the ID of the item of the deck.
+type AcceptDeckUpdateCommand struct {
+	WorkspaceID    int64 `json:"workspace_id,omitempty"`
+	SubscriptionID int64 `json:"subscription_id"`
+	SourceID       int64 `json:"source_id"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_accept_deck_update_presenter.go -source=accept_deck_update_presenter.go AcceptDeckUpdatePresenter
+
+// AcceptDeckUpdatePresenter represents output presenter of the accept models.DeckUpdate usecase.
+type AcceptDeckUpdatePresenter interface {
+	SetResult(item *models.KnowledgeItem)
+}
@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// AcceptWorkspaceInvitationCommand represents input of the accept models.WorkspaceInvitation usecase.
+type AcceptWorkspaceInvitationCommand struct {
+	ID int64 `json:"id"`
+}
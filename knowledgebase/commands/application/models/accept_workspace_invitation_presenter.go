@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_accept_workspace_invitation_presenter.go -source=accept_workspace_invitation_presenter.go AcceptWorkspaceInvitationPresenter
+
+// AcceptWorkspaceInvitationPresenter represents output presenter of the accept models.WorkspaceInvitation usecase.
+type AcceptWorkspaceInvitationPresenter interface {
+	SetResult(member *models.WorkspaceMember)
+}
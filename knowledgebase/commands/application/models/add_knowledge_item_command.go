@@ -2,10 +2,13 @@
 package models
 
 // AddKnowledgeItemCommand represents input of the add new models.KnowledgeItem usecase.
+// Categories are looked up among the ones of the workspace when the item is added to the workspace.
 type AddKnowledgeItemCommand struct {
 	Title      string   `json:"title"`
 	Anchor     string   `json:"anchor"`
 	Data       string   `json:"data"`
 	Tags       []string `json:"tags"`
 	Categories []string `json:"categories"`
+	// WorkspaceID is the workspace the item belongs to, zero is the personal knowledge base of the user.
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
 }
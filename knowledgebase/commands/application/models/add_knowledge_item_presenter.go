@@ -7,6 +7,8 @@ import (
 //go:generate mockgen -package=mock -destination=../../mock/mock_add_knowledge_item_presenter.go -source=add_knowledge_item_presenter.go AddKnowledgeItemPresenter
 
 // AddKnowledgeItemPresenter represents output presenter of the add models.KnowledgeItem usecase.
+// SetDuplicates warns about existing items similar to the new one, it is called only when there are any.
 type AddKnowledgeItemPresenter interface {
 	SetResult(item *models.KnowledgeItem)
+	SetDuplicates(duplicates []*models.Duplicate)
 }
@@ -0,0 +1,74 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+const (
+	ankiEaseAgain = 1
+	ankiEaseHard  = 2
+	ankiEaseGood  = 3
+	ankiEaseEasy  = 4
+)
+
+// AnkiPackage represents content of the Anki deck package (.apkg) in terms of the knowledge base.
+type AnkiPackage struct {
+	Notes []*AnkiNote `json:"notes"`
+	Media []string    `json:"media,omitempty"`
+}
+
+// AnkiNote represents one note of the basic Anki note type.
+// Front and Back contain plain text, HTML markup is handled by the package adapters.
+// Title and ReviewState are set only for the notes written by the export, so the knowledge items survive
+// the round trip, other notes have no title and their review state is replayed from Reviews.
+type AnkiNote struct {
+	ID          int64            `json:"id"`
+	Deck        string           `json:"deck"`
+	Title       string           `json:"title,omitempty"`
+	Front       string           `json:"front"`
+	Back        string           `json:"back"`
+	Tags        []string         `json:"tags,omitempty"`
+	Reviews     []*AnkiReview    `json:"reviews,omitempty"`
+	ReviewState *AnkiReviewState `json:"review_state,omitempty"`
+}
+
+// AnkiReviewState represents score and the last mark of the knowledge item, the time of the last check
+// is the time of the last review. Anki answer buttons are coarser than the marks, so the state travels
+// along with the note to be restored as it was.
+type AnkiReviewState struct {
+	Score    int64 `json:"score"`
+	LastMark int64 `json:"last_mark"`
+}
+
+// AnkiReview represents one entry of the Anki review log.
+type AnkiReview struct {
+	Ease       int64     `json:"ease"`
+	ReviewedAt time.Time `json:"reviewed_at"`
+}
+
+// Mark function converts Anki answer button (1-4) to the knowledge item mark (0-10).
+func (r *AnkiReview) Mark() int64 {
+	switch r.Ease {
+	case ankiEaseAgain:
+		return 0
+	case ankiEaseHard:
+		return 4
+	case ankiEaseGood:
+		return 7
+	default:
+		return 10
+	}
+}
+
+// AnkiEaseFromMark function converts knowledge item mark (0-10) to the closest Anki answer button (1-4).
+func AnkiEaseFromMark(mark int64) int64 {
+	switch {
+	case mark <= 1:
+		return ankiEaseAgain
+	case mark <= 5:
+		return ankiEaseHard
+	case mark <= 8:
+		return ankiEaseGood
+	default:
+		return ankiEaseEasy
+	}
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_anki_package_reader.go -source=anki_package_reader.go AnkiPackageReader
+
+// AnkiPackageReader represents a source of the Anki deck packages.
+type AnkiPackageReader interface {
+	Read(path string) (*AnkiPackage, error)
+}
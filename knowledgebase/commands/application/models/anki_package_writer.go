@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_anki_package_writer.go -source=anki_package_writer.go AnkiPackageWriter
+
+// AnkiPackageWriter represents a destination of the Anki deck packages.
+type AnkiPackageWriter interface {
+	Write(path string, pkg *AnkiPackage) error
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_approve_edit_presenter.go -source=approve_edit_presenter.go ApproveEditPresenter
+
+// ApproveEditPresenter represents output presenter of the approve models.SuggestedEdit usecase.
+type ApproveEditPresenter interface {
+	SetResult(edit *models.SuggestedEdit, item *models.KnowledgeItem)
+}
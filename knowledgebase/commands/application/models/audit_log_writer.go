@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_audit_log_writer.go -source=audit_log_writer.go AuditLogWriter
+
+// AuditLogWriter represents a destination of the exported audit log.
+type AuditLogWriter interface {
+	Write(path string, entries []*models.AuditEntry) error
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_autocomplete_index.go -source=autocomplete_index.go AutocompleteIndex
+
+// AutocompleteIndex represents index of the tags and category names in use.
+type AutocompleteIndex interface {
+	Suggest(query *AutocompleteQuery) ([]*AutocompleteSuggestion, error)
+}
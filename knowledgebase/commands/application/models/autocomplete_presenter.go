@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_autocomplete_presenter.go -source=autocomplete_presenter.go AutocompletePresenter
+
+// AutocompletePresenter represents output presenter of the autocomplete usecase.
+type AutocompletePresenter interface {
+	SetResult(suggestions []*AutocompleteSuggestion)
+}
@@ -0,0 +1,21 @@
+// Package models contains representations of requests and events.
+package models
+
+// Kinds of the autocompleted values.
+const (
+	AutocompleteKindTag      = "tag"
+	AutocompleteKindCategory = "category"
+)
+
+// AutocompleteQuery represents input of the autocomplete usecase.
+// Kind narrows suggestions down to tags or categories, both are suggested when it is empty.
+// Prefix is matched ignoring case and punctuation and tolerates typos, so "golnag" suggests "golang".
+// Owner is set by the usecase to the acting user or the workspace, values of other owners are never suggested.
+type AutocompleteQuery struct {
+	// WorkspaceID is the workspace to suggest the values of, zero is the personal knowledge base of the user.
+	WorkspaceID int64  `json:"workspace_id,omitempty"`
+	Owner       string `json:"-"`
+	Prefix      string `json:"prefix"`
+	Kind        string `json:"kind,omitempty"`
+	Limit       int    `json:"limit"`
+}
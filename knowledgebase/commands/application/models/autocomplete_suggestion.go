@@ -0,0 +1,12 @@
+// Package models contains representations of requests and events.
+package models
+
+// AutocompleteSuggestion represents existing tag or category name matching the prefix.
+// Count is the number of knowledge items using the value, Distance is the number of typos
+// in the prefix, exact prefix matches have zero distance.
+type AutocompleteSuggestion struct {
+	Value    string `json:"value"`
+	Kind     string `json:"kind"`
+	Count    int    `json:"count"`
+	Distance int    `json:"distance"`
+}
@@ -0,0 +1,30 @@
+// Package models contains representations of requests and events.
+package models
+
+// Supported formats of the bulk import content.
+const (
+	TableFormatCSV = "csv"
+	TableFormatTSV = "tsv"
+)
+
+// BulkImportKnowledgeItemsCommand represents input of the bulk import models.KnowledgeItem usecase.
+// Content is a table with the header row, columns are picked by the mapping.
+type BulkImportKnowledgeItemsCommand struct {
+	Content   string         `json:"content"`
+	Format    string         `json:"format"`
+	Mapping   *ColumnMapping `json:"mapping"`
+	DryRun    bool           `json:"dry_run"`
+	BatchSize int            `json:"batch_size"`
+}
+
+// ColumnMapping represents names of the table columns which hold models.KnowledgeItem fields.
+// Tags and Categories columns are optional and may hold several values joined by the separator.
+type ColumnMapping struct {
+	Title               string `json:"title"`
+	Anchor              string `json:"anchor"`
+	Data                string `json:"data"`
+	Tags                string `json:"tags"`
+	TagsSeparator       string `json:"tags_separator"`
+	Categories          string `json:"categories"`
+	CategoriesSeparator string `json:"categories_separator"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_bulk_import_knowledge_items_presenter.go -source=bulk_import_knowledge_items_presenter.go BulkImportKnowledgeItemsPresenter
+
+// BulkImportKnowledgeItemsPresenter represents output presenter of the bulk import models.KnowledgeItem usecase.
+type BulkImportKnowledgeItemsPresenter interface {
+	SetResult(report *BulkImportReport)
+}
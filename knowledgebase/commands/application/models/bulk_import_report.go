@@ -0,0 +1,26 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// Statuses of the bulk import rows.
+const (
+	RowStatusValid    = "valid"
+	RowStatusInvalid  = "invalid"
+	RowStatusImported = "imported"
+)
+
+// BulkImportReport represents outcome of the bulk import usecase for every row of the table.
+type BulkImportReport struct {
+	DryRun bool         `json:"dry_run"`
+	Rows   []*RowReport `json:"rows"`
+}
+
+// RowReport represents outcome of the bulk import for one table row.
+// Row is the line number in the table, the header is the first row.
+type RowReport struct {
+	Row    int                   `json:"row"`
+	Status string                `json:"status"`
+	Reason string                `json:"reason,omitempty"`
+	Item   *models.KnowledgeItem `json:"item,omitempty"`
+}
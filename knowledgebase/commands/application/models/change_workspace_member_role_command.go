@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// ChangeWorkspaceMemberRoleCommand represents input of the change role of models.WorkspaceMember usecase.
+type ChangeWorkspaceMemberRoleCommand struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Login       string `json:"login"`
+	Role        string `json:"role"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_change_workspace_member_role_presenter.go -source=change_workspace_member_role_presenter.go ChangeWorkspaceMemberRolePresenter
+
+// ChangeWorkspaceMemberRolePresenter represents output presenter of the change role of models.WorkspaceMember usecase.
+type ChangeWorkspaceMemberRolePresenter interface {
+	SetResult(member *models.WorkspaceMember)
+}
@@ -0,0 +1,12 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// CreateAPITokenCommand represents input of the create models.APIToken usecase.
+// Token without ExpiresAt never expires.
+type CreateAPITokenCommand struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
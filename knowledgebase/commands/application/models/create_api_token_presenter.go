@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_create_api_token_presenter.go -source=create_api_token_presenter.go CreateAPITokenPresenter
+
+// CreateAPITokenPresenter represents output presenter of the create models.APIToken usecase.
+type CreateAPITokenPresenter interface {
+	SetResult(issued *IssuedAPIToken)
+}
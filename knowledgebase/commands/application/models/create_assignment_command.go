@@ -0,0 +1,15 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// CreateAssignmentCommand represents input of the create models.Assignment usecase,
+// the items and categories of the workspace are given to its members listed in Learners.
+type CreateAssignmentCommand struct {
+	WorkspaceID int64      `json:"workspace_id"`
+	Title       string     `json:"title"`
+	ItemIDs     []int64    `json:"item_ids,omitempty"`
+	CategoryIDs []int64    `json:"category_ids,omitempty"`
+	Learners    []string   `json:"learners"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_create_assignment_presenter.go -source=create_assignment_presenter.go CreateAssignmentPresenter
+
+// CreateAssignmentPresenter represents output presenter of the create models.Assignment usecase.
+type CreateAssignmentPresenter interface {
+	SetResult(assignment *models.Assignment)
+}
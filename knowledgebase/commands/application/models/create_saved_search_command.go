@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// CreateSavedSearchCommand represents input of the create models.SavedSearch usecase.
+// Filter is written in the query language of the filter package.
+type CreateSavedSearchCommand struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_create_saved_search_presenter.go -source=create_saved_search_presenter.go CreateSavedSearchPresenter
+
+// CreateSavedSearchPresenter represents output presenter of the create models.SavedSearch usecase.
+type CreateSavedSearchPresenter interface {
+	SetResult(collection *SmartCollection)
+}
@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+// CreateWebhookCommand represents input of the create models.Webhook usecase,
+// the webhook receives the listed events of the workspace or of the acting user.
+type CreateWebhookCommand struct {
+	WorkspaceID int64    `json:"workspace_id,omitempty"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+}
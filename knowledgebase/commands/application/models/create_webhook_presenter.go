@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_create_webhook_presenter.go -source=create_webhook_presenter.go CreateWebhookPresenter
+
+// CreateWebhookPresenter represents output presenter of the create models.Webhook usecase.
+type CreateWebhookPresenter interface {
+	SetResult(issued *IssuedWebhook)
+}
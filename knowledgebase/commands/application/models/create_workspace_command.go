@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// CreateWorkspaceCommand represents input of the create models.Workspace usecase.
+type CreateWorkspaceCommand struct {
+	Name string `json:"name"`
+}
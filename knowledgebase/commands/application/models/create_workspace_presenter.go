@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_create_workspace_presenter.go -source=create_workspace_presenter.go CreateWorkspacePresenter
+
+// CreateWorkspacePresenter represents output presenter of the create models.Workspace usecase.
+type CreateWorkspacePresenter interface {
+	SetResult(workspace *models.Workspace)
+}
@@ -4,4 +4,6 @@ package models
 // DeleteKnowledgeItemCommand represents input of the delete models.KnowledgeItem usecase.
 type DeleteKnowledgeItemCommand struct {
 	ID int64 `json:"id"`
+	// WorkspaceID is the workspace the item belongs to, zero is the personal knowledge base of the user.
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
 }
@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// DeleteSavedSearchCommand represents input of the delete models.SavedSearch usecase.
+type DeleteSavedSearchCommand struct {
+	ID int64 `json:"id"`
+}
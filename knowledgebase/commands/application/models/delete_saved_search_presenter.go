@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_delete_saved_search_presenter.go -source=delete_saved_search_presenter.go DeleteSavedSearchPresenter
+
+// DeleteSavedSearchPresenter represents output presenter of the delete models.SavedSearch usecase.
+type DeleteSavedSearchPresenter interface {
+	SetResult(bool)
+}
@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// DeleteTagCommand represents input of the delete tag usecase.
+// The tag is removed from all the knowledge items, the items themselves are kept.
+type DeleteTagCommand struct {
+	Tag string `json:"tag"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_delete_tag_presenter.go -source=delete_tag_presenter.go DeleteTagPresenter
+
+// DeleteTagPresenter represents output presenter of the delete tag usecase.
+type DeleteTagPresenter interface {
+	SetResult(bool)
+}
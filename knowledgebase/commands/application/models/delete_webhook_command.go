@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// DeleteWebhookCommand represents input of the delete models.Webhook usecase.
+type DeleteWebhookCommand struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	ID          int64 `json:"id"`
+}
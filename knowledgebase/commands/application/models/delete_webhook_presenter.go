@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_delete_webhook_presenter.go -source=delete_webhook_presenter.go DeleteWebhookPresenter
+
+// DeleteWebhookPresenter represents output presenter of the delete models.Webhook usecase.
+type DeleteWebhookPresenter interface {
+	SetResult(bool)
+}
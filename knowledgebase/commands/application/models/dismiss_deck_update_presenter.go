@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_dismiss_deck_update_presenter.go -source=dismiss_deck_update_presenter.go DismissDeckUpdatePresenter
+
+// DismissDeckUpdatePresenter represents output presenter of the dismiss models.DeckUpdate usecase.
+type DismissDeckUpdatePresenter interface {
+	SetResult(bool)
+}
@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// EnableWebhookCommand represents input of the enable models.Webhook usecase.
+type EnableWebhookCommand struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	ID          int64 `json:"id"`
+}
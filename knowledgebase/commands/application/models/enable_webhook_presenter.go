@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_enable_webhook_presenter.go -source=enable_webhook_presenter.go EnableWebhookPresenter
+
+// EnableWebhookPresenter represents output presenter of the enable models.Webhook usecase.
+type EnableWebhookPresenter interface {
+	SetResult(webhook *models.Webhook)
+}
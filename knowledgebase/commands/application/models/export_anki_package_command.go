@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+// ExportAnkiPackageCommand represents input of the export Anki deck package usecase.
+// Whole knowledge base is exported when no categories are given.
+type ExportAnkiPackageCommand struct {
+	Path              string   `json:"path"`
+	Categories        []string `json:"categories"`
+	WithReviewHistory bool     `json:"with_review_history"`
+}
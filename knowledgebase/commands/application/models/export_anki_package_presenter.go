@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_export_anki_package_presenter.go -source=export_anki_package_presenter.go ExportAnkiPackagePresenter
+
+// ExportAnkiPackagePresenter represents output presenter of the export Anki deck package usecase.
+type ExportAnkiPackagePresenter interface {
+	SetResult(pkg *AnkiPackage)
+}
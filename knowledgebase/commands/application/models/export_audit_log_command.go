@@ -0,0 +1,16 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// ExportAuditLogCommand represents input of the export audit log usecase,
+// the entries are selected the same way SearchAuditLogQuery does.
+// Path is the name of the file within the export directory, paths leading out of it are refused.
+type ExportAuditLogCommand struct {
+	Path        string     `json:"path"`
+	WorkspaceID int64      `json:"workspace_id,omitempty"`
+	Actor       string     `json:"actor,omitempty"`
+	ItemID      int64      `json:"item_id,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	To          *time.Time `json:"to,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_export_audit_log_presenter.go -source=export_audit_log_presenter.go ExportAuditLogPresenter
+
+// ExportAuditLogPresenter represents output presenter of the export audit log usecase.
+type ExportAuditLogPresenter interface {
+	SetResult(entries []*models.AuditEntry)
+}
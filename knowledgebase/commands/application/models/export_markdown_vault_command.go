@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// ExportMarkdownVaultCommand represents input of the export Markdown vault usecase.
+// Whole knowledge base is exported when no categories are given.
+type ExportMarkdownVaultCommand struct {
+	Directory  string   `json:"directory"`
+	Categories []string `json:"categories"`
+}
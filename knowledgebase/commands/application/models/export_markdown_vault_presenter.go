@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_export_markdown_vault_presenter.go -source=export_markdown_vault_presenter.go ExportMarkdownVaultPresenter
+
+// ExportMarkdownVaultPresenter represents output presenter of the export Markdown vault usecase.
+type ExportMarkdownVaultPresenter interface {
+	SetResult(notes []*MarkdownNote)
+}
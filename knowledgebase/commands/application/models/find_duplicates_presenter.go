@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_find_duplicates_presenter.go -source=find_duplicates_presenter.go FindDuplicatesPresenter
+
+// FindDuplicatesPresenter represents output presenter of the find duplicates usecase.
+type FindDuplicatesPresenter interface {
+	SetResult(pairs []*models.DuplicatePair)
+}
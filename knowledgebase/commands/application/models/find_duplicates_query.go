@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// FindDuplicatesQuery represents input of the find duplicates usecase.
+// MinScore narrows the report down to the more similar pairs, the default threshold of the service applies otherwise.
+type FindDuplicatesQuery struct {
+	MinScore float64 `json:"min_score"`
+}
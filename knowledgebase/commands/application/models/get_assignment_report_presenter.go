@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_get_assignment_report_presenter.go -source=get_assignment_report_presenter.go GetAssignmentReportPresenter
+
+// GetAssignmentReportPresenter represents output presenter of the get models.AssignmentReport usecase.
+type GetAssignmentReportPresenter interface {
+	SetResult(report *models.AssignmentReport)
+}
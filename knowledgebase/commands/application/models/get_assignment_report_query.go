@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// GetAssignmentReportQuery represents input of the get models.AssignmentReport usecase.
+type GetAssignmentReportQuery struct {
+	WorkspaceID int64 `json:"workspace_id"`
+	ID          int64 `json:"id"`
+}
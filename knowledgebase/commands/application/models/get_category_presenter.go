@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_get_category_presenter.go -source=get_category_presenter.go GetCategoryPresenter
+
+// GetCategoryPresenter represents output presenter of the get category usecase.
+type GetCategoryPresenter interface {
+	SetResult(category *models.Category)
+}
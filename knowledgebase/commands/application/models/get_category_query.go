@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// GetCategoryQuery represents input of the get category usecase, the category is requested by its path.
+type GetCategoryQuery struct {
+	// WorkspaceID is the workspace the category belongs to, zero is the personal knowledge base of the user.
+	WorkspaceID int64  `json:"workspace_id,omitempty"`
+	Name        string `json:"name"`
+}
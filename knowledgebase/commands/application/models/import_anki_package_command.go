@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// ImportAnkiPackageCommand represents input of the import Anki deck package usecase.
+type ImportAnkiPackageCommand struct {
+	Path              string `json:"path"`
+	WithReviewHistory bool   `json:"with_review_history"`
+}
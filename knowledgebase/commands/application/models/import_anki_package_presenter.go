@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_import_anki_package_presenter.go -source=import_anki_package_presenter.go ImportAnkiPackagePresenter
+
+// ImportAnkiPackagePresenter represents output presenter of the import Anki deck package usecase.
+type ImportAnkiPackagePresenter interface {
+	SetResult(report *ImportReport)
+}
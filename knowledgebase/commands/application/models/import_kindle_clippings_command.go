@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+// ImportKindleClippingsCommand represents input of the import Kindle clippings usecase.
+// Highlights are always imported, notes and bookmarks only when they are included.
+type ImportKindleClippingsCommand struct {
+	Path             string `json:"path"`
+	IncludeNotes     bool   `json:"include_notes"`
+	IncludeBookmarks bool   `json:"include_bookmarks"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_import_kindle_clippings_presenter.go -source=import_kindle_clippings_presenter.go ImportKindleClippingsPresenter
+
+// ImportKindleClippingsPresenter represents output presenter of the import Kindle clippings usecase.
+type ImportKindleClippingsPresenter interface {
+	SetResult(report *ImportReport)
+}
@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// ImportMarkdownVaultCommand represents input of the import Markdown vault usecase.
+type ImportMarkdownVaultCommand struct {
+	Directory string `json:"directory"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_import_markdown_vault_presenter.go -source=import_markdown_vault_presenter.go ImportMarkdownVaultPresenter
+
+// ImportMarkdownVaultPresenter represents output presenter of the import Markdown vault usecase.
+type ImportMarkdownVaultPresenter interface {
+	SetResult(report *ImportReport)
+}
@@ -0,0 +1,18 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// ImportReport represents outcome of the import usecases.
+// Updated contains existing items which were overwritten by the upserting imports.
+type ImportReport struct {
+	Imported []*models.KnowledgeItem `json:"imported"`
+	Updated  []*models.KnowledgeItem `json:"updated,omitempty"`
+	Skipped  []*SkippedRecord        `json:"skipped"`
+}
+
+// SkippedRecord represents source record which hasn't been imported and the reason why.
+type SkippedRecord struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// InviteWorkspaceMemberCommand represents input of the invite models.WorkspaceMember usecase.
+type InviteWorkspaceMemberCommand struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Login       string `json:"login"`
+	Role        string `json:"role"`
+}
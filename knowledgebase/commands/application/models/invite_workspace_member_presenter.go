@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_invite_workspace_member_presenter.go -source=invite_workspace_member_presenter.go InviteWorkspaceMemberPresenter
+
+// InviteWorkspaceMemberPresenter represents output presenter of the invite models.WorkspaceMember usecase.
+type InviteWorkspaceMemberPresenter interface {
+	SetResult(invitation *models.WorkspaceInvitation)
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// IssuedAPIToken represents newly created API token together with its secret.
+// The secret is returned only once, it has to be passed as the bearer token by the scripts.
+type IssuedAPIToken struct {
+	Token  *models.APIToken `json:"token"`
+	Secret string           `json:"secret"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// IssuedWebhook represents newly created webhook together with its secret.
+// The secret is returned only once, receivers verify signatures of the payloads with it.
+type IssuedWebhook struct {
+	Webhook *models.Webhook `json:"webhook"`
+	Secret  string          `json:"secret"`
+}
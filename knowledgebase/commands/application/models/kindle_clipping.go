@@ -0,0 +1,23 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// Kinds of the Kindle clippings.
+const (
+	ClippingKindHighlight = "highlight"
+	ClippingKindNote      = "note"
+	ClippingKindBookmark  = "bookmark"
+)
+
+// KindleClipping represents one entry of the Kindle "My Clippings.txt" file.
+// Page and Location keep the original notation like "120-125", any of them may be empty.
+type KindleClipping struct {
+	Book     string     `json:"book"`
+	Author   string     `json:"author,omitempty"`
+	Kind     string     `json:"kind"`
+	Page     string     `json:"page,omitempty"`
+	Location string     `json:"location,omitempty"`
+	AddedAt  *time.Time `json:"added_at,omitempty"`
+	Content  string     `json:"content,omitempty"`
+}
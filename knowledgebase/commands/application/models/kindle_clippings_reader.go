@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_kindle_clippings_reader.go -source=kindle_clippings_reader.go KindleClippingsReader
+
+// KindleClippingsReader represents a source of the Kindle clippings.
+type KindleClippingsReader interface {
+	Read(path string) ([]*KindleClipping, error)
+}
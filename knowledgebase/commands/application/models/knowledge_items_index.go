@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_knowledge_items_index.go -source=knowledge_items_index.go KnowledgeItemsIndex
+
+// KnowledgeItemsIndex represents full-text index of the knowledge items.
+type KnowledgeItemsIndex interface {
+	Search(query *SearchKnowledgeItemsQuery) (*SearchResult, error)
+}
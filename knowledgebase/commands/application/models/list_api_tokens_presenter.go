@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_api_tokens_presenter.go -source=list_api_tokens_presenter.go ListAPITokensPresenter
+
+// ListAPITokensPresenter represents output presenter of the list models.APIToken usecase.
+type ListAPITokensPresenter interface {
+	SetResult(tokens []*models.APIToken)
+}
@@ -0,0 +1,6 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListAPITokensQuery represents input of the list models.APIToken usecase,
+// the tokens of the acting user are listed.
+type ListAPITokensQuery struct{}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_assignments_presenter.go -source=list_assignments_presenter.go ListAssignmentsPresenter
+
+// ListAssignmentsPresenter represents output presenter of the list models.Assignment usecase.
+type ListAssignmentsPresenter interface {
+	SetResult(assignments []*models.Assignment)
+}
@@ -0,0 +1,6 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListAssignmentsQuery represents input of the list models.Assignment usecase,
+// the assignments given to the acting user are listed.
+type ListAssignmentsQuery struct{}
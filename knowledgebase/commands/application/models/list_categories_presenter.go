@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_categories_presenter.go -source=list_categories_presenter.go ListCategoriesPresenter
+
+// ListCategoriesPresenter represents output presenter of the list categories usecase.
+type ListCategoriesPresenter interface {
+	SetResult(categories []*models.Category)
+}
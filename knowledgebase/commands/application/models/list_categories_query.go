@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListCategoriesQuery represents input of the list categories usecase, all the categories are listed
+// with their metadata in the order of the tree.
+type ListCategoriesQuery struct {
+	// WorkspaceID is the workspace the categories belong to, zero is the personal knowledge base of the user.
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_deck_subscriptions_presenter.go -source=list_deck_subscriptions_presenter.go ListDeckSubscriptionsPresenter
+
+// ListDeckSubscriptionsPresenter represents output presenter of the list models.DeckSubscription usecase.
+type ListDeckSubscriptionsPresenter interface {
+	SetResult(subscriptions []*models.DeckSubscription)
+}
@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListDeckSubscriptionsQuery represents input of the list models.DeckSubscription usecase.
+type ListDeckSubscriptionsQuery struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_deck_updates_presenter.go -source=list_deck_updates_presenter.go ListDeckUpdatesPresenter
+
+// ListDeckUpdatesPresenter represents output presenter of the list models.DeckUpdate usecase.
+type ListDeckUpdatesPresenter interface {
+	SetResult(updates []*models.DeckUpdate)
+}
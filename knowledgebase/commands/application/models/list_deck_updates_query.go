@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListDeckUpdatesQuery represents input of the list models.DeckUpdate usecase.
+type ListDeckUpdatesQuery struct {
+	WorkspaceID    int64 `json:"workspace_id,omitempty"`
+	SubscriptionID int64 `json:"subscription_id"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_decks_presenter.go -source=list_decks_presenter.go ListDecksPresenter
+
+// ListDecksPresenter represents output presenter of the list models.Deck usecase.
+type ListDecksPresenter interface {
+	SetResult(decks []*models.Deck)
+}
@@ -0,0 +1,5 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListDecksQuery represents input of the list models.Deck usecase, all the published decks are listed.
+type ListDecksQuery struct{}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_notifications_presenter.go -source=list_notifications_presenter.go ListNotificationsPresenter
+
+// ListNotificationsPresenter represents output presenter of the list models.Notification usecase.
+type ListNotificationsPresenter interface {
+	SetResult(notifications []*models.Notification)
+}
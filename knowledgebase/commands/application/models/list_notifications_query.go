@@ -0,0 +1,6 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListNotificationsQuery represents input of the list models.Notification usecase,
+// the notifications of the acting user are listed.
+type ListNotificationsQuery struct{}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_smart_collections_presenter.go -source=list_smart_collections_presenter.go ListSmartCollectionsPresenter
+
+// ListSmartCollectionsPresenter represents output presenter of the list smart collections usecase.
+type ListSmartCollectionsPresenter interface {
+	SetResult(collections []*SmartCollection)
+}
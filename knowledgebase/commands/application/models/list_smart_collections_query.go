@@ -0,0 +1,6 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListSmartCollectionsQuery represents input of the list smart collections usecase,
+// the collections of the acting user are listed.
+type ListSmartCollectionsQuery struct{}
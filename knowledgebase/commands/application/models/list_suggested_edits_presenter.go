@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_suggested_edits_presenter.go -source=list_suggested_edits_presenter.go ListSuggestedEditsPresenter
+
+// ListSuggestedEditsPresenter represents output presenter of the list pending models.SuggestedEdit usecase.
+type ListSuggestedEditsPresenter interface {
+	SetResult(edits []*models.SuggestedEdit)
+}
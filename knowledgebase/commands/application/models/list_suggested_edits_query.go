@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListSuggestedEditsQuery represents input of the list pending models.SuggestedEdit usecase.
+type ListSuggestedEditsQuery struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+}
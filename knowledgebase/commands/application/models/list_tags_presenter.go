@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_tags_presenter.go -source=list_tags_presenter.go ListTagsPresenter
+
+// ListTagsPresenter represents output presenter of the list tags usecase.
+type ListTagsPresenter interface {
+	SetResult(tags []*models.TagUsage)
+}
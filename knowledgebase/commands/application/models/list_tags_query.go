@@ -0,0 +1,5 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListTagsQuery represents input of the list tags usecase, all the tags in use are listed.
+type ListTagsQuery struct{}
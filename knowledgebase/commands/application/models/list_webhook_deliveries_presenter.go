@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_webhook_deliveries_presenter.go -source=list_webhook_deliveries_presenter.go ListWebhookDeliveriesPresenter
+
+// ListWebhookDeliveriesPresenter represents output presenter of the list models.WebhookDelivery usecase.
+type ListWebhookDeliveriesPresenter interface {
+	SetResult(deliveries []*models.WebhookDelivery)
+}
@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListWebhookDeliveriesQuery represents input of the list models.WebhookDelivery usecase.
+type ListWebhookDeliveriesQuery struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	WebhookID   int64 `json:"webhook_id"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_webhooks_presenter.go -source=list_webhooks_presenter.go ListWebhooksPresenter
+
+// ListWebhooksPresenter represents output presenter of the list models.Webhook usecase.
+type ListWebhooksPresenter interface {
+	SetResult(webhooks []*models.Webhook)
+}
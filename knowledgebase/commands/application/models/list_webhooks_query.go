@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListWebhooksQuery represents input of the list models.Webhook usecase.
+type ListWebhooksQuery struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_list_workspace_invitations_presenter.go -source=list_workspace_invitations_presenter.go ListWorkspaceInvitationsPresenter
+
+// ListWorkspaceInvitationsPresenter represents output presenter of the list models.WorkspaceInvitation usecase.
+type ListWorkspaceInvitationsPresenter interface {
+	SetResult(invitations []*models.WorkspaceInvitation)
+}
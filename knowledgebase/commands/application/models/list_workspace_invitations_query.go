@@ -0,0 +1,6 @@
+// Package models contains representations of requests and events.
+package models
+
+// ListWorkspaceInvitationsQuery represents input of the list models.WorkspaceInvitation usecase,
+// the invitations waiting for the acting user are listed.
+type ListWorkspaceInvitationsQuery struct{}
@@ -0,0 +1,24 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// MarkdownNote represents one Markdown file of the knowledge vault.
+// Folder is the slash separated directory of the file inside the vault, it is treated as a category.
+// Owner is the login of the user the note belongs to, it is empty in exported vaults.
+type MarkdownNote struct {
+	Path        string     `json:"path"`
+	Folder      string     `json:"folder"`
+	ID          int64      `json:"id"`
+	Owner       string     `json:"owner,omitempty"`
+	Title       string     `json:"title"`
+	Anchor      string     `json:"anchor"`
+	Data        string     `json:"data"`
+	Tags        []string   `json:"tags,omitempty"`
+	Categories  []string   `json:"categories,omitempty"`
+	Score       int64      `json:"score"`
+	LastMark    int64      `json:"last_mark"`
+	LastCheckAt *time.Time `json:"last_check_at"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_markdown_vault.go -source=markdown_vault.go MarkdownVaultReader,MarkdownVaultWriter
+
+// MarkdownVaultReader represents a source of the Markdown notes.
+type MarkdownVaultReader interface {
+	Read(dir string) ([]*MarkdownNote, error)
+}
+
+// MarkdownVaultWriter represents a destination of the Markdown notes.
+type MarkdownVaultWriter interface {
+	Write(dir string, notes []*MarkdownNote) error
+}
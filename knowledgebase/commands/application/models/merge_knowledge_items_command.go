@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// MergeKnowledgeItemsCommand represents input of the merge models.KnowledgeItem usecase.
+// The source item is merged into the target one and deleted.
+type MergeKnowledgeItemsCommand struct {
+	TargetID int64 `json:"target_id"`
+	SourceID int64 `json:"source_id"`
+}
@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_merge_knowledge_items_presenter.go -source=merge_knowledge_items_presenter.go MergeKnowledgeItemsPresenter
+
+// MergeKnowledgeItemsPresenter represents output presenter of the merge models.KnowledgeItem usecase.
+type MergeKnowledgeItemsPresenter interface {
+	SetResult(item *models.KnowledgeItem)
+}
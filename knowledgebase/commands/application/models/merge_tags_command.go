@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// MergeTagsCommand represents input of the merge tags usecase.
+// Source tags are replaced with the target one in all the knowledge items.
+type MergeTagsCommand struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
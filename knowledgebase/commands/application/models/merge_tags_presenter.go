@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_merge_tags_presenter.go -source=merge_tags_presenter.go MergeTagsPresenter
+
+// MergeTagsPresenter represents output presenter of the merge tags usecase.
+type MergeTagsPresenter interface {
+	SetResult(tag *models.TagUsage)
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// MoveCategoryCommand represents input of the move category usecase.
+// The category is moved with all its subcategories, empty Parent makes it a root category.
+type MoveCategoryCommand struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+}
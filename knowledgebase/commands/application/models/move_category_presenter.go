@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_move_category_presenter.go -source=move_category_presenter.go MoveCategoryPresenter
+
+// MoveCategoryPresenter represents output presenter of the move category usecase.
+type MoveCategoryPresenter interface {
+	SetResult(category *models.Category)
+}
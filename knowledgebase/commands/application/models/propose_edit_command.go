@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+// ProposeEditCommand represents input of the propose models.SuggestedEdit usecase,
+// the content replaces title, anchor and data of the item once the edit is approved.
+type ProposeEditCommand struct {
+	WorkspaceID int64  `json:"workspace_id,omitempty"`
+	ItemID      int64  `json:"item_id"`
+	Title       string `json:"title"`
+	Anchor      string `json:"anchor"`
+	Data        string `json:"data"`
+	Comment     string `json:"comment,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_propose_edit_presenter.go -source=propose_edit_presenter.go ProposeEditPresenter
+
+// ProposeEditPresenter represents output presenter of the propose models.SuggestedEdit usecase.
+type ProposeEditPresenter interface {
+	SetResult(edit *models.SuggestedEdit)
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+// PublishDeckCommand represents input of the publish models.Deck usecase,
+// the category of the workspace or of the acting user becomes the deck available to everyone.
+type PublishDeckCommand struct {
+	WorkspaceID int64  `json:"workspace_id,omitempty"`
+	CategoryID  int64  `json:"category_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_publish_deck_presenter.go -source=publish_deck_presenter.go PublishDeckPresenter
+
+// PublishDeckPresenter represents output presenter of the publish models.Deck usecase.
+type PublishDeckPresenter interface {
+	SetResult(deck *models.Deck)
+}
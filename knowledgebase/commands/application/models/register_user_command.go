@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// RegisterUserCommand represents input of the register models.User usecase.
+type RegisterUserCommand struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_register_user_presenter.go -source=register_user_presenter.go RegisterUserPresenter
+
+// RegisterUserPresenter represents output presenter of the register models.User usecase.
+type RegisterUserPresenter interface {
+	SetResult(user *models.User)
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// RejectEditCommand represents input of the reject models.SuggestedEdit usecase.
+type RejectEditCommand struct {
+	WorkspaceID int64  `json:"workspace_id,omitempty"`
+	ID          int64  `json:"id"`
+	Comment     string `json:"comment,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_reject_edit_presenter.go -source=reject_edit_presenter.go RejectEditPresenter
+
+// RejectEditPresenter represents output presenter of the reject models.SuggestedEdit usecase.
+type RejectEditPresenter interface {
+	SetResult(edit *models.SuggestedEdit)
+}
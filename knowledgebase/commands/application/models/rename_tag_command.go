@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// RenameTagCommand represents input of the rename tag usecase.
+// The tag is renamed in all the knowledge items, the new name must not be in use.
+type RenameTagCommand struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
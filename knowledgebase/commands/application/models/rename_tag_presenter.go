@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_rename_tag_presenter.go -source=rename_tag_presenter.go RenameTagPresenter
+
+// RenameTagPresenter represents output presenter of the rename tag usecase.
+type RenameTagPresenter interface {
+	SetResult(tag *models.TagUsage)
+}
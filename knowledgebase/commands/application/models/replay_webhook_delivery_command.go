@@ -0,0 +1,8 @@
+// Package models contains representations of requests and events.
+package models
+
+// ReplayWebhookDeliveryCommand represents input of the replay models.WebhookDelivery usecase.
+type ReplayWebhookDeliveryCommand struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	ID          int64 `json:"id"`
+}
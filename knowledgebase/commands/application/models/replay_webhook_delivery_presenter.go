@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_replay_webhook_delivery_presenter.go -source=replay_webhook_delivery_presenter.go ReplayWebhookDeliveryPresenter
+
+// ReplayWebhookDeliveryPresenter represents output presenter of the replay models.WebhookDelivery usecase.
+type ReplayWebhookDeliveryPresenter interface {
+	SetResult(delivery *models.WebhookDelivery)
+}
@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// ReviewSession represents the items of the smart collection to be reviewed, the most urgent go first.
+type ReviewSession struct {
+	Collection *SmartCollection        `json:"collection"`
+	Items      []*models.KnowledgeItem `json:"items"`
+}
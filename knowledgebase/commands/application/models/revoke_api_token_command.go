@@ -0,0 +1,7 @@
+// Package models contains representations of requests and events.
+package models
+
+// RevokeAPITokenCommand represents input of the revoke models.APIToken usecase.
+type RevokeAPITokenCommand struct {
+	ID int64 `json:"id"`
+}
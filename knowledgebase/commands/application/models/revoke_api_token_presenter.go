@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_revoke_api_token_presenter.go -source=revoke_api_token_presenter.go RevokeAPITokenPresenter
+
+// RevokeAPITokenPresenter represents output presenter of the revoke models.APIToken usecase.
+type RevokeAPITokenPresenter interface {
+	SetResult(bool)
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_search_audit_log_presenter.go -source=search_audit_log_presenter.go SearchAuditLogPresenter
+
+// SearchAuditLogPresenter represents output presenter of the search models.AuditEntry usecase.
+type SearchAuditLogPresenter interface {
+	SetResult(entries []*models.AuditEntry)
+}
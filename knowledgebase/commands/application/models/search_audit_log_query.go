@@ -0,0 +1,15 @@
+// Package models contains representations of requests and events.
+package models
+
+import "time"
+
+// SearchAuditLogQuery represents input of the search models.AuditEntry usecase. Entries of the commands
+// executed on the workspace or on the knowledge base of the acting user are searched,
+// zero values match any entry. The time range includes From and excludes To.
+type SearchAuditLogQuery struct {
+	WorkspaceID int64      `json:"workspace_id,omitempty"`
+	Actor       string     `json:"actor,omitempty"`
+	ItemID      int64      `json:"item_id,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	To          *time.Time `json:"to,omitempty"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_search_knowledge_items_presenter.go -source=search_knowledge_items_presenter.go SearchKnowledgeItemsPresenter
+
+// SearchKnowledgeItemsPresenter represents output presenter of the search knowledge items usecase.
+type SearchKnowledgeItemsPresenter interface {
+	SetResult(result *SearchResult)
+}
@@ -0,0 +1,18 @@
+// Package models contains representations of requests and events.
+package models
+
+// SearchKnowledgeItemsQuery represents input of the search knowledge items usecase.
+// Every term of the Query has to match, a term ending with "*" matches as a prefix.
+// Categories and Tags narrow the results down to the items having any of the categories and all the tags,
+// subcategories of the given categories count as well.
+// Owner is set by the usecase to the acting user or the workspace, items of other owners are never found.
+type SearchKnowledgeItemsQuery struct {
+	// WorkspaceID is the workspace to search in, zero is the personal knowledge base of the user.
+	WorkspaceID int64    `json:"workspace_id,omitempty"`
+	Owner       string   `json:"-"`
+	Query       string   `json:"query"`
+	Categories  []string `json:"categories,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Limit       int      `json:"limit"`
+	Offset      int      `json:"offset"`
+}
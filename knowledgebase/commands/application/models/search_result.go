@@ -0,0 +1,53 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// Fields of the knowledge item which are searched.
+const (
+	SearchFieldTitle  = "title"
+	SearchFieldAnchor = "anchor"
+	SearchFieldData   = "data"
+	SearchFieldTags   = "tags"
+)
+
+// SearchResult represents one page of the ranked search hits.
+// Total and Facets are counted over all the matching items, not only the page.
+type SearchResult struct {
+	Total  int           `json:"total"`
+	Hits   []*SearchHit  `json:"hits"`
+	Facets *SearchFacets `json:"facets"`
+}
+
+// SearchHit represents matching knowledge item with its relevance score.
+type SearchHit struct {
+	Item     *models.KnowledgeItem `json:"item"`
+	Score    float64               `json:"score"`
+	Snippets []*SearchSnippet      `json:"snippets,omitempty"`
+}
+
+// SearchSnippet represents fragment of the item field around the matched terms.
+// Highlights are byte ranges of the matched words inside the Text.
+type SearchSnippet struct {
+	Field      string       `json:"field"`
+	Text       string       `json:"text"`
+	Highlights []*TextRange `json:"highlights"`
+}
+
+// TextRange represents [Start, End) byte range of the text.
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SearchFacets represents numbers of the matching items per category and tag.
+type SearchFacets struct {
+	Categories []*FacetCount `json:"categories"`
+	Tags       []*FacetCount `json:"tags"`
+}
+
+// FacetCount represents number of the matching items having the value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
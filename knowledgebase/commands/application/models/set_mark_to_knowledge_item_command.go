@@ -2,7 +2,9 @@
 package models
 
 // SetMarkToKnowledgeItemCommand represents input of the set new mark to models.KnowledgeItem usecase.
+// Items of the workspace are marked with WorkspaceID, the mark goes to the progress of the acting user.
 type SetMarkToKnowledgeItemCommand struct {
-	ID   int64 `json:"id"`
-	Mark int64 `json:"mark"`
+	ID          int64 `json:"id"`
+	Mark        int64 `json:"mark"`
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
 }
@@ -0,0 +1,12 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+// SmartCollection represents saved search together with the items it selects at the moment.
+// Count and AverageScore are calculated when the collection is requested.
+type SmartCollection struct {
+	Search       *models.SavedSearch `json:"search"`
+	Count        int                 `json:"count"`
+	AverageScore float64             `json:"average_score"`
+}
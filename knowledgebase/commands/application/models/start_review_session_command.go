@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+// StartReviewSessionCommand represents input of the start review session usecase.
+// SavedSearchID is the smart collection the items are taken from, Limit is the size of the session.
+type StartReviewSessionCommand struct {
+	// WorkspaceID is the workspace the items are reviewed in, zero is the personal knowledge base of the user.
+	WorkspaceID   int64 `json:"workspace_id,omitempty"`
+	SavedSearchID int64 `json:"saved_search_id"`
+	Limit         int   `json:"limit"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_start_review_session_presenter.go -source=start_review_session_presenter.go StartReviewSessionPresenter
+
+// StartReviewSessionPresenter represents output presenter of the start review session usecase.
+type StartReviewSessionPresenter interface {
+	SetResult(session *ReviewSession)
+}
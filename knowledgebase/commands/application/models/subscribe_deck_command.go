@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// SubscribeDeckCommand represents input of the subscribe models.Deck usecase,
+// the items of the deck are copied to the workspace or to the acting user.
+type SubscribeDeckCommand struct {
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	DeckID      int64 `json:"deck_id"`
+}
@@ -0,0 +1,11 @@
+// Package models contains representations of requests and events.
+package models
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_subscribe_deck_presenter.go -source=subscribe_deck_presenter.go SubscribeDeckPresenter
+
+// SubscribeDeckPresenter represents output presenter of the subscribe models.Deck usecase.
+type SubscribeDeckPresenter interface {
+	SetResult(subscription *models.DeckSubscription)
+}
@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_suggest_related_items_presenter.go -source=suggest_related_items_presenter.go SuggestRelatedItemsPresenter
+
+// SuggestRelatedItemsPresenter represents output presenter of the suggest related items usecase.
+type SuggestRelatedItemsPresenter interface {
+	SetResult(related []*models.RelatedItem)
+}
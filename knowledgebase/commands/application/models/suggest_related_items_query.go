@@ -0,0 +1,10 @@
+// Package models contains representations of requests and events.
+package models
+
+// SuggestRelatedItemsQuery represents input of the suggest related items usecase.
+type SuggestRelatedItemsQuery struct {
+	// WorkspaceID is the workspace the item belongs to, zero is the personal knowledge base of the user.
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
+	ItemID      int64 `json:"item_id"`
+	Limit       int   `json:"limit"`
+}
@@ -0,0 +1,15 @@
+// Package models contains representations of requests and events.
+package models
+
+// UpdateCategoryCommand represents input of the update category usecase.
+// All the metadata of the category is replaced, empty values clear it.
+// Nil scheduling overrides make the items of the category use the global settings.
+type UpdateCategoryCommand struct {
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	Color              string   `json:"color"`
+	Icon               string   `json:"icon"`
+	SortOrder          int      `json:"sort_order"`
+	TargetRetention    *float64 `json:"target_retention"`
+	DailyNewItemsLimit *int     `json:"daily_new_items_limit"`
+}
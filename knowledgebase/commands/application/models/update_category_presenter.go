@@ -0,0 +1,13 @@
+// Package models contains representations of requests and events.
+package models
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_update_category_presenter.go -source=update_category_presenter.go UpdateCategoryPresenter
+
+// UpdateCategoryPresenter represents output presenter of the update category usecase.
+type UpdateCategoryPresenter interface {
+	SetResult(category *models.Category)
+}
@@ -9,4 +9,6 @@ type UpdateKnowledgeItemCommand struct {
 	Data       string   `json:"data"`
 	Tags       []string `json:"tags"`
 	Categories []string `json:"categories"`
+	// WorkspaceID is the workspace the item belongs to, zero is the personal knowledge base of the user.
+	WorkspaceID int64 `json:"workspace_id,omitempty"`
 }
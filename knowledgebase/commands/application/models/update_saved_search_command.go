@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+// UpdateSavedSearchCommand represents input of the update models.SavedSearch usecase.
+type UpdateSavedSearchCommand struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+}
@@ -0,0 +1,9 @@
+// Package models contains representations of requests and events.
+package models
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_update_saved_search_presenter.go -source=update_saved_search_presenter.go UpdateSavedSearchPresenter
+
+// UpdateSavedSearchPresenter represents output presenter of the update models.SavedSearch usecase.
+type UpdateSavedSearchPresenter interface {
+	SetResult(collection *SmartCollection)
+}
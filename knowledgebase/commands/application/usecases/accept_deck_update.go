@@ -0,0 +1,56 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// AcceptDeckUpdate type represents usecase that has sequence of actions to accept models.DeckUpdate.
+type AcceptDeckUpdate struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.AcceptDeckUpdatePresenter
+}
+
+// NewAcceptDeckUpdate function builds new instance of AcceptDeckUpdate usecase.
+func NewAcceptDeckUpdate(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.AcceptDeckUpdatePresenter,
+) *AcceptDeckUpdate {
+	return &AcceptDeckUpdate{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. The copy of the item keeps its review progress.
+func (uc *AcceptDeckUpdate) Handle(ctx context.Context, cmd *models.AcceptDeckUpdateCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+
+	item, err := uc.deckService.AcceptUpdate(owner, cmd.SubscriptionID, cmd.SourceID)
+	if err != nil {
+		return err
+	}
+
+	auditItems(ctx, item.ID)
+
+	uc.presenter.SetResult(item)
+
+	return nil
+}
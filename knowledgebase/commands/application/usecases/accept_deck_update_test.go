@@ -0,0 +1,69 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAcceptDeckUpdate_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	item := &domain.KnowledgeItem{ID: 21, Owner: "workspace:5", Data: "len\ncap", Reviews: 3}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().AcceptUpdate("workspace:5", int64(6), int64(11)).Return(item, nil)
+
+	presenter := mock.NewMockAcceptDeckUpdatePresenter(ctrl)
+	presenter.EXPECT().SetResult(item)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any(), nil).DoAndReturn(
+		func(entry *domain.AuditEntry, _ any, _ error) error {
+			if entry.Owner != "workspace:5" || len(entry.ItemIDs) != 1 || entry.ItemIDs[0] != 21 {
+				t.Errorf("expected item 21 of workspace:5 to be affected, got %+v", entry)
+			}
+
+			return nil
+		})
+
+	uc := usecases.NewAuditedUseCase[*models.AcceptDeckUpdateCommand](
+		"AcceptDeckUpdate",
+		usecases.NewAcceptDeckUpdate(deckService, workspaceService, presenter),
+		auditService,
+	)
+
+	err := uc.Handle(aliceContext(), &models.AcceptDeckUpdateCommand{WorkspaceID: 5, SubscriptionID: 6, SourceID: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcceptDeckUpdate_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("", services.ErrForbidden)
+
+	uc := usecases.NewAcceptDeckUpdate(
+		mock.NewMockDeckService(ctrl),
+		workspaceService,
+		mock.NewMockAcceptDeckUpdatePresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.AcceptDeckUpdateCommand{WorkspaceID: 5, SubscriptionID: 6, SourceID: 11})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
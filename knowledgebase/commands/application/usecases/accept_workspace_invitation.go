@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// AcceptWorkspaceInvitation type represents usecase that has sequence of actions to accept models.WorkspaceInvitation.
+type AcceptWorkspaceInvitation struct {
+	workspaceService services.WorkspaceService
+	presenter        models.AcceptWorkspaceInvitationPresenter
+}
+
+// NewAcceptWorkspaceInvitation function builds new instance of AcceptWorkspaceInvitation usecase.
+func NewAcceptWorkspaceInvitation(
+	workspaceService services.WorkspaceService,
+	presenter models.AcceptWorkspaceInvitationPresenter,
+) *AcceptWorkspaceInvitation {
+	return &AcceptWorkspaceInvitation{
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *AcceptWorkspaceInvitation) Handle(ctx context.Context, cmd *models.AcceptWorkspaceInvitationCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := uc.workspaceService.AcceptInvitation(user.Login, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(member)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAcceptWorkspaceInvitation_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := &domain.WorkspaceMember{WorkspaceID: 5, Login: "alice", Role: domain.RoleViewer}
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().AcceptInvitation("alice", int64(2)).Return(member, nil)
+
+	presenter := mock.NewMockAcceptWorkspaceInvitationPresenter(ctrl)
+	presenter.EXPECT().SetResult(member)
+
+	uc := usecases.NewAcceptWorkspaceInvitation(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.AcceptWorkspaceInvitationCommand{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcceptWorkspaceInvitation_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().AcceptInvitation("alice", int64(2)).Return(nil, expectedError)
+
+	uc := usecases.NewAcceptWorkspaceInvitation(service, mock.NewMockAcceptWorkspaceInvitationPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.AcceptWorkspaceInvitationCommand{ID: 2})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,38 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+// ErrUnauthenticated is returned by the usecases acting on behalf of the user when the context has no user.
+var ErrUnauthenticated = errors.New("user is not authenticated")
+
+// userContextKey is the key of the acting user in the context.
+type userContextKey struct{}
+
+// ContextWithUser function returns copy of the context carrying the user the usecases act on behalf of.
+// It is called by the transport once the user is authenticated.
+func ContextWithUser(ctx context.Context, user *domain.User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext function returns the acting user carried by the context.
+func UserFromContext(ctx context.Context) (*domain.User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*domain.User)
+
+	return user, ok && user != nil
+}
+
+// actingUser function returns the acting user of the context, ErrUnauthenticated is returned when there is none.
+func actingUser(ctx context.Context) (*domain.User, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok || user.Login == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	return user, nil
+}
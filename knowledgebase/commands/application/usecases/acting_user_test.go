@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+// aliceContext function returns context of the user the tested usecases act on behalf of.
+func aliceContext() context.Context {
+	return usecases.ContextWithUser(context.Background(), &domain.User{ID: 1, Login: "alice"})
+}
+
+// personalWorkspace function returns workspace service letting alice act in her personal knowledge base.
+func personalWorkspace(ctrl *gomock.Controller) *mock.MockWorkspaceService {
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(0), gomock.Any()).Return("alice", nil).AnyTimes()
+
+	return workspaceService
+}
+
+func TestUserFromContext(t *testing.T) {
+	if _, ok := usecases.UserFromContext(context.Background()); ok {
+		t.Error("expected no user in empty context")
+	}
+
+	if _, ok := usecases.UserFromContext(usecases.ContextWithUser(context.Background(), nil)); ok {
+		t.Error("expected nil user not to be found")
+	}
+
+	user, ok := usecases.UserFromContext(aliceContext())
+	if !ok || user.Login != "alice" {
+		t.Errorf("expected alice, got %v", user)
+	}
+}
+
+func TestUsecases_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// no service is expected to be called without the acting user.
+	addItem := usecases.NewAddKnowledgeItem(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		mock.NewMockDuplicateService(ctrl),
+		mock.NewMockWorkspaceService(ctrl),
+		mock.NewMockAddKnowledgeItemPresenter(ctrl),
+	)
+	search := usecases.NewSearchKnowledgeItems(
+		mock.NewMockKnowledgeItemsIndex(ctrl),
+		mock.NewMockWorkspaceService(ctrl),
+		mock.NewMockSearchKnowledgeItemsPresenter(ctrl),
+	)
+	listCategories := usecases.NewListCategories(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockWorkspaceService(ctrl),
+		mock.NewMockListCategoriesPresenter(ctrl),
+	)
+
+	anonymous := usecases.ContextWithUser(context.Background(), &domain.User{})
+	for _, ctx := range []context.Context{context.Background(), anonymous} {
+		errs := []error{
+			addItem.Handle(ctx, &models.AddKnowledgeItemCommand{Title: "Go"}),
+			search.Handle(ctx, &models.SearchKnowledgeItemsQuery{}),
+			listCategories.Handle(ctx, &models.ListCategoriesQuery{}),
+		}
+		for _, err := range errs {
+			if !errors.Is(err, usecases.ErrUnauthenticated) {
+				t.Errorf("expected ErrUnauthenticated, got %v", err)
+			}
+		}
+	}
+}
@@ -13,6 +13,8 @@ import (
 type AddKnowledgeItem struct {
 	categoryService      services.CategoryService
 	knowledgeItemService services.KnowledgeItemService
+	duplicateService     services.DuplicateService
+	workspaceService     services.WorkspaceService
 	presenter            models.AddKnowledgeItemPresenter
 }
 
@@ -20,20 +22,50 @@ type AddKnowledgeItem struct {
 func NewAddKnowledgeItem(
 	categoryService services.CategoryService,
 	knowledgeItemService services.KnowledgeItemService,
+	duplicateService services.DuplicateService,
+	workspaceService services.WorkspaceService,
 	presenter models.AddKnowledgeItemPresenter,
 ) *AddKnowledgeItem {
 	return &AddKnowledgeItem{
 		categoryService:      categoryService,
 		knowledgeItemService: knowledgeItemService,
+		duplicateService:     duplicateService,
+		workspaceService:     workspaceService,
 		presenter:            presenter,
 	}
 }
 
 // Handle function performs usecase actions.
-func (uc *AddKnowledgeItem) Handle(_ context.Context, cmd *models.AddKnowledgeItemCommand) error {
+// Similar existing items don't prevent the new item from being created, they are presented as a warning,
+// no warning is presented when the similar items can't be looked up.
+// Items are added to the workspace by the members allowed to edit it.
+func (uc *AddKnowledgeItem) Handle(ctx context.Context, cmd *models.AddKnowledgeItemCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+
+	duplicates, err := uc.duplicateService.FindSimilar(&domain.KnowledgeItem{
+		Owner:  owner,
+		Title:  cmd.Title,
+		Anchor: cmd.Anchor,
+		Data:   cmd.Data,
+	})
+	if err != nil {
+		// the warning is only a hint, a failed lookup doesn't prevent the item from being added.
+		duplicates = nil
+	}
+
 	var categories []*domain.Category
 	for _, categoryName := range cmd.Categories {
-		cat, err := uc.categoryService.CreateOrGetCategory(categoryName)
+		cat, err := uc.categoryService.CreateOrGetCategory(owner, categoryName)
 		if err != nil {
 			return err
 		}
@@ -41,11 +73,19 @@ func (uc *AddKnowledgeItem) Handle(_ context.Context, cmd *models.AddKnowledgeIt
 		categories = append(categories, cat)
 	}
 
-	item, err := uc.knowledgeItemService.NewItem(cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, categories)
+	item, err := uc.knowledgeItemService.NewItem(owner, cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, categories)
 	if err != nil {
 		return err
 	}
 
+	auditItems(ctx, item.ID)
+	for _, category := range item.Categories {
+		auditCategories(ctx, category.ID)
+	}
+
+	if len(duplicates) > 0 {
+		uc.presenter.SetDuplicates(duplicates)
+	}
 	uc.presenter.SetResult(item)
 
 	return nil
@@ -1,13 +1,13 @@
 package usecases_test
 
 import (
-	"context"
 	"errors"
 	"testing"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
 	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
 	"github.com/96solutions/neurography/knowledgebase/commands/mock"
 	"go.uber.org/mock/gomock"
 )
@@ -44,19 +44,20 @@ func TestAddKnowledgeItem_Do_Success(t *testing.T) {
 	}
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategories[0], nil
-	})
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[1]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategories[1], nil
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(expectedCategories[0], nil)
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[1]).Return(expectedCategories[1], nil)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
-	itemService.EXPECT().NewItem(cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, expectedCategories).Return(expectedItem, nil)
+	itemService.EXPECT().
+		NewItem("alice", cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, expectedCategories).
+		Return(expectedItem, nil)
+
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).Return(nil, nil)
 
 	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
 
-	uc := usecases.NewAddKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewAddKnowledgeItem(catService, itemService, duplicateService, personalWorkspace(ctrl), presenter)
 
 	presenter.EXPECT().SetResult(expectedItem).Do(func(item *domain.KnowledgeItem) {
 		if item.ID != expectedItem.ID {
@@ -92,7 +93,7 @@ func TestAddKnowledgeItem_Do_Success(t *testing.T) {
 		}
 	})
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, cmd)
 	if err != nil {
@@ -114,17 +115,18 @@ func TestAddKnowledgeItem_Do_CategoryServiceError(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return nil, expectedError
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(nil, expectedError)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
 
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).Return(nil, nil)
+
 	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
-	uc := usecases.NewAddKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewAddKnowledgeItem(catService, itemService, duplicateService, personalWorkspace(ctrl), presenter)
 	err := uc.Handle(ctx, cmd)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("expected error %s, got %s", expectedError, err)
@@ -149,23 +151,165 @@ func TestAddKnowledgeItem_Do_KnowledgeItemServiceError(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategory, nil
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(expectedCategory, nil)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
 	itemService.EXPECT().
-		NewItem(cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, []*domain.Category{expectedCategory}).
+		NewItem("alice", cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, []*domain.Category{expectedCategory}).
 		Return(nil, expectedError)
 
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).Return(nil, nil)
+
 	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
 
-	uc := usecases.NewAddKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewAddKnowledgeItem(catService, itemService, duplicateService, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, cmd)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("expected error %s, got %s", expectedError, err)
 	}
 }
+
+func TestAddKnowledgeItem_Do_Duplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.AddKnowledgeItemCommand{
+		Title:  "What is a goroutine?",
+		Anchor: "goroutine",
+		Data:   "Lightweight thread managed by the Go runtime.",
+	}
+	expectedItem := &domain.KnowledgeItem{ID: 5, Title: cmd.Title, Anchor: cmd.Anchor, Data: cmd.Data}
+	expectedDuplicates := []*domain.Duplicate{
+		{
+			Item:       &domain.KnowledgeItem{ID: 2, Title: "Goroutine", Anchor: cmd.Anchor, Data: cmd.Data},
+			Similarity: &domain.Similarity{Anchor: 1, Data: 1, Score: 1},
+		},
+	}
+
+	catService := mock.NewMockCategoryService(ctrl)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().NewItem("alice", cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, nil).Return(expectedItem, nil)
+
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).DoAndReturn(
+		func(item *domain.KnowledgeItem) ([]*domain.Duplicate, error) {
+			if item.ID != 0 || item.Title != cmd.Title || item.Data != cmd.Data {
+				t.Errorf("unexpected candidate %v", item)
+			}
+
+			return expectedDuplicates, nil
+		})
+
+	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
+	gomock.InOrder(
+		presenter.EXPECT().SetDuplicates(expectedDuplicates),
+		presenter.EXPECT().SetResult(expectedItem),
+	)
+
+	uc := usecases.NewAddKnowledgeItem(catService, itemService, duplicateService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddKnowledgeItem_Do_DuplicatesError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.AddKnowledgeItemCommand{Title: "What is a goroutine?", Data: "Lightweight thread."}
+	expectedItem := &domain.KnowledgeItem{ID: 5, Title: cmd.Title, Data: cmd.Data}
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().NewItem("alice", cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, nil).Return(expectedItem, nil)
+
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).Return(nil, errors.New("index is unavailable"))
+
+	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(expectedItem)
+
+	uc := usecases.NewAddKnowledgeItem(
+		mock.NewMockCategoryService(ctrl),
+		itemService,
+		duplicateService,
+		personalWorkspace(ctrl),
+		presenter,
+	)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddKnowledgeItem_Do_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.AddKnowledgeItemCommand{
+		Title:       "What is a runbook?",
+		Anchor:      "runbook",
+		Data:        "Instructions for handling incidents",
+		Categories:  []string{"Operations"},
+		WorkspaceID: 5,
+	}
+	category := &domain.Category{ID: 3, Owner: "workspace:5", Name: "Operations"}
+	expectedItem := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5", Title: cmd.Title}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	// categories and items are owned by the workspace.
+	duplicateService := mock.NewMockDuplicateService(ctrl)
+	duplicateService.EXPECT().FindSimilar(gomock.Any()).
+		DoAndReturn(func(item *domain.KnowledgeItem) ([]*domain.KnowledgeItem, error) {
+			if item.Owner != "workspace:5" {
+				t.Errorf("expected duplicates of the workspace, got owner %s", item.Owner)
+			}
+
+			return nil, nil
+		})
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().CreateOrGetCategory("workspace:5", "Operations").Return(category, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().
+		NewItem("workspace:5", cmd.Title, cmd.Anchor, cmd.Data, nil, []*domain.Category{category}).
+		Return(expectedItem, nil)
+
+	presenter := mock.NewMockAddKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(expectedItem)
+
+	uc := usecases.NewAddKnowledgeItem(catService, itemService, duplicateService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddKnowledgeItem_Do_WorkspaceForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("", services.ErrForbidden)
+
+	uc := usecases.NewAddKnowledgeItem(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		mock.NewMockDuplicateService(ctrl),
+		workspaceService,
+		mock.NewMockAddKnowledgeItemPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.AddKnowledgeItemCommand{Title: "Go", WorkspaceID: 5})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
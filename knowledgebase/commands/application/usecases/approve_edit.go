@@ -0,0 +1,83 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ApproveEdit type represents usecase that has sequence of actions to approve models.SuggestedEdit
+// and apply it to the models.KnowledgeItem.
+type ApproveEdit struct {
+	knowledgeItemService services.KnowledgeItemService
+	suggestedEditService services.SuggestedEditService
+	workspaceService     services.WorkspaceService
+	presenter            models.ApproveEditPresenter
+}
+
+// NewApproveEdit function builds new instance of ApproveEdit usecase.
+func NewApproveEdit(
+	knowledgeItemService services.KnowledgeItemService,
+	suggestedEditService services.SuggestedEditService,
+	workspaceService services.WorkspaceService,
+	presenter models.ApproveEditPresenter,
+) *ApproveEdit {
+	return &ApproveEdit{
+		knowledgeItemService: knowledgeItemService,
+		suggestedEditService: suggestedEditService,
+		workspaceService:     workspaceService,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions. The approved content replaces title, anchor and data
+// of the item, its tags and categories stay as they are. The approval is recorded once the item is updated,
+// so the edit failed to apply stays pending and can be approved again.
+func (uc *ApproveEdit) Handle(ctx context.Context, cmd *models.ApproveEditCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+
+	edit, err := uc.suggestedEditService.GetEdit(owner, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	auditItems(ctx, edit.ItemID)
+
+	item, err := uc.knowledgeItemService.GetItem(owner, edit.ItemID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.suggestedEditService.ValidateApproval(edit, item, cmd.Comment); err != nil {
+		return err
+	}
+
+	updated, err := uc.knowledgeItemService.UpdateItem(
+		owner, item.ID, edit.Title, edit.Anchor,
+		edit.Data, item.Tags, item.Categories)
+	if err != nil {
+		return err
+	}
+
+	edit, err = uc.suggestedEditService.ApproveEdit(user.Login, edit, item, cmd.Comment)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(edit, updated)
+
+	return nil
+}
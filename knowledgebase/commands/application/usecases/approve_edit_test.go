@@ -0,0 +1,127 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApproveEdit_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	categories := []*domain.Category{{ID: 2, Owner: "workspace:5", Name: "Distributed Systems"}}
+	item := &domain.KnowledgeItem{
+		ID:         7,
+		Owner:      "workspace:5",
+		Title:      "Raft",
+		Anchor:     "raft",
+		Data:       "Leader is elected by 51% of votes.",
+		Tags:       []string{"consensus"},
+		Categories: categories,
+	}
+	edit := &domain.SuggestedEdit{ID: 3, ItemID: 7, Title: "Raft leader election", Anchor: "raft", Data: "By majority."}
+	approved := &domain.SuggestedEdit{ID: 3, ItemID: 7, Title: edit.Title, Anchor: edit.Anchor, Data: edit.Data}
+	updated := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5", Title: edit.Title}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	gomock.InOrder(
+		editService.EXPECT().GetEdit("workspace:5", int64(3)).Return(edit, nil),
+		itemService.EXPECT().GetItem("workspace:5", int64(7)).Return(item, nil),
+		editService.EXPECT().ValidateApproval(edit, item, "thanks").Return(nil),
+		itemService.EXPECT().
+			UpdateItem("workspace:5", int64(7), edit.Title, edit.Anchor, edit.Data, item.Tags, categories).
+			Return(updated, nil),
+		editService.EXPECT().ApproveEdit("alice", edit, item, "thanks").Return(approved, nil),
+	)
+
+	presenter := mock.NewMockApproveEditPresenter(ctrl)
+	presenter.EXPECT().SetResult(approved, updated)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any(), nil).DoAndReturn(
+		func(entry *domain.AuditEntry, _ any, _ error) error {
+			if entry.Owner != "workspace:5" || len(entry.ItemIDs) != 1 || entry.ItemIDs[0] != 7 {
+				t.Errorf("expected item 7 of workspace:5 to be affected, got %+v", entry)
+			}
+
+			return nil
+		})
+
+	uc := usecases.NewAuditedUseCase[*models.ApproveEditCommand](
+		"ApproveEdit",
+		usecases.NewApproveEdit(itemService, editService, workspaceService, presenter),
+		auditService,
+	)
+
+	err := uc.Handle(aliceContext(), &models.ApproveEditCommand{WorkspaceID: 5, ID: 3, Comment: "thanks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApproveEdit_Handle_ReviewError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+	edit := &domain.SuggestedEdit{ID: 3, ItemID: 7}
+	item := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	editService.EXPECT().GetEdit("workspace:5", int64(3)).Return(edit, nil)
+	editService.EXPECT().ValidateApproval(edit, item, "").Return(expectedError)
+
+	// the item isn't updated when the edit can't be approved.
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("workspace:5", int64(7)).Return(item, nil)
+
+	uc := usecases.NewApproveEdit(itemService, editService, workspaceService, mock.NewMockApproveEditPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ApproveEditCommand{WorkspaceID: 5, ID: 3})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %v", expectedError, err)
+	}
+}
+
+func TestApproveEdit_Handle_UpdateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+	edit := &domain.SuggestedEdit{ID: 3, ItemID: 7, Title: "Raft leader election"}
+	item := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5", Title: "Raft"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	// the edit stays pending and its author isn't notified when the item can't be updated.
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	editService.EXPECT().GetEdit("workspace:5", int64(3)).Return(edit, nil)
+	editService.EXPECT().ValidateApproval(edit, item, "").Return(nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("workspace:5", int64(7)).Return(item, nil)
+	itemService.EXPECT().
+		UpdateItem("workspace:5", int64(7), edit.Title, edit.Anchor, edit.Data, item.Tags, item.Categories).
+		Return(nil, expectedError)
+
+	uc := usecases.NewApproveEdit(itemService, editService, workspaceService, mock.NewMockApproveEditPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ApproveEditCommand{WorkspaceID: 5, ID: 3})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// auditContextKey is the key of the audit record of the command being executed in the context.
+type auditContextKey struct{}
+
+// auditRecord keeps what the usecase has told about the command it executes.
+type auditRecord struct {
+	owner       string
+	itemIDs     []int64
+	categoryIDs []int64
+}
+
+// AuditedUseCase type decorates the usecase, so every execution of its command is written into the audit log
+// along with the acting user, the outcome and the affected items and categories, whether it succeeds or not.
+// The decorated usecase tells the owner and the affected IDs through the context, commands of the usecases
+// telling nothing are recorded as acting on the knowledge base of the acting user.
+type AuditedUseCase[Command any] struct {
+	command      string
+	useCase      UseCase[Command]
+	auditService services.AuditService
+}
+
+// NewAuditedUseCase function builds new instance of AuditedUseCase, command names the command in the audit log.
+func NewAuditedUseCase[Command any](
+	command string,
+	useCase UseCase[Command],
+	auditService services.AuditService,
+) *AuditedUseCase[Command] {
+	return &AuditedUseCase[Command]{
+		command:      command,
+		useCase:      useCase,
+		auditService: auditService,
+	}
+}
+
+// Handle function performs the decorated usecase and records its execution. The command fails
+// when it can't be recorded, even though its changes are kept.
+func (uc *AuditedUseCase[Command]) Handle(ctx context.Context, cmd Command) error {
+	record := &auditRecord{}
+
+	failure := uc.useCase.Handle(context.WithValue(ctx, auditContextKey{}, record), cmd)
+
+	entry := &domain.AuditEntry{
+		Owner:       record.owner,
+		Command:     uc.command,
+		ItemIDs:     record.itemIDs,
+		CategoryIDs: record.categoryIDs,
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		entry.Actor = user.Login
+	}
+	if entry.Owner == "" {
+		entry.Owner = entry.Actor
+	}
+
+	if err := uc.auditService.Record(entry, cmd, failure); err != nil {
+		return errors.Join(failure, err)
+	}
+
+	return failure
+}
+
+// auditOwner function tells the audit log the knowledge base the command acts on.
+func auditOwner(ctx context.Context, owner string) {
+	if record, ok := ctx.Value(auditContextKey{}).(*auditRecord); ok {
+		record.owner = owner
+	}
+}
+
+// auditItems function tells the audit log the items affected by the command.
+func auditItems(ctx context.Context, ids ...int64) {
+	if record, ok := ctx.Value(auditContextKey{}).(*auditRecord); ok {
+		record.itemIDs = append(record.itemIDs, ids...)
+	}
+}
+
+// auditCategories function tells the audit log the categories affected by the command.
+func auditCategories(ctx context.Context, ids ...int64) {
+	if record, ok := ctx.Value(auditContextKey{}).(*auditRecord); ok {
+		record.categoryIDs = append(record.categoryIDs, ids...)
+	}
+}
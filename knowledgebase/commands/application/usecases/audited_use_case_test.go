@@ -0,0 +1,118 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditedUseCase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.DeleteKnowledgeItemCommand{ID: 7, WorkspaceID: 5}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	knowledgeItemService := mock.NewMockKnowledgeItemService(ctrl)
+	knowledgeItemService.EXPECT().DeleteItem("workspace:5", int64(7)).Return(nil)
+
+	presenter := mock.NewMockDeleteKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), cmd, nil).DoAndReturn(
+		func(entry *domain.AuditEntry, _ any, _ error) error {
+			if entry.Actor != "alice" || entry.Owner != "workspace:5" || entry.Command != "DeleteKnowledgeItem" {
+				t.Errorf("unexpected entry %+v", entry)
+			}
+			if len(entry.ItemIDs) != 1 || entry.ItemIDs[0] != 7 {
+				t.Errorf("expected item 7 to be affected, got %v", entry.ItemIDs)
+			}
+
+			return nil
+		})
+
+	uc := usecases.NewAuditedUseCase[*models.DeleteKnowledgeItemCommand](
+		"DeleteKnowledgeItem",
+		usecases.NewDeleteKnowledgeItem(knowledgeItemService, workspaceService, presenter),
+		auditService,
+	)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditedUseCase_Handle_Failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.DeleteKnowledgeItemCommand{ID: 7}
+	expectedError := errors.New("expected error")
+
+	knowledgeItemService := mock.NewMockKnowledgeItemService(ctrl)
+	knowledgeItemService.EXPECT().DeleteItem("alice", int64(7)).Return(expectedError)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), cmd, expectedError).Return(nil)
+	auditService.EXPECT().Record(gomock.Any(), cmd, usecases.ErrUnauthenticated).DoAndReturn(
+		func(entry *domain.AuditEntry, _ any, _ error) error {
+			if entry.Actor != "" || entry.Owner != "" {
+				t.Errorf("expected anonymous entry, got %+v", entry)
+			}
+
+			return nil
+		})
+
+	uc := usecases.NewAuditedUseCase[*models.DeleteKnowledgeItemCommand](
+		"DeleteKnowledgeItem",
+		usecases.NewDeleteKnowledgeItem(
+			knowledgeItemService,
+			personalWorkspace(ctrl),
+			mock.NewMockDeleteKnowledgeItemPresenter(ctrl),
+		),
+		auditService,
+	)
+
+	if err := uc.Handle(aliceContext(), cmd); !errors.Is(err, expectedError) {
+		t.Errorf("got error %v, want %v", err, expectedError)
+	}
+	if err := uc.Handle(context.Background(), cmd); !errors.Is(err, usecases.ErrUnauthenticated) {
+		t.Errorf("got error %v, want %v", err, usecases.ErrUnauthenticated)
+	}
+}
+
+func TestAuditedUseCase_Handle_NotRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.DeleteKnowledgeItemCommand{ID: 7}
+	expectedError := errors.New("expected error")
+
+	knowledgeItemService := mock.NewMockKnowledgeItemService(ctrl)
+	knowledgeItemService.EXPECT().DeleteItem("alice", int64(7)).Return(nil)
+
+	presenter := mock.NewMockDeleteKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), cmd, nil).Return(expectedError)
+
+	uc := usecases.NewAuditedUseCase[*models.DeleteKnowledgeItemCommand](
+		"DeleteKnowledgeItem",
+		usecases.NewDeleteKnowledgeItem(knowledgeItemService, personalWorkspace(ctrl), presenter),
+		auditService,
+	)
+
+	if err := uc.Handle(aliceContext(), cmd); !errors.Is(err, expectedError) {
+		t.Errorf("got error %v, want %v", err, expectedError)
+	}
+}
@@ -0,0 +1,79 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+)
+
+// Autocomplete type represents usecase that has sequence of actions
+// to suggest existing tags and category names while they are typed.
+type Autocomplete struct {
+	index            models.AutocompleteIndex
+	workspaceService services.WorkspaceService
+	presenter        models.AutocompletePresenter
+}
+
+// NewAutocomplete function builds new instance of Autocomplete usecase.
+func NewAutocomplete(
+	index models.AutocompleteIndex,
+	workspaceService services.WorkspaceService,
+	presenter models.AutocompletePresenter,
+) *Autocomplete {
+	return &Autocomplete{
+		index:            index,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// The number of suggestions defaults to 10 and can't be more than 50.
+func (uc *Autocomplete) Handle(ctx context.Context, query *models.AutocompleteQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	switch query.Kind {
+	case "", models.AutocompleteKindTag, models.AutocompleteKindCategory:
+	default:
+		return errors.New("kind has to be tag or category")
+	}
+
+	if query.Limit < 0 {
+		return errors.New("limit cannot be negative")
+	}
+
+	page := *query
+	page.Owner = owner
+	page.Prefix = strings.TrimSpace(page.Prefix)
+	if page.Limit == 0 {
+		page.Limit = defaultAutocompleteLimit
+	}
+	page.Limit = min(page.Limit, maxAutocompleteLimit)
+
+	suggestions, err := uc.index.Suggest(&page)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(suggestions)
+
+	return nil
+}
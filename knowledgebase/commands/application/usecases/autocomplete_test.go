@@ -0,0 +1,78 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAutocomplete_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := []*models.AutocompleteSuggestion{
+		{Value: "golang", Kind: models.AutocompleteKindTag, Count: 3},
+	}
+
+	index := mock.NewMockAutocompleteIndex(ctrl)
+	index.EXPECT().
+		Suggest(&models.AutocompleteQuery{Owner: "alice", Prefix: "go", Kind: models.AutocompleteKindTag, Limit: 10}).
+		Return(expected, nil)
+
+	presenter := mock.NewMockAutocompletePresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewAutocomplete(index, personalWorkspace(ctrl), presenter)
+
+	query := &models.AutocompleteQuery{Prefix: " go ", Kind: models.AutocompleteKindTag}
+	if err := uc.Handle(aliceContext(), query); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAutocomplete_Handle_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := mock.NewMockAutocompleteIndex(ctrl)
+	index.EXPECT().Suggest(&models.AutocompleteQuery{Owner: "alice", Prefix: "go", Limit: 50}).Return(nil, nil)
+
+	presenter := mock.NewMockAutocompletePresenter(ctrl)
+	presenter.EXPECT().SetResult(nil)
+
+	uc := usecases.NewAutocomplete(index, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.AutocompleteQuery{Prefix: "go", Limit: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uc.Handle(aliceContext(), &models.AutocompleteQuery{Prefix: "go", Limit: -1}); err == nil {
+		t.Error("expected error for negative limit")
+	}
+
+	if err := uc.Handle(aliceContext(), &models.AutocompleteQuery{Prefix: "go", Kind: "anchor"}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestAutocomplete_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	index := mock.NewMockAutocompleteIndex(ctrl)
+	index.EXPECT().Suggest(gomock.Any()).Return(nil, expectedError)
+
+	presenter := mock.NewMockAutocompletePresenter(ctrl)
+
+	uc := usecases.NewAutocomplete(index, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.AutocompleteQuery{Prefix: "go"}); !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,251 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	defaultBatchSize      = 100
+	defaultValueSeparator = ","
+)
+
+// BulkImportKnowledgeItems type represents usecase that has sequence of actions
+// to create models.KnowledgeItem from the rows of CSV or TSV table.
+type BulkImportKnowledgeItems struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	presenter            models.BulkImportKnowledgeItemsPresenter
+}
+
+// NewBulkImportKnowledgeItems function builds new instance of BulkImportKnowledgeItems usecase.
+func NewBulkImportKnowledgeItems(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	presenter models.BulkImportKnowledgeItemsPresenter,
+) *BulkImportKnowledgeItems {
+	return &BulkImportKnowledgeItems{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		presenter:            presenter,
+	}
+}
+
+// tableRow represents knowledge item fields extracted from the table row.
+type tableRow struct {
+	report     *models.RowReport
+	title      string
+	anchor     string
+	data       string
+	tags       []string
+	categories []string
+}
+
+// Handle function performs usecase actions.
+// Every row is validated first, valid rows are stored in batches unless it is a dry run.
+func (uc *BulkImportKnowledgeItems) Handle(ctx context.Context, cmd *models.BulkImportKnowledgeItemsCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := parseTable(cmd)
+	if err != nil {
+		return err
+	}
+
+	report := &models.BulkImportReport{DryRun: cmd.DryRun}
+	var valid []*tableRow
+	for _, row := range rows {
+		report.Rows = append(report.Rows, row.report)
+
+		if err = uc.validate(row); err != nil {
+			row.report.Status = models.RowStatusInvalid
+			row.report.Reason = err.Error()
+			continue
+		}
+
+		row.report.Status = models.RowStatusValid
+		valid = append(valid, row)
+	}
+
+	if !cmd.DryRun {
+		batchSize := cmd.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultBatchSize
+		}
+
+		categories := make(map[string]*domain.Category)
+		for start := 0; start < len(valid); start += batchSize {
+			end := min(start+batchSize, len(valid))
+			if err = uc.importBatch(user.Login, valid[start:end], categories); err != nil {
+				return err
+			}
+		}
+	}
+
+	uc.presenter.SetResult(report)
+
+	return nil
+}
+
+// validate function runs the same rules as AddKnowledgeItem usecase without storing anything.
+func (uc *BulkImportKnowledgeItems) validate(row *tableRow) error {
+	err := uc.knowledgeItemService.ValidateItem(row.title, row.anchor, row.data, row.tags)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range row.categories {
+		if err = uc.categoryService.ValidateName(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *BulkImportKnowledgeItems) importBatch(
+	owner string,
+	rows []*tableRow,
+	categories map[string]*domain.Category,
+) error {
+	items := make([]*domain.KnowledgeItem, 0, len(rows))
+	for _, row := range rows {
+		item := &domain.KnowledgeItem{
+			Title:  row.title,
+			Anchor: row.anchor,
+			Data:   row.data,
+			Tags:   row.tags,
+		}
+
+		for _, name := range row.categories {
+			cat, ok := categories[name]
+			if !ok {
+				var err error
+				cat, err = uc.categoryService.CreateOrGetCategory(owner, name)
+				if err != nil {
+					return err
+				}
+
+				categories[name] = cat
+			}
+
+			item.Categories = append(item.Categories, cat)
+		}
+
+		items = append(items, item)
+	}
+
+	items, err := uc.knowledgeItemService.NewItems(owner, items)
+	if err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		row.report.Status = models.RowStatusImported
+		row.report.Item = items[i]
+	}
+
+	return nil
+}
+
+func parseTable(cmd *models.BulkImportKnowledgeItemsCommand) ([]*tableRow, error) {
+	if cmd.Mapping == nil {
+		return nil, errors.New("column mapping is required")
+	}
+
+	reader := csv.NewReader(strings.NewReader(cmd.Content))
+	reader.FieldsPerRecord = -1
+	switch cmd.Format {
+	case models.TableFormatCSV:
+		reader.Comma = ','
+	case models.TableFormatTSV:
+		reader.Comma = '\t'
+		reader.LazyQuotes = true
+	default:
+		return nil, fmt.Errorf("unsupported table format %q", cmd.Format)
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("table header is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	m := cmd.Mapping
+	for _, name := range []string{m.Title, m.Anchor, m.Data} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %q is not found", name)
+		}
+	}
+	for _, name := range []string{m.Tags, m.Categories} {
+		if _, ok := columns[name]; name != "" && !ok {
+			return nil, fmt.Errorf("column %q is not found", name)
+		}
+	}
+
+	var rows []*tableRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(name string) string {
+			i, ok := columns[name]
+			if name == "" || !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		rows = append(rows, &tableRow{
+			report:     &models.RowReport{Row: line},
+			title:      value(m.Title),
+			anchor:     value(m.Anchor),
+			data:       value(m.Data),
+			tags:       splitValues(value(m.Tags), m.TagsSeparator),
+			categories: splitValues(value(m.Categories), m.CategoriesSeparator),
+		})
+	}
+
+	return rows, nil
+}
+
+// splitValues function splits multi-value cell dropping empty values.
+func splitValues(cell, separator string) []string {
+	if separator == "" {
+		separator = defaultValueSeparator
+	}
+
+	var values []string
+	for _, value := range strings.Split(cell, separator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
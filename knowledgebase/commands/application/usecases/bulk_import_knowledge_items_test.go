@@ -0,0 +1,206 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+const bulkImportTable = "Question\tAnswer\tLabels\tSections\n" +
+	"What is a goroutine?\tLightweight thread managed by the Go runtime\tgo; concurrency\tGolang\n" +
+	"Too short\tNo\t\t\n" +
+	"What is a channel?\tTyped conduit between goroutines\tgo\tGolang;Concurrency\n"
+
+func bulkImportMapping() *models.ColumnMapping {
+	return &models.ColumnMapping{
+		Title:               "Question",
+		Anchor:              "Question",
+		Data:                "Answer",
+		Tags:                "Labels",
+		TagsSeparator:       ";",
+		Categories:          "Sections",
+		CategoriesSeparator: ";",
+	}
+}
+
+// expectBulkImportValidation function sets up validation expectations with the real business rules.
+func expectBulkImportValidation(
+	catService *mock.MockCategoryService,
+	itemService *mock.MockKnowledgeItemService,
+) {
+	realItemService := services.NewKnowledgeItemService(nil, nil)
+	realCatService := services.NewCategoryService(nil)
+
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(realItemService.ValidateItem).AnyTimes()
+	catService.EXPECT().ValidateName(gomock.Any()).DoAndReturn(realCatService.ValidateName).AnyTimes()
+}
+
+func TestBulkImportKnowledgeItems_Do_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.BulkImportKnowledgeItemsCommand{
+		Content: bulkImportTable,
+		Format:  models.TableFormatTSV,
+		Mapping: bulkImportMapping(),
+		DryRun:  true,
+	}
+
+	catService := mock.NewMockCategoryService(ctrl)
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	expectBulkImportValidation(catService, itemService)
+
+	presenter := mock.NewMockBulkImportKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.BulkImportReport) {
+		if !report.DryRun {
+			t.Error("expected dry run report")
+		}
+		if len(report.Rows) != 3 {
+			t.Fatalf("expected 3 rows, got %d", len(report.Rows))
+		}
+
+		expected := []struct {
+			row    int
+			status string
+			reason string
+		}{
+			{2, models.RowStatusValid, ""},
+			{3, models.RowStatusInvalid, "data is too short"},
+			{4, models.RowStatusValid, ""},
+		}
+		for i, ex := range expected {
+			row := report.Rows[i]
+			if row.Row != ex.row || row.Status != ex.status || row.Reason != ex.reason {
+				t.Errorf("expected row %+v, got %+v", ex, row)
+			}
+		}
+	})
+
+	uc := usecases.NewBulkImportKnowledgeItems(catService, itemService, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkImportKnowledgeItems_Do_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.BulkImportKnowledgeItemsCommand{
+		Content:   bulkImportTable,
+		Format:    models.TableFormatTSV,
+		Mapping:   bulkImportMapping(),
+		BatchSize: 1,
+	}
+
+	golang := &domain.Category{ID: 1, Name: "Golang"}
+	concurrency := &domain.Category{ID: 2, Name: "Concurrency"}
+
+	catService := mock.NewMockCategoryService(ctrl)
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	expectBulkImportValidation(catService, itemService)
+
+	// categories are resolved once per import.
+	catService.EXPECT().CreateOrGetCategory("alice", "Golang").Return(golang, nil)
+	catService.EXPECT().CreateOrGetCategory("alice", "Concurrency").Return(concurrency, nil)
+
+	var nextID int64 = 10
+	itemService.EXPECT().NewItems("alice", gomock.Any()).Times(2).
+		DoAndReturn(func(_ string, items []*domain.KnowledgeItem) ([]*domain.KnowledgeItem, error) {
+			if len(items) != 1 {
+				t.Fatalf("expected batch of 1 item, got %d", len(items))
+			}
+			nextID++
+			items[0].ID = nextID
+
+			return items, nil
+		})
+
+	presenter := mock.NewMockBulkImportKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.BulkImportReport) {
+		if report.Rows[0].Status != models.RowStatusImported || report.Rows[0].Item.ID != 11 {
+			t.Errorf("unexpected first row %+v", report.Rows[0])
+		}
+		if report.Rows[1].Status != models.RowStatusInvalid {
+			t.Errorf("unexpected second row %+v", report.Rows[1])
+		}
+
+		item := report.Rows[2].Item
+		if report.Rows[2].Status != models.RowStatusImported || item.ID != 12 {
+			t.Fatalf("unexpected third row %+v", report.Rows[2])
+		}
+		if item.Title != "What is a channel?" || item.Data != "Typed conduit between goroutines" {
+			t.Errorf("unexpected item %+v", item)
+		}
+		if len(item.Tags) != 1 || item.Tags[0] != "go" {
+			t.Errorf("unexpected tags %v", item.Tags)
+		}
+		if len(item.Categories) != 2 || item.Categories[0] != golang || item.Categories[1] != concurrency {
+			t.Errorf("unexpected categories %v", item.Categories)
+		}
+	})
+
+	uc := usecases.NewBulkImportKnowledgeItems(catService, itemService, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkImportKnowledgeItems_Do_MissingColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mapping := bulkImportMapping()
+	mapping.Data = "Description"
+	cmd := &models.BulkImportKnowledgeItemsCommand{
+		Content: "Question,Answer\nWhat is a goroutine?,Lightweight thread managed by the Go runtime\n",
+		Format:  models.TableFormatCSV,
+		Mapping: mapping,
+	}
+
+	uc := usecases.NewBulkImportKnowledgeItems(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		mock.NewMockBulkImportKnowledgeItemsPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err == nil || err.Error() != `column "Description" is not found` {
+		t.Errorf("expected missing column error, got %v", err)
+	}
+}
+
+func TestBulkImportKnowledgeItems_Do_NewItemsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.BulkImportKnowledgeItemsCommand{
+		Content: "Question,Answer\nWhat is a goroutine?,Lightweight thread managed by the Go runtime\n",
+		Format:  models.TableFormatCSV,
+		Mapping: &models.ColumnMapping{Title: "Question", Anchor: "Question", Data: "Answer"},
+	}
+	expectedError := errors.New("expected error")
+
+	catService := mock.NewMockCategoryService(ctrl)
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	expectBulkImportValidation(catService, itemService)
+	itemService.EXPECT().NewItems("alice", gomock.Any()).Return(nil, expectedError)
+
+	uc := usecases.NewBulkImportKnowledgeItems(catService, itemService, mock.NewMockBulkImportKnowledgeItemsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ChangeWorkspaceMemberRole type represents usecase that has sequence of actions to change models.WorkspaceMember role.
+type ChangeWorkspaceMemberRole struct {
+	workspaceService services.WorkspaceService
+	presenter        models.ChangeWorkspaceMemberRolePresenter
+}
+
+// NewChangeWorkspaceMemberRole function builds new instance of ChangeWorkspaceMemberRole usecase.
+func NewChangeWorkspaceMemberRole(
+	workspaceService services.WorkspaceService,
+	presenter models.ChangeWorkspaceMemberRolePresenter,
+) *ChangeWorkspaceMemberRole {
+	return &ChangeWorkspaceMemberRole{
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ChangeWorkspaceMemberRole) Handle(ctx context.Context, cmd *models.ChangeWorkspaceMemberRoleCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := uc.workspaceService.ChangeMemberRole(user.Login, cmd.WorkspaceID, cmd.Login, cmd.Role)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(member)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangeWorkspaceMemberRole_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := &domain.WorkspaceMember{WorkspaceID: 5, Login: "bob", Role: domain.RoleReviewer}
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().ChangeMemberRole("alice", int64(5), "bob", domain.RoleReviewer).Return(member, nil)
+
+	presenter := mock.NewMockChangeWorkspaceMemberRolePresenter(ctrl)
+	presenter.EXPECT().SetResult(member)
+
+	uc := usecases.NewChangeWorkspaceMemberRole(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ChangeWorkspaceMemberRoleCommand{
+		WorkspaceID: 5, Login: "bob", Role: domain.RoleReviewer,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangeWorkspaceMemberRole_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().ChangeMemberRole("alice", int64(5), "bob", domain.RoleReviewer).Return(nil, expectedError)
+
+	uc := usecases.NewChangeWorkspaceMemberRole(service, mock.NewMockChangeWorkspaceMemberRolePresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ChangeWorkspaceMemberRoleCommand{
+		WorkspaceID: 5, Login: "bob", Role: domain.RoleReviewer,
+	})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
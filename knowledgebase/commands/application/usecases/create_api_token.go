@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// CreateAPIToken type represents usecase that has sequence of actions to issue new models.APIToken.
+type CreateAPIToken struct {
+	apiTokenService services.APITokenService
+	presenter       models.CreateAPITokenPresenter
+}
+
+// NewCreateAPIToken function builds new instance of CreateAPIToken usecase.
+func NewCreateAPIToken(
+	apiTokenService services.APITokenService,
+	presenter models.CreateAPITokenPresenter,
+) *CreateAPIToken {
+	return &CreateAPIToken{
+		apiTokenService: apiTokenService,
+		presenter:       presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *CreateAPIToken) Handle(ctx context.Context, cmd *models.CreateAPITokenCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, secret, err := uc.apiTokenService.NewToken(user.Login, cmd.Name, cmd.Scopes, cmd.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(&models.IssuedAPIToken{Token: token, Secret: secret})
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIToken_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	cmd := &models.CreateAPITokenCommand{
+		Name:      "CI",
+		Scopes:    []string{domain.ScopeItemsRead},
+		ExpiresAt: &expiresAt,
+	}
+	token := &domain.APIToken{ID: 1, Owner: "alice", Name: "CI", Scopes: cmd.Scopes, ExpiresAt: &expiresAt}
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().NewToken("alice", cmd.Name, cmd.Scopes, cmd.ExpiresAt).Return(token, "ngt_secret", nil)
+
+	presenter := mock.NewMockCreateAPITokenPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(issued *models.IssuedAPIToken) {
+		if issued.Token != token || issued.Secret != "ngt_secret" {
+			t.Errorf("unexpected issued token %+v", issued)
+		}
+	})
+
+	uc := usecases.NewCreateAPIToken(service, presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateAPIToken_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().NewToken("alice", "CI", nil, nil).Return(nil, "", expectedError)
+
+	uc := usecases.NewCreateAPIToken(service, mock.NewMockCreateAPITokenPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.CreateAPITokenCommand{Name: "CI"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// CreateAssignment type represents usecase that has sequence of actions to create new models.Assignment.
+type CreateAssignment struct {
+	assignmentService services.AssignmentService
+	workspaceService  services.WorkspaceService
+	presenter         models.CreateAssignmentPresenter
+}
+
+// NewCreateAssignment function builds new instance of CreateAssignment usecase.
+func NewCreateAssignment(
+	assignmentService services.AssignmentService,
+	workspaceService services.WorkspaceService,
+	presenter models.CreateAssignmentPresenter,
+) *CreateAssignment {
+	return &CreateAssignment{
+		assignmentService: assignmentService,
+		workspaceService:  workspaceService,
+		presenter:         presenter,
+	}
+}
+
+// Handle function performs usecase actions. Assignments are given by the members allowed to manage the workspace,
+// the acting user becomes the instructor.
+func (uc *CreateAssignment) Handle(ctx context.Context, cmd *models.CreateAssignmentCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	assignment, err := uc.assignmentService.NewAssignment(
+		owner, user.Login, cmd.Title,
+		cmd.ItemIDs, cmd.CategoryIDs,
+		cmd.Learners, cmd.DueAt)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(assignment)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAssignment_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dueAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	cmd := &models.CreateAssignmentCommand{
+		WorkspaceID: 5,
+		Title:       "Onboarding",
+		ItemIDs:     []int64{7},
+		CategoryIDs: []int64{2},
+		Learners:    []string{"bob"},
+		DueAt:       &dueAt,
+	}
+	assignment := &domain.Assignment{ID: 4, Owner: "workspace:5", Instructor: "alice", Title: cmd.Title}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	assignmentService := mock.NewMockAssignmentService(ctrl)
+	assignmentService.EXPECT().
+		NewAssignment("workspace:5", "alice", cmd.Title, cmd.ItemIDs, cmd.CategoryIDs, cmd.Learners, cmd.DueAt).
+		Return(assignment, nil)
+
+	presenter := mock.NewMockCreateAssignmentPresenter(ctrl)
+	presenter.EXPECT().SetResult(assignment)
+
+	uc := usecases.NewCreateAssignment(assignmentService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateAssignment_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("", services.ErrForbidden)
+
+	uc := usecases.NewCreateAssignment(
+		mock.NewMockAssignmentService(ctrl),
+		workspaceService,
+		mock.NewMockCreateAssignmentPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.CreateAssignmentCommand{WorkspaceID: 5, Title: "Onboarding"})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
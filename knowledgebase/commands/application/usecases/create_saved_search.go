@@ -0,0 +1,48 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// CreateSavedSearch type represents usecase that has sequence of actions to create new models.SavedSearch.
+type CreateSavedSearch struct {
+	savedSearchService services.SavedSearchService
+	presenter          models.CreateSavedSearchPresenter
+}
+
+// NewCreateSavedSearch function builds new instance of CreateSavedSearch usecase.
+func NewCreateSavedSearch(
+	savedSearchService services.SavedSearchService,
+	presenter models.CreateSavedSearchPresenter,
+) *CreateSavedSearch {
+	return &CreateSavedSearch{
+		savedSearchService: savedSearchService,
+		presenter:          presenter,
+	}
+}
+
+// Handle function performs usecase actions, the new search is presented as a smart collection.
+func (uc *CreateSavedSearch) Handle(ctx context.Context, cmd *models.CreateSavedSearchCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	search, err := uc.savedSearchService.NewSavedSearch(user.Login, cmd.Name, cmd.Filter)
+	if err != nil {
+		return err
+	}
+
+	collection, _, err := collect(uc.savedSearchService, search.Owner, search)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(collection)
+
+	return nil
+}
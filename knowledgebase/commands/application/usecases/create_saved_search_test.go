@@ -0,0 +1,61 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSavedSearch_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	search := &domain.SavedSearch{ID: 1, Owner: "alice", Name: "Weak Go topics", Filter: "tag:go score<40"}
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().NewSavedSearch("alice", "Weak Go topics", "tag:go score<40").Return(search, nil)
+	service.EXPECT().FindItems("alice", search).
+		Return([]*domain.KnowledgeItem{{ID: 1, Score: 10}, {ID: 2, Score: 25}}, nil)
+
+	presenter := mock.NewMockCreateSavedSearchPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(collection *models.SmartCollection) {
+		if collection.Search != search {
+			t.Errorf("expected search %v, got %v", search, collection.Search)
+		}
+		if collection.Count != 2 || collection.AverageScore != 17.5 {
+			t.Errorf("expected 2 items with average 17.5, got %d with %v", collection.Count, collection.AverageScore)
+		}
+	})
+
+	uc := usecases.NewCreateSavedSearch(service, presenter)
+
+	err := uc.Handle(aliceContext(), &models.CreateSavedSearchCommand{
+		Name:   "Weak Go topics",
+		Filter: "tag:go score<40",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateSavedSearch_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().NewSavedSearch("alice", "Weak", "score<").Return(nil, expectedError)
+
+	uc := usecases.NewCreateSavedSearch(service, mock.NewMockCreateSavedSearchPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.CreateSavedSearchCommand{Name: "Weak", Filter: "score<"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// CreateWebhook type represents usecase that has sequence of actions to create new models.Webhook.
+type CreateWebhook struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.CreateWebhookPresenter
+}
+
+// NewCreateWebhook function builds new instance of CreateWebhook usecase.
+func NewCreateWebhook(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.CreateWebhookPresenter,
+) *CreateWebhook {
+	return &CreateWebhook{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. Webhooks are managed by the members allowed to manage the workspace.
+func (uc *CreateWebhook) Handle(ctx context.Context, cmd *models.CreateWebhookCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	webhook, secret, err := uc.webhookService.NewWebhook(owner, user.Login, cmd.URL, cmd.Events)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(&models.IssuedWebhook{Webhook: webhook, Secret: secret})
+
+	return nil
+}
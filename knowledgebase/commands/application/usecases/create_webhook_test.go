@@ -0,0 +1,57 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhook_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhook := &domain.Webhook{ID: 3, Owner: "workspace:5", URL: "https://chat.example.com/hooks/1"}
+	events := []string{domain.EventItemCreated, domain.EventItemReviewed}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().NewWebhook("workspace:5", "alice", "https://chat.example.com/hooks/1", events).
+		Return(webhook, "whs_secret", nil)
+
+	presenter := mock.NewMockCreateWebhookPresenter(ctrl)
+	presenter.EXPECT().SetResult(&models.IssuedWebhook{Webhook: webhook, Secret: "whs_secret"})
+
+	uc := usecases.NewCreateWebhook(webhookService, workspaceService, presenter)
+
+	cmd := &models.CreateWebhookCommand{WorkspaceID: 5, URL: "https://chat.example.com/hooks/1", Events: events}
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateWebhook_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("", services.ErrForbidden)
+
+	uc := usecases.NewCreateWebhook(
+		mock.NewMockWebhookService(ctrl),
+		workspaceService,
+		mock.NewMockCreateWebhookPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.CreateWebhookCommand{WorkspaceID: 5, URL: "https://example.com"})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// CreateWorkspace type represents usecase that has sequence of actions to create new models.Workspace.
+type CreateWorkspace struct {
+	workspaceService services.WorkspaceService
+	presenter        models.CreateWorkspacePresenter
+}
+
+// NewCreateWorkspace function builds new instance of CreateWorkspace usecase.
+func NewCreateWorkspace(
+	workspaceService services.WorkspaceService,
+	presenter models.CreateWorkspacePresenter,
+) *CreateWorkspace {
+	return &CreateWorkspace{
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *CreateWorkspace) Handle(ctx context.Context, cmd *models.CreateWorkspaceCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := uc.workspaceService.NewWorkspace(user.Login, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(workspace)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWorkspace_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspace := &domain.Workspace{ID: 5, Name: "Platform team"}
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().NewWorkspace("alice", "Platform team").Return(workspace, nil)
+
+	presenter := mock.NewMockCreateWorkspacePresenter(ctrl)
+	presenter.EXPECT().SetResult(workspace)
+
+	uc := usecases.NewCreateWorkspace(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.CreateWorkspaceCommand{Name: "Platform team"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateWorkspace_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().NewWorkspace("alice", "Platform team").Return(nil, expectedError)
+
+	uc := usecases.NewCreateWorkspace(service, mock.NewMockCreateWorkspacePresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.CreateWorkspaceCommand{Name: "Platform team"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
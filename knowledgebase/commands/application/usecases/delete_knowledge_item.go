@@ -5,29 +5,46 @@ import (
 	"context"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
 )
 
 // DeleteKnowledgeItem type represents usecase that has sequence of actions to delete new models.KnowledgeItem.
 type DeleteKnowledgeItem struct {
 	knowledgeItemService services.KnowledgeItemService
+	workspaceService     services.WorkspaceService
 	presenter            models.DeleteKnowledgeItemPresenter
 }
 
 // NewDeleteKnowledgeItem function builds new instance of DeleteKnowledgeItem usecase.
 func NewDeleteKnowledgeItem(
 	knowledgeItemService services.KnowledgeItemService,
+	workspaceService services.WorkspaceService,
 	presenter models.DeleteKnowledgeItemPresenter,
 ) *DeleteKnowledgeItem {
 	return &DeleteKnowledgeItem{
 		knowledgeItemService: knowledgeItemService,
+		workspaceService:     workspaceService,
 		presenter:            presenter,
 	}
 }
 
-// Handle function performs usecase actions.
-func (uc *DeleteKnowledgeItem) Handle(_ context.Context, cmd *models.DeleteKnowledgeItemCommand) error {
-	err := uc.knowledgeItemService.DeleteItem(cmd.ID)
+// Handle function performs usecase actions. Items of the workspace are deleted by the members allowed to edit it.
+func (uc *DeleteKnowledgeItem) Handle(ctx context.Context, cmd *models.DeleteKnowledgeItemCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+	auditItems(ctx, cmd.ID)
+
+	err = uc.knowledgeItemService.DeleteItem(owner, cmd.ID)
 	if err != nil {
 		return err
 	}
@@ -1,12 +1,13 @@
 package usecases_test
 
 import (
-	"context"
 	"errors"
 	"testing"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
 	"github.com/96solutions/neurography/knowledgebase/commands/mock"
 	"go.uber.org/mock/gomock"
 )
@@ -18,16 +19,16 @@ func TestDeleteKnowledgeItem_Do(t *testing.T) {
 	expectedItemID := int64(5)
 
 	service := mock.NewMockKnowledgeItemService(ctrl)
-	service.EXPECT().DeleteItem(expectedItemID).Return(nil)
+	service.EXPECT().DeleteItem("alice", expectedItemID).Return(nil)
 
 	req := &models.DeleteKnowledgeItemCommand{ID: expectedItemID}
 
 	presenter := mock.NewMockDeleteKnowledgeItemPresenter(ctrl)
 	presenter.EXPECT().SetResult(true)
 
-	uc := usecases.NewDeleteKnowledgeItem(service, presenter)
+	uc := usecases.NewDeleteKnowledgeItem(service, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, req)
 	if err != nil {
@@ -43,15 +44,15 @@ func TestDeleteKnowledgeItem_Do_Error(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	service := mock.NewMockKnowledgeItemService(ctrl)
-	service.EXPECT().DeleteItem(expectedItemID).Return(expectedError)
+	service.EXPECT().DeleteItem("alice", expectedItemID).Return(expectedError)
 
 	req := &models.DeleteKnowledgeItemCommand{ID: expectedItemID}
 
 	presenter := mock.NewMockDeleteKnowledgeItemPresenter(ctrl)
 
-	uc := usecases.NewDeleteKnowledgeItem(service, presenter)
+	uc := usecases.NewDeleteKnowledgeItem(service, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, req)
 	if err == nil {
@@ -61,3 +62,29 @@ func TestDeleteKnowledgeItem_Do_Error(t *testing.T) {
 		t.Fatalf("Expected: %s, got: %s", expectedError.Error(), err.Error())
 	}
 }
+
+func TestDeleteKnowledgeItem_Do_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+	workspaceService.EXPECT().Authorize("alice", int64(6), domain.PermissionEdit).Return("", services.ErrForbidden)
+
+	service := mock.NewMockKnowledgeItemService(ctrl)
+	service.EXPECT().DeleteItem("workspace:5", int64(7)).Return(nil)
+
+	presenter := mock.NewMockDeleteKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewDeleteKnowledgeItem(service, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.DeleteKnowledgeItemCommand{ID: 7, WorkspaceID: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := uc.Handle(aliceContext(), &models.DeleteKnowledgeItemCommand{ID: 7, WorkspaceID: 6})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// DeleteSavedSearch type represents usecase that has sequence of actions to delete models.SavedSearch.
+type DeleteSavedSearch struct {
+	savedSearchService services.SavedSearchService
+	presenter          models.DeleteSavedSearchPresenter
+}
+
+// NewDeleteSavedSearch function builds new instance of DeleteSavedSearch usecase.
+func NewDeleteSavedSearch(
+	savedSearchService services.SavedSearchService,
+	presenter models.DeleteSavedSearchPresenter,
+) *DeleteSavedSearch {
+	return &DeleteSavedSearch{
+		savedSearchService: savedSearchService,
+		presenter:          presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *DeleteSavedSearch) Handle(ctx context.Context, cmd *models.DeleteSavedSearchCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = uc.savedSearchService.DeleteSavedSearch(user.Login, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(true)
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteSavedSearch_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().DeleteSavedSearch("alice", int64(3)).Return(nil)
+
+	presenter := mock.NewMockDeleteSavedSearchPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewDeleteSavedSearch(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.DeleteSavedSearchCommand{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
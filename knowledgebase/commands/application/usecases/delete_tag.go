@@ -0,0 +1,44 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// DeleteTag type represents usecase that has sequence of actions
+// to remove the tag from all the knowledge items.
+type DeleteTag struct {
+	tagService services.TagService
+	presenter  models.DeleteTagPresenter
+}
+
+// NewDeleteTag function builds new instance of DeleteTag usecase.
+func NewDeleteTag(
+	tagService services.TagService,
+	presenter models.DeleteTagPresenter,
+) *DeleteTag {
+	return &DeleteTag{
+		tagService: tagService,
+		presenter:  presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *DeleteTag) Handle(ctx context.Context, cmd *models.DeleteTagCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = uc.tagService.DeleteTag(user.Login, cmd.Tag)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(true)
+
+	return nil
+}
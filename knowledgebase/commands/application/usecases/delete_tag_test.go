@@ -0,0 +1,44 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteTag_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().DeleteTag("alice", "obsolete").Return(nil)
+
+	presenter := mock.NewMockDeleteTagPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewDeleteTag(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.DeleteTagCommand{Tag: "obsolete"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteTag_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().DeleteTag("alice", "obsolete").Return(expectedError)
+
+	uc := usecases.NewDeleteTag(service, mock.NewMockDeleteTagPresenter(ctrl))
+
+	if err := uc.Handle(aliceContext(), &models.DeleteTagCommand{Tag: "obsolete"}); !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// DeleteWebhook type represents usecase that has sequence of actions to delete models.Webhook.
+type DeleteWebhook struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.DeleteWebhookPresenter
+}
+
+// NewDeleteWebhook function builds new instance of DeleteWebhook usecase.
+func NewDeleteWebhook(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.DeleteWebhookPresenter,
+) *DeleteWebhook {
+	return &DeleteWebhook{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. The delivery log of the webhook is deleted as well.
+func (uc *DeleteWebhook) Handle(ctx context.Context, cmd *models.DeleteWebhookCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.webhookService.DeleteWebhook(owner, cmd.ID); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(true)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteWebhook_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().DeleteWebhook("alice", int64(3)).Return(nil)
+
+	presenter := mock.NewMockDeleteWebhookPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewDeleteWebhook(webhookService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.DeleteWebhookCommand{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteWebhook_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().DeleteWebhook("alice", int64(3)).Return(expectedError)
+
+	uc := usecases.NewDeleteWebhook(webhookService, personalWorkspace(ctrl), mock.NewMockDeleteWebhookPresenter(ctrl))
+
+	if err := uc.Handle(aliceContext(), &models.DeleteWebhookCommand{ID: 3}); !errors.Is(err, expectedError) {
+		t.Errorf("got error %v, want %v", err, expectedError)
+	}
+}
@@ -0,0 +1,51 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// DismissDeckUpdate type represents usecase that has sequence of actions to dismiss models.DeckUpdate.
+type DismissDeckUpdate struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.DismissDeckUpdatePresenter
+}
+
+// NewDismissDeckUpdate function builds new instance of DismissDeckUpdate usecase.
+func NewDismissDeckUpdate(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.DismissDeckUpdatePresenter,
+) *DismissDeckUpdate {
+	return &DismissDeckUpdate{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. The copy of the item keeps local edits.
+func (uc *DismissDeckUpdate) Handle(ctx context.Context, cmd *models.DismissDeckUpdateCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.deckService.DismissUpdate(owner, cmd.SubscriptionID, cmd.SourceID); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(true)
+
+	return nil
+}
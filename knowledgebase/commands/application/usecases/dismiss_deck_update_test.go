@@ -0,0 +1,27 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDismissDeckUpdate_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().DismissUpdate("alice", int64(6), int64(11)).Return(nil)
+
+	presenter := mock.NewMockDismissDeckUpdatePresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewDismissDeckUpdate(deckService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.DismissDeckUpdateCommand{SubscriptionID: 6, SourceID: 11}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// EnableWebhook type represents usecase that has sequence of actions
+// to enable models.Webhook disabled for failing too often.
+type EnableWebhook struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.EnableWebhookPresenter
+}
+
+// NewEnableWebhook function builds new instance of EnableWebhook usecase.
+func NewEnableWebhook(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.EnableWebhookPresenter,
+) *EnableWebhook {
+	return &EnableWebhook{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *EnableWebhook) Handle(ctx context.Context, cmd *models.EnableWebhookCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	webhook, err := uc.webhookService.EnableWebhook(owner, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(webhook)
+
+	return nil
+}
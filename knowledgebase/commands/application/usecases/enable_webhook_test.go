@@ -0,0 +1,33 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnableWebhook_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhook := &domain.Webhook{ID: 3, Owner: "workspace:5"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().EnableWebhook("workspace:5", int64(3)).Return(webhook, nil)
+
+	presenter := mock.NewMockEnableWebhookPresenter(ctrl)
+	presenter.EXPECT().SetResult(webhook)
+
+	uc := usecases.NewEnableWebhook(webhookService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.EnableWebhookCommand{WorkspaceID: 5, ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
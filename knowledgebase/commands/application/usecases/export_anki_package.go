@@ -0,0 +1,136 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ankiDeckSeparator separates names of the parent decks in the name of Anki deck.
+const ankiDeckSeparator = "::"
+
+// ExportAnkiPackage type represents usecase that has sequence of actions
+// to write models.KnowledgeItem into Anki deck package.
+type ExportAnkiPackage struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	writer               models.AnkiPackageWriter
+	presenter            models.ExportAnkiPackagePresenter
+}
+
+// NewExportAnkiPackage function builds new instance of ExportAnkiPackage usecase.
+func NewExportAnkiPackage(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	writer models.AnkiPackageWriter,
+	presenter models.ExportAnkiPackagePresenter,
+) *ExportAnkiPackage {
+	return &ExportAnkiPackage{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		writer:               writer,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ExportAnkiPackage) Handle(ctx context.Context, cmd *models.ExportAnkiPackageCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var categories []*domain.Category
+	for _, categoryName := range cmd.Categories {
+		cat, err := uc.categoryService.GetCategory(user.Login, categoryName)
+		if err != nil {
+			return err
+		}
+
+		categories = append(categories, cat)
+	}
+
+	categories, err = withDescendants(uc.categoryService, user.Login, categories)
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.knowledgeItemService.ListItems(user.Login, categories)
+	if err != nil {
+		return err
+	}
+
+	pkg := &models.AnkiPackage{}
+	for _, item := range items {
+		note := &models.AnkiNote{
+			ID:    item.ID,
+			Deck:  ankiDeckName(primaryCategoryName(item, categories)),
+			Title: item.Title,
+			Front: item.Anchor,
+			Back:  item.Data,
+			Tags:  item.Tags,
+		}
+
+		// items keep no review history, so the last check is the only review Anki gets,
+		// score and the exact mark travel in the note to be restored by the import.
+		if cmd.WithReviewHistory && item.LastCheckAt != nil {
+			note.Reviews = []*models.AnkiReview{{
+				Ease:       models.AnkiEaseFromMark(item.LastMark),
+				ReviewedAt: *item.LastCheckAt,
+			}}
+			note.ReviewState = &models.AnkiReviewState{Score: item.Score, LastMark: item.LastMark}
+		}
+
+		pkg.Notes = append(pkg.Notes, note)
+	}
+
+	if err = uc.writer.Write(cmd.Path, pkg); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(pkg)
+
+	return nil
+}
+
+// primaryCategoryName function chooses the single category of the knowledge item for exports
+// which can place it only in one place, like Anki deck or vault folder.
+// The first selected category wins, otherwise the first category of the item is used.
+func primaryCategoryName(item *domain.KnowledgeItem, selected []*domain.Category) string {
+	for _, category := range item.Categories {
+		for _, sel := range selected {
+			if category.ID == sel.ID {
+				return category.Name
+			}
+		}
+	}
+
+	if len(item.Categories) > 0 {
+		return item.Categories[0].Name
+	}
+
+	return ""
+}
+
+// withDescendants function adds subcategories to the categories selected for export,
+// nothing is added when no category is selected, since all the items are exported then.
+func withDescendants(
+	categoryService services.CategoryService,
+	owner string,
+	categories []*domain.Category,
+) ([]*domain.Category, error) {
+	if len(categories) == 0 {
+		return categories, nil
+	}
+
+	return categoryService.WithDescendants(owner, categories)
+}
+
+// ankiDeckName function converts the category path into the name of Anki deck, which separates subdecks by "::".
+func ankiDeckName(category string) string {
+	return strings.ReplaceAll(category, domain.CategorySeparator, ankiDeckSeparator)
+}
@@ -0,0 +1,148 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportAnkiPackage_Do_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ExportAnkiPackageCommand{
+		Path:              "deck.apkg",
+		Categories:        []string{"Runtime"},
+		WithReviewHistory: true,
+	}
+
+	golang := &domain.Category{ID: 1, Name: "Golang"}
+	runtime := &domain.Category{ID: 2, Name: "Runtime"}
+	scheduler := &domain.Category{ID: 3, Name: "Runtime/Scheduler", ParentID: 2}
+	lastCheckAt := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []*domain.KnowledgeItem{
+		{
+			ID:          5,
+			Title:       "Goroutines",
+			Anchor:      "What is a goroutine?",
+			Data:        "Lightweight thread managed by the Go runtime",
+			Tags:        []string{"go"},
+			Categories:  []*domain.Category{golang, runtime},
+			LastMark:    9,
+			LastCheckAt: &lastCheckAt,
+		},
+		{
+			ID:         6,
+			Title:      "Scheduler",
+			Anchor:     "What is GOMAXPROCS?",
+			Data:       "Limit of OS threads executing Go code simultaneously",
+			Categories: []*domain.Category{scheduler},
+		},
+	}
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().GetCategory("alice", "Runtime").Return(runtime, nil)
+	catService.EXPECT().
+		WithDescendants("alice", []*domain.Category{runtime}).
+		Return([]*domain.Category{runtime, scheduler}, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ListItems("alice", []*domain.Category{runtime, scheduler}).Return(items, nil)
+
+	writer := mock.NewMockAnkiPackageWriter(ctrl)
+	writer.EXPECT().Write(cmd.Path, gomock.Any()).DoAndReturn(func(_ string, pkg *models.AnkiPackage) error {
+		if len(pkg.Notes) != 2 {
+			t.Fatalf("expected 2 notes, got %d", len(pkg.Notes))
+		}
+
+		note := pkg.Notes[0]
+		if note.ID != 5 || note.Title != items[0].Title || note.Front != items[0].Anchor || note.Back != items[0].Data {
+			t.Errorf("unexpected note %+v", note)
+		}
+		if note.Deck != "Runtime" {
+			t.Errorf("expected deck of the selected category, got %s", note.Deck)
+		}
+		if len(note.Reviews) != 1 || note.Reviews[0].Ease != 4 || !note.Reviews[0].ReviewedAt.Equal(lastCheckAt) {
+			t.Errorf("unexpected reviews %+v", note.Reviews)
+		}
+		if note.ReviewState == nil || note.ReviewState.LastMark != 9 {
+			t.Errorf("expected review state of the item, got %+v", note.ReviewState)
+		}
+		if pkg.Notes[1].Deck != "Runtime::Scheduler" {
+			t.Errorf("expected subdeck of the subcategory, got %s", pkg.Notes[1].Deck)
+		}
+		if len(pkg.Notes[1].Reviews) != 0 {
+			t.Errorf("expected no reviews for never checked item, got %+v", pkg.Notes[1].Reviews)
+		}
+
+		return nil
+	})
+
+	presenter := mock.NewMockExportAnkiPackagePresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any())
+
+	uc := usecases.NewExportAnkiPackage(catService, itemService, writer, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportAnkiPackage_Do_CategoryServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ExportAnkiPackageCommand{
+		Path:       "deck.apkg",
+		Categories: []string{"Runtime"},
+	}
+	expectedError := errors.New("expected error")
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().GetCategory("alice", "Runtime").Return(nil, expectedError)
+
+	uc := usecases.NewExportAnkiPackage(
+		catService,
+		mock.NewMockKnowledgeItemService(ctrl),
+		mock.NewMockAnkiPackageWriter(ctrl),
+		mock.NewMockExportAnkiPackagePresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
+
+func TestExportAnkiPackage_Do_WriterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ExportAnkiPackageCommand{Path: "deck.apkg"}
+	expectedError := errors.New("expected error")
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ListItems("alice", nil).Return(nil, nil)
+
+	writer := mock.NewMockAnkiPackageWriter(ctrl)
+	writer.EXPECT().Write(cmd.Path, gomock.Any()).Return(expectedError)
+
+	uc := usecases.NewExportAnkiPackage(
+		mock.NewMockCategoryService(ctrl),
+		itemService,
+		writer,
+		mock.NewMockExportAnkiPackagePresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
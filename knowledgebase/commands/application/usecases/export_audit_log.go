@@ -0,0 +1,67 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ExportAuditLog type represents usecase that has sequence of actions to write models.AuditEntry
+// into the file, like JSON Lines one.
+type ExportAuditLog struct {
+	auditService     services.AuditService
+	workspaceService services.WorkspaceService
+	writer           models.AuditLogWriter
+	presenter        models.ExportAuditLogPresenter
+}
+
+// NewExportAuditLog function builds new instance of ExportAuditLog usecase.
+func NewExportAuditLog(
+	auditService services.AuditService,
+	workspaceService services.WorkspaceService,
+	writer models.AuditLogWriter,
+	presenter models.ExportAuditLogPresenter,
+) *ExportAuditLog {
+	return &ExportAuditLog{
+		auditService:     auditService,
+		workspaceService: workspaceService,
+		writer:           writer,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. The audit log of the workspace is exported by the members
+// allowed to manage it.
+func (uc *ExportAuditLog) Handle(ctx context.Context, cmd *models.ExportAuditLogCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	entries, err := uc.auditService.Search(&domain.AuditFilter{
+		Owner:  owner,
+		Actor:  cmd.Actor,
+		ItemID: cmd.ItemID,
+		From:   cmd.From,
+		To:     cmd.To,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = uc.writer.Write(cmd.Path, entries); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(entries)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportAuditLog_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	entries := []*domain.AuditEntry{{ID: 3, Actor: "alice", Owner: "alice", ItemIDs: []int64{7}}}
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Search(&domain.AuditFilter{Owner: "alice", ItemID: 7}).Return(entries, nil)
+
+	writer := mock.NewMockAuditLogWriter(ctrl)
+	writer.EXPECT().Write("audit.jsonl", entries).Return(nil)
+
+	presenter := mock.NewMockExportAuditLogPresenter(ctrl)
+	presenter.EXPECT().SetResult(entries)
+
+	uc := usecases.NewExportAuditLog(auditService, personalWorkspace(ctrl), writer, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ExportAuditLogCommand{Path: "audit.jsonl", ItemID: 7}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ExportMarkdownVault type represents usecase that has sequence of actions
+// to write models.KnowledgeItem as Markdown files.
+type ExportMarkdownVault struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	writer               models.MarkdownVaultWriter
+	presenter            models.ExportMarkdownVaultPresenter
+}
+
+// NewExportMarkdownVault function builds new instance of ExportMarkdownVault usecase.
+func NewExportMarkdownVault(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	writer models.MarkdownVaultWriter,
+	presenter models.ExportMarkdownVaultPresenter,
+) *ExportMarkdownVault {
+	return &ExportMarkdownVault{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		writer:               writer,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// Every item is placed into the folder of its first category, all categories are kept in the frontmatter.
+func (uc *ExportMarkdownVault) Handle(ctx context.Context, cmd *models.ExportMarkdownVaultCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var categories []*domain.Category
+	for _, categoryName := range cmd.Categories {
+		cat, err := uc.categoryService.GetCategory(user.Login, categoryName)
+		if err != nil {
+			return err
+		}
+
+		categories = append(categories, cat)
+	}
+
+	categories, err = withDescendants(uc.categoryService, user.Login, categories)
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.knowledgeItemService.ListItems(user.Login, categories)
+	if err != nil {
+		return err
+	}
+
+	notes := make([]*models.MarkdownNote, 0, len(items))
+	for _, item := range items {
+		note := &models.MarkdownNote{
+			Folder:      primaryCategoryName(item, categories),
+			ID:          item.ID,
+			Title:       item.Title,
+			Anchor:      item.Anchor,
+			Data:        item.Data,
+			Tags:        item.Tags,
+			Score:       item.Score,
+			LastMark:    item.LastMark,
+			LastCheckAt: item.LastCheckAt,
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.UpdatedAt,
+		}
+		for _, category := range item.Categories {
+			note.Categories = append(note.Categories, category.Name)
+		}
+
+		notes = append(notes, note)
+	}
+
+	if err = uc.writer.Write(cmd.Directory, notes); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(notes)
+
+	return nil
+}
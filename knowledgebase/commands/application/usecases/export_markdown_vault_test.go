@@ -0,0 +1,92 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportMarkdownVault_Do_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ExportMarkdownVaultCommand{Directory: "vault"}
+	golang := &domain.Category{ID: 1, Name: "Golang"}
+	runtime := &domain.Category{ID: 2, Name: "Golang/Runtime"}
+	items := []*domain.KnowledgeItem{
+		{
+			ID:         5,
+			Title:      "Goroutines",
+			Anchor:     "What is a goroutine?",
+			Data:       "Lightweight thread managed by the Go runtime",
+			Tags:       []string{"go"},
+			Categories: []*domain.Category{runtime, golang},
+			Score:      30,
+			LastMark:   7,
+		},
+	}
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ListItems("alice", nil).Return(items, nil)
+
+	writer := mock.NewMockMarkdownVaultWriter(ctrl)
+	writer.EXPECT().Write(cmd.Directory, gomock.Any()).DoAndReturn(func(_ string, notes []*models.MarkdownNote) error {
+		if len(notes) != 1 {
+			t.Fatalf("expected 1 note, got %d", len(notes))
+		}
+
+		note := notes[0]
+		if note.Folder != "Golang/Runtime" {
+			t.Errorf("expected folder of the first category, got %s", note.Folder)
+		}
+		if note.ID != 5 || note.Title != "Goroutines" || note.Score != 30 || note.LastMark != 7 {
+			t.Errorf("unexpected note %+v", note)
+		}
+		if len(note.Categories) != 2 || note.Categories[0] != "Golang/Runtime" || note.Categories[1] != "Golang" {
+			t.Errorf("unexpected categories %v", note.Categories)
+		}
+
+		return nil
+	})
+
+	presenter := mock.NewMockExportMarkdownVaultPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any())
+
+	uc := usecases.NewExportMarkdownVault(mock.NewMockCategoryService(ctrl), itemService, writer, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportMarkdownVault_Do_WriterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ExportMarkdownVaultCommand{Directory: "vault"}
+	expectedError := errors.New("expected error")
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ListItems("alice", nil).Return(nil, nil)
+
+	writer := mock.NewMockMarkdownVaultWriter(ctrl)
+	writer.EXPECT().Write(cmd.Directory, gomock.Any()).Return(expectedError)
+
+	uc := usecases.NewExportMarkdownVault(
+		mock.NewMockCategoryService(ctrl),
+		itemService,
+		writer,
+		mock.NewMockExportMarkdownVaultPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// FindDuplicates type represents usecase that has sequence of actions
+// to report pairs of models.KnowledgeItem which likely contain the same fact.
+type FindDuplicates struct {
+	duplicateService services.DuplicateService
+	presenter        models.FindDuplicatesPresenter
+}
+
+// NewFindDuplicates function builds new instance of FindDuplicates usecase.
+func NewFindDuplicates(
+	duplicateService services.DuplicateService,
+	presenter models.FindDuplicatesPresenter,
+) *FindDuplicates {
+	return &FindDuplicates{
+		duplicateService: duplicateService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *FindDuplicates) Handle(ctx context.Context, query *models.FindDuplicatesQuery) error {
+	if query.MinScore < 0 || query.MinScore > 1 {
+		return errors.New("min score has to be between 0 and 1")
+	}
+
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	pairs, err := uc.duplicateService.FindDuplicates(user.Login)
+	if err != nil {
+		return err
+	}
+
+	result := make([]*domain.DuplicatePair, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.Similarity.Score >= query.MinScore {
+			result = append(result, pair)
+		}
+	}
+
+	uc.presenter.SetResult(result)
+
+	return nil
+}
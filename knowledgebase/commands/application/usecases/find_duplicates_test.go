@@ -0,0 +1,50 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindDuplicates_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exact := &domain.DuplicatePair{
+		First:      &domain.KnowledgeItem{ID: 1},
+		Second:     &domain.KnowledgeItem{ID: 2},
+		Similarity: &domain.Similarity{Score: 0.95},
+	}
+	loose := &domain.DuplicatePair{
+		First:      &domain.KnowledgeItem{ID: 3},
+		Second:     &domain.KnowledgeItem{ID: 4},
+		Similarity: &domain.Similarity{Score: 0.65},
+	}
+
+	service := mock.NewMockDuplicateService(ctrl)
+	service.EXPECT().FindDuplicates("alice").Return([]*domain.DuplicatePair{exact, loose}, nil)
+
+	presenter := mock.NewMockFindDuplicatesPresenter(ctrl)
+	presenter.EXPECT().SetResult([]*domain.DuplicatePair{exact})
+
+	uc := usecases.NewFindDuplicates(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.FindDuplicatesQuery{MinScore: 0.9}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindDuplicates_Handle_InvalidMinScore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := usecases.NewFindDuplicates(mock.NewMockDuplicateService(ctrl), mock.NewMockFindDuplicatesPresenter(ctrl))
+
+	if err := uc.Handle(aliceContext(), &models.FindDuplicatesQuery{MinScore: 1.5}); err == nil {
+		t.Error("expected error for min score above 1")
+	}
+}
@@ -0,0 +1,54 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// GetAssignmentReport type represents usecase that has sequence of actions to report progress
+// of the learners of models.Assignment.
+type GetAssignmentReport struct {
+	assignmentService services.AssignmentService
+	workspaceService  services.WorkspaceService
+	presenter         models.GetAssignmentReportPresenter
+}
+
+// NewGetAssignmentReport function builds new instance of GetAssignmentReport usecase.
+func NewGetAssignmentReport(
+	assignmentService services.AssignmentService,
+	workspaceService services.WorkspaceService,
+	presenter models.GetAssignmentReportPresenter,
+) *GetAssignmentReport {
+	return &GetAssignmentReport{
+		assignmentService: assignmentService,
+		workspaceService:  workspaceService,
+		presenter:         presenter,
+	}
+}
+
+// Handle function performs usecase actions. Progress of the learners is seen by the members
+// allowed to manage the workspace.
+func (uc *GetAssignmentReport) Handle(ctx context.Context, query *models.GetAssignmentReportQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	report, err := uc.assignmentService.Report(owner, query.ID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(report)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAssignmentReport_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	report := &domain.AssignmentReport{
+		Assignment: &domain.Assignment{ID: 4, Owner: "workspace:5"},
+		Items:      []*domain.AssignmentItemReport{{ItemID: 8, Struggling: 2, Struggles: true}},
+	}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	assignmentService := mock.NewMockAssignmentService(ctrl)
+	assignmentService.EXPECT().Report("workspace:5", int64(4)).Return(report, nil)
+
+	presenter := mock.NewMockGetAssignmentReportPresenter(ctrl)
+	presenter.EXPECT().SetResult(report)
+
+	uc := usecases.NewGetAssignmentReport(assignmentService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.GetAssignmentReportQuery{WorkspaceID: 5, ID: 4}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetAssignmentReport_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("", services.ErrForbidden)
+
+	uc := usecases.NewGetAssignmentReport(
+		mock.NewMockAssignmentService(ctrl),
+		workspaceService,
+		mock.NewMockGetAssignmentReportPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.GetAssignmentReportQuery{WorkspaceID: 5, ID: 4})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
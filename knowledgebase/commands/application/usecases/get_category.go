@@ -0,0 +1,53 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// GetCategory type represents usecase that has sequence of actions
+// to fetch the category with its metadata.
+type GetCategory struct {
+	categoryService  services.CategoryService
+	workspaceService services.WorkspaceService
+	presenter        models.GetCategoryPresenter
+}
+
+// NewGetCategory function builds new instance of GetCategory usecase.
+func NewGetCategory(
+	categoryService services.CategoryService,
+	workspaceService services.WorkspaceService,
+	presenter models.GetCategoryPresenter,
+) *GetCategory {
+	return &GetCategory{
+		categoryService:  categoryService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *GetCategory) Handle(ctx context.Context, query *models.GetCategoryQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	category, err := uc.categoryService.GetCategory(owner, query.Name)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(category)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCategory_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := &domain.Category{ID: 1, Name: "Engineering", CategoryMetadata: domain.CategoryMetadata{Icon: "gear"}}
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().GetCategory("alice", "Engineering").Return(expected, nil)
+
+	presenter := mock.NewMockGetCategoryPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewGetCategory(service, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.GetCategoryQuery{Name: "Engineering"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetCategory_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().GetCategory("alice", "Engineering").Return(nil, expectedError)
+
+	uc := usecases.NewGetCategory(service, personalWorkspace(ctrl), mock.NewMockGetCategoryPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.GetCategoryQuery{Name: "Engineering"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
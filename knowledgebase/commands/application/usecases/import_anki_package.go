@@ -0,0 +1,138 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ImportAnkiPackage type represents usecase that has sequence of actions
+// to create models.KnowledgeItem from the notes of Anki deck package.
+type ImportAnkiPackage struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	reader               models.AnkiPackageReader
+	presenter            models.ImportAnkiPackagePresenter
+}
+
+// NewImportAnkiPackage function builds new instance of ImportAnkiPackage usecase.
+func NewImportAnkiPackage(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	reader models.AnkiPackageReader,
+	presenter models.ImportAnkiPackagePresenter,
+) *ImportAnkiPackage {
+	return &ImportAnkiPackage{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		reader:               reader,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// Notes which violate knowledge item rules are reported as skipped, any other error stops the import.
+func (uc *ImportAnkiPackage) Handle(ctx context.Context, cmd *models.ImportAnkiPackageCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := uc.reader.Read(cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	report := &models.ImportReport{}
+	for _, note := range pkg.Notes {
+		item, err := uc.importNote(user.Login, note, cmd.WithReviewHistory)
+
+		var validationErr *services.ValidationError
+		if errors.As(err, &validationErr) {
+			report.Skipped = append(report.Skipped, &models.SkippedRecord{
+				Source: fmt.Sprintf("note %d", note.ID),
+				Reason: err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		report.Imported = append(report.Imported, item)
+	}
+
+	uc.presenter.SetResult(report)
+
+	return nil
+}
+
+func (uc *ImportAnkiPackage) importNote(
+	owner string,
+	note *models.AnkiNote,
+	withReviewHistory bool,
+) (*domain.KnowledgeItem, error) {
+	title := note.Title
+	if title == "" {
+		title = note.Front
+	}
+
+	// the note is checked before its deck is created, so the skipped note leaves no empty category behind.
+	if err := uc.knowledgeItemService.ValidateItem(title, note.Front, note.Back, note.Tags); err != nil {
+		return nil, err
+	}
+
+	restore := withReviewHistory && note.ReviewState != nil && len(note.Reviews) > 0
+	if restore {
+		err := uc.knowledgeItemService.ValidateReviewState(note.ReviewState.Score, note.ReviewState.LastMark)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var categories []*domain.Category
+	if note.Deck != "" {
+		cat, err := uc.categoryService.CreateOrGetCategory(
+			owner,
+			strings.ReplaceAll(note.Deck, ankiDeckSeparator, domain.CategorySeparator))
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, cat)
+	}
+
+	item, err := uc.knowledgeItemService.NewItem(owner, title, note.Front, note.Back, note.Tags, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	if !withReviewHistory || len(note.Reviews) == 0 {
+		return item, nil
+	}
+
+	// notes written by the export keep the review state, it's restored as it was
+	// with the time of the last review.
+	if restore {
+		lastCheckAt := note.Reviews[len(note.Reviews)-1].ReviewedAt
+
+		return uc.knowledgeItemService.RestoreReviewState(
+			owner, item.ID, note.ReviewState.Score, note.ReviewState.LastMark, &lastCheckAt)
+	}
+
+	reviews := make([]*domain.Review, 0, len(note.Reviews))
+	for _, review := range note.Reviews {
+		reviews = append(reviews, &domain.Review{
+			Mark:      review.Mark(),
+			CheckedAt: review.ReviewedAt,
+		})
+	}
+
+	return uc.knowledgeItemService.ReplayMarks(owner, item.ID, reviews)
+}
@@ -0,0 +1,248 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportAnkiPackage_Do_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewedAt := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	cmd := &models.ImportAnkiPackageCommand{
+		Path:              "deck.apkg",
+		WithReviewHistory: true,
+	}
+	pkg := &models.AnkiPackage{
+		Notes: []*models.AnkiNote{
+			{
+				ID:    1,
+				Deck:  "Engineering::Golang",
+				Front: "What is a goroutine?",
+				Back:  "Lightweight thread managed by the Go runtime",
+				Tags:  []string{"go", "concurrency"},
+				Reviews: []*models.AnkiReview{
+					{Ease: 3, ReviewedAt: reviewedAt},
+				},
+			},
+		},
+	}
+	expectedCategory := &domain.Category{ID: 3, Name: "Engineering/Golang", ParentID: 2}
+	expectedItem := &domain.KnowledgeItem{
+		ID:         7,
+		Title:      pkg.Notes[0].Front,
+		Anchor:     pkg.Notes[0].Front,
+		Data:       pkg.Notes[0].Back,
+		Tags:       pkg.Notes[0].Tags,
+		Categories: []*domain.Category{expectedCategory},
+	}
+
+	reader := mock.NewMockAnkiPackageReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(pkg, nil)
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().CreateOrGetCategory("alice", "Engineering/Golang").Return(expectedCategory, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(pkg.Notes[0].Front, pkg.Notes[0].Front, pkg.Notes[0].Back, pkg.Notes[0].Tags)
+	itemService.EXPECT().
+		NewItem("alice", pkg.Notes[0].Front, pkg.Notes[0].Front, pkg.Notes[0].Back, pkg.Notes[0].Tags,
+			[]*domain.Category{expectedCategory}).
+		Return(expectedItem, nil)
+	itemService.EXPECT().ReplayMarks("alice", expectedItem.ID, gomock.Any()).
+		DoAndReturn(func(_ string, _ int64, reviews []*domain.Review) (*domain.KnowledgeItem, error) {
+			if len(reviews) != 1 {
+				t.Fatalf("expected 1 review, got %d", len(reviews))
+			}
+			if reviews[0].Mark != 7 {
+				t.Errorf("expected mark 7, got %d", reviews[0].Mark)
+			}
+			if !reviews[0].CheckedAt.Equal(reviewedAt) {
+				t.Errorf("expected checked at %s, got %s", reviewedAt, reviews[0].CheckedAt)
+			}
+
+			return expectedItem, nil
+		})
+
+	presenter := mock.NewMockImportAnkiPackagePresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 1 || report.Imported[0] != expectedItem {
+			t.Errorf("expected imported item %+v, got %+v", expectedItem, report.Imported)
+		}
+		if len(report.Skipped) != 0 {
+			t.Errorf("expected no skipped notes, got %d", len(report.Skipped))
+		}
+	})
+
+	uc := usecases.NewImportAnkiPackage(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportAnkiPackage_Do_RestoresReviewState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	last := first.Add(24 * time.Hour)
+	cmd := &models.ImportAnkiPackageCommand{Path: "deck.apkg", WithReviewHistory: true}
+	note := &models.AnkiNote{
+		ID:          1,
+		Title:       "Goroutines",
+		Front:       "What is a goroutine?",
+		Back:        "Lightweight thread managed by the Go runtime",
+		Reviews:     []*models.AnkiReview{{Ease: 3, ReviewedAt: first}, {Ease: 4, ReviewedAt: last}},
+		ReviewState: &models.AnkiReviewState{Score: 35, LastMark: 9},
+	}
+	item := &domain.KnowledgeItem{ID: 7, Title: note.Title}
+
+	reader := mock.NewMockAnkiPackageReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(&models.AnkiPackage{Notes: []*models.AnkiNote{note}}, nil)
+
+	// notes of the export keep the title and the state, which is restored instead of replaying the reviews.
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	gomock.InOrder(
+		itemService.EXPECT().ValidateItem(note.Title, note.Front, note.Back, nil).Return(nil),
+		itemService.EXPECT().ValidateReviewState(int64(35), int64(9)).Return(nil),
+		itemService.EXPECT().NewItem("alice", note.Title, note.Front, note.Back, nil, nil).Return(item, nil),
+		itemService.EXPECT().RestoreReviewState("alice", item.ID, int64(35), int64(9), &last).Return(item, nil),
+	)
+
+	presenter := mock.NewMockImportAnkiPackagePresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any())
+
+	uc := usecases.NewImportAnkiPackage(mock.NewMockCategoryService(ctrl), itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportAnkiPackage_Do_SkipsInvalidNotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportAnkiPackageCommand{Path: "deck.apkg"}
+	pkg := &models.AnkiPackage{
+		Notes: []*models.AnkiNote{
+			{ID: 1, Deck: "Golang", Front: "Go", Back: "Programming language"},
+			{
+				ID:      2,
+				Front:   "What is a channel?",
+				Back:    "Typed conduit between goroutines",
+				Reviews: []*models.AnkiReview{{Ease: 4, ReviewedAt: time.Now()}},
+			},
+		},
+	}
+	expectedItem := &domain.KnowledgeItem{ID: 8}
+
+	reader := mock.NewMockAnkiPackageReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(pkg, nil)
+
+	// deck of the invalid note isn't created.
+	catService := mock.NewMockCategoryService(ctrl)
+
+	// validation error is built by the real service to keep the type.
+	validationErr := func() error {
+		_, err := services.NewKnowledgeItemService(nil, nil).NewItem("alice", "Go", "Go", "", nil, nil)
+		return err
+	}()
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem("Go", "Go", "Programming language", nil).Return(validationErr)
+	itemService.EXPECT().ValidateItem(pkg.Notes[1].Front, pkg.Notes[1].Front, pkg.Notes[1].Back, nil)
+	itemService.EXPECT().
+		NewItem("alice", pkg.Notes[1].Front, pkg.Notes[1].Front, pkg.Notes[1].Back, nil, nil).
+		Return(expectedItem, nil)
+
+	presenter := mock.NewMockImportAnkiPackagePresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 1 {
+			t.Fatalf("expected 1 imported item, got %d", len(report.Imported))
+		}
+		if len(report.Skipped) != 1 {
+			t.Fatalf("expected 1 skipped note, got %d", len(report.Skipped))
+		}
+		if report.Skipped[0].Source != "note 1" {
+			t.Errorf("expected source %s, got %s", "note 1", report.Skipped[0].Source)
+		}
+		if report.Skipped[0].Reason != validationErr.Error() {
+			t.Errorf("expected reason %s, got %s", validationErr.Error(), report.Skipped[0].Reason)
+		}
+	})
+
+	uc := usecases.NewImportAnkiPackage(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportAnkiPackage_Do_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportAnkiPackageCommand{Path: "deck.apkg"}
+	pkg := &models.AnkiPackage{
+		Notes: []*models.AnkiNote{
+			{ID: 1, Front: "What is a channel?", Back: "Typed conduit between goroutines"},
+		},
+	}
+	expectedError := errors.New("expected error")
+
+	reader := mock.NewMockAnkiPackageReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(pkg, nil)
+
+	catService := mock.NewMockCategoryService(ctrl)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	itemService.EXPECT().NewItem("alice", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, expectedError)
+
+	presenter := mock.NewMockImportAnkiPackagePresenter(ctrl)
+
+	uc := usecases.NewImportAnkiPackage(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
+
+func TestImportAnkiPackage_Do_ReaderError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportAnkiPackageCommand{Path: "deck.apkg"}
+	expectedError := errors.New("expected error")
+
+	reader := mock.NewMockAnkiPackageReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(nil, expectedError)
+
+	uc := usecases.NewImportAnkiPackage(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		reader,
+		mock.NewMockImportAnkiPackagePresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,210 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	kindleTag             = "kindle"
+	clippingTitleLength   = 60
+	clippingTitleEllipsis = "…"
+)
+
+// ImportKindleClippings type represents usecase that has sequence of actions
+// to create models.KnowledgeItem from the Kindle highlights.
+type ImportKindleClippings struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	reader               models.KindleClippingsReader
+	presenter            models.ImportKindleClippingsPresenter
+}
+
+// NewImportKindleClippings function builds new instance of ImportKindleClippings usecase.
+func NewImportKindleClippings(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	reader models.KindleClippingsReader,
+	presenter models.ImportKindleClippingsPresenter,
+) *ImportKindleClippings {
+	return &ImportKindleClippings{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		reader:               reader,
+		presenter:            presenter,
+	}
+}
+
+// book represents category of the book and keys of its already imported clippings.
+type book struct {
+	category *domain.Category
+	imported map[string]bool
+}
+
+// Handle function performs usecase actions.
+// Every book becomes a category, every clipping becomes a knowledge item anchored by the book and location.
+// Clippings imported before, excluded kinds and clippings which violate knowledge item rules
+// are reported as skipped, any other error stops the import.
+func (uc *ImportKindleClippings) Handle(ctx context.Context, cmd *models.ImportKindleClippingsCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	clippings, err := uc.reader.Read(cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	report := &models.ImportReport{}
+	books := make(map[string]*book)
+	for i, clipping := range clippings {
+		skip := func(reason string) {
+			report.Skipped = append(report.Skipped, &models.SkippedRecord{
+				Source: fmt.Sprintf("clipping %d", i+1),
+				Reason: reason,
+			})
+		}
+
+		// the kind names the item and tags it, clippings of other readers might come without one.
+		if clipping.Kind == "" {
+			skip("clipping has no kind")
+			continue
+		}
+
+		if (clipping.Kind == models.ClippingKindNote && !cmd.IncludeNotes) ||
+			(clipping.Kind == models.ClippingKindBookmark && !cmd.IncludeBookmarks) {
+			skip(clipping.Kind + "s are excluded")
+			continue
+		}
+
+		item, err := uc.importClipping(user.Login, clipping, books)
+
+		var validationErr *services.ValidationError
+		if errors.As(err, &validationErr) {
+			skip(err.Error())
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			skip("clipping is already imported")
+			continue
+		}
+
+		report.Imported = append(report.Imported, item)
+	}
+
+	uc.presenter.SetResult(report)
+
+	return nil
+}
+
+// importClipping function creates knowledge item of the clipping, it returns nil when the clipping is imported already.
+func (uc *ImportKindleClippings) importClipping(
+	owner string,
+	clipping *models.KindleClipping,
+	books map[string]*book,
+) (*domain.KnowledgeItem, error) {
+	anchor := clippingAnchor(clipping)
+	data := clipping.Content
+	if data == "" {
+		data = fmt.Sprintf("%s of %s", strings.ToUpper(clipping.Kind[:1])+clipping.Kind[1:], anchor)
+	}
+	title := clippingTitle(clipping, anchor)
+	tags := []string{kindleTag, clipping.Kind}
+
+	// the clipping is checked before the category of its book is created,
+	// so the skipped clipping leaves no empty category behind.
+	if err := uc.knowledgeItemService.ValidateItem(title, anchor, data, tags); err != nil {
+		return nil, err
+	}
+
+	b, err := uc.book(owner, clipping.Book, books)
+	if err != nil {
+		return nil, err
+	}
+
+	key := clippingKey(anchor, data)
+	if b.imported[key] {
+		return nil, nil
+	}
+
+	item, err := uc.knowledgeItemService.NewItem(owner, title, anchor, data, tags, []*domain.Category{b.category})
+	if err != nil {
+		return nil, err
+	}
+
+	b.imported[key] = true
+
+	return item, nil
+}
+
+// book function returns category of the book with the clippings already imported into it.
+func (uc *ImportKindleClippings) book(owner, name string, books map[string]*book) (*book, error) {
+	if b, ok := books[name]; ok {
+		return b, nil
+	}
+
+	category, err := uc.categoryService.CreateOrGetCategory(owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.knowledgeItemService.ListItems(owner, []*domain.Category{category})
+	if err != nil {
+		return nil, err
+	}
+
+	b := &book{category: category, imported: make(map[string]bool, len(items))}
+	for _, item := range items {
+		b.imported[clippingKey(item.Anchor, item.Data)] = true
+	}
+	books[name] = b
+
+	return b, nil
+}
+
+// clippingAnchor function builds anchor from the book and the most precise position of the clipping.
+func clippingAnchor(clipping *models.KindleClipping) string {
+	switch {
+	case clipping.Location != "":
+		return fmt.Sprintf("%s, location %s", clipping.Book, clipping.Location)
+	case clipping.Page != "":
+		return fmt.Sprintf("%s, page %s", clipping.Book, clipping.Page)
+	default:
+		return clipping.Book
+	}
+}
+
+// clippingTitle function shortens the clipping content to the title, bookmarks are titled by their anchor.
+func clippingTitle(clipping *models.KindleClipping, anchor string) string {
+	content := strings.Join(strings.Fields(clipping.Content), " ")
+	if content == "" {
+		return anchor
+	}
+
+	runes := []rune(content)
+	if len(runes) <= clippingTitleLength {
+		return content
+	}
+
+	title := string(runes[:clippingTitleLength])
+	if i := strings.LastIndex(title, " "); i > 0 {
+		title = title[:i]
+	}
+
+	return title + clippingTitleEllipsis
+}
+
+func clippingKey(anchor, data string) string {
+	return anchor + "\n" + data
+}
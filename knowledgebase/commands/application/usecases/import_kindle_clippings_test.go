@@ -0,0 +1,246 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func kindleClippings() []*models.KindleClipping {
+	return []*models.KindleClipping{
+		{
+			Book:     "The Go Programming Language",
+			Kind:     models.ClippingKindHighlight,
+			Location: "3312-3314",
+			Content:  "Goroutines are multiplexed onto a small number of operating system threads.",
+		},
+		{
+			Book:     "The Go Programming Language",
+			Kind:     models.ClippingKindNote,
+			Location: "3314",
+			Content:  "Compare with green threads",
+		},
+		{
+			Book:     "Designing Data-Intensive Applications",
+			Kind:     models.ClippingKindBookmark,
+			Location: "1205",
+		},
+		{
+			Book:    "The Go Programming Language",
+			Kind:    models.ClippingKindHighlight,
+			Page:    "12",
+			Content: "Go is a compiled language.",
+		},
+	}
+}
+
+func TestImportKindleClippings_Do_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportKindleClippingsCommand{Path: "My Clippings.txt"}
+	goBook := &domain.Category{ID: 3, Name: "The Go Programming Language"}
+
+	reader := mock.NewMockKindleClippingsReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(kindleClippings(), nil)
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().CreateOrGetCategory("alice", goBook.Name).Return(goBook, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	// the first highlight has been imported before.
+	itemService.EXPECT().ListItems("alice", []*domain.Category{goBook}).Return([]*domain.KnowledgeItem{{
+		ID:     1,
+		Anchor: "The Go Programming Language, location 3312-3314",
+		Data:   "Goroutines are multiplexed onto a small number of operating system threads.",
+	}}, nil)
+
+	expectedItem := &domain.KnowledgeItem{ID: 2}
+	itemService.EXPECT().NewItem(
+		"alice",
+		"Go is a compiled language.",
+		"The Go Programming Language, page 12",
+		"Go is a compiled language.",
+		[]string{"kindle", models.ClippingKindHighlight},
+		[]*domain.Category{goBook},
+	).Return(expectedItem, nil)
+
+	presenter := mock.NewMockImportKindleClippingsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 1 || report.Imported[0] != expectedItem {
+			t.Errorf("expected imported item %+v, got %+v", expectedItem, report.Imported)
+		}
+
+		expected := []models.SkippedRecord{
+			{Source: "clipping 1", Reason: "clipping is already imported"},
+			{Source: "clipping 2", Reason: "notes are excluded"},
+			{Source: "clipping 3", Reason: "bookmarks are excluded"},
+		}
+		if len(report.Skipped) != len(expected) {
+			t.Fatalf("expected %d skipped clippings, got %d", len(expected), len(report.Skipped))
+		}
+		for i, skipped := range expected {
+			if *report.Skipped[i] != skipped {
+				t.Errorf("expected skipped %+v, got %+v", skipped, report.Skipped[i])
+			}
+		}
+	})
+
+	uc := usecases.NewImportKindleClippings(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportKindleClippings_Do_IncludeNotesAndBookmarks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportKindleClippingsCommand{
+		Path:             "My Clippings.txt",
+		IncludeNotes:     true,
+		IncludeBookmarks: true,
+	}
+	clippings := kindleClippings()
+	clippings[1].Content = "Compare with green threads, they are scheduled by the runtime too."
+	// Kindle repeats the highlight when it is made again, the copy is imported once.
+	clippings = append(clippings, clippings[0])
+
+	goBook := &domain.Category{ID: 3, Name: "The Go Programming Language"}
+	ddiaBook := &domain.Category{ID: 4, Name: "Designing Data-Intensive Applications"}
+
+	reader := mock.NewMockKindleClippingsReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(clippings, nil)
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().CreateOrGetCategory("alice", goBook.Name).Return(goBook, nil)
+	catService.EXPECT().CreateOrGetCategory("alice", ddiaBook.Name).Return(ddiaBook, nil)
+
+	realItemService := services.NewKnowledgeItemService(nil, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(5).
+		DoAndReturn(realItemService.ValidateItem)
+	itemService.EXPECT().ListItems("alice", gomock.Any()).Return(nil, nil).Times(2)
+
+	var nextID int64
+	itemService.EXPECT().NewItem("alice", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(4).
+		DoAndReturn(func(_, title, anchor, data string, tags []string, categories []*domain.Category) (*domain.KnowledgeItem, error) {
+			nextID++
+
+			return &domain.KnowledgeItem{
+				ID:         nextID,
+				Title:      title,
+				Anchor:     anchor,
+				Data:       data,
+				Tags:       tags,
+				Categories: categories,
+			}, nil
+		})
+
+	presenter := mock.NewMockImportKindleClippingsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 4 {
+			t.Fatalf("expected 4 imported items, got %d", len(report.Imported))
+		}
+
+		first := report.Imported[0]
+		if first.Title != "Goroutines are multiplexed onto a small number of operating…" {
+			t.Errorf("unexpected title %q", first.Title)
+		}
+
+		note := report.Imported[1]
+		if note.Tags[1] != models.ClippingKindNote || note.Anchor != "The Go Programming Language, location 3314" {
+			t.Errorf("unexpected note item %+v", note)
+		}
+
+		bookmark := report.Imported[2]
+		if bookmark.Title != "Designing Data-Intensive Applications, location 1205" ||
+			bookmark.Data != "Bookmark of Designing Data-Intensive Applications, location 1205" ||
+			bookmark.Categories[0] != ddiaBook {
+			t.Errorf("unexpected bookmark item %+v", bookmark)
+		}
+
+		if len(report.Skipped) != 1 || report.Skipped[0].Source != "clipping 5" {
+			t.Errorf("expected repeated highlight to be skipped, got %+v", report.Skipped)
+		}
+	})
+
+	uc := usecases.NewImportKindleClippings(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportKindleClippings_Do_SkipsInvalidClippings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportKindleClippingsCommand{Path: "My Clippings.txt"}
+	clippings := []*models.KindleClipping{
+		{Book: "Refactoring", Kind: models.ClippingKindHighlight, Location: "120", Content: "Small steps"},
+		{Book: "Refactoring", Location: "130"},
+	}
+
+	reader := mock.NewMockKindleClippingsReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(clippings, nil)
+
+	// the book of the invalid clipping isn't created.
+	catService := mock.NewMockCategoryService(ctrl)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(services.NewKnowledgeItemService(nil, nil).ValidateItem)
+
+	presenter := mock.NewMockImportKindleClippingsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 0 {
+			t.Errorf("expected no imported items, got %d", len(report.Imported))
+		}
+		if len(report.Skipped) != 2 || report.Skipped[0].Reason != "data is too short" ||
+			report.Skipped[1].Reason != "clipping has no kind" {
+			t.Errorf("unexpected skipped clippings %+v", report.Skipped)
+		}
+	})
+
+	uc := usecases.NewImportKindleClippings(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportKindleClippings_Do_ReaderError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportKindleClippingsCommand{Path: "My Clippings.txt"}
+	expectedError := errors.New("expected error")
+
+	reader := mock.NewMockKindleClippingsReader(ctrl)
+	reader.EXPECT().Read(cmd.Path).Return(nil, expectedError)
+
+	uc := usecases.NewImportKindleClippings(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		reader,
+		mock.NewMockImportKindleClippingsPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
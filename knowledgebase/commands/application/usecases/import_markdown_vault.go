@@ -0,0 +1,154 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ImportMarkdownVault type represents usecase that has sequence of actions
+// to create or update models.KnowledgeItem from the Markdown files.
+type ImportMarkdownVault struct {
+	categoryService      services.CategoryService
+	knowledgeItemService services.KnowledgeItemService
+	reader               models.MarkdownVaultReader
+	presenter            models.ImportMarkdownVaultPresenter
+}
+
+// NewImportMarkdownVault function builds new instance of ImportMarkdownVault usecase.
+func NewImportMarkdownVault(
+	categoryService services.CategoryService,
+	knowledgeItemService services.KnowledgeItemService,
+	reader models.MarkdownVaultReader,
+	presenter models.ImportMarkdownVaultPresenter,
+) *ImportMarkdownVault {
+	return &ImportMarkdownVault{
+		categoryService:      categoryService,
+		knowledgeItemService: knowledgeItemService,
+		reader:               reader,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// Notes are matched with existing items by ID first and by title then,
+// matched items are updated and the rest are created along with their review state.
+func (uc *ImportMarkdownVault) Handle(ctx context.Context, cmd *models.ImportMarkdownVaultCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	notes, err := uc.reader.Read(cmd.Directory)
+	if err != nil {
+		return err
+	}
+
+	report := &models.ImportReport{}
+	for _, note := range notes {
+		item, created, err := uc.importNote(user.Login, note)
+
+		var validationErr *services.ValidationError
+		if errors.As(err, &validationErr) {
+			report.Skipped = append(report.Skipped, &models.SkippedRecord{
+				Source: note.Path,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if created {
+			report.Imported = append(report.Imported, item)
+		} else {
+			report.Updated = append(report.Updated, item)
+		}
+	}
+
+	uc.presenter.SetResult(report)
+
+	return nil
+}
+
+func (uc *ImportMarkdownVault) importNote(
+	owner string,
+	note *models.MarkdownNote,
+) (*domain.KnowledgeItem, bool, error) {
+	existing, err := uc.findExisting(owner, note)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the note is checked before its categories are created, so the skipped note leaves no empty category behind.
+	err = uc.knowledgeItemService.ValidateItem(note.Title, note.Anchor, note.Data, note.Tags)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// review state is restored for new items only, it's checked before anything is stored,
+	// so the skipped note leaves no item behind.
+	withReviewState := note.LastCheckAt != nil || note.Score != 0 || note.LastMark != 0
+	if existing == nil && withReviewState {
+		if err = uc.knowledgeItemService.ValidateReviewState(note.Score, note.LastMark); err != nil {
+			return nil, false, err
+		}
+	}
+
+	names := note.Categories
+	if note.Folder != "" && !slices.Contains(names, note.Folder) {
+		names = append(slices.Clone(names), note.Folder)
+	}
+
+	var categories []*domain.Category
+	for _, name := range names {
+		cat, err := uc.categoryService.CreateOrGetCategory(owner, name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		categories = append(categories, cat)
+	}
+
+	if existing != nil {
+		item, err := uc.knowledgeItemService.UpdateItem(
+			owner, existing.ID, note.Title, note.Anchor,
+			note.Data, note.Tags, categories)
+
+		return item, false, err
+	}
+
+	item, err := uc.knowledgeItemService.NewItem(owner, note.Title, note.Anchor, note.Data, note.Tags, categories)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !withReviewState {
+		return item, true, nil
+	}
+
+	item, err = uc.knowledgeItemService.RestoreReviewState(owner, item.ID, note.Score, note.LastMark, note.LastCheckAt)
+
+	return item, true, err
+}
+
+func (uc *ImportMarkdownVault) findExisting(owner string, note *models.MarkdownNote) (*domain.KnowledgeItem, error) {
+	if note.ID != 0 {
+		item, err := uc.knowledgeItemService.GetItem(owner, note.ID)
+		if err == nil {
+			return item, nil
+		}
+		if !errors.Is(err, repositories.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return uc.knowledgeItemService.FindItemByTitle(owner, note.Title)
+}
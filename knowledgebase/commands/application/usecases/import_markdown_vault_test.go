@@ -0,0 +1,203 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportMarkdownVault_Do_Upsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastCheckAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cmd := &models.ImportMarkdownVaultCommand{Directory: "vault"}
+	notes := []*models.MarkdownNote{
+		{
+			Path:   "Engineering/Go/Goroutines.md",
+			Folder: "Engineering/Go",
+			ID:     5,
+			Title:  "Goroutines",
+			Anchor: "What is a goroutine?",
+			Data:   "Lightweight thread managed by the Go runtime",
+		},
+		{
+			Path:   "Channels.md",
+			ID:     99,
+			Title:  "Channels",
+			Anchor: "What is a channel?",
+			Data:   "Typed conduit between goroutines",
+		},
+		{
+			Path:        "Concurrency/Mutex.md",
+			Folder:      "Concurrency",
+			Title:       "Mutex",
+			Anchor:      "What is a mutex?",
+			Data:        "Mutual exclusion lock guarding shared state",
+			Tags:        []string{"sync"},
+			Categories:  []string{"Concurrency", "Golang"},
+			Score:       40,
+			LastMark:    8,
+			LastCheckAt: &lastCheckAt,
+		},
+	}
+
+	goCategory := &domain.Category{ID: 1, Name: "Engineering/Go"}
+	concurrency := &domain.Category{ID: 2, Name: "Concurrency"}
+	golang := &domain.Category{ID: 3, Name: "Golang"}
+	byID := &domain.KnowledgeItem{ID: 5}
+	byTitle := &domain.KnowledgeItem{ID: 7}
+	created := &domain.KnowledgeItem{ID: 8}
+
+	reader := mock.NewMockMarkdownVaultReader(ctrl)
+	reader.EXPECT().Read(cmd.Directory).Return(notes, nil)
+
+	catService := mock.NewMockCategoryService(ctrl)
+	catService.EXPECT().CreateOrGetCategory("alice", "Engineering/Go").Return(goCategory, nil)
+	catService.EXPECT().CreateOrGetCategory("alice", "Concurrency").Return(concurrency, nil)
+	catService.EXPECT().CreateOrGetCategory("alice", "Golang").Return(golang, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().ValidateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(len(notes))
+
+	// matched by ID.
+	itemService.EXPECT().GetItem("alice", int64(5)).Return(byID, nil)
+	itemService.EXPECT().
+		UpdateItem("alice", int64(5), notes[0].Title, notes[0].Anchor, notes[0].Data, nil, []*domain.Category{goCategory}).
+		Return(byID, nil)
+
+	// unknown ID, matched by title.
+	itemService.EXPECT().GetItem("alice", int64(99)).Return(nil, repositories.ErrNotFound)
+	itemService.EXPECT().FindItemByTitle("alice", "Channels").Return(byTitle, nil)
+	itemService.EXPECT().
+		UpdateItem("alice", int64(7), notes[1].Title, notes[1].Anchor, notes[1].Data, nil, nil).
+		Return(byTitle, nil)
+
+	// new item with review state.
+	itemService.EXPECT().FindItemByTitle("alice", "Mutex").Return(nil, nil)
+	itemService.EXPECT().ValidateReviewState(int64(40), int64(8)).Return(nil)
+	itemService.EXPECT().
+		NewItem("alice", notes[2].Title, notes[2].Anchor, notes[2].Data, notes[2].Tags,
+			[]*domain.Category{concurrency, golang}).
+		Return(created, nil)
+	itemService.EXPECT().RestoreReviewState("alice", int64(8), int64(40), int64(8), &lastCheckAt).Return(created, nil)
+
+	presenter := mock.NewMockImportMarkdownVaultPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 1 || report.Imported[0] != created {
+			t.Errorf("unexpected imported items %+v", report.Imported)
+		}
+		if len(report.Updated) != 2 || report.Updated[0] != byID || report.Updated[1] != byTitle {
+			t.Errorf("unexpected updated items %+v", report.Updated)
+		}
+	})
+
+	uc := usecases.NewImportMarkdownVault(catService, itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportMarkdownVault_Do_InvalidReviewState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportMarkdownVaultCommand{Directory: "vault"}
+	note := &models.MarkdownNote{
+		Path:   "Golang/Mutex.md",
+		Folder: "Golang",
+		Title:  "Mutex",
+		Anchor: "What is a mutex?",
+		Data:   "Mutual exclusion lock guarding shared state",
+		Score:  150,
+	}
+
+	reader := mock.NewMockMarkdownVaultReader(ctrl)
+	reader.EXPECT().Read(cmd.Directory).Return([]*models.MarkdownNote{note}, nil)
+
+	// neither the item nor its category are created.
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().FindItemByTitle("alice", "Mutex").Return(nil, nil)
+	itemService.EXPECT().ValidateItem(note.Title, note.Anchor, note.Data, nil)
+	itemService.EXPECT().ValidateReviewState(int64(150), int64(0)).Return(&services.ValidationError{})
+
+	presenter := mock.NewMockImportMarkdownVaultPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 0 || len(report.Skipped) != 1 || report.Skipped[0].Source != note.Path {
+			t.Errorf("expected the note to be skipped, got %+v", report)
+		}
+	})
+
+	uc := usecases.NewImportMarkdownVault(mock.NewMockCategoryService(ctrl), itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportMarkdownVault_Do_InvalidNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportMarkdownVaultCommand{Directory: "vault"}
+	note := &models.MarkdownNote{Path: "Golang/Mutex.md", Folder: "Golang", Title: "Mutex", Anchor: "What is a mutex?"}
+
+	reader := mock.NewMockMarkdownVaultReader(ctrl)
+	reader.EXPECT().Read(cmd.Directory).Return([]*models.MarkdownNote{note}, nil)
+
+	// folder of the invalid note isn't created.
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().FindItemByTitle("alice", "Mutex").Return(nil, nil)
+	itemService.EXPECT().ValidateItem(note.Title, note.Anchor, "", nil).Return(&services.ValidationError{})
+
+	presenter := mock.NewMockImportMarkdownVaultPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(report *models.ImportReport) {
+		if len(report.Imported) != 0 || len(report.Skipped) != 1 || report.Skipped[0].Source != note.Path {
+			t.Errorf("expected the note to be skipped, got %+v", report)
+		}
+	})
+
+	uc := usecases.NewImportMarkdownVault(mock.NewMockCategoryService(ctrl), itemService, reader, presenter)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportMarkdownVault_Do_GetItemError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ImportMarkdownVaultCommand{Directory: "vault"}
+	expectedError := errors.New("expected error")
+
+	reader := mock.NewMockMarkdownVaultReader(ctrl)
+	reader.EXPECT().Read(cmd.Directory).Return([]*models.MarkdownNote{{ID: 5, Title: "Goroutines"}}, nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("alice", int64(5)).Return(nil, expectedError)
+
+	uc := usecases.NewImportMarkdownVault(
+		mock.NewMockCategoryService(ctrl),
+		itemService,
+		reader,
+		mock.NewMockImportMarkdownVaultPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), cmd)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
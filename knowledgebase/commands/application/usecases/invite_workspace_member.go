@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// InviteWorkspaceMember type represents usecase that has sequence of actions to invite the user to models.Workspace.
+type InviteWorkspaceMember struct {
+	workspaceService services.WorkspaceService
+	presenter        models.InviteWorkspaceMemberPresenter
+}
+
+// NewInviteWorkspaceMember function builds new instance of InviteWorkspaceMember usecase.
+func NewInviteWorkspaceMember(
+	workspaceService services.WorkspaceService,
+	presenter models.InviteWorkspaceMemberPresenter,
+) *InviteWorkspaceMember {
+	return &InviteWorkspaceMember{
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *InviteWorkspaceMember) Handle(ctx context.Context, cmd *models.InviteWorkspaceMemberCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := uc.workspaceService.InviteMember(user.Login, cmd.WorkspaceID, cmd.Login, cmd.Role)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(invitation)
+
+	return nil
+}
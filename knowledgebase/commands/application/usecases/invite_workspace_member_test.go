@@ -0,0 +1,52 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInviteWorkspaceMember_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invitation := &domain.WorkspaceInvitation{ID: 2, WorkspaceID: 5, Login: "bob", Role: domain.RoleEditor}
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().InviteMember("alice", int64(5), "bob", domain.RoleEditor).Return(invitation, nil)
+
+	presenter := mock.NewMockInviteWorkspaceMemberPresenter(ctrl)
+	presenter.EXPECT().SetResult(invitation)
+
+	uc := usecases.NewInviteWorkspaceMember(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.InviteWorkspaceMemberCommand{
+		WorkspaceID: 5, Login: "bob", Role: domain.RoleEditor,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInviteWorkspaceMember_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().InviteMember("alice", int64(5), "bob", domain.RoleEditor).Return(nil, expectedError)
+
+	uc := usecases.NewInviteWorkspaceMember(service, mock.NewMockInviteWorkspaceMemberPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.InviteWorkspaceMemberCommand{
+		WorkspaceID: 5, Login: "bob", Role: domain.RoleEditor,
+	})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListAPITokens type represents usecase that has sequence of actions to list models.APIToken of the user.
+type ListAPITokens struct {
+	apiTokenService services.APITokenService
+	presenter       models.ListAPITokensPresenter
+}
+
+// NewListAPITokens function builds new instance of ListAPITokens usecase.
+func NewListAPITokens(
+	apiTokenService services.APITokenService,
+	presenter models.ListAPITokensPresenter,
+) *ListAPITokens {
+	return &ListAPITokens{
+		apiTokenService: apiTokenService,
+		presenter:       presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListAPITokens) Handle(ctx context.Context, _ *models.ListAPITokensQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := uc.apiTokenService.ListTokens(user.Login)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(tokens)
+
+	return nil
+}
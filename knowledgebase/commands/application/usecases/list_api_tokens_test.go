@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAPITokens_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := []*domain.APIToken{{ID: 1, Owner: "alice", Name: "CI"}}
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().ListTokens("alice").Return(tokens, nil)
+
+	presenter := mock.NewMockListAPITokensPresenter(ctrl)
+	presenter.EXPECT().SetResult(tokens)
+
+	uc := usecases.NewListAPITokens(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListAPITokensQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAPITokens_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().ListTokens("alice").Return(nil, expectedError)
+
+	uc := usecases.NewListAPITokens(service, mock.NewMockListAPITokensPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ListAPITokensQuery{})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListAssignments type represents usecase that has sequence of actions to list models.Assignment of the learner.
+type ListAssignments struct {
+	assignmentService services.AssignmentService
+	presenter         models.ListAssignmentsPresenter
+}
+
+// NewListAssignments function builds new instance of ListAssignments usecase.
+func NewListAssignments(
+	assignmentService services.AssignmentService,
+	presenter models.ListAssignmentsPresenter,
+) *ListAssignments {
+	return &ListAssignments{
+		assignmentService: assignmentService,
+		presenter:         presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListAssignments) Handle(ctx context.Context, _ *models.ListAssignmentsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := uc.assignmentService.ListAssignments(user.Login)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(assignments)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAssignments_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assignments := []*domain.Assignment{{ID: 4, Owner: "workspace:5", Learners: []string{"alice"}}}
+
+	service := mock.NewMockAssignmentService(ctrl)
+	service.EXPECT().ListAssignments("alice").Return(assignments, nil)
+
+	presenter := mock.NewMockListAssignmentsPresenter(ctrl)
+	presenter.EXPECT().SetResult(assignments)
+
+	uc := usecases.NewListAssignments(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListAssignmentsQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAssignments_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockAssignmentService(ctrl)
+	service.EXPECT().ListAssignments("alice").Return(nil, expectedError)
+
+	uc := usecases.NewListAssignments(service, mock.NewMockListAssignmentsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ListAssignmentsQuery{})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
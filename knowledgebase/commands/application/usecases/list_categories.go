@@ -0,0 +1,53 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListCategories type represents usecase that has sequence of actions
+// to list the categories with their metadata.
+type ListCategories struct {
+	categoryService  services.CategoryService
+	workspaceService services.WorkspaceService
+	presenter        models.ListCategoriesPresenter
+}
+
+// NewListCategories function builds new instance of ListCategories usecase.
+func NewListCategories(
+	categoryService services.CategoryService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListCategoriesPresenter,
+) *ListCategories {
+	return &ListCategories{
+		categoryService:  categoryService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListCategories) Handle(ctx context.Context, query *models.ListCategoriesQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	categories, err := uc.categoryService.ListCategories(owner)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(categories)
+
+	return nil
+}
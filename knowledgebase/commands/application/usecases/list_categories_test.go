@@ -0,0 +1,50 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListCategories_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := []*domain.Category{
+		{ID: 1, Name: "Engineering", CategoryMetadata: domain.CategoryMetadata{Color: "#1e90ff"}},
+		{ID: 2, Name: "Engineering/Backend", ParentID: 1},
+	}
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().ListCategories("alice").Return(expected, nil)
+
+	presenter := mock.NewMockListCategoriesPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewListCategories(service, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListCategoriesQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCategories_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().ListCategories("alice").Return(nil, expectedError)
+
+	uc := usecases.NewListCategories(service, personalWorkspace(ctrl), mock.NewMockListCategoriesPresenter(ctrl))
+
+	if err := uc.Handle(aliceContext(), &models.ListCategoriesQuery{}); !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
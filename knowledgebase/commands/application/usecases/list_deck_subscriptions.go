@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListDeckSubscriptions type represents usecase that has sequence of actions to list models.DeckSubscription.
+type ListDeckSubscriptions struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.ListDeckSubscriptionsPresenter
+}
+
+// NewListDeckSubscriptions function builds new instance of ListDeckSubscriptions usecase.
+func NewListDeckSubscriptions(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListDeckSubscriptionsPresenter,
+) *ListDeckSubscriptions {
+	return &ListDeckSubscriptions{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListDeckSubscriptions) Handle(ctx context.Context, query *models.ListDeckSubscriptionsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := uc.deckService.ListSubscriptions(owner)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(subscriptions)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDeckSubscriptions_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptions := []*domain.DeckSubscription{{ID: 6, DeckID: 4, Owner: "workspace:5"}}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionView).Return("workspace:5", nil)
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().ListSubscriptions("workspace:5").Return(subscriptions, nil)
+
+	presenter := mock.NewMockListDeckSubscriptionsPresenter(ctrl)
+	presenter.EXPECT().SetResult(subscriptions)
+
+	uc := usecases.NewListDeckSubscriptions(deckService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListDeckSubscriptionsQuery{WorkspaceID: 5}); err != nil {
+		t.Fatal(err)
+	}
+}
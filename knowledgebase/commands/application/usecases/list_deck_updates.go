@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListDeckUpdates type represents usecase that has sequence of actions to list models.DeckUpdate of the subscription.
+type ListDeckUpdates struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.ListDeckUpdatesPresenter
+}
+
+// NewListDeckUpdates function builds new instance of ListDeckUpdates usecase.
+func NewListDeckUpdates(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListDeckUpdatesPresenter,
+) *ListDeckUpdates {
+	return &ListDeckUpdates{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListDeckUpdates) Handle(ctx context.Context, query *models.ListDeckUpdatesQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	updates, err := uc.deckService.ListUpdates(owner, query.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(updates)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDeckUpdates_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updates := []*domain.DeckUpdate{{SourceID: 11, ItemID: 21, Data: "len\ncap", LocalEdits: true}}
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().ListUpdates("alice", int64(6)).Return(updates, nil)
+
+	presenter := mock.NewMockListDeckUpdatesPresenter(ctrl)
+	presenter.EXPECT().SetResult(updates)
+
+	uc := usecases.NewListDeckUpdates(deckService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListDeckUpdatesQuery{SubscriptionID: 6}); err != nil {
+		t.Fatal(err)
+	}
+}
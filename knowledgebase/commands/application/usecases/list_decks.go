@@ -0,0 +1,42 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListDecks type represents usecase that has sequence of actions to list models.Deck.
+type ListDecks struct {
+	deckService services.DeckService
+	presenter   models.ListDecksPresenter
+}
+
+// NewListDecks function builds new instance of ListDecks usecase.
+func NewListDecks(
+	deckService services.DeckService,
+	presenter models.ListDecksPresenter,
+) *ListDecks {
+	return &ListDecks{
+		deckService: deckService,
+		presenter:   presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListDecks) Handle(ctx context.Context, _ *models.ListDecksQuery) error {
+	if _, err := actingUser(ctx); err != nil {
+		return err
+	}
+
+	decks, err := uc.deckService.ListDecks()
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(decks)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDecks_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	decks := []*domain.Deck{{ID: 4, Owner: "workspace:5", Name: "Go basics"}}
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().ListDecks().Return(decks, nil)
+
+	presenter := mock.NewMockListDecksPresenter(ctrl)
+	presenter.EXPECT().SetResult(decks)
+
+	uc := usecases.NewListDecks(deckService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListDecksQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListNotifications type represents usecase that has sequence of actions to list models.Notification of the user.
+type ListNotifications struct {
+	notificationService services.NotificationService
+	presenter           models.ListNotificationsPresenter
+}
+
+// NewListNotifications function builds new instance of ListNotifications usecase.
+func NewListNotifications(
+	notificationService services.NotificationService,
+	presenter models.ListNotificationsPresenter,
+) *ListNotifications {
+	return &ListNotifications{
+		notificationService: notificationService,
+		presenter:           presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListNotifications) Handle(ctx context.Context, _ *models.ListNotificationsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := uc.notificationService.ListNotifications(user.Login)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(notifications)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListNotifications_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notifications := []*domain.Notification{{ID: 1, Recipient: "alice", Kind: domain.NotificationEditApproved}}
+
+	service := mock.NewMockNotificationService(ctrl)
+	service.EXPECT().ListNotifications("alice").Return(notifications, nil)
+
+	presenter := mock.NewMockListNotificationsPresenter(ctrl)
+	presenter.EXPECT().SetResult(notifications)
+
+	uc := usecases.NewListNotifications(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListNotificationsQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListNotifications_Handle_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := usecases.NewListNotifications(
+		mock.NewMockNotificationService(ctrl),
+		mock.NewMockListNotificationsPresenter(ctrl),
+	)
+
+	err := uc.Handle(context.Background(), &models.ListNotificationsQuery{})
+	if !errors.Is(err, usecases.ErrUnauthenticated) {
+		t.Errorf("expected error %s, got %v", usecases.ErrUnauthenticated, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListSmartCollections type represents usecase that has sequence of actions
+// to list saved searches of the user with their current counts and average scores.
+type ListSmartCollections struct {
+	savedSearchService services.SavedSearchService
+	presenter          models.ListSmartCollectionsPresenter
+}
+
+// NewListSmartCollections function builds new instance of ListSmartCollections usecase.
+func NewListSmartCollections(
+	savedSearchService services.SavedSearchService,
+	presenter models.ListSmartCollectionsPresenter,
+) *ListSmartCollections {
+	return &ListSmartCollections{
+		savedSearchService: savedSearchService,
+		presenter:          presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListSmartCollections) Handle(ctx context.Context, query *models.ListSmartCollectionsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	searches, err := uc.savedSearchService.ListSavedSearches(user.Login)
+	if err != nil {
+		return err
+	}
+
+	collections := make([]*models.SmartCollection, 0, len(searches))
+	for _, search := range searches {
+		collection, _, err := collect(uc.savedSearchService, search.Owner, search)
+		if err != nil {
+			return err
+		}
+
+		collections = append(collections, collection)
+	}
+
+	uc.presenter.SetResult(collections)
+
+	return nil
+}
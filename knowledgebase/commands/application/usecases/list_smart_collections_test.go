@@ -0,0 +1,43 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSmartCollections_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weak := &domain.SavedSearch{ID: 1, Owner: "alice", Filter: "score<40"}
+	empty := &domain.SavedSearch{ID: 2, Owner: "alice", Filter: "tag:nothing"}
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().ListSavedSearches("alice").Return([]*domain.SavedSearch{weak, empty}, nil)
+	service.EXPECT().FindItems("alice", weak).Return([]*domain.KnowledgeItem{{ID: 1, Score: 30}}, nil)
+	service.EXPECT().FindItems("alice", empty).Return(nil, nil)
+
+	presenter := mock.NewMockListSmartCollectionsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(collections []*models.SmartCollection) {
+		if len(collections) != 2 {
+			t.Fatalf("expected 2 collections, got %d", len(collections))
+		}
+		if collections[0].Count != 1 || collections[0].AverageScore != 30 {
+			t.Errorf("unexpected collection %v", collections[0])
+		}
+		if collections[1].Count != 0 || collections[1].AverageScore != 0 {
+			t.Errorf("unexpected collection %v", collections[1])
+		}
+	})
+
+	uc := usecases.NewListSmartCollections(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListSmartCollectionsQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListSuggestedEdits type represents usecase that has sequence of actions to list pending models.SuggestedEdit.
+type ListSuggestedEdits struct {
+	suggestedEditService services.SuggestedEditService
+	workspaceService     services.WorkspaceService
+	presenter            models.ListSuggestedEditsPresenter
+}
+
+// NewListSuggestedEdits function builds new instance of ListSuggestedEdits usecase.
+func NewListSuggestedEdits(
+	suggestedEditService services.SuggestedEditService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListSuggestedEditsPresenter,
+) *ListSuggestedEdits {
+	return &ListSuggestedEdits{
+		suggestedEditService: suggestedEditService,
+		workspaceService:     workspaceService,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions. Edits are reviewed by the members allowed to edit the items.
+func (uc *ListSuggestedEdits) Handle(ctx context.Context, query *models.ListSuggestedEditsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	edits, err := uc.suggestedEditService.ListPendingEdits(owner)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(edits)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSuggestedEdits_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	edits := []*domain.SuggestedEdit{{ID: 3, ItemID: 7, Author: "bob", Status: domain.EditPending}}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	editService.EXPECT().ListPendingEdits("workspace:5").Return(edits, nil)
+
+	presenter := mock.NewMockListSuggestedEditsPresenter(ctrl)
+	presenter.EXPECT().SetResult(edits)
+
+	uc := usecases.NewListSuggestedEdits(editService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListSuggestedEditsQuery{WorkspaceID: 5}); err != nil {
+		t.Fatal(err)
+	}
+}
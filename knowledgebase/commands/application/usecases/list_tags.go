@@ -0,0 +1,44 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListTags type represents usecase that has sequence of actions
+// to list the tags in use with the number of knowledge items having them.
+type ListTags struct {
+	tagService services.TagService
+	presenter  models.ListTagsPresenter
+}
+
+// NewListTags function builds new instance of ListTags usecase.
+func NewListTags(
+	tagService services.TagService,
+	presenter models.ListTagsPresenter,
+) *ListTags {
+	return &ListTags{
+		tagService: tagService,
+		presenter:  presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListTags) Handle(ctx context.Context, _ *models.ListTagsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	tags, err := uc.tagService.ListTags(user.Login)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(tags)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTags_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := []*domain.TagUsage{{Tag: "go", Count: 3}, {Tag: "databases", Count: 1}}
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().ListTags("alice").Return(expected, nil)
+
+	presenter := mock.NewMockListTagsPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewListTags(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListTagsQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListTags_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().ListTags("alice").Return(nil, expectedError)
+
+	uc := usecases.NewListTags(service, mock.NewMockListTagsPresenter(ctrl))
+
+	if err := uc.Handle(aliceContext(), &models.ListTagsQuery{}); !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListWebhookDeliveries type represents usecase that has sequence of actions
+// to list models.WebhookDelivery of the webhook.
+type ListWebhookDeliveries struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.ListWebhookDeliveriesPresenter
+}
+
+// NewListWebhookDeliveries function builds new instance of ListWebhookDeliveries usecase.
+func NewListWebhookDeliveries(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListWebhookDeliveriesPresenter,
+) *ListWebhookDeliveries {
+	return &ListWebhookDeliveries{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListWebhookDeliveries) Handle(ctx context.Context, query *models.ListWebhookDeliveriesQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := uc.webhookService.ListDeliveries(owner, query.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(deliveries)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhookDeliveries_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := []*domain.WebhookDelivery{{ID: 5, WebhookID: 3, Status: domain.DeliveryFailed}}
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().ListDeliveries("alice", int64(3)).Return(deliveries, nil)
+
+	presenter := mock.NewMockListWebhookDeliveriesPresenter(ctrl)
+	presenter.EXPECT().SetResult(deliveries)
+
+	uc := usecases.NewListWebhookDeliveries(webhookService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListWebhookDeliveriesQuery{WebhookID: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListWebhooks type represents usecase that has sequence of actions to list models.Webhook.
+type ListWebhooks struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.ListWebhooksPresenter
+}
+
+// NewListWebhooks function builds new instance of ListWebhooks usecase.
+func NewListWebhooks(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.ListWebhooksPresenter,
+) *ListWebhooks {
+	return &ListWebhooks{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListWebhooks) Handle(ctx context.Context, query *models.ListWebhooksQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := uc.webhookService.ListWebhooks(owner)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(webhooks)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhooks_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhooks := []*domain.Webhook{{ID: 3, Owner: "alice"}}
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().ListWebhooks("alice").Return(webhooks, nil)
+
+	presenter := mock.NewMockListWebhooksPresenter(ctrl)
+	presenter.EXPECT().SetResult(webhooks)
+
+	uc := usecases.NewListWebhooks(webhookService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListWebhooksQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
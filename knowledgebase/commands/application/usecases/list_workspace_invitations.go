@@ -0,0 +1,43 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ListWorkspaceInvitations type represents usecase that has sequence of actions to list models.WorkspaceInvitation.
+type ListWorkspaceInvitations struct {
+	workspaceService services.WorkspaceService
+	presenter        models.ListWorkspaceInvitationsPresenter
+}
+
+// NewListWorkspaceInvitations function builds new instance of ListWorkspaceInvitations usecase.
+func NewListWorkspaceInvitations(
+	workspaceService services.WorkspaceService,
+	presenter models.ListWorkspaceInvitationsPresenter,
+) *ListWorkspaceInvitations {
+	return &ListWorkspaceInvitations{
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ListWorkspaceInvitations) Handle(ctx context.Context, _ *models.ListWorkspaceInvitationsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	invitations, err := uc.workspaceService.ListInvitations(user.Login)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(invitations)
+
+	return nil
+}
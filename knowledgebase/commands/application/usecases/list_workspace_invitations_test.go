@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWorkspaceInvitations_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invitations := []*domain.WorkspaceInvitation{{ID: 2, WorkspaceID: 5, Login: "alice", Role: domain.RoleViewer}}
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().ListInvitations("alice").Return(invitations, nil)
+
+	presenter := mock.NewMockListWorkspaceInvitationsPresenter(ctrl)
+	presenter.EXPECT().SetResult(invitations)
+
+	uc := usecases.NewListWorkspaceInvitations(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ListWorkspaceInvitationsQuery{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListWorkspaceInvitations_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockWorkspaceService(ctrl)
+	service.EXPECT().ListInvitations("alice").Return(nil, expectedError)
+
+	uc := usecases.NewListWorkspaceInvitations(service, mock.NewMockListWorkspaceInvitationsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.ListWorkspaceInvitationsQuery{})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
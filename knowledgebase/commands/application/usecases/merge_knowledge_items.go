@@ -0,0 +1,69 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// MergeKnowledgeItems type represents usecase that has sequence of actions
+// to combine duplicated models.KnowledgeItem into one.
+type MergeKnowledgeItems struct {
+	knowledgeItemService services.KnowledgeItemService
+	suggestedEditService services.SuggestedEditService
+	assignmentService    services.AssignmentService
+	deckService          services.DeckService
+	presenter            models.MergeKnowledgeItemsPresenter
+}
+
+// NewMergeKnowledgeItems function builds new instance of MergeKnowledgeItems usecase.
+func NewMergeKnowledgeItems(
+	knowledgeItemService services.KnowledgeItemService,
+	suggestedEditService services.SuggestedEditService,
+	assignmentService services.AssignmentService,
+	deckService services.DeckService,
+	presenter models.MergeKnowledgeItemsPresenter,
+) *MergeKnowledgeItems {
+	return &MergeKnowledgeItems{
+		knowledgeItemService: knowledgeItemService,
+		suggestedEditService: suggestedEditService,
+		assignmentService:    assignmentService,
+		deckService:          deckService,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions. The source item with pending suggested edits isn't merged,
+// the target keeps its content, so its own pending edits still apply after the merge.
+// Assignments picking the source pick the target instead, and so do the copies of the subscribed decks.
+func (uc *MergeKnowledgeItems) Handle(ctx context.Context, cmd *models.MergeKnowledgeItemsCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	auditItems(ctx, cmd.TargetID, cmd.SourceID)
+
+	if err = uc.suggestedEditService.ValidateMerge(user.Login, cmd.SourceID); err != nil {
+		return err
+	}
+
+	item, err := uc.knowledgeItemService.MergeItems(user.Login, cmd.TargetID, cmd.SourceID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.assignmentService.ReplaceItem(user.Login, cmd.SourceID, cmd.TargetID); err != nil {
+		return err
+	}
+
+	if err = uc.deckService.ReplaceItem(user.Login, cmd.SourceID, cmd.TargetID); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(item)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeKnowledgeItems_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedItem := &domain.KnowledgeItem{ID: 1, Tags: []string{"go", "concurrency"}}
+
+	service := mock.NewMockKnowledgeItemService(ctrl)
+
+	presenter := mock.NewMockMergeKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(expectedItem)
+
+	edits := mock.NewMockSuggestedEditService(ctrl)
+	edits.EXPECT().ValidateMerge("alice", int64(2)).Return(nil)
+
+	// assignments and deck copies of the source point to the target once it is merged.
+	assignments := mock.NewMockAssignmentService(ctrl)
+	decks := mock.NewMockDeckService(ctrl)
+	gomock.InOrder(
+		service.EXPECT().MergeItems("alice", int64(1), int64(2)).Return(expectedItem, nil),
+		assignments.EXPECT().ReplaceItem("alice", int64(2), int64(1)).Return(nil),
+		decks.EXPECT().ReplaceItem("alice", int64(2), int64(1)).Return(nil),
+	)
+
+	uc := usecases.NewMergeKnowledgeItems(service, edits, assignments, decks, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.MergeKnowledgeItemsCommand{TargetID: 1, SourceID: 2}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeKnowledgeItems_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockKnowledgeItemService(ctrl)
+	service.EXPECT().MergeItems("alice", int64(1), int64(2)).Return(nil, expectedError)
+
+	edits := mock.NewMockSuggestedEditService(ctrl)
+	edits.EXPECT().ValidateMerge("alice", int64(2)).Return(nil)
+
+	uc := usecases.NewMergeKnowledgeItems(
+		service,
+		edits,
+		mock.NewMockAssignmentService(ctrl),
+		mock.NewMockDeckService(ctrl),
+		mock.NewMockMergeKnowledgeItemsPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.MergeKnowledgeItemsCommand{TargetID: 1, SourceID: 2})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
+
+func TestMergeKnowledgeItems_Handle_PendingEdits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("item has pending suggested edits")
+
+	// the items aren't merged, so the pending edits of the source keep their item.
+	edits := mock.NewMockSuggestedEditService(ctrl)
+	edits.EXPECT().ValidateMerge("alice", int64(2)).Return(expectedError)
+
+	uc := usecases.NewMergeKnowledgeItems(
+		mock.NewMockKnowledgeItemService(ctrl),
+		edits,
+		mock.NewMockAssignmentService(ctrl),
+		mock.NewMockDeckService(ctrl),
+		mock.NewMockMergeKnowledgeItemsPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.MergeKnowledgeItemsCommand{TargetID: 1, SourceID: 2})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
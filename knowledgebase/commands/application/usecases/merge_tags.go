@@ -0,0 +1,44 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// MergeTags type represents usecase that has sequence of actions
+// to combine several tags of the knowledge items into one.
+type MergeTags struct {
+	tagService services.TagService
+	presenter  models.MergeTagsPresenter
+}
+
+// NewMergeTags function builds new instance of MergeTags usecase.
+func NewMergeTags(
+	tagService services.TagService,
+	presenter models.MergeTagsPresenter,
+) *MergeTags {
+	return &MergeTags{
+		tagService: tagService,
+		presenter:  presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *MergeTags) Handle(ctx context.Context, cmd *models.MergeTagsCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := uc.tagService.MergeTags(user.Login, cmd.Sources, cmd.Target)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(tag)
+
+	return nil
+}
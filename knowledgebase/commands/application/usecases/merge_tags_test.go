@@ -0,0 +1,49 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeTags_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := &domain.TagUsage{Tag: "go", Count: 4}
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().MergeTags("alice", []string{"golang", "go-lang"}, "go").Return(expected, nil)
+
+	presenter := mock.NewMockMergeTagsPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewMergeTags(service, presenter)
+
+	err := uc.Handle(aliceContext(), &models.MergeTagsCommand{Sources: []string{"golang", "go-lang"}, Target: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeTags_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().MergeTags("alice", []string{"golang"}, "go").Return(nil, expectedError)
+
+	uc := usecases.NewMergeTags(service, mock.NewMockMergeTagsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.MergeTagsCommand{Sources: []string{"golang"}, Target: "go"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,45 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// MoveCategory type represents usecase that has sequence of actions
+// to move the category with its subcategories under another category.
+type MoveCategory struct {
+	categoryService services.CategoryService
+	presenter       models.MoveCategoryPresenter
+}
+
+// NewMoveCategory function builds new instance of MoveCategory usecase.
+func NewMoveCategory(
+	categoryService services.CategoryService,
+	presenter models.MoveCategoryPresenter,
+) *MoveCategory {
+	return &MoveCategory{
+		categoryService: categoryService,
+		presenter:       presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *MoveCategory) Handle(ctx context.Context, cmd *models.MoveCategoryCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	category, err := uc.categoryService.MoveCategory(user.Login, cmd.Name, cmd.Parent)
+	if err != nil {
+		return err
+	}
+
+	auditCategories(ctx, category.ID)
+	uc.presenter.SetResult(category)
+
+	return nil
+}
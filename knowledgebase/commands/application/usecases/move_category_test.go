@@ -0,0 +1,49 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMoveCategory_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := &domain.Category{ID: 2, Name: "Engineering/Databases", ParentID: 1}
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().MoveCategory("alice", "Databases", "Engineering").Return(expected, nil)
+
+	presenter := mock.NewMockMoveCategoryPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewMoveCategory(service, presenter)
+
+	cmd := &models.MoveCategoryCommand{Name: "Databases", Parent: "Engineering"}
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveCategory_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().MoveCategory("alice", "Engineering", "Engineering/Backend").Return(nil, expectedError)
+
+	uc := usecases.NewMoveCategory(service, mock.NewMockMoveCategoryPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.MoveCategoryCommand{Name: "Engineering", Parent: "Engineering/Backend"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ProposeEdit type represents usecase that has sequence of actions to propose models.SuggestedEdit.
+type ProposeEdit struct {
+	knowledgeItemService services.KnowledgeItemService
+	suggestedEditService services.SuggestedEditService
+	workspaceService     services.WorkspaceService
+	presenter            models.ProposeEditPresenter
+}
+
+// NewProposeEdit function builds new instance of ProposeEdit usecase.
+func NewProposeEdit(
+	knowledgeItemService services.KnowledgeItemService,
+	suggestedEditService services.SuggestedEditService,
+	workspaceService services.WorkspaceService,
+	presenter models.ProposeEditPresenter,
+) *ProposeEdit {
+	return &ProposeEdit{
+		knowledgeItemService: knowledgeItemService,
+		suggestedEditService: suggestedEditService,
+		workspaceService:     workspaceService,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions. Every member allowed to view the item may propose the edit,
+// the content is validated the same way as the content of the item.
+func (uc *ProposeEdit) Handle(ctx context.Context, cmd *models.ProposeEditCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	item, err := uc.knowledgeItemService.GetItem(owner, cmd.ItemID)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.knowledgeItemService.ValidateItem(cmd.Title, cmd.Anchor, cmd.Data, item.Tags); err != nil {
+		return err
+	}
+
+	edit, err := uc.suggestedEditService.ProposeEdit(user.Login, item, cmd.Title, cmd.Anchor, cmd.Data, cmd.Comment)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(edit)
+
+	return nil
+}
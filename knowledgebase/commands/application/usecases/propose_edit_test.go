@@ -0,0 +1,70 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProposeEdit_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := &models.ProposeEditCommand{
+		WorkspaceID: 5,
+		ItemID:      7,
+		Title:       "Raft leader election",
+		Anchor:      "raft",
+		Data:        "Leader is elected by the majority of votes.",
+		Comment:     "51% isn't required",
+	}
+	item := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5", Title: cmd.Title, Tags: []string{"consensus"}}
+	expectedEdit := &domain.SuggestedEdit{ID: 3, ItemID: 7, Author: "alice", Status: domain.EditPending}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionView).Return("workspace:5", nil)
+
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("workspace:5", int64(7)).Return(item, nil)
+	itemService.EXPECT().ValidateItem(cmd.Title, cmd.Anchor, cmd.Data, item.Tags).Return(nil)
+
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	editService.EXPECT().
+		ProposeEdit("alice", item, cmd.Title, cmd.Anchor, cmd.Data, cmd.Comment).
+		Return(expectedEdit, nil)
+
+	presenter := mock.NewMockProposeEditPresenter(ctrl)
+	presenter.EXPECT().SetResult(expectedEdit)
+
+	uc := usecases.NewProposeEdit(itemService, editService, workspaceService, presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProposeEdit_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionView).Return("", services.ErrForbidden)
+
+	uc := usecases.NewProposeEdit(
+		mock.NewMockKnowledgeItemService(ctrl),
+		mock.NewMockSuggestedEditService(ctrl),
+		workspaceService,
+		mock.NewMockProposeEditPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.ProposeEditCommand{WorkspaceID: 5, ItemID: 7})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// PublishDeck type represents usecase that has sequence of actions to publish models.Deck.
+type PublishDeck struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.PublishDeckPresenter
+}
+
+// NewPublishDeck function builds new instance of PublishDeck usecase.
+func NewPublishDeck(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.PublishDeckPresenter,
+) *PublishDeck {
+	return &PublishDeck{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. Decks are published by the members allowed to manage the workspace.
+func (uc *PublishDeck) Handle(ctx context.Context, cmd *models.PublishDeckCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	deck, err := uc.deckService.PublishDeck(owner, user.Login, cmd.CategoryID, cmd.Name, cmd.Description)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(deck)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublishDeck_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deck := &domain.Deck{ID: 4, Owner: "workspace:5", CategoryID: 2, Name: "Go basics", PublishedBy: "alice"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().PublishDeck("workspace:5", "alice", int64(2), "Go basics", "Syntax").Return(deck, nil)
+
+	presenter := mock.NewMockPublishDeckPresenter(ctrl)
+	presenter.EXPECT().SetResult(deck)
+
+	uc := usecases.NewPublishDeck(deckService, workspaceService, presenter)
+
+	cmd := &models.PublishDeckCommand{WorkspaceID: 5, CategoryID: 2, Name: "Go basics", Description: "Syntax"}
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPublishDeck_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("", services.ErrForbidden)
+
+	uc := usecases.NewPublishDeck(mock.NewMockDeckService(ctrl), workspaceService, mock.NewMockPublishDeckPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.PublishDeckCommand{WorkspaceID: 5, CategoryID: 2, Name: "Go basics"})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
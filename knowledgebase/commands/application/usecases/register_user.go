@@ -0,0 +1,36 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// RegisterUser type represents usecase that has sequence of actions to create new models.User.
+type RegisterUser struct {
+	userService services.UserService
+	presenter   models.RegisterUserPresenter
+}
+
+// NewRegisterUser function builds new instance of RegisterUser usecase.
+func NewRegisterUser(userService services.UserService, presenter models.RegisterUserPresenter) *RegisterUser {
+	return &RegisterUser{
+		userService: userService,
+		presenter:   presenter,
+	}
+}
+
+// Handle function performs usecase actions. It doesn't require the acting user,
+// the transport decides who is allowed to register users.
+func (uc *RegisterUser) Handle(_ context.Context, cmd *models.RegisterUserCommand) error {
+	user, err := uc.userService.NewUser(cmd.Login, cmd.Name, cmd.Email)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(user)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRegisterUser_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: 1, Login: "alice", Name: "Alice", Email: "alice@example.com"}
+
+	service := mock.NewMockUserService(ctrl)
+	service.EXPECT().NewUser("Alice", "Alice", "alice@example.com").Return(user, nil)
+
+	presenter := mock.NewMockRegisterUserPresenter(ctrl)
+	presenter.EXPECT().SetResult(user)
+
+	uc := usecases.NewRegisterUser(service, presenter)
+
+	err := uc.Handle(context.Background(), &models.RegisterUserCommand{
+		Login: "Alice",
+		Name:  "Alice",
+		Email: "alice@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterUser_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockUserService(ctrl)
+	service.EXPECT().NewUser("a", "", "").Return(nil, expectedError)
+
+	uc := usecases.NewRegisterUser(service, mock.NewMockRegisterUserPresenter(ctrl))
+
+	err := uc.Handle(context.Background(), &models.RegisterUserCommand{Login: "a"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// RejectEdit type represents usecase that has sequence of actions to reject models.SuggestedEdit.
+type RejectEdit struct {
+	suggestedEditService services.SuggestedEditService
+	workspaceService     services.WorkspaceService
+	presenter            models.RejectEditPresenter
+}
+
+// NewRejectEdit function builds new instance of RejectEdit usecase.
+func NewRejectEdit(
+	suggestedEditService services.SuggestedEditService,
+	workspaceService services.WorkspaceService,
+	presenter models.RejectEditPresenter,
+) *RejectEdit {
+	return &RejectEdit{
+		suggestedEditService: suggestedEditService,
+		workspaceService:     workspaceService,
+		presenter:            presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *RejectEdit) Handle(ctx context.Context, cmd *models.RejectEditCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	edit, err := uc.suggestedEditService.RejectEdit(user.Login, owner, cmd.ID, cmd.Comment)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(edit)
+
+	return nil
+}
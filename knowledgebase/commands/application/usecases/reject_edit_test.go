@@ -0,0 +1,55 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRejectEdit_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rejected := &domain.SuggestedEdit{ID: 3, Status: domain.EditRejected, Reviewer: "alice"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("workspace:5", nil)
+
+	editService := mock.NewMockSuggestedEditService(ctrl)
+	editService.EXPECT().RejectEdit("alice", "workspace:5", int64(3), "the number is right").Return(rejected, nil)
+
+	presenter := mock.NewMockRejectEditPresenter(ctrl)
+	presenter.EXPECT().SetResult(rejected)
+
+	uc := usecases.NewRejectEdit(editService, workspaceService, presenter)
+
+	err := uc.Handle(aliceContext(), &models.RejectEditCommand{WorkspaceID: 5, ID: 3, Comment: "the number is right"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRejectEdit_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("", services.ErrForbidden)
+
+	uc := usecases.NewRejectEdit(
+		mock.NewMockSuggestedEditService(ctrl),
+		workspaceService,
+		mock.NewMockRejectEditPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.RejectEditCommand{WorkspaceID: 5, ID: 3})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
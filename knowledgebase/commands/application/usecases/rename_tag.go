@@ -0,0 +1,44 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// RenameTag type represents usecase that has sequence of actions
+// to rename the tag in all the knowledge items.
+type RenameTag struct {
+	tagService services.TagService
+	presenter  models.RenameTagPresenter
+}
+
+// NewRenameTag function builds new instance of RenameTag usecase.
+func NewRenameTag(
+	tagService services.TagService,
+	presenter models.RenameTagPresenter,
+) *RenameTag {
+	return &RenameTag{
+		tagService: tagService,
+		presenter:  presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *RenameTag) Handle(ctx context.Context, cmd *models.RenameTagCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := uc.tagService.RenameTag(user.Login, cmd.From, cmd.To)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(tag)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenameTag_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := &domain.TagUsage{Tag: "go", Count: 2}
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().RenameTag("alice", "golang", "go").Return(expected, nil)
+
+	presenter := mock.NewMockRenameTagPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewRenameTag(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.RenameTagCommand{From: "golang", To: "go"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenameTag_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockTagService(ctrl)
+	service.EXPECT().RenameTag("alice", "golang", "go").Return(nil, expectedError)
+
+	uc := usecases.NewRenameTag(service, mock.NewMockRenameTagPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.RenameTagCommand{From: "golang", To: "go"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// ReplayWebhookDelivery type represents usecase that has sequence of actions to send models.WebhookDelivery once again.
+type ReplayWebhookDelivery struct {
+	webhookService   services.WebhookService
+	workspaceService services.WorkspaceService
+	presenter        models.ReplayWebhookDeliveryPresenter
+}
+
+// NewReplayWebhookDelivery function builds new instance of ReplayWebhookDelivery usecase.
+func NewReplayWebhookDelivery(
+	webhookService services.WebhookService,
+	workspaceService services.WorkspaceService,
+	presenter models.ReplayWebhookDeliveryPresenter,
+) *ReplayWebhookDelivery {
+	return &ReplayWebhookDelivery{
+		webhookService:   webhookService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *ReplayWebhookDelivery) Handle(ctx context.Context, cmd *models.ReplayWebhookDeliveryCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := uc.webhookService.ReplayDelivery(owner, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(delivery)
+
+	return nil
+}
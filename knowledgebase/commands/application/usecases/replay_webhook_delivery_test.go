@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayWebhookDelivery_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delivery := &domain.WebhookDelivery{ID: 6, WebhookID: 3, Status: domain.DeliveryPending, ReplayOf: 5}
+
+	webhookService := mock.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().ReplayDelivery("alice", int64(5)).Return(delivery, nil)
+
+	presenter := mock.NewMockReplayWebhookDeliveryPresenter(ctrl)
+	presenter.EXPECT().SetResult(delivery)
+
+	uc := usecases.NewReplayWebhookDelivery(webhookService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.ReplayWebhookDeliveryCommand{ID: 5}); err != nil {
+		t.Fatal(err)
+	}
+}
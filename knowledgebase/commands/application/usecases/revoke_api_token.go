@@ -0,0 +1,42 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// RevokeAPIToken type represents usecase that has sequence of actions to revoke models.APIToken.
+type RevokeAPIToken struct {
+	apiTokenService services.APITokenService
+	presenter       models.RevokeAPITokenPresenter
+}
+
+// NewRevokeAPIToken function builds new instance of RevokeAPIToken usecase.
+func NewRevokeAPIToken(
+	apiTokenService services.APITokenService,
+	presenter models.RevokeAPITokenPresenter,
+) *RevokeAPIToken {
+	return &RevokeAPIToken{
+		apiTokenService: apiTokenService,
+		presenter:       presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *RevokeAPIToken) Handle(ctx context.Context, cmd *models.RevokeAPITokenCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.apiTokenService.RevokeToken(user.Login, cmd.ID); err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(true)
+
+	return nil
+}
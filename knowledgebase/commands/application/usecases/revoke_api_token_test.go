@@ -0,0 +1,44 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRevokeAPIToken_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().RevokeToken("alice", int64(3)).Return(nil)
+
+	presenter := mock.NewMockRevokeAPITokenPresenter(ctrl)
+	presenter.EXPECT().SetResult(true)
+
+	uc := usecases.NewRevokeAPIToken(service, presenter)
+
+	if err := uc.Handle(aliceContext(), &models.RevokeAPITokenCommand{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRevokeAPIToken_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockAPITokenService(ctrl)
+	service.EXPECT().RevokeToken("alice", int64(3)).Return(services.ErrForbidden)
+
+	uc := usecases.NewRevokeAPIToken(service, mock.NewMockRevokeAPITokenPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.RevokeAPITokenCommand{ID: 3})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %s", services.ErrForbidden, err)
+	}
+}
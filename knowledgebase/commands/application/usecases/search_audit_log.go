@@ -0,0 +1,59 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// SearchAuditLog type represents usecase that has sequence of actions to search models.AuditEntry.
+type SearchAuditLog struct {
+	auditService     services.AuditService
+	workspaceService services.WorkspaceService
+	presenter        models.SearchAuditLogPresenter
+}
+
+// NewSearchAuditLog function builds new instance of SearchAuditLog usecase.
+func NewSearchAuditLog(
+	auditService services.AuditService,
+	workspaceService services.WorkspaceService,
+	presenter models.SearchAuditLogPresenter,
+) *SearchAuditLog {
+	return &SearchAuditLog{
+		auditService:     auditService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions. The audit log of the workspace is searched by the members
+// allowed to manage it.
+func (uc *SearchAuditLog) Handle(ctx context.Context, query *models.SearchAuditLogQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	entries, err := uc.auditService.Search(&domain.AuditFilter{
+		Owner:  owner,
+		Actor:  query.Actor,
+		ItemID: query.ItemID,
+		From:   query.From,
+		To:     query.To,
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(entries)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchAuditLog_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []*domain.AuditEntry{{ID: 3, Actor: "bob", Owner: "workspace:5", ItemIDs: []int64{7}}}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("workspace:5", nil)
+
+	auditService := mock.NewMockAuditService(ctrl)
+	auditService.EXPECT().Search(&domain.AuditFilter{Owner: "workspace:5", Actor: "bob", ItemID: 7, From: &from}).
+		Return(entries, nil)
+
+	presenter := mock.NewMockSearchAuditLogPresenter(ctrl)
+	presenter.EXPECT().SetResult(entries)
+
+	uc := usecases.NewSearchAuditLog(auditService, workspaceService, presenter)
+
+	err := uc.Handle(aliceContext(), &models.SearchAuditLogQuery{WorkspaceID: 5, Actor: "bob", ItemID: 7, From: &from})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchAuditLog_Handle_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionManage).Return("", services.ErrForbidden)
+
+	uc := usecases.NewSearchAuditLog(
+		mock.NewMockAuditService(ctrl),
+		workspaceService,
+		mock.NewMockSearchAuditLogPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.SearchAuditLogQuery{WorkspaceID: 5})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchKnowledgeItems type represents usecase that has sequence of actions
+// to find models.KnowledgeItem by the full-text query.
+type SearchKnowledgeItems struct {
+	index            models.KnowledgeItemsIndex
+	workspaceService services.WorkspaceService
+	presenter        models.SearchKnowledgeItemsPresenter
+}
+
+// NewSearchKnowledgeItems function builds new instance of SearchKnowledgeItems usecase.
+func NewSearchKnowledgeItems(
+	index models.KnowledgeItemsIndex,
+	workspaceService services.WorkspaceService,
+	presenter models.SearchKnowledgeItemsPresenter,
+) *SearchKnowledgeItems {
+	return &SearchKnowledgeItems{
+		index:            index,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// The page size defaults to 20 hits and can't be more than 100.
+func (uc *SearchKnowledgeItems) Handle(ctx context.Context, query *models.SearchKnowledgeItemsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	if query.Limit < 0 || query.Offset < 0 {
+		return errors.New("limit and offset cannot be negative")
+	}
+
+	page := *query
+	page.Owner = owner
+	if page.Limit == 0 {
+		page.Limit = defaultSearchLimit
+	}
+	page.Limit = min(page.Limit, maxSearchLimit)
+
+	result, err := uc.index.Search(&page)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(result)
+
+	return nil
+}
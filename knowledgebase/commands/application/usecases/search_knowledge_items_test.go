@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchKnowledgeItems_Do_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := &models.SearchKnowledgeItemsQuery{Query: "goroutine", Tags: []string{"go"}}
+	expectedResult := &models.SearchResult{
+		Total: 1,
+		Hits:  []*models.SearchHit{{Item: &domain.KnowledgeItem{ID: 1}, Score: 1.5}},
+	}
+
+	index := mock.NewMockKnowledgeItemsIndex(ctrl)
+	index.EXPECT().Search(gomock.Any()).DoAndReturn(func(q *models.SearchKnowledgeItemsQuery) (*models.SearchResult, error) {
+		if q.Owner != "alice" || q.Query != query.Query || q.Limit != 20 || q.Offset != 0 {
+			t.Errorf("unexpected query %+v", q)
+		}
+
+		return expectedResult, nil
+	})
+
+	presenter := mock.NewMockSearchKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(expectedResult)
+
+	uc := usecases.NewSearchKnowledgeItems(index, personalWorkspace(ctrl), presenter)
+
+	err := uc.Handle(aliceContext(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchKnowledgeItems_Do_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// viewers of the workspace search its items.
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionView).Return("workspace:5", nil)
+
+	index := mock.NewMockKnowledgeItemsIndex(ctrl)
+	index.EXPECT().Search(gomock.Any()).DoAndReturn(func(q *models.SearchKnowledgeItemsQuery) (*models.SearchResult, error) {
+		if q.Owner != "workspace:5" {
+			t.Errorf("expected items of the workspace to be searched, got %q", q.Owner)
+		}
+
+		return &models.SearchResult{}, nil
+	})
+
+	presenter := mock.NewMockSearchKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any())
+
+	uc := usecases.NewSearchKnowledgeItems(index, workspaceService, presenter)
+
+	err := uc.Handle(aliceContext(), &models.SearchKnowledgeItemsQuery{WorkspaceID: 5, Query: "raft"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchKnowledgeItems_Do_LimitIsCapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := mock.NewMockKnowledgeItemsIndex(ctrl)
+	index.EXPECT().Search(gomock.Any()).DoAndReturn(func(q *models.SearchKnowledgeItemsQuery) (*models.SearchResult, error) {
+		if q.Limit != 100 {
+			t.Errorf("expected limit 100, got %d", q.Limit)
+		}
+
+		return &models.SearchResult{}, nil
+	})
+
+	presenter := mock.NewMockSearchKnowledgeItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any())
+
+	uc := usecases.NewSearchKnowledgeItems(index, personalWorkspace(ctrl), presenter)
+
+	err := uc.Handle(aliceContext(), &models.SearchKnowledgeItemsQuery{Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchKnowledgeItems_Do_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	index := mock.NewMockKnowledgeItemsIndex(ctrl)
+	index.EXPECT().Search(gomock.Any()).Return(nil, expectedError)
+
+	uc := usecases.NewSearchKnowledgeItems(index, personalWorkspace(ctrl), mock.NewMockSearchKnowledgeItemsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.SearchKnowledgeItemsQuery{Offset: -1})
+	if err == nil || err.Error() != "limit and offset cannot be negative" {
+		t.Errorf("expected negative offset error, got %v", err)
+	}
+
+	err = uc.Handle(aliceContext(), &models.SearchKnowledgeItemsQuery{Query: "go"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
 )
 
@@ -12,23 +13,39 @@ import (
 // to set/update models.KnowledgeItem`s LastMark.
 type SetMarkToKnowledgeItem struct {
 	knowledgeItemService services.KnowledgeItemService
+	workspaceService     services.WorkspaceService
 	presenter            models.SetMarkToKnowledgeItemPresenter
 }
 
 // NewSetMarkToKnowledgeItem function builds new instance of SetMarkToKnowledgeItem usecase.
 func NewSetMarkToKnowledgeItem(
 	service services.KnowledgeItemService,
+	workspaceService services.WorkspaceService,
 	presenter models.SetMarkToKnowledgeItemPresenter,
 ) *SetMarkToKnowledgeItem {
 	return &SetMarkToKnowledgeItem{
 		knowledgeItemService: service,
+		workspaceService:     workspaceService,
 		presenter:            presenter,
 	}
 }
 
-// Handle function performs usecase actions.
-func (uc *SetMarkToKnowledgeItem) Handle(_ context.Context, cmd models.SetMarkToKnowledgeItemCommand) error {
-	item, err := uc.knowledgeItemService.SetLatestMark(cmd.ID, cmd.Mark)
+// Handle function performs usecase actions. Items of the workspace are marked by the members allowed to review it.
+func (uc *SetMarkToKnowledgeItem) Handle(ctx context.Context, cmd models.SetMarkToKnowledgeItemCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionReview)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+	auditItems(ctx, cmd.ID)
+
+	item, err := uc.knowledgeItemService.SetLatestMark(owner, user.Login, cmd.ID, cmd.Mark)
 	if err != nil {
 		return err
 	}
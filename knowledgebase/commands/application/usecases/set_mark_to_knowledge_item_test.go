@@ -1,7 +1,6 @@
 package usecases_test
 
 import (
-	"context"
 	"errors"
 	"testing"
 
@@ -30,7 +29,7 @@ func TestSetMarkToKnowledgeItem_Do(t *testing.T) {
 	}
 
 	knowledgeItemsService := mock.NewMockKnowledgeItemService(ctrl)
-	knowledgeItemsService.EXPECT().SetLatestMark(expectedItemID, expectedMark).Return(item, nil)
+	knowledgeItemsService.EXPECT().SetLatestMark("alice", "alice", expectedItemID, expectedMark).Return(item, nil)
 
 	presenter := mock.NewMockSetMarkToKnowledgeItemPresenter(ctrl)
 	presenter.EXPECT().SetResult(gomock.Any()).Do(func(resultItem *domain.KnowledgeItem) {
@@ -42,9 +41,9 @@ func TestSetMarkToKnowledgeItem_Do(t *testing.T) {
 		}
 	})
 
-	uc := usecases.NewSetMarkToKnowledgeItem(knowledgeItemsService, presenter)
+	uc := usecases.NewSetMarkToKnowledgeItem(knowledgeItemsService, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, req)
 	if err != nil {
@@ -67,13 +66,13 @@ func TestSetMarkToKnowledgeItem_Error(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	knowledgeItemsService := mock.NewMockKnowledgeItemService(ctrl)
-	knowledgeItemsService.EXPECT().SetLatestMark(expectedItemID, expectedMark).Return(nil, expectedError)
+	knowledgeItemsService.EXPECT().SetLatestMark("alice", "alice", expectedItemID, expectedMark).Return(nil, expectedError)
 
 	presenter := mock.NewMockSetMarkToKnowledgeItemPresenter(ctrl)
 
-	uc := usecases.NewSetMarkToKnowledgeItem(knowledgeItemsService, presenter)
+	uc := usecases.NewSetMarkToKnowledgeItem(knowledgeItemsService, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, req)
 	if err == nil {
@@ -83,3 +82,26 @@ func TestSetMarkToKnowledgeItem_Error(t *testing.T) {
 		t.Errorf("got error %v, want %v", err, expectedError)
 	}
 }
+
+func TestSetMarkToKnowledgeItem_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	item := &domain.KnowledgeItem{ID: 7, Owner: "workspace:5", LastMark: 8}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionReview).Return("workspace:5", nil)
+
+	knowledgeItemsService := mock.NewMockKnowledgeItemService(ctrl)
+	knowledgeItemsService.EXPECT().SetLatestMark("workspace:5", "alice", int64(7), int64(8)).Return(item, nil)
+
+	presenter := mock.NewMockSetMarkToKnowledgeItemPresenter(ctrl)
+	presenter.EXPECT().SetResult(item)
+
+	uc := usecases.NewSetMarkToKnowledgeItem(knowledgeItemsService, workspaceService, presenter)
+
+	err := uc.Handle(aliceContext(), models.SetMarkToKnowledgeItemCommand{ID: 7, Mark: 8, WorkspaceID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
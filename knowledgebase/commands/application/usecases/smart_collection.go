@@ -0,0 +1,36 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// collect function selects items of the owner matching the saved search and summarizes them
+// into the smart collection.
+func collect(
+	service services.SavedSearchService,
+	owner string,
+	search *domain.SavedSearch,
+) (*models.SmartCollection, []*domain.KnowledgeItem, error) {
+	items, err := service.FindItems(owner, search)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collection := &models.SmartCollection{
+		Search: search,
+		Count:  len(items),
+	}
+
+	if len(items) > 0 {
+		var total int64
+		for _, item := range items {
+			total += item.Score
+		}
+		collection.AverageScore = float64(total) / float64(len(items))
+	}
+
+	return collection, items, nil
+}
@@ -0,0 +1,102 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	defaultReviewSessionSize = 20
+	maxReviewSessionSize     = 100
+)
+
+// StartReviewSession type represents usecase that has sequence of actions
+// to pick items of the smart collection for the review.
+type StartReviewSession struct {
+	savedSearchService services.SavedSearchService
+	workspaceService   services.WorkspaceService
+	presenter          models.StartReviewSessionPresenter
+}
+
+// NewStartReviewSession function builds new instance of StartReviewSession usecase.
+func NewStartReviewSession(
+	savedSearchService services.SavedSearchService,
+	workspaceService services.WorkspaceService,
+	presenter models.StartReviewSessionPresenter,
+) *StartReviewSession {
+	return &StartReviewSession{
+		savedSearchService: savedSearchService,
+		workspaceService:   workspaceService,
+		presenter:          presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// Items which were never checked go first, then the ones with the lowest score, then the longest unchecked.
+// The session size defaults to 20 items and can't be more than 100. The saved search is the one of the acting user,
+// it picks items of the workspace then, ordered by the review progress of the user.
+func (uc *StartReviewSession) Handle(ctx context.Context, cmd *models.StartReviewSessionCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Limit < 0 {
+		return errors.New("limit cannot be negative")
+	}
+
+	limit := cmd.Limit
+	if limit == 0 {
+		limit = defaultReviewSessionSize
+	}
+	limit = min(limit, maxReviewSessionSize)
+
+	search, err := uc.savedSearchService.GetSavedSearch(user.Login, cmd.SavedSearchID)
+	if err != nil {
+		return err
+	}
+
+	collection, items, err := collect(uc.savedSearchService, owner, search)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return reviewsEarlier(items[i], items[j])
+	})
+
+	uc.presenter.SetResult(&models.ReviewSession{
+		Collection: collection,
+		Items:      items[:min(limit, len(items))],
+	})
+
+	return nil
+}
+
+// reviewsEarlier function reports whether item a is more urgent to review than item b.
+func reviewsEarlier(a, b *domain.KnowledgeItem) bool {
+	if (a.LastCheckAt == nil) != (b.LastCheckAt == nil) {
+		return a.LastCheckAt == nil
+	}
+
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+
+	if a.LastCheckAt != nil && !a.LastCheckAt.Equal(*b.LastCheckAt) {
+		return a.LastCheckAt.Before(*b.LastCheckAt)
+	}
+
+	return a.ID < b.ID
+}
@@ -0,0 +1,99 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStartReviewSession_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	week := time.Now().Add(-7 * 24 * time.Hour)
+	month := time.Now().Add(-30 * 24 * time.Hour)
+
+	search := &domain.SavedSearch{ID: 3, Owner: "alice", Filter: "tag:go"}
+	items := []*domain.KnowledgeItem{
+		{ID: 1, Score: 50, LastCheckAt: &week},
+		{ID: 2, Score: 20, LastCheckAt: &week},
+		{ID: 3, Score: 20, LastCheckAt: &month},
+		{ID: 4, Score: 90},
+	}
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().GetSavedSearch("alice", int64(3)).Return(search, nil)
+	service.EXPECT().FindItems("alice", search).Return(items, nil)
+
+	presenter := mock.NewMockStartReviewSessionPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(session *models.ReviewSession) {
+		var ids []int64
+		for _, item := range session.Items {
+			ids = append(ids, item.ID)
+		}
+
+		if len(ids) != 3 || ids[0] != 4 || ids[1] != 3 || ids[2] != 2 {
+			t.Errorf("expected items [4 3 2], got %v", ids)
+		}
+		if session.Collection.Count != 4 {
+			t.Errorf("expected collection of 4 items, got %d", session.Collection.Count)
+		}
+	})
+
+	uc := usecases.NewStartReviewSession(service, personalWorkspace(ctrl), presenter)
+
+	err := uc.Handle(aliceContext(), &models.StartReviewSessionCommand{SavedSearchID: 3, Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartReviewSession_Handle_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	search := &domain.SavedSearch{ID: 3, Owner: "alice", Filter: "checked:never"}
+
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionView).Return("workspace:5", nil)
+
+	// the saved search of the learner picks items of the workspace.
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().GetSavedSearch("alice", int64(3)).Return(search, nil)
+	service.EXPECT().FindItems("workspace:5", search).Return([]*domain.KnowledgeItem{{ID: 7, Owner: "workspace:5"}}, nil)
+
+	presenter := mock.NewMockStartReviewSessionPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(session *models.ReviewSession) {
+		if len(session.Items) != 1 || session.Items[0].ID != 7 {
+			t.Errorf("expected item 7 of the workspace, got %+v", session.Items)
+		}
+	})
+
+	uc := usecases.NewStartReviewSession(service, workspaceService, presenter)
+
+	err := uc.Handle(aliceContext(), &models.StartReviewSessionCommand{WorkspaceID: 5, SavedSearchID: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartReviewSession_Handle_NegativeLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := usecases.NewStartReviewSession(
+		mock.NewMockSavedSearchService(ctrl),
+		personalWorkspace(ctrl),
+		mock.NewMockStartReviewSessionPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.StartReviewSessionCommand{SavedSearchID: 3, Limit: -1})
+	if err == nil {
+		t.Error("expected error for negative limit")
+	}
+}
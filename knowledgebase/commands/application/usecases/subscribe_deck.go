@@ -0,0 +1,52 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// SubscribeDeck type represents usecase that has sequence of actions to subscribe to models.Deck.
+type SubscribeDeck struct {
+	deckService      services.DeckService
+	workspaceService services.WorkspaceService
+	presenter        models.SubscribeDeckPresenter
+}
+
+// NewSubscribeDeck function builds new instance of SubscribeDeck usecase.
+func NewSubscribeDeck(
+	deckService services.DeckService,
+	workspaceService services.WorkspaceService,
+	presenter models.SubscribeDeckPresenter,
+) *SubscribeDeck {
+	return &SubscribeDeck{
+		deckService:      deckService,
+		workspaceService: workspaceService,
+		presenter:        presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *SubscribeDeck) Handle(ctx context.Context, cmd *models.SubscribeDeckCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := uc.deckService.Subscribe(owner, cmd.DeckID)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(subscription)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubscribeDeck_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscription := &domain.DeckSubscription{ID: 6, DeckID: 4, Owner: "alice", CategoryID: 8}
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().Subscribe("alice", int64(4)).Return(subscription, nil)
+
+	presenter := mock.NewMockSubscribeDeckPresenter(ctrl)
+	presenter.EXPECT().SetResult(subscription)
+
+	uc := usecases.NewSubscribeDeck(deckService, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.SubscribeDeckCommand{DeckID: 4}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeDeck_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	deckService := mock.NewMockDeckService(ctrl)
+	deckService.EXPECT().Subscribe("alice", int64(4)).Return(nil, expectedError)
+
+	uc := usecases.NewSubscribeDeck(deckService, personalWorkspace(ctrl), mock.NewMockSubscribeDeckPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.SubscribeDeckCommand{DeckID: 4})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("got error %v, want %v", err, expectedError)
+	}
+}
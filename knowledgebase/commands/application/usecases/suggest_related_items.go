@@ -0,0 +1,70 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	defaultRelatedItemsLimit = 10
+	maxRelatedItemsLimit     = 50
+)
+
+// SuggestRelatedItems type represents usecase that has sequence of actions
+// to suggest models.KnowledgeItem which might be linked with the given one.
+type SuggestRelatedItems struct {
+	relatedItemsService services.RelatedItemsService
+	workspaceService    services.WorkspaceService
+	presenter           models.SuggestRelatedItemsPresenter
+}
+
+// NewSuggestRelatedItems function builds new instance of SuggestRelatedItems usecase.
+func NewSuggestRelatedItems(
+	relatedItemsService services.RelatedItemsService,
+	workspaceService services.WorkspaceService,
+	presenter models.SuggestRelatedItemsPresenter,
+) *SuggestRelatedItems {
+	return &SuggestRelatedItems{
+		relatedItemsService: relatedItemsService,
+		workspaceService:    workspaceService,
+		presenter:           presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+// The number of suggestions defaults to 10 and can't be more than 50.
+func (uc *SuggestRelatedItems) Handle(ctx context.Context, query *models.SuggestRelatedItemsQuery) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, query.WorkspaceID, domain.PermissionView)
+	if err != nil {
+		return err
+	}
+
+	if query.Limit < 0 {
+		return errors.New("limit cannot be negative")
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultRelatedItemsLimit
+	}
+	limit = min(limit, maxRelatedItemsLimit)
+
+	related, err := uc.relatedItemsService.SuggestRelated(owner, query.ItemID, limit)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(related)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSuggestRelatedItems_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := []*domain.RelatedItem{
+		{
+			Item:  &domain.KnowledgeItem{ID: 2},
+			Score: 0.4,
+			Explanations: []*domain.Explanation{
+				{Kind: domain.RelationTags, Values: []string{"go"}, Score: 0.4},
+			},
+		},
+	}
+
+	service := mock.NewMockRelatedItemsService(ctrl)
+	service.EXPECT().SuggestRelated("alice", int64(1), 10).Return(expected, nil)
+
+	presenter := mock.NewMockSuggestRelatedItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewSuggestRelatedItems(service, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.SuggestRelatedItemsQuery{ItemID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSuggestRelatedItems_Handle_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock.NewMockRelatedItemsService(ctrl)
+	service.EXPECT().SuggestRelated("alice", int64(1), 50).Return(nil, nil)
+
+	presenter := mock.NewMockSuggestRelatedItemsPresenter(ctrl)
+	presenter.EXPECT().SetResult(nil)
+
+	uc := usecases.NewSuggestRelatedItems(service, personalWorkspace(ctrl), presenter)
+
+	if err := uc.Handle(aliceContext(), &models.SuggestRelatedItemsQuery{ItemID: 1, Limit: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uc.Handle(aliceContext(), &models.SuggestRelatedItemsQuery{ItemID: 1, Limit: -1}); err == nil {
+		t.Error("expected error for negative limit")
+	}
+}
+
+func TestSuggestRelatedItems_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockRelatedItemsService(ctrl)
+	service.EXPECT().SuggestRelated("alice", int64(1), 5).Return(nil, expectedError)
+
+	uc := usecases.NewSuggestRelatedItems(service, personalWorkspace(ctrl), mock.NewMockSuggestRelatedItemsPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.SuggestRelatedItemsQuery{ItemID: 1, Limit: 5})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+}
@@ -0,0 +1,55 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// UpdateCategory type represents usecase that has sequence of actions
+// to replace metadata of the category.
+type UpdateCategory struct {
+	categoryService services.CategoryService
+	presenter       models.UpdateCategoryPresenter
+}
+
+// NewUpdateCategory function builds new instance of UpdateCategory usecase.
+func NewUpdateCategory(
+	categoryService services.CategoryService,
+	presenter models.UpdateCategoryPresenter,
+) *UpdateCategory {
+	return &UpdateCategory{
+		categoryService: categoryService,
+		presenter:       presenter,
+	}
+}
+
+// Handle function performs usecase actions.
+func (uc *UpdateCategory) Handle(ctx context.Context, cmd *models.UpdateCategoryCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	category, err := uc.categoryService.UpdateCategory(user.Login, cmd.Name, domain.CategoryMetadata{
+		Description: cmd.Description,
+		Color:       cmd.Color,
+		Icon:        cmd.Icon,
+		SortOrder:   cmd.SortOrder,
+		Scheduling: domain.CategoryScheduling{
+			TargetRetention:    cmd.TargetRetention,
+			DailyNewItemsLimit: cmd.DailyNewItemsLimit,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	auditCategories(ctx, category.ID)
+	uc.presenter.SetResult(category)
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateCategory_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	retention := 0.9
+	limit := 20
+	cmd := &models.UpdateCategoryCommand{
+		Name:               "Engineering",
+		Description:        "Everything about building software",
+		Color:              "#1E90FF",
+		Icon:               "gear",
+		SortOrder:          2,
+		TargetRetention:    &retention,
+		DailyNewItemsLimit: &limit,
+	}
+	expectedMetadata := domain.CategoryMetadata{
+		Description: cmd.Description,
+		Color:       cmd.Color,
+		Icon:        cmd.Icon,
+		SortOrder:   cmd.SortOrder,
+		Scheduling: domain.CategoryScheduling{
+			TargetRetention:    &retention,
+			DailyNewItemsLimit: &limit,
+		},
+	}
+	expected := &domain.Category{ID: 1, Name: "Engineering", CategoryMetadata: expectedMetadata}
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().UpdateCategory("alice", "Engineering", expectedMetadata).Return(expected, nil)
+
+	presenter := mock.NewMockUpdateCategoryPresenter(ctrl)
+	presenter.EXPECT().SetResult(expected)
+
+	uc := usecases.NewUpdateCategory(service, presenter)
+
+	if err := uc.Handle(aliceContext(), cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateCategory_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockCategoryService(ctrl)
+	service.EXPECT().UpdateCategory("alice", "Engineering", gomock.Any()).Return(nil, expectedError)
+
+	uc := usecases.NewUpdateCategory(service, mock.NewMockUpdateCategoryPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.UpdateCategoryCommand{Name: "Engineering", Color: "blue"})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 type UpdateKnowledgeItem struct {
 	categoryService      services.CategoryService
 	knowledgeItemService services.KnowledgeItemService
+	workspaceService     services.WorkspaceService
 	presenter            models.UpdateKnowledgeItemPresenter
 }
 
@@ -20,20 +21,40 @@ type UpdateKnowledgeItem struct {
 func NewUpdateKnowledgeItem(
 	categoryService services.CategoryService,
 	knowledgeItemService services.KnowledgeItemService,
+	workspaceService services.WorkspaceService,
 	presenter models.UpdateKnowledgeItemPresenter,
 ) *UpdateKnowledgeItem {
 	return &UpdateKnowledgeItem{
 		categoryService:      categoryService,
 		knowledgeItemService: knowledgeItemService,
+		workspaceService:     workspaceService,
 		presenter:            presenter,
 	}
 }
 
-// Handle function performs usecase actions.
-func (uc *UpdateKnowledgeItem) Handle(_ context.Context, cmd *models.UpdateKnowledgeItemCommand) error {
+// Handle function performs usecase actions. Items of the workspace are updated by the members allowed to edit it.
+// The item is looked up before its categories, so no category is created for an item which can't be updated.
+func (uc *UpdateKnowledgeItem) Handle(ctx context.Context, cmd *models.UpdateKnowledgeItemCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := uc.workspaceService.Authorize(user.Login, cmd.WorkspaceID, domain.PermissionEdit)
+	if err != nil {
+		return err
+	}
+
+	auditOwner(ctx, owner)
+	auditItems(ctx, cmd.ID)
+
+	if _, err = uc.knowledgeItemService.GetItem(owner, cmd.ID); err != nil {
+		return err
+	}
+
 	var categories []*domain.Category
 	for _, categoryName := range cmd.Categories {
-		cat, err := uc.categoryService.CreateOrGetCategory(categoryName)
+		cat, err := uc.categoryService.CreateOrGetCategory(owner, categoryName)
 		if err != nil {
 			return err
 		}
@@ -42,7 +63,7 @@ func (uc *UpdateKnowledgeItem) Handle(_ context.Context, cmd *models.UpdateKnowl
 	}
 
 	item, err := uc.knowledgeItemService.UpdateItem(
-		cmd.ID, cmd.Title, cmd.Anchor,
+		owner, cmd.ID, cmd.Title, cmd.Anchor,
 		cmd.Data, cmd.Tags, categories)
 	if err != nil {
 		return err
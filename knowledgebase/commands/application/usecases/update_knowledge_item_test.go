@@ -1,13 +1,13 @@
 package usecases_test
 
 import (
-	"context"
 	"errors"
 	"testing"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
 	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
 	"github.com/96solutions/neurography/knowledgebase/commands/mock"
 	"go.uber.org/mock/gomock"
 )
@@ -47,15 +47,12 @@ func TestUpdateKnowledgeItem_Do_Success(t *testing.T) {
 	}
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategories[0], nil
-	})
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[1]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategories[1], nil
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(expectedCategories[0], nil)
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[1]).Return(expectedCategories[1], nil)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
-	itemService.EXPECT().UpdateItem(
+	itemService.EXPECT().GetItem("alice", expectedItemID).Return(&domain.KnowledgeItem{ID: expectedItemID}, nil)
+	itemService.EXPECT().UpdateItem("alice",
 		expectedItemID, cmd.Title, cmd.Anchor,
 		cmd.Data, cmd.Tags, expectedCategories).
 		Return(expectedItem, nil)
@@ -95,9 +92,9 @@ func TestUpdateKnowledgeItem_Do_Success(t *testing.T) {
 		}
 	})
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
-	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, personalWorkspace(ctrl), presenter)
 
 	err := uc.Handle(ctx, cmd)
 	if err != nil {
@@ -121,17 +118,16 @@ func TestUpdateKnowledgeItem_Do_CategoryServiceError(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return nil, expectedError
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(nil, expectedError)
 
 	presenter := mock.NewMockUpdateKnowledgeItemPresenter(ctrl)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("alice", expectedItemID).Return(&domain.KnowledgeItem{ID: expectedItemID}, nil)
 
-	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, cmd)
 	if !errors.Is(err, expectedError) {
@@ -159,23 +155,64 @@ func TestUpdateKnowledgeItem_Do_KnowledgeItemServiceError(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	catService := mock.NewMockCategoryService(ctrl)
-	catService.EXPECT().CreateOrGetCategory(cmd.Categories[0]).DoAndReturn(func(_ string) (*domain.Category, error) {
-		return expectedCategory, nil
-	})
+	catService.EXPECT().CreateOrGetCategory("alice", cmd.Categories[0]).Return(expectedCategory, nil)
 
 	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("alice", expectedItemID).Return(&domain.KnowledgeItem{ID: expectedItemID}, nil)
 	itemService.EXPECT().
-		UpdateItem(expectedItemID, cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, []*domain.Category{expectedCategory}).
+		UpdateItem("alice", expectedItemID, cmd.Title, cmd.Anchor, cmd.Data, cmd.Tags, []*domain.Category{expectedCategory}).
 		Return(nil, expectedError)
 
 	presenter := mock.NewMockUpdateKnowledgeItemPresenter(ctrl)
 
-	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, presenter)
+	uc := usecases.NewUpdateKnowledgeItem(catService, itemService, personalWorkspace(ctrl), presenter)
 
-	ctx := context.Background()
+	ctx := aliceContext()
 
 	err := uc.Handle(ctx, cmd)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("expected error %s, got %s", expectedError, err)
 	}
 }
+
+func TestUpdateKnowledgeItem_Do_WorkspaceForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// reviewers of the workspace can't edit its items.
+	workspaceService := mock.NewMockWorkspaceService(ctrl)
+	workspaceService.EXPECT().Authorize("alice", int64(5), domain.PermissionEdit).Return("", services.ErrForbidden)
+
+	uc := usecases.NewUpdateKnowledgeItem(
+		mock.NewMockCategoryService(ctrl),
+		mock.NewMockKnowledgeItemService(ctrl),
+		workspaceService,
+		mock.NewMockUpdateKnowledgeItemPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.UpdateKnowledgeItemCommand{ID: 7, Categories: []string{"Go"}, WorkspaceID: 5})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
+
+func TestUpdateKnowledgeItem_Do_ItemOfOtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// no category is created for the item which can't be updated.
+	itemService := mock.NewMockKnowledgeItemService(ctrl)
+	itemService.EXPECT().GetItem("alice", int64(7)).Return(nil, services.ErrForbidden)
+
+	uc := usecases.NewUpdateKnowledgeItem(
+		mock.NewMockCategoryService(ctrl),
+		itemService,
+		personalWorkspace(ctrl),
+		mock.NewMockUpdateKnowledgeItemPresenter(ctrl),
+	)
+
+	err := uc.Handle(aliceContext(), &models.UpdateKnowledgeItemCommand{ID: 7, Categories: []string{"Go"}})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected error %s, got %v", services.ErrForbidden, err)
+	}
+}
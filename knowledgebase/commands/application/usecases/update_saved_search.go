@@ -0,0 +1,48 @@
+// Package usecases contains a set of sequences for interactions between services and users.
+package usecases
+
+import (
+	"context"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// UpdateSavedSearch type represents usecase that has sequence of actions to update existing models.SavedSearch.
+type UpdateSavedSearch struct {
+	savedSearchService services.SavedSearchService
+	presenter          models.UpdateSavedSearchPresenter
+}
+
+// NewUpdateSavedSearch function builds new instance of UpdateSavedSearch usecase.
+func NewUpdateSavedSearch(
+	savedSearchService services.SavedSearchService,
+	presenter models.UpdateSavedSearchPresenter,
+) *UpdateSavedSearch {
+	return &UpdateSavedSearch{
+		savedSearchService: savedSearchService,
+		presenter:          presenter,
+	}
+}
+
+// Handle function performs usecase actions, the updated search is presented as a smart collection.
+func (uc *UpdateSavedSearch) Handle(ctx context.Context, cmd *models.UpdateSavedSearchCommand) error {
+	user, err := actingUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	search, err := uc.savedSearchService.UpdateSavedSearch(user.Login, cmd.ID, cmd.Name, cmd.Filter)
+	if err != nil {
+		return err
+	}
+
+	collection, _, err := collect(uc.savedSearchService, search.Owner, search)
+	if err != nil {
+		return err
+	}
+
+	uc.presenter.SetResult(collection)
+
+	return nil
+}
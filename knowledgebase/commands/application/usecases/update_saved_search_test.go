@@ -0,0 +1,65 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	domain "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateSavedSearch_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	search := &domain.SavedSearch{ID: 1, Owner: "alice", Name: "Weak Go topics", Filter: "tag:go score<50"}
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().UpdateSavedSearch("alice", int64(1), "Weak Go topics", "tag:go score<50").Return(search, nil)
+	service.EXPECT().FindItems("alice", search).Return([]*domain.KnowledgeItem{{ID: 1, Score: 40}}, nil)
+
+	presenter := mock.NewMockUpdateSavedSearchPresenter(ctrl)
+	presenter.EXPECT().SetResult(gomock.Any()).Do(func(collection *models.SmartCollection) {
+		if collection.Search != search {
+			t.Errorf("expected search %v, got %v", search, collection.Search)
+		}
+		if collection.Count != 1 || collection.AverageScore != 40 {
+			t.Errorf("expected 1 item with average 40, got %d with %v", collection.Count, collection.AverageScore)
+		}
+	})
+
+	uc := usecases.NewUpdateSavedSearch(service, presenter)
+
+	err := uc.Handle(aliceContext(), &models.UpdateSavedSearchCommand{
+		ID:     1,
+		Name:   "Weak Go topics",
+		Filter: "tag:go score<50",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateSavedSearch_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	service := mock.NewMockSavedSearchService(ctrl)
+	service.EXPECT().UpdateSavedSearch("alice", int64(1), "Weak", "score<").Return(nil, expectedError)
+
+	uc := usecases.NewUpdateSavedSearch(service, mock.NewMockUpdateSavedSearchPresenter(ctrl))
+
+	err := uc.Handle(aliceContext(), &models.UpdateSavedSearchCommand{
+		ID:     1,
+		Name:   "Weak",
+		Filter: "score<",
+	})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
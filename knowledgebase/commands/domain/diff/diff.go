@@ -0,0 +1,65 @@
+// Package diff contains line differences of the texts shown to reviewers of the suggested edits.
+// Lines are matched by the longest common subsequence, the texts of knowledge items are short enough
+// for the quadratic table.
+package diff
+
+import (
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+// Lines function returns the lines of both texts in order, the lines missing in after are deleted
+// and the lines missing in before are inserted. Deleted lines go before the inserted ones they are replaced by.
+func Lines(before, after string) []*models.DiffLine {
+	a, b := split(before), split(after)
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]*models.DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, &models.DiffLine{Op: models.DiffEqual, Text: a[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, &models.DiffLine{Op: models.DiffDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, &models.DiffLine{Op: models.DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, &models.DiffLine{Op: models.DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, &models.DiffLine{Op: models.DiffInsert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// split function returns lines of the text, the empty text has no lines.
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
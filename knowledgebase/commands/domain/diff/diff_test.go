@@ -0,0 +1,37 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/diff"
+)
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after string
+		expected      string
+	}{
+		{name: "same text", before: "a\nb", after: "a\nb\n", expected: "=a =b"},
+		{name: "empty before", before: "", after: "a\nb", expected: "+a +b"},
+		{name: "empty after", before: "a", after: "", expected: "-a"},
+		{name: "replaced line", before: "a\nb\nc", after: "a\nB\nc", expected: "=a -b +B =c"},
+		{name: "inserted and deleted", before: "a\nb\nc", after: "b\nc\nd", expected: "-a =b =c +d"},
+	}
+
+	ops := map[string]string{"equal": "=", "insert": "+", "delete": "-"}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, line := range diff.Lines(tc.before, tc.after) {
+				actual = append(actual, ops[line.Op]+line.Text)
+			}
+
+			if strings.Join(actual, " ") != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, strings.Join(actual, " "))
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Package filter contains the query language for filtering knowledge items, like
+//
+//	tag:go category:"Distributed Systems" score<40 lastmark<=5 checked:>30d -tag:deprecated
+//
+// Terms separated by spaces have to match all, OR between terms matches any of them,
+// terms are grouped by parentheses and negated by the leading minus or NOT.
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+// Fields of the knowledge item which can be used in the filters.
+const (
+	FieldTag      = "tag"
+	FieldCategory = "category"
+	FieldTitle    = "title"
+	FieldAnchor   = "anchor"
+	FieldData     = "data"
+	FieldScore    = "score"
+	FieldLastMark = "lastmark"
+	FieldChecked  = "checked"
+	FieldCreated  = "created"
+	FieldUpdated  = "updated"
+)
+
+// Operator represents comparison of the field with the value.
+type Operator string
+
+// Comparison operators.
+const (
+	OpEq Operator = "="
+	OpLt Operator = "<"
+	OpLe Operator = "<="
+	OpGt Operator = ">"
+	OpGe Operator = ">="
+)
+
+// Expr represents node of the filter syntax tree.
+type Expr interface {
+	// Match function reports whether the item satisfies the expression.
+	Match(item *models.KnowledgeItem) bool
+	// String function renders the expression in the query language.
+	String() string
+}
+
+// All matches every item, it is the result of an empty query.
+type All struct{}
+
+// And matches items which satisfy all the expressions.
+type And struct {
+	Exprs []Expr
+}
+
+// Or matches items which satisfy any of the expressions.
+type Or struct {
+	Exprs []Expr
+}
+
+// Not matches items which don't satisfy the expression.
+type Not struct {
+	Expr Expr
+}
+
+// Text matches items containing the text in the title, anchor or data, ignoring case.
+type Text struct {
+	Value string
+}
+
+// Match represents the field matching the value ignoring case: tags and categories are compared whole,
+// title, anchor and data have to contain the value. Categories match their descendants as well,
+// so category:Engineering matches items of "Engineering/Backend".
+type Match struct {
+	Field string
+	Value string
+}
+
+// Compare represents comparison of the numeric field, score or lastmark, with the value.
+type Compare struct {
+	Field string
+	Op    Operator
+	Value int64
+}
+
+// Age represents comparison of the time field with the moment Age ago. The moment is resolved
+// by the parser, so the expression is stable while it is used. Items without the time never match.
+//
+//	checked:>30d  checked more than 30 days ago
+//	checked:<7d   checked within the last 7 days
+type Age struct {
+	Field string
+	Op    Operator
+	Age   string
+	// Since is the moment Age ago from the time of parsing.
+	Since time.Time
+}
+
+// Missing matches items which don't have the time field set, like checked:never.
+type Missing struct {
+	Field string
+}
+
+// Match function reports whether the item satisfies the expression.
+func (All) Match(*models.KnowledgeItem) bool {
+	return true
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *And) Match(item *models.KnowledgeItem) bool {
+	for _, expr := range e.Exprs {
+		if !expr.Match(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Or) Match(item *models.KnowledgeItem) bool {
+	for _, expr := range e.Exprs {
+		if expr.Match(item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Not) Match(item *models.KnowledgeItem) bool {
+	return !e.Expr.Match(item)
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Text) Match(item *models.KnowledgeItem) bool {
+	value := strings.ToLower(e.Value)
+	for _, text := range []string{item.Title, item.Anchor, item.Data} {
+		if strings.Contains(strings.ToLower(text), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Match) Match(item *models.KnowledgeItem) bool {
+	switch e.Field {
+	case FieldTag:
+		return slices.ContainsFunc(item.Tags, func(tag string) bool { return strings.EqualFold(tag, e.Value) })
+	case FieldCategory:
+		value := strings.ToLower(e.Value)
+		return slices.ContainsFunc(item.Categories, func(c *models.Category) bool {
+			name := strings.ToLower(c.Name)
+			return name == value || strings.HasPrefix(name, value+models.CategorySeparator)
+		})
+	case FieldTitle:
+		return strings.Contains(strings.ToLower(item.Title), strings.ToLower(e.Value))
+	case FieldAnchor:
+		return strings.Contains(strings.ToLower(item.Anchor), strings.ToLower(e.Value))
+	case FieldData:
+		return strings.Contains(strings.ToLower(item.Data), strings.ToLower(e.Value))
+	default:
+		return false
+	}
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Compare) Match(item *models.KnowledgeItem) bool {
+	var value int64
+	switch e.Field {
+	case FieldScore:
+		value = item.Score
+	case FieldLastMark:
+		value = item.LastMark
+	default:
+		return false
+	}
+
+	switch e.Op {
+	case OpEq:
+		return value == e.Value
+	case OpLt:
+		return value < e.Value
+	case OpLe:
+		return value <= e.Value
+	case OpGt:
+		return value > e.Value
+	case OpGe:
+		return value >= e.Value
+	default:
+		return false
+	}
+}
+
+// Match function reports whether the item satisfies the expression.
+// Older time means greater age, so the operator is applied to the moments reversed.
+func (e *Age) Match(item *models.KnowledgeItem) bool {
+	t := timeField(item, e.Field)
+	if t == nil {
+		return false
+	}
+
+	switch e.Op {
+	case OpLt:
+		return t.After(e.Since)
+	case OpLe, OpEq:
+		return !t.Before(e.Since)
+	case OpGt:
+		return t.Before(e.Since)
+	case OpGe:
+		return !t.After(e.Since)
+	default:
+		return false
+	}
+}
+
+// Match function reports whether the item satisfies the expression.
+func (e *Missing) Match(item *models.KnowledgeItem) bool {
+	return timeField(item, e.Field) == nil
+}
+
+func timeField(item *models.KnowledgeItem, field string) *time.Time {
+	switch field {
+	case FieldChecked:
+		return item.LastCheckAt
+	case FieldCreated:
+		return item.CreatedAt
+	case FieldUpdated:
+		return item.UpdatedAt
+	default:
+		return nil
+	}
+}
+
+// String function renders the expression in the query language.
+func (All) String() string {
+	return ""
+}
+
+// String function renders the expression in the query language.
+func (e *And) String() string {
+	return joinExprs(e.Exprs, " ")
+}
+
+// String function renders the expression in the query language.
+func (e *Or) String() string {
+	return joinExprs(e.Exprs, " OR ")
+}
+
+// String function renders the expression in the query language.
+func (e *Not) String() string {
+	return "-" + group(e.Expr)
+}
+
+// String function renders the expression in the query language.
+func (e *Text) String() string {
+	return quote(e.Value)
+}
+
+// String function renders the expression in the query language.
+func (e *Match) String() string {
+	return e.Field + ":" + quote(e.Value)
+}
+
+// String function renders the expression in the query language.
+func (e *Compare) String() string {
+	return e.Field + string(e.Op) + strconv.FormatInt(e.Value, 10)
+}
+
+// String function renders the expression in the query language.
+func (e *Age) String() string {
+	if e.Op == OpEq {
+		return e.Field + ":" + e.Age
+	}
+
+	return e.Field + ":" + string(e.Op) + e.Age
+}
+
+// String function renders the expression in the query language.
+func (e *Missing) String() string {
+	return e.Field + ":" + neverValue
+}
+
+func joinExprs(exprs []Expr, separator string) string {
+	parts := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		parts = append(parts, group(expr))
+	}
+
+	return strings.Join(parts, separator)
+}
+
+// group function wraps compound expressions in parentheses.
+func group(expr Expr) string {
+	switch expr.(type) {
+	case *And, *Or:
+		return "(" + expr.String() + ")"
+	default:
+		return expr.String()
+	}
+}
+
+// quote function quotes the value unless it is a plain word.
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\"()") && !isKeyword(value) &&
+		!strings.HasPrefix(value, "-") && !strings.ContainsAny(value, ":<>=") {
+		return value
+	}
+
+	return fmt.Sprintf("%q", value)
+}
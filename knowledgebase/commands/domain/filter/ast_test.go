@@ -0,0 +1,128 @@
+package filter_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/filter"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func daysAgo(days int) *time.Time {
+	t := now.Add(-time.Duration(days) * 24 * time.Hour)
+	return &t
+}
+
+func testItems() []*models.KnowledgeItem {
+	golang := &models.Category{ID: 1, Name: "Golang"}
+	distributed := &models.Category{ID: 2, Name: "Distributed Systems"}
+	clocks := &models.Category{ID: 3, Name: "Distributed Systems/Clocks", ParentID: 2}
+
+	return []*models.KnowledgeItem{
+		{
+			ID:          1,
+			Title:       "What is a goroutine?",
+			Anchor:      "goroutine",
+			Data:        "Lightweight thread managed by the Go runtime.",
+			Tags:        []string{"go", "concurrency"},
+			Categories:  []*models.Category{golang},
+			Score:       30,
+			LastMark:    4,
+			LastCheckAt: daysAgo(40),
+			CreatedAt:   daysAgo(100),
+		},
+		{
+			ID:          2,
+			Title:       "Raft leader election",
+			Anchor:      "raft",
+			Data:        "Leader is elected by the majority of votes.",
+			Tags:        []string{"Go", "consensus"},
+			Categories:  []*models.Category{golang, distributed},
+			Score:       20,
+			LastMark:    2,
+			LastCheckAt: daysAgo(45),
+			CreatedAt:   daysAgo(60),
+		},
+		{
+			ID:          3,
+			Title:       "Paxos",
+			Anchor:      "paxos",
+			Data:        "Consensus protocol.",
+			Tags:        []string{"go", "deprecated"},
+			Categories:  []*models.Category{distributed},
+			Score:       10,
+			LastMark:    1,
+			LastCheckAt: daysAgo(90),
+			CreatedAt:   daysAgo(90),
+		},
+		{
+			ID:         4,
+			Title:      "Vector clock",
+			Anchor:     "vector clock",
+			Data:       "Detects causality violations.",
+			Categories: []*models.Category{clocks},
+			Score:      0,
+			CreatedAt:  daysAgo(3),
+		},
+	}
+}
+
+func matchIDs(t *testing.T, query string) []int64 {
+	t.Helper()
+
+	expr, err := filter.Parse(query, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	for _, item := range testItems() {
+		if expr.Match(item) {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	return ids
+}
+
+func TestExpr_Match(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected []int64
+	}{
+		{"", []int64{1, 2, 3, 4}},
+		{"tag:go", []int64{1, 2, 3}},
+		{"tag:GO -tag:deprecated", []int64{1, 2}},
+		{`category:"distributed systems"`, []int64{2, 3, 4}},
+		{`category:"distributed systems/clocks"`, []int64{4}},
+		{"category:distributed", nil},
+		{"score<25", []int64{2, 3, 4}},
+		{"score>=20", []int64{1, 2}},
+		{"score=0", []int64{4}},
+		{"lastmark<=2", []int64{2, 3, 4}},
+		{"checked:>30d", []int64{1, 2, 3}},
+		{"checked:<42d", []int64{1}},
+		{"checked:>=45d", []int64{2, 3}},
+		{"checked:never", []int64{4}},
+		{"created:<7d", []int64{4}},
+		{"updated:<7d", nil},
+		{"leader", []int64{2}},
+		{"title:raft", []int64{2}},
+		{"anchor:clock", []int64{4}},
+		{"data:consensus", []int64{3}},
+		{"tag:consensus OR anchor:paxos", []int64{2, 3}},
+		{"-(tag:go OR checked:never)", nil},
+		{
+			`tag:go category:"Distributed Systems" score<40 lastmark<=5 checked:>30d -tag:deprecated`,
+			[]int64{2},
+		},
+	}
+
+	for _, c := range cases {
+		ids := matchIDs(t, c.query)
+		if !slices.Equal(ids, c.expected) {
+			t.Errorf("expected %q to match %v, got %v", c.query, c.expected, ids)
+		}
+	}
+}
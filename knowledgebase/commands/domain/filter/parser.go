@@ -0,0 +1,362 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	keywordOr  = "OR"
+	keywordNot = "NOT"
+	neverValue = "never"
+)
+
+// ageUnits are the units of ages like 30d.
+var ageUnits = map[byte]time.Duration{
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'm': 30 * 24 * time.Hour,
+	'y': 365 * 24 * time.Hour,
+}
+
+// fieldKinds tells how the value of every field is parsed.
+var fieldKinds = map[string]fieldKind{
+	FieldTag:      textKind,
+	FieldCategory: textKind,
+	FieldTitle:    textKind,
+	FieldAnchor:   textKind,
+	FieldData:     textKind,
+	FieldScore:    numberKind,
+	FieldLastMark: numberKind,
+	FieldChecked:  timeKind,
+	FieldCreated:  timeKind,
+	FieldUpdated:  timeKind,
+}
+
+type fieldKind int
+
+const (
+	textKind fieldKind = iota
+	numberKind
+	timeKind
+)
+
+// ParseError represents syntax error of the query. Position is the 1-based character position of the error.
+type ParseError struct {
+	Query    string
+	Position int
+	Message  string
+}
+
+// Error function returns the message with the position.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Pointer function renders the query with a caret under the offending position.
+func (e *ParseError) Pointer() string {
+	return e.Query + "\n" + strings.Repeat(" ", e.Position-1) + "^"
+}
+
+// Parse function parses the query into the syntax tree, empty query matches all items.
+// Ages like 30d are resolved into moments relative to now.
+func Parse(query string, now time.Time) (Expr, error) {
+	p := &parser{input: []rune(query), query: query, now: now}
+
+	p.skipSpaces()
+	if p.eof() {
+		return All{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", string(p.input[p.pos]))
+	}
+
+	return expr, nil
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	or    = and { "OR" and }
+//	and   = unary { unary }
+//	unary = ( "-" | "NOT" ) unary | "(" or ")" | term
+//	term  = field ( ":" [ op ] | op ) value | value
+type parser struct {
+	input []rune
+	query string
+	pos   int
+	now   time.Time
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{first}
+	for p.keyword(keywordOr) {
+		start := p.pos
+		p.pos += len(keywordOr)
+		p.skipSpaces()
+
+		if p.eof() || p.input[p.pos] == ')' || p.keyword(keywordOr) {
+			return nil, p.errorf(start, "missing term after OR")
+		}
+
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+
+	return &Or{Exprs: exprs}, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	var exprs []Expr
+	for !p.eof() && p.input[p.pos] != ')' && !p.keyword(keywordOr) {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+		p.skipSpaces()
+	}
+
+	switch len(exprs) {
+	case 0:
+		return nil, p.errorf(p.pos, "missing term")
+	case 1:
+		return exprs[0], nil
+	default:
+		return &And{Exprs: exprs}, nil
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	start := p.pos
+
+	if p.input[p.pos] == '-' || p.keyword(keywordNot) {
+		if p.input[p.pos] == '-' {
+			p.pos++
+		} else {
+			p.pos += len(keywordNot)
+			p.skipSpaces()
+		}
+
+		if p.eof() || unicode.IsSpace(p.input[p.pos]) || p.input[p.pos] == ')' {
+			return nil, p.errorf(start, "missing term after negation")
+		}
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Not{Expr: expr}, nil
+	}
+
+	if p.input[p.pos] == '(' {
+		p.pos++
+		p.skipSpaces()
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.eof() || p.input[p.pos] != ')' {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		p.pos++
+
+		return expr, nil
+	}
+
+	return p.parseTerm()
+}
+
+func (p *parser) parseTerm() (Expr, error) {
+	start := p.pos
+
+	name := p.identifier()
+	if name == "" || p.eof() || !strings.ContainsRune(":<>=", p.input[p.pos]) {
+		p.pos = start
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Text{Value: value}, nil
+	}
+
+	field := strings.ToLower(name)
+	kind, ok := fieldKinds[field]
+	if !ok {
+		return nil, p.errorf(start, "unknown field %q", name)
+	}
+
+	if p.input[p.pos] == ':' {
+		p.pos++
+	}
+	opStart := p.pos
+	op := p.operator()
+
+	valueStart := p.pos
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, p.errorf(valueStart, "missing value of %s", field)
+	}
+
+	switch kind {
+	case numberKind:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, p.errorf(valueStart, "%s has to be a number", field)
+		}
+
+		return &Compare{Field: field, Op: op, Value: number}, nil
+	case timeKind:
+		if value == neverValue {
+			if op != OpEq {
+				return nil, p.errorf(opStart, "%s:never cannot be compared", field)
+			}
+
+			return &Missing{Field: field}, nil
+		}
+
+		age, err := parseAge(value)
+		if err != nil {
+			return nil, p.errorf(valueStart, "%s has to be an age like 30d or never", field)
+		}
+
+		return &Age{Field: field, Op: op, Age: value, Since: p.now.Add(-age)}, nil
+	default:
+		if op != OpEq {
+			return nil, p.errorf(opStart, "%s cannot be compared", field)
+		}
+
+		return &Match{Field: field, Value: value}, nil
+	}
+}
+
+// identifier function reads the field name candidate.
+func (p *parser) identifier() string {
+	start := p.pos
+	for !p.eof() && (unicode.IsLetter(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+// operator function reads comparison operator, no operator means equality.
+func (p *parser) operator() Operator {
+	for _, op := range []Operator{OpLe, OpGe, OpLt, OpGt, OpEq} {
+		if strings.HasPrefix(string(p.input[p.pos:]), string(op)) {
+			p.pos += len(op)
+			return op
+		}
+	}
+
+	return OpEq
+}
+
+// value function reads quoted string or a word up to the space or closing parenthesis.
+func (p *parser) value() (string, error) {
+	if p.eof() || p.input[p.pos] != '"' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.input[p.pos]) && p.input[p.pos] != ')' && p.input[p.pos] != '(' {
+			p.pos++
+		}
+
+		return string(p.input[start:p.pos]), nil
+	}
+
+	start := p.pos
+	for p.pos++; !p.eof(); p.pos++ {
+		switch p.input[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+
+			value, err := strconv.Unquote(string(p.input[start:p.pos]))
+			if err != nil {
+				return "", p.errorf(start, "invalid quoted string")
+			}
+
+			return value, nil
+		}
+	}
+
+	return "", p.errorf(start, "unclosed quote")
+}
+
+// keyword function reports whether the keyword stands at the current position as a separate word.
+func (p *parser) keyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end > len(p.input) || string(p.input[p.pos:end]) != keyword {
+		return false
+	}
+
+	return end == len(p.input) || unicode.IsSpace(p.input[end]) || p.input[end] == '('
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) *ParseError {
+	return &ParseError{Query: p.query, Position: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseAge function parses the age like 30d.
+func parseAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+
+	unit, ok := ageUnits[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+
+	count, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+
+	return time.Duration(count) * unit, nil
+}
+
+func isKeyword(value string) bool {
+	return value == keywordOr || value == keywordNot
+}
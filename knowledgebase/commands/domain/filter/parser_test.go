@@ -0,0 +1,147 @@
+package filter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/filter"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"goroutine", "goroutine"},
+		{`"lightweight thread"`, `"lightweight thread"`},
+		{"tag:go", "tag:go"},
+		{"TAG:Go", "tag:Go"},
+		{`category:"Distributed Systems"`, `category:"Distributed Systems"`},
+		{"score<40", "score<40"},
+		{"score:<=40", "score<=40"},
+		{"lastmark=5", "lastmark=5"},
+		{"checked:>30d", "checked:>30d"},
+		{"checked:2w", "checked:2w"},
+		{"checked:never", "checked:never"},
+		{"-tag:deprecated", "-tag:deprecated"},
+		{"NOT tag:deprecated", "-tag:deprecated"},
+		{"tag:go OR tag:rust", "tag:go OR tag:rust"},
+		{"tag:go tag:rust OR tag:c", "(tag:go tag:rust) OR tag:c"},
+		{"tag:go (tag:rust OR tag:c)", "tag:go (tag:rust OR tag:c)"},
+		{"-(tag:go OR tag:rust)", "-(tag:go OR tag:rust)"},
+		{`title:"say \"hi\""`, `title:"say \"hi\""`},
+		{`"OR"`, `"OR"`},
+		{
+			`tag:go category:"Distributed Systems" score<40 lastmark<=5 checked:>30d -tag:deprecated`,
+			`tag:go category:"Distributed Systems" score<40 lastmark<=5 checked:>30d -tag:deprecated`,
+		},
+	}
+
+	for _, c := range cases {
+		expr, err := filter.Parse(c.query, now)
+		if err != nil {
+			t.Errorf("expected %q to be parsed, got %v", c.query, err)
+			continue
+		}
+
+		if expr.String() != c.expected {
+			t.Errorf("expected %q to be parsed as %q, got %q", c.query, c.expected, expr.String())
+			continue
+		}
+
+		// rendered expression has to be parsed back into the same expression.
+		again, err := filter.Parse(expr.String(), now)
+		if err != nil {
+			t.Errorf("expected %q to be parsed, got %v", expr.String(), err)
+			continue
+		}
+		if again.String() != expr.String() {
+			t.Errorf("expected %q after round-trip, got %q", expr.String(), again.String())
+		}
+	}
+}
+
+func TestParse_Ages(t *testing.T) {
+	cases := map[string]time.Duration{
+		"checked:>12h": 12 * time.Hour,
+		"checked:>30d": 30 * 24 * time.Hour,
+		"created:<2w":  14 * 24 * time.Hour,
+		"updated:>=1m": 30 * 24 * time.Hour,
+		"updated:1y":   365 * 24 * time.Hour,
+	}
+
+	for query, age := range cases {
+		expr, err := filter.Parse(query, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, ok := expr.(*filter.Age)
+		if !ok {
+			t.Errorf("expected %q to be parsed as age, got %T", query, expr)
+			continue
+		}
+
+		if !a.Since.Equal(now.Add(-age)) {
+			t.Errorf("expected %q to be resolved to %v, got %v", query, now.Add(-age), a.Since)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		query    string
+		position int
+		message  string
+	}{
+		{"tag:go color:red", 8, `unknown field "color"`},
+		{"score<high", 7, "score has to be a number"},
+		{"checked:>30x", 10, "checked has to be an age like 30d or never"},
+		{"checked:>never", 9, "checked:never cannot be compared"},
+		{"tag:<go", 5, "tag cannot be compared"},
+		{"tag:", 5, "missing value of tag"},
+		{`title:"unclosed`, 7, "unclosed quote"},
+		{"(tag:go OR tag:c", 1, "unclosed parenthesis"},
+		{"tag:go)", 7, `unexpected ")"`},
+		{"tag:go OR", 8, "missing term after OR"},
+		{"- tag:go", 1, "missing term after negation"},
+		{"()", 2, "missing term"},
+	}
+
+	for _, c := range cases {
+		_, err := filter.Parse(c.query, now)
+
+		var parseErr *filter.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("expected parse error for %q, got %v", c.query, err)
+			continue
+		}
+
+		if parseErr.Position != c.position || parseErr.Message != c.message {
+			t.Errorf("expected %q at %d for %q, got %q at %d",
+				c.message, c.position, c.query, parseErr.Message, parseErr.Position)
+		}
+	}
+}
+
+func TestParseError_Pointer(t *testing.T) {
+	_, err := filter.Parse("tag:go color:red", now)
+
+	var parseErr *filter.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+
+	expected := "tag:go color:red\n       ^"
+	if parseErr.Pointer() != expected {
+		t.Errorf("expected %q, got %q", expected, parseErr.Pointer())
+	}
+	if parseErr.Error() != `unknown field "color" at position 8` {
+		t.Errorf("expected error with position, got %q", parseErr.Error())
+	}
+}
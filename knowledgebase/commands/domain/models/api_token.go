@@ -0,0 +1,42 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Scopes of the API tokens, ScopeAdmin grants all of them.
+const (
+	ScopeItemsRead   = "items:read"
+	ScopeItemsWrite  = "items:write"
+	ScopeReviewWrite = "review:write"
+	ScopeAdmin       = "admin"
+)
+
+// Scopes lists all the known scopes of the API tokens.
+var Scopes = []string{ScopeItemsRead, ScopeItemsWrite, ScopeReviewWrite, ScopeAdmin}
+
+// APIToken represents personal access token used by scripts and integrations to act on behalf of the owner.
+// Only the SHA-256 hash of the secret is stored, the secret itself is shown once when the token is created.
+// Token without ExpiresAt never expires.
+type APIToken struct {
+	ID        int64      `json:"id"`
+	Owner     string     `json:"owner"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"-"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// HasScope function reports whether the token grants the scope.
+func (t *APIToken) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope) || slices.Contains(t.Scopes, ScopeAdmin)
+}
+
+// Expired function reports whether the token is expired at the moment.
+func (t *APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
@@ -0,0 +1,60 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Assignment represents the set of knowledge items given by the instructor to the group of learners,
+// like the items new engineers learn during onboarding. Items are picked one by one with ItemIDs
+// or with whole categories and their subcategories with CategoryIDs. Owner is the owner of the items,
+// assignments are given in workspaces, so Owner is WorkspaceOwner and the learners are its members.
+type Assignment struct {
+	ID          int64      `json:"id"`
+	Owner       string     `json:"owner"`
+	Instructor  string     `json:"instructor"`
+	Title       string     `json:"title"`
+	ItemIDs     []int64    `json:"item_ids,omitempty"`
+	CategoryIDs []int64    `json:"category_ids,omitempty"`
+	Learners    []string   `json:"learners"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Assigned function reports whether the assignment is given to the learner.
+func (a *Assignment) Assigned(learner string) bool {
+	return slices.Contains(a.Learners, learner)
+}
+
+// AssignmentReport represents progress of the learners of the assignment, aggregated per learner and per item.
+// Items are ordered by the number of struggling learners, so the hardest ones go first.
+type AssignmentReport struct {
+	Assignment *Assignment             `json:"assignment"`
+	Learners   []*LearnerReport        `json:"learners"`
+	Items      []*AssignmentItemReport `json:"items"`
+}
+
+// LearnerReport represents progress of one learner in the assignment. Progress has an entry for every item
+// of the assignment, items never reviewed by the learner have zero progress.
+type LearnerReport struct {
+	Learner      string             `json:"learner"`
+	Reviewed     int                `json:"reviewed"`
+	Reviews      int64              `json:"reviews"`
+	AverageScore float64            `json:"average_score"`
+	Progress     []*LearnerProgress `json:"progress"`
+}
+
+// AssignmentItemReport represents progress of the whole class on one item of the assignment.
+// Struggling counts the learners whose last mark is below the passing one, the class struggles
+// with the item when most of the learners do.
+type AssignmentItemReport struct {
+	ItemID       int64   `json:"item_id"`
+	Title        string  `json:"title"`
+	Reviewed     int     `json:"reviewed"`
+	Reviews      int64   `json:"reviews"`
+	AverageScore float64 `json:"average_score"`
+	Struggling   int     `json:"struggling"`
+	Struggles    bool    `json:"struggles"`
+}
@@ -0,0 +1,61 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Outcomes of the audited commands.
+const (
+	AuditSucceeded = "succeeded"
+	AuditFailed    = "failed"
+)
+
+// AuditEntry represents one execution of the command kept in the audit log. Actor is the login of the user
+// who executed the command, Owner is the knowledge base the command acted on, either the login
+// or WorkspaceOwner of the models.Workspace. Payload is the command as it was given, Error is set
+// for the failed commands. ItemIDs and CategoryIDs are the items and categories affected by the command.
+// Entries are never changed once written.
+type AuditEntry struct {
+	ID          int64           `json:"id"`
+	Actor       string          `json:"actor"`
+	Owner       string          `json:"owner"`
+	Command     string          `json:"command"`
+	Payload     json.RawMessage `json:"payload"`
+	Outcome     string          `json:"outcome"`
+	Error       string          `json:"error,omitempty"`
+	ItemIDs     []int64         `json:"item_ids,omitempty"`
+	CategoryIDs []int64         `json:"category_ids,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// AuditFilter represents conditions of the audit log query, zero values match any entry.
+// The time range includes From and excludes To.
+type AuditFilter struct {
+	Owner  string     `json:"owner,omitempty"`
+	Actor  string     `json:"actor,omitempty"`
+	ItemID int64      `json:"item_id,omitempty"`
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+}
+
+// Matches function reports whether the entry meets the conditions of the filter.
+func (f *AuditFilter) Matches(entry *AuditEntry) bool {
+	switch {
+	case f.Owner != "" && entry.Owner != f.Owner:
+		return false
+	case f.Actor != "" && entry.Actor != f.Actor:
+		return false
+	case f.ItemID != 0 && !slices.Contains(entry.ItemIDs, f.ItemID):
+		return false
+	case f.From != nil && (entry.CreatedAt == nil || entry.CreatedAt.Before(*f.From)):
+		return false
+	case f.To != nil && (entry.CreatedAt == nil || !entry.CreatedAt.Before(*f.To)):
+		return false
+	}
+
+	return true
+}
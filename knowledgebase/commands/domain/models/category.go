@@ -1,9 +1,48 @@
 // Package models contains types that represent entities of business logic.
 package models
 
+import "strings"
+
+// CategorySeparator separates names of the ancestors in the category name.
+const CategorySeparator = "/"
+
 // Category type represents category data
 // which is used to structure knowledge items.
+// Categories form a tree: Name is the whole path from the root, like "Engineering/Backend/Databases",
+// and ParentID refers to the category one level up, root categories have no parent.
+// Every user and workspace has own tree of categories, Owner is the login of the models.User
+// or WorkspaceOwner of the models.Workspace.
 type Category struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID       int64  `json:"id"`
+	Owner    string `json:"owner"`
+	Name     string `json:"name"`
+	ParentID int64  `json:"parent_id,omitempty"`
+	CategoryMetadata
+}
+
+// CategoryMetadata type represents optional details of the category used to display it
+// and to tune the review of its items.
+type CategoryMetadata struct {
+	Description string `json:"description,omitempty"`
+	// Color is a hex RGB color like "#1e90ff".
+	Color string `json:"color,omitempty"`
+	// Icon is a short name or an emoji shown next to the category name.
+	Icon string `json:"icon,omitempty"`
+	// SortOrder places the category among its siblings, lower values go first.
+	SortOrder  int                `json:"sort_order,omitempty"`
+	Scheduling CategoryScheduling `json:"scheduling"`
+}
+
+// CategoryScheduling type represents overrides of the scheduling settings for the items of the category,
+// nil values mean the global settings are used.
+type CategoryScheduling struct {
+	// TargetRetention is the desired probability to recall an item when it is reviewed, between 0 and 1.
+	TargetRetention *float64 `json:"target_retention,omitempty"`
+	// DailyNewItemsLimit is the maximum number of new items introduced per day.
+	DailyNewItemsLimit *int `json:"daily_new_items_limit,omitempty"`
+}
+
+// Within function reports whether the category is the named one or its descendant.
+func (c *Category) Within(name string) bool {
+	return c.Name == name || strings.HasPrefix(c.Name, name+CategorySeparator)
 }
@@ -0,0 +1,76 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Deck represents the category published by its owner as a read-only deck other users and workspaces
+// subscribe to. Items of the category and its subcategories form the deck, Owner is the owner of the category
+// and PublishedBy is the login of the user who published it.
+type Deck struct {
+	ID          int64  `json:"id"`
+	Owner       string `json:"owner"`
+	CategoryID  int64  `json:"category_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	PublishedBy string `json:"published_by"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// DeckSubscription represents the copy of the deck kept by the subscriber. Items of the deck are copied
+// into the category of the subscriber, so the subscriber edits and reviews them as own ones.
+// Owner is the owner of the copies, like the owner of other items.
+type DeckSubscription struct {
+	ID         int64           `json:"id"`
+	DeckID     int64           `json:"deck_id"`
+	Owner      string          `json:"owner"`
+	CategoryID int64           `json:"category_id"`
+	Items      []*DeckItemCopy `json:"items"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Copy function returns the copy of the item of the deck with the source ID or nil if it wasn't copied.
+func (s *DeckSubscription) Copy(sourceID int64) *DeckItemCopy {
+	i := slices.IndexFunc(s.Items, func(c *DeckItemCopy) bool { return c.SourceID == sourceID })
+	if i < 0 {
+		return nil
+	}
+
+	return s.Items[i]
+}
+
+// DeckItemCopy represents the item of the deck copied to the subscriber. Title, Anchor, Data and Tags
+// are the content of the item of the deck at the last synchronization, they tell upstream changes apart
+// from local edits of the copy. ItemID is zero when the subscriber has declined the item added to the deck.
+type DeckItemCopy struct {
+	SourceID int64    `json:"source_id"`
+	ItemID   int64    `json:"item_id"`
+	Title    string   `json:"title"`
+	Anchor   string   `json:"anchor"`
+	Data     string   `json:"data"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Matches function reports whether the item has the content of the copy.
+func (c *DeckItemCopy) Matches(item *KnowledgeItem) bool {
+	return item.Title == c.Title && item.Anchor == c.Anchor && item.Data == c.Data && slices.Equal(item.Tags, c.Tags)
+}
+
+// DeckUpdate represents the change of the item of the deck not synchronized to the subscriber yet.
+// Title, Anchor, Data and Tags are the upstream content, ItemID is zero for the item added to the deck.
+// LocalEdits tells that the subscriber has changed the copy, accepting the update replaces those changes.
+// Diff goes from the data of the copy to the upstream one.
+type DeckUpdate struct {
+	SourceID   int64       `json:"source_id"`
+	ItemID     int64       `json:"item_id,omitempty"`
+	Title      string      `json:"title"`
+	Anchor     string      `json:"anchor"`
+	Data       string      `json:"data"`
+	Tags       []string    `json:"tags,omitempty"`
+	LocalEdits bool        `json:"local_edits"`
+	Diff       []*DiffLine `json:"diff,omitempty"`
+}
@@ -0,0 +1,23 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+// Similarity represents closeness of two knowledge items per field, every value is between 0 and 1.
+type Similarity struct {
+	Title  float64 `json:"title"`
+	Anchor float64 `json:"anchor"`
+	Data   float64 `json:"data"`
+	Score  float64 `json:"score"`
+}
+
+// Duplicate represents existing knowledge item which is similar to another one.
+type Duplicate struct {
+	Item       *KnowledgeItem `json:"item"`
+	Similarity *Similarity    `json:"similarity"`
+}
+
+// DuplicatePair represents two existing knowledge items which likely contain the same fact.
+type DuplicatePair struct {
+	First      *KnowledgeItem `json:"first"`
+	Second     *KnowledgeItem `json:"second"`
+	Similarity *Similarity    `json:"similarity"`
+}
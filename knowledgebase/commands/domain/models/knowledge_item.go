@@ -4,8 +4,13 @@ package models
 import "time"
 
 // KnowledgeItem represents one particular piece of knowledge.
+// The item is visible to its owner only, Owner is the login of the models.User
+// or WorkspaceOwner of the models.Workspace curating it.
+// Score, LastMark, LastCheckAt and Reviews are the review state of the user the item is loaded for,
+// they are taken from models.LearnerProgress and never stored with the item.
 type KnowledgeItem struct {
 	ID     int64  `json:"id"`
+	Owner  string `json:"owner"`
 	Title  string `json:"title"`
 	Anchor string `json:"anchor"`
 	Data   string `json:"description"`
@@ -18,6 +23,7 @@ type KnowledgeItem struct {
 
 	LastMark    int64      `json:"last_mark"`
 	LastCheckAt *time.Time `json:"last_check_at"`
+	Reviews     int64      `json:"reviews"`
 
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
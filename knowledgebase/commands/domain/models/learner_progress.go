@@ -0,0 +1,18 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// LearnerProgress represents review state of the knowledge item for one learner.
+// Content of the item is shared, while every learner studies it at own pace, Learner is the login of the models.User.
+// Reviews counts the marks the learner has given to the item.
+type LearnerProgress struct {
+	ItemID  int64  `json:"item_id"`
+	Learner string `json:"learner"`
+
+	Score int64 `json:"score"`
+
+	LastMark    int64      `json:"last_mark"`
+	LastCheckAt *time.Time `json:"last_check_at"`
+	Reviews     int64      `json:"reviews"`
+}
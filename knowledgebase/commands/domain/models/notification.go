@@ -0,0 +1,22 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// Kinds of the notifications.
+const (
+	NotificationEditApproved = "edit_approved"
+	NotificationEditRejected = "edit_rejected"
+)
+
+// Notification represents the message to the user about something done by other users,
+// like the review of the edit the user suggested. SubjectID refers to the entity of the Kind.
+type Notification struct {
+	ID        int64  `json:"id"`
+	Recipient string `json:"recipient"`
+	Kind      string `json:"kind"`
+	SubjectID int64  `json:"subject_id"`
+	Message   string `json:"message"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
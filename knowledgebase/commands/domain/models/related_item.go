@@ -0,0 +1,25 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+// Kinds of relations between the knowledge items.
+const (
+	RelationText       = "text"
+	RelationTags       = "tags"
+	RelationCategories = "categories"
+)
+
+// RelatedItem represents knowledge item suggested to be linked with another one.
+// Score is between 0 and 1, Explanations tell which relations it consists of.
+type RelatedItem struct {
+	Item         *KnowledgeItem `json:"item"`
+	Score        float64        `json:"score"`
+	Explanations []*Explanation `json:"explanations"`
+}
+
+// Explanation represents one relation between the items: shared terms of the data, shared tags or categories.
+// Score is the share of the relation in the score of the related item.
+type Explanation struct {
+	Kind   string   `json:"kind"`
+	Values []string `json:"values"`
+	Score  float64  `json:"score"`
+}
@@ -0,0 +1,10 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// Review represents one historical testing result of the knowledge item.
+type Review struct {
+	Mark      int64     `json:"mark"`
+	CheckedAt time.Time `json:"checked_at"`
+}
@@ -0,0 +1,17 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// SavedSearch represents named filter of the knowledge items owned by the user, like "Weak Go topics".
+// It works as a smart collection: items aren't stored with it but selected by the Filter every time,
+// so the collection follows changes of the items.
+type SavedSearch struct {
+	ID     int64  `json:"id"`
+	Owner  string `json:"owner"`
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
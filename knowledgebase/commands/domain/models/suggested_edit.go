@@ -0,0 +1,63 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// Statuses of the suggested edits.
+const (
+	EditPending  = "pending"
+	EditApproved = "approved"
+	EditRejected = "rejected"
+)
+
+// Operations of the diff lines.
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// SuggestedEdit represents the change of the knowledge item proposed by the user who isn't allowed to edit it.
+// Title, Anchor and Data are the proposed content, the Base ones are the content of the item the edit was
+// proposed against, so the edit isn't applied over changes made after it. Owner is the owner of the item.
+// Diff of the data is built for presentation and never stored.
+type SuggestedEdit struct {
+	ID      int64  `json:"id"`
+	ItemID  int64  `json:"item_id"`
+	Owner   string `json:"owner"`
+	Author  string `json:"author"`
+	Comment string `json:"comment,omitempty"`
+
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+	Data   string `json:"data"`
+
+	BaseTitle  string `json:"base_title"`
+	BaseAnchor string `json:"base_anchor"`
+	BaseData   string `json:"base_data"`
+
+	Diff []*DiffLine `json:"diff,omitempty"`
+
+	Status        string `json:"status"`
+	Reviewer      string `json:"reviewer,omitempty"`
+	ReviewComment string `json:"review_comment,omitempty"`
+
+	CreatedAt  *time.Time `json:"created_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// Pending function reports whether the edit still waits for the review.
+func (e *SuggestedEdit) Pending() bool {
+	return e.Status == EditPending
+}
+
+// Applies function reports whether the item still has the content the edit was proposed against.
+func (e *SuggestedEdit) Applies(item *KnowledgeItem) bool {
+	return item.ID == e.ItemID && item.Title == e.BaseTitle && item.Anchor == e.BaseAnchor && item.Data == e.BaseData
+}
+
+// DiffLine represents one line of the difference between two texts.
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,8 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+// TagUsage represents a tag together with the number of knowledge items marked with it.
+type TagUsage struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
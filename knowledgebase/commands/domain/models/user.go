@@ -0,0 +1,18 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import "time"
+
+// User represents a person sharing the knowledge base server with others.
+// Login identifies the user, it is stored as the owner of the knowledge items, categories and saved searches.
+// Users signed in with OpenID Connect keep the issuer and the subject of their identity at the provider.
+type User struct {
+	ID      int64  `json:"id"`
+	Login   string `json:"login"`
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Issuer  string `json:"issuer,omitempty"`
+	Subject string `json:"subject,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
@@ -0,0 +1,90 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Events delivered to the webhooks.
+const (
+	EventItemCreated  = "item.created"
+	EventItemUpdated  = "item.updated"
+	EventItemDeleted  = "item.deleted"
+	EventItemReviewed = "item.reviewed"
+)
+
+// Events lists all the events the webhooks subscribe to.
+var Events = []string{EventItemCreated, EventItemUpdated, EventItemDeleted, EventItemReviewed}
+
+// Statuses of the webhook deliveries.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook represents the endpoint receiving the events of the knowledge base of the owner, the login
+// or WorkspaceOwner of the models.Workspace. Payloads are signed with HMAC-SHA256 using the secret,
+// it is shown once when the webhook is created. Failures counts the failed attempts in a row,
+// the webhook is disabled once there are too many of them and gets no deliveries until it's enabled again.
+type Webhook struct {
+	ID         int64      `json:"id"`
+	Owner      string     `json:"owner"`
+	URL        string     `json:"url"`
+	Secret     string     `json:"-"`
+	Events     []string   `json:"events"`
+	CreatedBy  string     `json:"created_by"`
+	Failures   int        `json:"failures"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Subscribed function reports whether the webhook receives the event.
+func (w *Webhook) Subscribed(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// Disabled function reports whether the webhook was disabled for failing too often.
+func (w *Webhook) Disabled() bool {
+	return w.DisabledAt != nil
+}
+
+// WebhookEvent represents the change of the knowledge base of the owner delivered to its webhooks.
+// Item is the changed item, Progress is the review state of the learner for the reviewed one.
+type WebhookEvent struct {
+	Event    string           `json:"event"`
+	Owner    string           `json:"owner"`
+	Item     *KnowledgeItem   `json:"item,omitempty"`
+	Progress *LearnerProgress `json:"progress,omitempty"`
+
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+// WebhookDelivery represents the event sent to the webhook, all of them are kept as the delivery log.
+// Pending deliveries are attempted again at NextAttemptAt until they are delivered or failed.
+// ResponseStatus and LastError describe the last attempt. The replayed delivery refers to the original one
+// in ReplayOf.
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	ReplayOf       int64           `json:"replay_of,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// DueDelivery represents the pending delivery due to be sent along with its webhook.
+type DueDelivery struct {
+	Webhook  *Webhook
+	Delivery *WebhookDelivery
+}
@@ -0,0 +1,106 @@
+// Package models contains types that represent entities of business logic.
+package models
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// workspaceOwnerPrefix starts owners of the items and categories curated in the workspace.
+// Logins can't contain a colon, so workspace owners never clash with the users.
+const workspaceOwnerPrefix = "workspace:"
+
+// Roles of the workspace members.
+const (
+	RoleOwner    = "owner"
+	RoleEditor   = "editor"
+	RoleReviewer = "reviewer"
+	RoleViewer   = "viewer"
+)
+
+// Permissions of the workspace members granted by their roles.
+const (
+	PermissionView   = "view"
+	PermissionReview = "review"
+	PermissionEdit   = "edit"
+	PermissionManage = "manage"
+)
+
+// rolePermissions lists permissions granted by every role.
+var rolePermissions = map[string][]string{
+	RoleOwner:    {PermissionView, PermissionReview, PermissionEdit, PermissionManage},
+	RoleEditor:   {PermissionView, PermissionReview, PermissionEdit},
+	RoleReviewer: {PermissionView, PermissionReview},
+	RoleViewer:   {PermissionView},
+}
+
+// Workspace represents knowledge base curated by a team. Items and categories of the workspace
+// are owned by the workspace itself, their Owner is WorkspaceOwner of the workspace ID,
+// so every workspace has own tree of categories.
+type Workspace struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Owner function returns the owner of the items and categories of the workspace.
+func (w *Workspace) Owner() string {
+	return WorkspaceOwner(w.ID)
+}
+
+// WorkspaceOwner function returns the owner of the items and categories of the workspace with the ID.
+func WorkspaceOwner(workspaceID int64) string {
+	return workspaceOwnerPrefix + strconv.FormatInt(workspaceID, 10)
+}
+
+// WorkspaceOf function returns the ID of the workspace owning the items and categories of the owner,
+// false is returned for the owners which are users.
+func WorkspaceOf(owner string) (int64, bool) {
+	id, ok := strings.CutPrefix(owner, workspaceOwnerPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	workspaceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return workspaceID, true
+}
+
+// WorkspaceMember represents the user taking part in the workspace with the role.
+type WorkspaceMember struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Login       string `json:"login"`
+	Role        string `json:"role"`
+
+	JoinedAt *time.Time `json:"joined_at"`
+}
+
+// Can function reports whether the role of the member grants the permission.
+func (m *WorkspaceMember) Can(permission string) bool {
+	return slices.Contains(rolePermissions[m.Role], permission)
+}
+
+// WorkspaceInvitation represents the offer to the user to join the workspace with the role.
+// The user becomes a member once the invitation is accepted.
+type WorkspaceInvitation struct {
+	ID          int64  `json:"id"`
+	WorkspaceID int64  `json:"workspace_id"`
+	Login       string `json:"login"`
+	Role        string `json:"role"`
+	InvitedBy   string `json:"invited_by"`
+
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// ValidRole function reports whether the role is known.
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+
+	return ok
+}
@@ -0,0 +1,17 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_api_tokens_repo.go -source=api_tokens_repo.go APITokensRepo
+
+// APITokensRepo interface is a set of methods required
+// for services to work with models.APIToken and storage.
+// FindByID returns ErrNotFound for missing token, FindByHash returns nil instead.
+type APITokensRepo interface {
+	Create(token *models.APIToken) (int64, error)
+	Delete(token *models.APIToken) error
+	FindByID(id int64) (*models.APIToken, error)
+	FindByHash(hash string) (*models.APIToken, error)
+	FindByOwner(owner string) ([]*models.APIToken, error)
+}
@@ -0,0 +1,17 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_assignments_repo.go -source=assignments_repo.go AssignmentsRepo
+
+// AssignmentsRepo interface is a set of methods required
+// for services to work with models.Assignment and storage.
+// Save and FindByID return ErrNotFound for missing assignment, lists are ordered by ID.
+type AssignmentsRepo interface {
+	Create(assignment *models.Assignment) (int64, error)
+	Save(assignment *models.Assignment) error
+	FindByID(id int64) (*models.Assignment, error)
+	FindByOwner(owner string) ([]*models.Assignment, error)
+	FindByLearner(learner string) ([]*models.Assignment, error)
+}
@@ -0,0 +1,14 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_audit_log_repo.go -source=audit_log_repo.go AuditLogRepo
+
+// AuditLogRepo interface is a set of methods required
+// for services to work with models.AuditEntry and storage.
+// The audit log is append-only, Find returns the entries matching the filter ordered by ID.
+type AuditLogRepo interface {
+	Append(entry *models.AuditEntry) (int64, error)
+	Find(filter *models.AuditFilter) ([]*models.AuditEntry, error)
+}
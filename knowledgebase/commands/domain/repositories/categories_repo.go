@@ -7,8 +7,13 @@ import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 
 // CategoriesRepo interface is a set of methods required
 // for services to work with models.Category and storage.
+// Category names are unique among the categories of the owner.
 type CategoriesRepo interface {
-	FindByName(name string) (*models.Category, error)
+	FindByID(id int64) (*models.Category, error)
+	FindByName(owner, name string) (*models.Category, error)
+	FindByOwner(owner string) ([]*models.Category, error)
+	FindAll() ([]*models.Category, error)
 	Create(category *models.Category) (int64, error)
+	Save(category *models.Category) error
 	Delete(category *models.Category) error
 }
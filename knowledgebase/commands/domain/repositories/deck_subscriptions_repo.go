@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_deck_subscriptions_repo.go -source=deck_subscriptions_repo.go DeckSubscriptionsRepo
+
+// DeckSubscriptionsRepo interface is a set of methods required
+// for services to work with models.DeckSubscription and storage.
+// Save replaces the subscription along with its copies, FindByID returns ErrNotFound for missing subscription.
+type DeckSubscriptionsRepo interface {
+	Create(subscription *models.DeckSubscription) (int64, error)
+	Save(subscription *models.DeckSubscription) error
+	FindByID(id int64) (*models.DeckSubscription, error)
+	FindByOwner(owner string) ([]*models.DeckSubscription, error)
+}
@@ -0,0 +1,15 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_decks_repo.go -source=decks_repo.go DecksRepo
+
+// DecksRepo interface is a set of methods required
+// for services to work with models.Deck and storage.
+// FindByID returns ErrNotFound for missing deck, FindAll returns the decks ordered by ID.
+type DecksRepo interface {
+	Create(deck *models.Deck) (int64, error)
+	FindByID(id int64) (*models.Deck, error)
+	FindAll() ([]*models.Deck, error)
+}
@@ -0,0 +1,7 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "errors"
+
+// ErrNotFound is returned by the repositories when entity requested by ID doesn't exist.
+var ErrNotFound = errors.New("not found")
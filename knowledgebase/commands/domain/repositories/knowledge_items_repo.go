@@ -7,9 +7,15 @@ import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 
 // KnowledgeItemsRepo interface represents a list of functions required for domain services
 // to work with storage.
+// FindByID returns ErrNotFound for missing item, FindByTitle returns nil instead.
 type KnowledgeItemsRepo interface {
 	Create(item *models.KnowledgeItem) (int64, error)
+	CreateBatch(items []*models.KnowledgeItem) ([]int64, error)
 	Save(item *models.KnowledgeItem) error
 	Delete(item *models.KnowledgeItem) error
 	FindByID(id int64) (*models.KnowledgeItem, error)
+	FindByTitle(owner, title string) (*models.KnowledgeItem, error)
+	FindByOwner(owner string) ([]*models.KnowledgeItem, error)
+	FindAll() ([]*models.KnowledgeItem, error)
+	FindByCategoryIDs(categoryIDs []int64) ([]*models.KnowledgeItem, error)
 }
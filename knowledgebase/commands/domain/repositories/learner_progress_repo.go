@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_learner_progress_repo.go -source=learner_progress_repo.go LearnerProgressRepo
+
+// LearnerProgressRepo interface is a set of methods required
+// for services to work with models.LearnerProgress and storage.
+// Save creates the progress or replaces the existing one, Find returns nil for the item never reviewed by the learner.
+type LearnerProgressRepo interface {
+	Save(progress *models.LearnerProgress) error
+	Find(learner string, itemID int64) (*models.LearnerProgress, error)
+	FindByLearner(learner string) ([]*models.LearnerProgress, error)
+	DeleteByItemID(itemID int64) error
+}
@@ -0,0 +1,14 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_notifications_repo.go -source=notifications_repo.go NotificationsRepo
+
+// NotificationsRepo interface is a set of methods required
+// for services to work with models.Notification and storage.
+// FindByRecipient returns the notifications of the user, the latest first.
+type NotificationsRepo interface {
+	Create(notification *models.Notification) (int64, error)
+	FindByRecipient(recipient string) ([]*models.Notification, error)
+}
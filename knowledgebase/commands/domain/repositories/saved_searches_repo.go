@@ -0,0 +1,18 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_saved_searches_repo.go -source=saved_searches_repo.go SavedSearchesRepo
+
+// SavedSearchesRepo interface is a set of methods required
+// for services to work with models.SavedSearch and storage.
+// FindByID returns ErrNotFound for missing search, FindByName returns nil instead.
+type SavedSearchesRepo interface {
+	Create(search *models.SavedSearch) (int64, error)
+	Save(search *models.SavedSearch) error
+	Delete(search *models.SavedSearch) error
+	FindByID(id int64) (*models.SavedSearch, error)
+	FindByName(owner, name string) (*models.SavedSearch, error)
+	FindByOwner(owner string) ([]*models.SavedSearch, error)
+}
@@ -0,0 +1,14 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_similar_items_repo.go -source=similar_items_repo.go SimilarItemsRepo
+
+// SimilarItemsRepo interface is a set of methods required for services to look up
+// models.KnowledgeItem which might contain the same fact without comparing every item of the owner.
+// FindCandidates returns the stored items of the item owner sharing a bucket of the similarity index with the item,
+// the candidates still have to be compared, since the index lets through some dissimilar items.
+type SimilarItemsRepo interface {
+	FindCandidates(item *models.KnowledgeItem) ([]*models.KnowledgeItem, error)
+}
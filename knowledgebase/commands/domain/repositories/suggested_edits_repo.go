@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_suggested_edits_repo.go -source=suggested_edits_repo.go SuggestedEditsRepo
+
+// SuggestedEditsRepo interface is a set of methods required
+// for services to work with models.SuggestedEdit and storage.
+// FindByID returns ErrNotFound for missing edit, FindPending returns the edits waiting for the review ordered by ID.
+type SuggestedEditsRepo interface {
+	Create(edit *models.SuggestedEdit) (int64, error)
+	Save(edit *models.SuggestedEdit) error
+	FindByID(id int64) (*models.SuggestedEdit, error)
+	FindPending(owner string) ([]*models.SuggestedEdit, error)
+}
@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_users_repo.go -source=users_repo.go UsersRepo
+
+// UsersRepo interface is a set of methods required
+// for services to work with models.User and storage.
+// FindByID returns ErrNotFound for missing user, FindByLogin and FindByIdentity return nil instead.
+type UsersRepo interface {
+	Create(user *models.User) (int64, error)
+	FindByID(id int64) (*models.User, error)
+	FindByLogin(login string) (*models.User, error)
+	FindByIdentity(issuer, subject string) (*models.User, error)
+}
@@ -0,0 +1,18 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_webhook_deliveries_repo.go -source=webhook_deliveries_repo.go WebhookDeliveriesRepo
+
+// WebhookDeliveriesRepo interface is a set of methods required
+// for services to work with models.WebhookDelivery and storage.
+// FindByID returns ErrNotFound for missing delivery, lists are ordered by ID.
+type WebhookDeliveriesRepo interface {
+	Create(delivery *models.WebhookDelivery) (int64, error)
+	Save(delivery *models.WebhookDelivery) error
+	FindByID(id int64) (*models.WebhookDelivery, error)
+	FindByWebhookID(webhookID int64) ([]*models.WebhookDelivery, error)
+	FindPending() ([]*models.WebhookDelivery, error)
+	DeleteByWebhookID(webhookID int64) error
+}
@@ -0,0 +1,17 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_webhooks_repo.go -source=webhooks_repo.go WebhooksRepo
+
+// WebhooksRepo interface is a set of methods required
+// for services to work with models.Webhook and storage.
+// FindByID returns ErrNotFound for missing webhook, FindByOwner returns the webhooks ordered by ID.
+type WebhooksRepo interface {
+	Create(webhook *models.Webhook) (int64, error)
+	Save(webhook *models.Webhook) error
+	Delete(webhook *models.Webhook) error
+	FindByID(id int64) (*models.Webhook, error)
+	FindByOwner(owner string) ([]*models.Webhook, error)
+}
@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_workspace_invitations_repo.go -source=workspace_invitations_repo.go WorkspaceInvitationsRepo
+
+// WorkspaceInvitationsRepo interface is a set of methods required
+// for services to work with models.WorkspaceInvitation and storage.
+// FindByID returns ErrNotFound for missing invitation.
+type WorkspaceInvitationsRepo interface {
+	Create(invitation *models.WorkspaceInvitation) (int64, error)
+	Delete(invitation *models.WorkspaceInvitation) error
+	FindByID(id int64) (*models.WorkspaceInvitation, error)
+	FindByLogin(login string) ([]*models.WorkspaceInvitation, error)
+}
@@ -0,0 +1,16 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_workspace_members_repo.go -source=workspace_members_repo.go WorkspaceMembersRepo
+
+// WorkspaceMembersRepo interface is a set of methods required
+// for services to work with models.WorkspaceMember and storage.
+// Save creates or replaces the member, Find returns nil for the user who isn't a member.
+type WorkspaceMembersRepo interface {
+	Save(member *models.WorkspaceMember) error
+	Find(workspaceID int64, login string) (*models.WorkspaceMember, error)
+	FindByWorkspace(workspaceID int64) ([]*models.WorkspaceMember, error)
+	FindByLogin(login string) ([]*models.WorkspaceMember, error)
+}
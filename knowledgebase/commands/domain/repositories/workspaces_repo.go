@@ -0,0 +1,14 @@
+// Package repositories contains list of interfaces required for domain services to provide them with data.
+package repositories
+
+import "github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_workspaces_repo.go -source=workspaces_repo.go WorkspacesRepo
+
+// WorkspacesRepo interface is a set of methods required
+// for services to work with models.Workspace and storage.
+// FindByID returns ErrNotFound for missing workspace.
+type WorkspacesRepo interface {
+	Create(workspace *models.Workspace) (int64, error)
+	FindByID(id int64) (*models.Workspace, error)
+}
@@ -0,0 +1,156 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const (
+	// apiTokenPrefix marks secrets of the API tokens, so they are easy to recognize in scripts and leaks.
+	apiTokenPrefix        = "ngt_"
+	apiTokenSecretLength  = 32
+	maxAPITokenNameLength = 64
+)
+
+// ErrInvalidToken is returned when the secret doesn't belong to any token or the token is expired.
+var ErrInvalidToken = errors.New("api token is invalid or expired")
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_api_token_service.go -source=api_token_service.go APITokenService
+
+// APITokenService represents a service that provides functionality related to the models.APIToken.
+// Tokens are visible to their owners only, tokens of other users are ErrForbidden.
+type APITokenService interface {
+	NewToken(owner, name string, scopes []string, expiresAt *time.Time) (*models.APIToken, string, error)
+	ListTokens(owner string) ([]*models.APIToken, error)
+	RevokeToken(owner string, tokenID int64) error
+	Authenticate(secret string) (*models.APIToken, error)
+}
+
+// apiTokenService is a set of business rules & actions related to the APIToken.
+type apiTokenService struct {
+	repo repositories.APITokensRepo
+}
+
+// NewAPITokenService function makes new instance of APITokenService.
+func NewAPITokenService(repo repositories.APITokensRepo) APITokenService {
+	return &apiTokenService{
+		repo: repo,
+	}
+}
+
+// NewToken function stores new models.APIToken of the owner and returns it with its secret.
+// The secret isn't stored anywhere, so it can't be shown again.
+func (s *apiTokenService) NewToken(
+	owner, name string,
+	scopes []string,
+	expiresAt *time.Time,
+) (*models.APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxAPITokenNameLength {
+		return nil, "", newValidationError("name must be 1 to %d characters", maxAPITokenNameLength)
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", newValidationError("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(models.Scopes, scope) {
+			return nil, "", newValidationError("scope %q is unknown", scope)
+		}
+	}
+
+	createdAt := time.Now()
+	if expiresAt != nil && !expiresAt.After(createdAt) {
+		return nil, "", newValidationError("expiration time must be in the future")
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+
+	token := &models.APIToken{
+		Owner:     owner,
+		Name:      name,
+		Hash:      hashAPITokenSecret(secret),
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: expiresAt,
+		CreatedAt: &createdAt,
+	}
+
+	token.ID, err = s.repo.Create(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+// ListTokens function returns all the models.APIToken of the owner, expired ones included.
+func (s *apiTokenService) ListTokens(owner string) ([]*models.APIToken, error) {
+	return s.repo.FindByOwner(owner)
+}
+
+// RevokeToken function deletes existing models.APIToken of the owner, its secret stops working at once.
+func (s *apiTokenService) RevokeToken(owner string, tokenID int64) error {
+	token, err := s.repo.FindByID(tokenID)
+	if err != nil {
+		return err
+	}
+
+	if token.Owner != owner {
+		return ErrForbidden
+	}
+
+	return s.repo.Delete(token)
+}
+
+// Authenticate function returns the models.APIToken of the secret, ErrInvalidToken is returned
+// for unknown secrets and expired tokens.
+func (s *apiTokenService) Authenticate(secret string) (*models.APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.repo.FindByHash(hashAPITokenSecret(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil || token.Expired(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	return token, nil
+}
+
+// newAPITokenSecret function generates random secret of the token.
+func newAPITokenSecret() (string, error) {
+	b := make([]byte, apiTokenSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPITokenSecret function returns hex encoded SHA-256 hash of the secret, it is how the secret is stored.
+// Secrets are long random strings, so a fast hash is enough to keep them safe.
+func hashAPITokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPITokenService_NewToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAPITokensRepo(ctrl)
+	s := services.NewAPITokenService(repo)
+
+	var stored *models.APIToken
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *models.APIToken) (int64, error) {
+		stored = token
+
+		return 3, nil
+	})
+
+	expiresAt := time.Now().Add(time.Hour)
+	token, secret, err := s.NewToken("alice", " CI ", []string{models.ScopeItemsWrite, models.ScopeItemsRead,
+		models.ScopeItemsRead}, &expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != 3 || token.Owner != "alice" || token.Name != "CI" || token.CreatedAt == nil {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if len(token.Scopes) != 2 || token.Scopes[0] != models.ScopeItemsRead || token.Scopes[1] != models.ScopeItemsWrite {
+		t.Errorf("expected sorted unique scopes, got %v", token.Scopes)
+	}
+	if !strings.HasPrefix(secret, "ngt_") || stored.Hash == "" || strings.Contains(stored.Hash, secret) {
+		t.Errorf("expected hashed secret to be stored, got %q for %q", stored.Hash, secret)
+	}
+
+	// the secret authenticates the token it was issued for.
+	repo.EXPECT().FindByHash(stored.Hash).Return(stored, nil)
+
+	found, err := s.Authenticate(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != stored {
+		t.Errorf("expected token %+v, got %+v", stored, found)
+	}
+}
+
+func TestAPITokenService_NewToken_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewAPITokenService(mock.NewMockAPITokensRepo(ctrl))
+
+	past := time.Now().Add(-time.Minute)
+	cases := []struct {
+		name      string
+		scopes    []string
+		expiresAt *time.Time
+	}{
+		{"", []string{models.ScopeItemsRead}, nil},
+		{strings.Repeat("a", 65), []string{models.ScopeItemsRead}, nil},
+		{"CI", nil, nil},
+		{"CI", []string{"items:delete"}, nil},
+		{"CI", []string{models.ScopeItemsRead}, &past},
+	}
+
+	for _, tc := range cases {
+		_, _, err := s.NewToken("alice", tc.name, tc.scopes, tc.expiresAt)
+		var validationErr *services.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%q %v: expected validation error, got %v", tc.name, tc.scopes, err)
+		}
+	}
+}
+
+func TestAPITokenService_Authenticate_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAPITokensRepo(ctrl)
+	s := services.NewAPITokenService(repo)
+
+	expiredAt := time.Now().Add(-time.Second)
+	repo.EXPECT().FindByHash(gomock.Any()).Return(nil, nil)
+	repo.EXPECT().FindByHash(gomock.Any()).Return(&models.APIToken{ID: 1, ExpiresAt: &expiredAt}, nil)
+
+	for _, secret := range []string{"password", "ngt_unknown", "ngt_expired"} {
+		if _, err := s.Authenticate(secret); !errors.Is(err, services.ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", secret, err)
+		}
+	}
+}
+
+func TestAPITokenService_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAPITokensRepo(ctrl)
+	s := services.NewAPITokenService(repo)
+
+	token := &models.APIToken{ID: 1, Owner: "alice"}
+	repo.EXPECT().FindByID(int64(1)).Return(token, nil).Times(2)
+	repo.EXPECT().Delete(token).Return(nil)
+
+	if err := s.RevokeToken("bob", 1); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+
+	if err := s.RevokeToken("alice", 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPIToken_HasScope(t *testing.T) {
+	token := &models.APIToken{Scopes: []string{models.ScopeItemsRead}}
+	if !token.HasScope(models.ScopeItemsRead) || token.HasScope(models.ScopeItemsWrite) {
+		t.Errorf("unexpected scopes of %v", token.Scopes)
+	}
+
+	admin := &models.APIToken{Scopes: []string{models.ScopeAdmin}}
+	if !admin.HasScope(models.ScopeReviewWrite) {
+		t.Error("expected admin to grant all the scopes")
+	}
+}
@@ -0,0 +1,324 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const maxAssignmentTitleLength = 128
+
+// passingMark is the lowest mark of the learner who recalled the item,
+// learners whose last mark is lower struggle with the item.
+const passingMark = maxMark / 2
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_assignment_service.go -source=assignment_service.go AssignmentService
+
+// AssignmentService represents a service that provides functionality related to the models.Assignment.
+// Assignments of other owners are ErrForbidden.
+type AssignmentService interface {
+	NewAssignment(
+		owner, instructor, title string,
+		itemIDs, categoryIDs []int64,
+		learners []string,
+		dueAt *time.Time,
+	) (*models.Assignment, error)
+
+	ListAssignments(learner string) ([]*models.Assignment, error)
+	Report(owner string, assignmentID int64) (*models.AssignmentReport, error)
+	ReplaceItem(owner string, sourceID, targetID int64) error
+}
+
+// assignmentService is a set of business rules & actions related to the Assignment.
+type assignmentService struct {
+	repo           repositories.AssignmentsRepo
+	itemsRepo      repositories.KnowledgeItemsRepo
+	categoriesRepo repositories.CategoriesRepo
+	membersRepo    repositories.WorkspaceMembersRepo
+	progressRepo   repositories.LearnerProgressRepo
+}
+
+// NewAssignmentService function makes new instance of AssignmentService.
+func NewAssignmentService(
+	repo repositories.AssignmentsRepo,
+	itemsRepo repositories.KnowledgeItemsRepo,
+	categoriesRepo repositories.CategoriesRepo,
+	membersRepo repositories.WorkspaceMembersRepo,
+	progressRepo repositories.LearnerProgressRepo,
+) AssignmentService {
+	return &assignmentService{
+		repo:           repo,
+		itemsRepo:      itemsRepo,
+		categoriesRepo: categoriesRepo,
+		membersRepo:    membersRepo,
+		progressRepo:   progressRepo,
+	}
+}
+
+// NewAssignment function stores new models.Assignment of the items and categories of the owner.
+// Every learner has to be a member of the workspace allowed to review its items.
+func (s *assignmentService) NewAssignment(
+	owner, instructor, title string,
+	itemIDs, categoryIDs []int64,
+	learners []string,
+	dueAt *time.Time,
+) (*models.Assignment, error) {
+	workspaceID, ok := models.WorkspaceOf(owner)
+	if !ok {
+		return nil, newValidationError("assignments are given in workspaces")
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" || len(title) > maxAssignmentTitleLength {
+		return nil, newValidationError("title must be 1 to %d characters", maxAssignmentTitleLength)
+	}
+
+	createdAt := time.Now()
+	if dueAt != nil && !dueAt.After(createdAt) {
+		return nil, newValidationError("due date must be in the future")
+	}
+
+	itemIDs = unique(itemIDs)
+	categoryIDs = unique(categoryIDs)
+	if len(itemIDs) == 0 && len(categoryIDs) == 0 {
+		return nil, newValidationError("assignment must have items or categories")
+	}
+
+	for _, id := range itemIDs {
+		item, err := s.itemsRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if item.Owner != owner {
+			return nil, ErrForbidden
+		}
+	}
+
+	for _, id := range categoryIDs {
+		category, err := s.categoriesRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			return nil, errCategoryNotExists
+		}
+		if category.Owner != owner {
+			return nil, ErrForbidden
+		}
+	}
+
+	learners = unique(learners)
+	if len(learners) == 0 {
+		return nil, newValidationError("assignment must have learners")
+	}
+
+	for _, learner := range learners {
+		member, err := s.membersRepo.Find(workspaceID, learner)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil || !member.Can(models.PermissionReview) {
+			return nil, newValidationError("user %q can't review the items of the workspace", learner)
+		}
+	}
+
+	assignment := &models.Assignment{
+		Owner:       owner,
+		Instructor:  instructor,
+		Title:       title,
+		ItemIDs:     itemIDs,
+		CategoryIDs: categoryIDs,
+		Learners:    learners,
+		DueAt:       dueAt,
+		CreatedAt:   &createdAt,
+	}
+
+	var err error
+	assignment.ID, err = s.repo.Create(assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// ListAssignments function returns the assignments given to the learner.
+func (s *assignmentService) ListAssignments(learner string) ([]*models.Assignment, error) {
+	return s.repo.FindByLearner(learner)
+}
+
+// ReplaceItem function points the assignments of the owner which pick the source item to the target one,
+// so they keep it once the source is merged into the target. The target is picked only once.
+func (s *assignmentService) ReplaceItem(owner string, sourceID, targetID int64) error {
+	assignments, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	for _, assignment := range assignments {
+		if !slices.Contains(assignment.ItemIDs, sourceID) {
+			continue
+		}
+
+		for i, id := range assignment.ItemIDs {
+			if id == sourceID {
+				assignment.ItemIDs[i] = targetID
+			}
+		}
+		assignment.ItemIDs = unique(assignment.ItemIDs)
+
+		if err = s.repo.Save(assignment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Report function aggregates Score, LastMark and reviews of every learner of the assignment per learner
+// and per item. Items deleted since the assignment was given are left out.
+func (s *assignmentService) Report(owner string, assignmentID int64) (*models.AssignmentReport, error) {
+	assignment, err := s.repo.FindByID(assignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if assignment.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	items, err := s.assignedItems(assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.AssignmentReport{
+		Assignment: assignment,
+		Learners:   make([]*models.LearnerReport, 0, len(assignment.Learners)),
+		Items:      make([]*models.AssignmentItemReport, 0, len(items)),
+	}
+
+	itemReports := make(map[int64]*models.AssignmentItemReport, len(items))
+	for _, item := range items {
+		itemReport := &models.AssignmentItemReport{ItemID: item.ID, Title: item.Title}
+		itemReports[item.ID] = itemReport
+		report.Items = append(report.Items, itemReport)
+	}
+
+	for _, learner := range assignment.Learners {
+		progress, err := s.progressRepo.FindByLearner(learner)
+		if err != nil {
+			return nil, err
+		}
+
+		byItem := make(map[int64]*models.LearnerProgress, len(progress))
+		for _, p := range progress {
+			byItem[p.ItemID] = p
+		}
+
+		learnerReport := &models.LearnerReport{
+			Learner:  learner,
+			Progress: make([]*models.LearnerProgress, 0, len(items)),
+		}
+
+		var score int64
+		for _, item := range items {
+			p, ok := byItem[item.ID]
+			if !ok {
+				p = &models.LearnerProgress{ItemID: item.ID, Learner: learner}
+			}
+			learnerReport.Progress = append(learnerReport.Progress, p)
+
+			score += p.Score
+			learnerReport.Reviews += p.Reviews
+
+			itemReport := itemReports[item.ID]
+			itemReport.AverageScore += float64(p.Score)
+			itemReport.Reviews += p.Reviews
+
+			if p.LastCheckAt == nil {
+				continue
+			}
+
+			learnerReport.Reviewed++
+			itemReport.Reviewed++
+			if p.LastMark < passingMark {
+				itemReport.Struggling++
+			}
+		}
+
+		if len(items) > 0 {
+			learnerReport.AverageScore = float64(score) / float64(len(items))
+		}
+
+		report.Learners = append(report.Learners, learnerReport)
+	}
+
+	for _, itemReport := range report.Items {
+		if len(assignment.Learners) > 0 {
+			itemReport.AverageScore /= float64(len(assignment.Learners))
+		}
+		itemReport.Struggles = itemReport.Struggling*2 > len(assignment.Learners)
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		if report.Items[i].Struggling != report.Items[j].Struggling {
+			return report.Items[i].Struggling > report.Items[j].Struggling
+		}
+
+		return report.Items[i].AverageScore < report.Items[j].AverageScore
+	})
+
+	return report, nil
+}
+
+// assignedItems function returns the items of the assignment picked one by one and the items
+// of its categories and their subcategories ordered by ID.
+func (s *assignmentService) assignedItems(assignment *models.Assignment) ([]*models.KnowledgeItem, error) {
+	byID := make(map[int64]*models.KnowledgeItem)
+
+	for _, id := range assignment.ItemIDs {
+		item, err := s.itemsRepo.FindByID(id)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		byID[item.ID] = item
+	}
+
+	items, err := itemsWithin(s.itemsRepo, s.categoriesRepo, assignment.Owner, assignment.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	items = make([]*models.KnowledgeItem, 0, len(byID))
+	for _, item := range byID {
+		if item.Owner == assignment.Owner {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items, nil
+}
+
+// unique function returns sorted values without duplicates.
+func unique[T int64 | string](values []T) []T {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	return slices.Compact(values)
+}
@@ -0,0 +1,277 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssignmentService_NewAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAssignmentsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	s := services.NewAssignmentService(
+		repo,
+		itemsRepo,
+		categoriesRepo,
+		membersRepo,
+		mock.NewMockLearnerProgressRepo(ctrl),
+	)
+
+	dueAt := time.Now().Add(7 * 24 * time.Hour)
+
+	itemsRepo.EXPECT().FindByID(int64(7)).Return(&models.KnowledgeItem{ID: 7, Owner: "workspace:5"}, nil)
+	categoriesRepo.EXPECT().FindByID(int64(2)).Return(&models.Category{ID: 2, Owner: "workspace:5"}, nil)
+	membersRepo.EXPECT().Find(int64(5), "bob").Return(&models.WorkspaceMember{Role: models.RoleReviewer}, nil)
+	membersRepo.EXPECT().Find(int64(5), "carol").Return(&models.WorkspaceMember{Role: models.RoleEditor}, nil)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(a *models.Assignment) (int64, error) {
+		if a.Owner != "workspace:5" || a.Instructor != "alice" || a.Title != "Onboarding" {
+			t.Errorf("unexpected assignment %+v", a)
+		}
+		if len(a.Learners) != 2 || a.Learners[0] != "bob" || a.Learners[1] != "carol" {
+			t.Errorf("expected unique learners, got %v", a.Learners)
+		}
+
+		return 4, nil
+	})
+
+	assignment, err := s.NewAssignment(
+		"workspace:5", "alice", " Onboarding ",
+		[]int64{7, 7}, []int64{2},
+		[]string{"carol", "bob", "carol"},
+		&dueAt,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignment.ID != 4 || !assignment.Assigned("bob") {
+		t.Errorf("unexpected assignment %+v", assignment)
+	}
+}
+
+func TestAssignmentService_NewAssignment_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	s := services.NewAssignmentService(
+		mock.NewMockAssignmentsRepo(ctrl),
+		itemsRepo,
+		mock.NewMockCategoriesRepo(ctrl),
+		membersRepo,
+		mock.NewMockLearnerProgressRepo(ctrl),
+	)
+
+	past := time.Now().Add(-time.Hour)
+
+	itemsRepo.EXPECT().FindByID(int64(7)).Return(&models.KnowledgeItem{ID: 7, Owner: "workspace:5"}, nil).AnyTimes()
+	membersRepo.EXPECT().Find(int64(5), "dave").Return(nil, nil)
+	membersRepo.EXPECT().Find(int64(5), "erin").Return(&models.WorkspaceMember{Role: models.RoleViewer}, nil)
+
+	cases := []struct {
+		name     string
+		owner    string
+		title    string
+		itemIDs  []int64
+		learners []string
+		dueAt    *time.Time
+	}{
+		{name: "personal knowledge base", owner: "alice", title: "Go", itemIDs: []int64{7}, learners: []string{"bob"}},
+		{name: "empty title", owner: "workspace:5", title: " ", itemIDs: []int64{7}, learners: []string{"bob"}},
+		{name: "past due date", owner: "workspace:5", title: "Go", itemIDs: []int64{7}, dueAt: &past},
+		{name: "no items", owner: "workspace:5", title: "Go", learners: []string{"bob"}},
+		{name: "no learners", owner: "workspace:5", title: "Go", itemIDs: []int64{7}},
+		{name: "not a member", owner: "workspace:5", title: "Go", itemIDs: []int64{7}, learners: []string{"dave"}},
+		{name: "can't review", owner: "workspace:5", title: "Go", itemIDs: []int64{7}, learners: []string{"erin"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.NewAssignment(tc.owner, "alice", tc.title, tc.itemIDs, nil, tc.learners, tc.dueAt)
+
+			var validationErr *services.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignmentService_NewAssignment_OtherOwnerItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	s := services.NewAssignmentService(
+		mock.NewMockAssignmentsRepo(ctrl),
+		itemsRepo,
+		mock.NewMockCategoriesRepo(ctrl),
+		mock.NewMockWorkspaceMembersRepo(ctrl),
+		mock.NewMockLearnerProgressRepo(ctrl),
+	)
+
+	itemsRepo.EXPECT().FindByID(int64(7)).Return(&models.KnowledgeItem{ID: 7, Owner: "workspace:6"}, nil)
+
+	_, err := s.NewAssignment("workspace:5", "alice", "Go", []int64{7}, nil, []string{"bob"}, nil)
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAssignmentService_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAssignmentsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewAssignmentService(
+		repo,
+		itemsRepo,
+		categoriesRepo,
+		mock.NewMockWorkspaceMembersRepo(ctrl),
+		progressRepo,
+	)
+
+	checkedAt := time.Now().Add(-24 * time.Hour)
+	raft := &models.KnowledgeItem{ID: 7, Owner: "workspace:5", Title: "Raft"}
+	paxos := &models.KnowledgeItem{ID: 8, Owner: "workspace:5", Title: "Paxos"}
+	clocks := &models.KnowledgeItem{ID: 9, Owner: "workspace:5", Title: "Vector clock"}
+
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Assignment{
+		ID:          4,
+		Owner:       "workspace:5",
+		ItemIDs:     []int64{7, 10},
+		CategoryIDs: []int64{2},
+		Learners:    []string{"bob", "carol", "dave"},
+	}, nil)
+	itemsRepo.EXPECT().FindByID(int64(7)).Return(raft, nil)
+	itemsRepo.EXPECT().FindByID(int64(10)).Return(nil, repositories.ErrNotFound)
+	categoriesRepo.EXPECT().FindByOwner("workspace:5").Return([]*models.Category{
+		{ID: 2, Owner: "workspace:5", Name: "Distributed Systems"},
+		{ID: 3, Owner: "workspace:5", Name: "Distributed Systems/Clocks", ParentID: 2},
+		{ID: 4, Owner: "workspace:5", Name: "Golang"},
+	}, nil)
+	itemsRepo.EXPECT().FindByCategoryIDs([]int64{2, 3}).Return([]*models.KnowledgeItem{paxos, raft, clocks}, nil)
+
+	progress := func(itemID int64, learner string, score, lastMark, reviews int64) *models.LearnerProgress {
+		return &models.LearnerProgress{
+			ItemID: itemID, Learner: learner, Score: score, LastMark: lastMark, LastCheckAt: &checkedAt, Reviews: reviews,
+		}
+	}
+	progressRepo.EXPECT().FindByLearner("bob").Return([]*models.LearnerProgress{
+		progress(7, "bob", 30, 8, 4), progress(8, "bob", 2, 2, 3),
+	}, nil)
+	progressRepo.EXPECT().FindByLearner("carol").Return([]*models.LearnerProgress{
+		progress(8, "carol", 1, 1, 2), progress(9, "carol", 9, 9, 1),
+	}, nil)
+	progressRepo.EXPECT().FindByLearner("dave").Return(nil, nil)
+
+	report, err := s.Report("workspace:5", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(report.Items))
+	}
+
+	// both learners who reviewed paxos struggle with it, so it goes first.
+	first := report.Items[0]
+	if first.ItemID != 8 || first.Struggling != 2 || !first.Struggles || first.Reviewed != 2 || first.Reviews != 5 {
+		t.Errorf("expected the class to struggle with paxos, got %+v", first)
+	}
+	if first.AverageScore != 1 {
+		t.Errorf("expected average score 1, got %v", first.AverageScore)
+	}
+	for _, item := range report.Items[1:] {
+		if item.Struggles || item.Struggling != 0 {
+			t.Errorf("expected no struggle with %s, got %+v", item.Title, item)
+		}
+	}
+
+	if len(report.Learners) != 3 {
+		t.Fatalf("expected 3 learners, got %d", len(report.Learners))
+	}
+
+	bob := report.Learners[0]
+	if bob.Learner != "bob" || bob.Reviewed != 2 || bob.Reviews != 7 || bob.AverageScore != 32.0/3 {
+		t.Errorf("unexpected report of bob %+v", bob)
+	}
+	if len(bob.Progress) != 3 || bob.Progress[2].ItemID != 9 || bob.Progress[2].LastCheckAt != nil {
+		t.Errorf("expected zero progress of bob for the vector clock, got %+v", bob.Progress)
+	}
+
+	dave := report.Learners[2]
+	if dave.Reviewed != 0 || dave.Reviews != 0 || dave.AverageScore != 0 {
+		t.Errorf("expected no progress of dave, got %+v", dave)
+	}
+}
+
+func TestAssignmentService_Report_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAssignmentsRepo(ctrl)
+	s := services.NewAssignmentService(
+		repo,
+		mock.NewMockKnowledgeItemsRepo(ctrl),
+		mock.NewMockCategoriesRepo(ctrl),
+		mock.NewMockWorkspaceMembersRepo(ctrl),
+		mock.NewMockLearnerProgressRepo(ctrl),
+	)
+
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Assignment{ID: 4, Owner: "workspace:6"}, nil)
+
+	if _, err := s.Report("workspace:5", 4); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAssignmentService_ReplaceItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAssignmentsRepo(ctrl)
+	s := services.NewAssignmentService(
+		repo,
+		mock.NewMockKnowledgeItemsRepo(ctrl),
+		mock.NewMockCategoriesRepo(ctrl),
+		mock.NewMockWorkspaceMembersRepo(ctrl),
+		mock.NewMockLearnerProgressRepo(ctrl),
+	)
+
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Assignment{
+		{ID: 1, ItemIDs: []int64{2, 7}},
+		{ID: 2, ItemIDs: []int64{1, 2}},
+		{ID: 3, ItemIDs: []int64{3}},
+	}, nil)
+
+	// the assignment picking both items keeps the target only once, the one without the source isn't saved.
+	var saved []string
+	repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(a *models.Assignment) error {
+		saved = append(saved, fmt.Sprintf("%d:%v", a.ID, a.ItemIDs))
+		return nil
+	}).Times(2)
+
+	if err := s.ReplaceItem("alice", 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(saved, ",") != "1:[1 7],2:[1]" {
+		t.Errorf("unexpected assignments %v", saved)
+	}
+}
@@ -0,0 +1,73 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_audit_service.go -source=audit_service.go AuditService
+
+// AuditService represents a service that provides functionality related to the models.AuditEntry.
+type AuditService interface {
+	Record(entry *models.AuditEntry, payload any, failure error) error
+	Search(filter *models.AuditFilter) ([]*models.AuditEntry, error)
+}
+
+// auditService is a set of business rules & actions related to the AuditEntry.
+type auditService struct {
+	repo repositories.AuditLogRepo
+}
+
+// NewAuditService function makes new instance of AuditService.
+func NewAuditService(repo repositories.AuditLogRepo) AuditService {
+	return &auditService{repo: repo}
+}
+
+// Record function appends the execution of the command to the audit log. The entry names the actor,
+// the owner, the command and the affected IDs, the failure is nil for the succeeded command.
+// The payload is kept as JSON.
+func (s *auditService) Record(entry *models.AuditEntry, payload any, failure error) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	createdAt := time.Now()
+
+	entry.Payload = data
+	entry.Outcome = models.AuditSucceeded
+	entry.Error = ""
+	entry.ItemIDs = compactIDs(entry.ItemIDs)
+	entry.CategoryIDs = compactIDs(entry.CategoryIDs)
+	entry.CreatedAt = &createdAt
+	if failure != nil {
+		entry.Outcome = models.AuditFailed
+		entry.Error = failure.Error()
+	}
+
+	entry.ID, err = s.repo.Append(entry)
+
+	return err
+}
+
+// Search function returns the entries of the audit log matching the filter, the oldest first.
+func (s *auditService) Search(filter *models.AuditFilter) ([]*models.AuditEntry, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, newValidationError("time range must end after it starts")
+	}
+
+	return s.repo.Find(filter)
+}
+
+// compactIDs function returns the sorted unique non-zero IDs.
+func compactIDs(ids []int64) []int64 {
+	ids = slices.DeleteFunc(slices.Clone(ids), func(id int64) bool { return id == 0 })
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
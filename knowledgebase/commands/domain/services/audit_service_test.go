@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAuditLogRepo(ctrl)
+	repo.EXPECT().Append(gomock.Any()).DoAndReturn(func(entry *models.AuditEntry) (int64, error) {
+		if entry.Outcome != models.AuditSucceeded || string(entry.Payload) != `{"id":7,"mark":8}` {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+		if len(entry.ItemIDs) != 2 || entry.ItemIDs[0] != 3 || entry.ItemIDs[1] != 7 || entry.CreatedAt == nil {
+			t.Errorf("expected sorted unique item IDs, got %v", entry.ItemIDs)
+		}
+
+		return 1, nil
+	})
+	repo.EXPECT().Append(gomock.Any()).Return(int64(2), nil)
+
+	s := services.NewAuditService(repo)
+
+	payload := struct {
+		ID   int64 `json:"id"`
+		Mark int64 `json:"mark"`
+	}{ID: 7, Mark: 8}
+
+	entry := &models.AuditEntry{Actor: "alice", Owner: "alice", Command: "SetMark", ItemIDs: []int64{7, 0, 3, 7}}
+	if err := s.Record(entry, payload, nil); err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != 1 {
+		t.Errorf("expected ID 1, got %d", entry.ID)
+	}
+
+	failed := &models.AuditEntry{Actor: "alice", Owner: "alice", Command: "SetMark"}
+	if err := s.Record(failed, payload, services.ErrForbidden); err != nil {
+		t.Fatal(err)
+	}
+	if failed.Outcome != models.AuditFailed || failed.Error != services.ErrForbidden.Error() {
+		t.Errorf("expected failed entry, got %+v", failed)
+	}
+}
+
+func TestAuditService_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	entries := []*models.AuditEntry{{ID: 1, Actor: "alice"}}
+
+	repo := mock.NewMockAuditLogRepo(ctrl)
+	repo.EXPECT().Find(&models.AuditFilter{Actor: "alice", From: &from, To: &to}).Return(entries, nil)
+
+	s := services.NewAuditService(repo)
+
+	found, err := s.Search(&models.AuditFilter{Actor: "alice", From: &from, To: &to})
+	if err != nil || len(found) != 1 {
+		t.Errorf("expected the entry of alice, got %+v, %v", found, err)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.Search(&models.AuditFilter{From: &to, To: &from}); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestAuditFilter_Matches(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	before, after := at.Add(-time.Hour), at.Add(time.Hour)
+	entry := &models.AuditEntry{Actor: "bob", Owner: "workspace:5", ItemIDs: []int64{7}, CreatedAt: &at}
+
+	tests := []struct {
+		filter models.AuditFilter
+		want   bool
+	}{
+		{models.AuditFilter{}, true},
+		{models.AuditFilter{Owner: "workspace:5", Actor: "bob", ItemID: 7}, true},
+		{models.AuditFilter{Actor: "alice"}, false},
+		{models.AuditFilter{ItemID: 8}, false},
+		{models.AuditFilter{From: &at, To: &after}, true},
+		{models.AuditFilter{From: &before, To: &at}, false},
+	}
+	for i, tc := range tests {
+		if got := tc.filter.Matches(entry); got != tc.want {
+			t.Errorf("%d: got %v, want %v", i, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// itemsWithin function returns the items of the owner in the categories and their subcategories ordered by ID.
+func itemsWithin(
+	itemsRepo repositories.KnowledgeItemsRepo,
+	categoriesRepo repositories.CategoriesRepo,
+	owner string,
+	categoryIDs []int64,
+) ([]*models.KnowledgeItem, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	categories, err := categoriesRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, category := range categories {
+		if slices.Contains(categoryIDs, category.ID) {
+			names = append(names, category.Name)
+		}
+	}
+
+	var within []int64
+	for _, category := range categories {
+		if slices.ContainsFunc(names, category.Within) {
+			within = append(within, category.ID)
+		}
+	}
+
+	if len(within) == 0 {
+		return nil, nil
+	}
+
+	found, err := itemsRepo.FindByCategoryIDs(within)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(found))
+	items := make([]*models.KnowledgeItem, 0, len(found))
+	for _, item := range found {
+		if item.Owner == owner && !seen[item.ID] {
+			seen[item.ID] = true
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items, nil
+}
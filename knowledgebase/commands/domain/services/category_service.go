@@ -3,19 +3,45 @@ package services
 
 import (
 	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
 )
 
-const minCategoryNameLength = 1
+const (
+	minCategoryNameLength         = 1
+	maxCategoryDescriptionLength  = 2000
+	maxCategoryIconLength         = 32
+	maxCategoryDailyNewItemsLimit = 10000
+)
+
+// categoryColorRe matches hex RGB colors in the short "#rgb" and the long "#rrggbb" forms.
+var categoryColorRe = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// errCategoryNotExists is returned when the category requested by name doesn't exist.
+var errCategoryNotExists = errors.New("category not exists")
 
 //go:generate mockgen -package=mock -destination=../../mock/mock_category_service.go -source=category_service.go CategoryService
 
 // CategoryService represents a service that provides functionality related to the models.Category.
+// Categories are addressed by their paths, like "Engineering/Backend/Databases", spaces around
+// the separators are ignored. Every user has own tree of categories, categories of other users are ErrForbidden.
 type CategoryService interface {
-	CreateOrGetCategory(name string) (*models.Category, error)
-	DeleteCategory(name string) error
+	CreateOrGetCategory(owner, name string) (*models.Category, error)
+	GetCategory(owner, name string) (*models.Category, error)
+	GetCategoryByID(owner string, id int64) (*models.Category, error)
+	ListCategories(owner string) ([]*models.Category, error)
+	UpdateCategory(owner, name string, metadata models.CategoryMetadata) (*models.Category, error)
+	ValidateName(name string) error
+	DeleteCategory(owner, name string) error
+	MoveCategory(owner, name, parent string) (*models.Category, error)
+	WithDescendants(owner string, categories []*models.Category) ([]*models.Category, error)
 }
 
 // categoryService is a set of business rules & actions related to the Category.
@@ -30,9 +56,16 @@ func NewCategoryService(repo repositories.CategoriesRepo) CategoryService {
 	}
 }
 
-// CreateOrGetCategory functions creates new models.Category or returns existing.
-func (s *categoryService) CreateOrGetCategory(name string) (*models.Category, error) {
-	cat, err := s.repo.FindByName(name)
+// CreateOrGetCategory functions creates new models.Category of the owner or returns existing.
+// Missing ancestors of the category are created as well.
+func (s *categoryService) CreateOrGetCategory(owner, name string) (*models.Category, error) {
+	if owner == "" {
+		return nil, newValidationError("owner cannot be empty")
+	}
+
+	name = categoryPath(name)
+
+	cat, err := s.repo.FindByName(owner, name)
 	if err != nil {
 		return nil, err
 	}
@@ -41,12 +74,22 @@ func (s *categoryService) CreateOrGetCategory(name string) (*models.Category, er
 		return cat, nil
 	}
 
-	if len(name) <= minCategoryNameLength {
-		return nil, errors.New("category name is too short")
+	if err = s.ValidateName(name); err != nil {
+		return nil, err
 	}
 
 	cat = &models.Category{
-		Name: name,
+		Owner: owner,
+		Name:  name,
+	}
+
+	if parentName := parentPath(name); parentName != "" {
+		parent, parentErr := s.CreateOrGetCategory(owner, parentName)
+		if parentErr != nil {
+			return nil, parentErr
+		}
+
+		cat.ParentID = parent.ID
 	}
 
 	cat.ID, err = s.repo.Create(cat)
@@ -57,21 +100,300 @@ func (s *categoryService) CreateOrGetCategory(name string) (*models.Category, er
 	return cat, nil
 }
 
-// DeleteCategory function deletes models.Category.
-func (s *categoryService) DeleteCategory(name string) error {
-	cat, err := s.repo.FindByName(name)
+// ValidateName function checks name of the new models.Category without storing it.
+// Every category of the path has to satisfy the rules.
+func (s *categoryService) ValidateName(name string) error {
+	for _, segment := range strings.Split(categoryPath(name), models.CategorySeparator) {
+		if len(segment) <= minCategoryNameLength {
+			return newValidationError("category name is too short")
+		}
+	}
+
+	return nil
+}
+
+// GetCategory function returns existing models.Category of the owner.
+func (s *categoryService) GetCategory(owner, name string) (*models.Category, error) {
+	cat, err := s.repo.FindByName(owner, categoryPath(name))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if cat == nil {
-		return errors.New("category not exists")
+		return nil, errCategoryNotExists
 	}
 
-	err = s.repo.Delete(cat)
+	return cat, nil
+}
+
+// GetCategoryByID function returns existing models.Category by its ID, categories of other users are ErrForbidden.
+func (s *categoryService) GetCategoryByID(owner string, id int64) (*models.Category, error) {
+	cat, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if cat == nil {
+		return nil, errCategoryNotExists
+	}
+
+	if cat.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	return cat, nil
+}
+
+// ListCategories function returns all the categories of the owner in the order of the tree:
+// every category is followed by its subcategories, siblings are ordered by SortOrder and then by name.
+func (s *categoryService) ListCategories(owner string) ([]*models.Category, error) {
+	all, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]bool, len(all))
+	for _, cat := range all {
+		known[cat.ID] = true
+	}
+
+	children := make(map[int64][]*models.Category)
+	for _, cat := range all {
+		parentID := cat.ParentID
+		if !known[parentID] {
+			parentID = 0
+		}
+		children[parentID] = append(children[parentID], cat)
+	}
+
+	result := make([]*models.Category, 0, len(all))
+	var walk func(parentID int64)
+	walk = func(parentID int64) {
+		siblings := children[parentID]
+		sort.Slice(siblings, func(i, j int) bool {
+			if siblings[i].SortOrder != siblings[j].SortOrder {
+				return siblings[i].SortOrder < siblings[j].SortOrder
+			}
+
+			return siblings[i].Name < siblings[j].Name
+		})
+
+		for _, cat := range siblings {
+			result = append(result, cat)
+			walk(cat.ID)
+		}
+	}
+	walk(0)
+
+	return result, nil
+}
+
+// UpdateCategory function replaces metadata of existing models.Category, the name and the place in the tree
+// are kept, use MoveCategory to change them. There is no partial update: every field of the metadata is
+// replaced, so callers changing a part of it pass the current values of the rest.
+func (s *categoryService) UpdateCategory(
+	owner, name string,
+	metadata models.CategoryMetadata,
+) (*models.Category, error) {
+	cat, err := s.GetCategory(owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = normalizeMetadata(&metadata); err != nil {
+		return nil, err
+	}
+
+	cat.CategoryMetadata = metadata
+	if err = s.repo.Save(cat); err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+// DeleteCategory function deletes models.Category, categories with subcategories can't be deleted.
+func (s *categoryService) DeleteCategory(owner, name string) error {
+	cat, err := s.GetCategory(owner, name)
+	if err != nil {
+		return err
+	}
+
+	all, err := s.repo.FindByOwner(owner)
 	if err != nil {
 		return err
 	}
 
+	for _, other := range all {
+		if other.ParentID == cat.ID {
+			return newValidationError("category %q has subcategories", cat.Name)
+		}
+	}
+
+	return s.repo.Delete(cat)
+}
+
+// MoveCategory function moves the category with all its descendants under the parent,
+// empty parent makes it a root category. Missing ancestors of the parent are created.
+// A category can't be moved under itself or its descendant.
+func (s *categoryService) MoveCategory(owner, name, parent string) (*models.Category, error) {
+	cat, err := s.GetCategory(owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	parent = categoryPath(parent)
+	if parent == cat.Name || strings.HasPrefix(parent, cat.Name+models.CategorySeparator) {
+		return nil, newValidationError("category cannot be moved under itself or its subcategory")
+	}
+
+	newName := cat.Name[strings.LastIndex(cat.Name, models.CategorySeparator)+1:]
+	if parent != "" {
+		newName = parent + models.CategorySeparator + newName
+	}
+	if newName == cat.Name {
+		return cat, nil
+	}
+
+	existing, err := s.repo.FindByName(owner, newName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, newValidationError("category %q already exists", newName)
+	}
+
+	var parentID int64
+	if parent != "" {
+		parentCat, parentErr := s.CreateOrGetCategory(owner, parent)
+		if parentErr != nil {
+			return nil, parentErr
+		}
+		parentID = parentCat.ID
+	}
+
+	all, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := cat.Name
+	for _, descendant := range all {
+		if descendant.ID == cat.ID || !descendant.Within(oldName) {
+			continue
+		}
+
+		descendant.Name = newName + strings.TrimPrefix(descendant.Name, oldName)
+		if err = s.repo.Save(descendant); err != nil {
+			return nil, err
+		}
+	}
+
+	cat.Name = newName
+	cat.ParentID = parentID
+	if err = s.repo.Save(cat); err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+// WithDescendants function returns the categories of the owner together with all their descendants,
+// so filtering items by a category includes the items of its subcategories.
+func (s *categoryService) WithDescendants(
+	owner string,
+	categories []*models.Category,
+) ([]*models.Category, error) {
+	if len(categories) == 0 {
+		return categories, nil
+	}
+
+	all, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Category, 0, len(categories))
+	seen := make(map[int64]bool)
+	add := func(category *models.Category) {
+		if !seen[category.ID] {
+			seen[category.ID] = true
+			result = append(result, category)
+		}
+	}
+
+	for _, category := range categories {
+		if category == nil {
+			return nil, newValidationError("category cannot be empty")
+		}
+		if category.Owner != owner {
+			return nil, ErrForbidden
+		}
+
+		add(category)
+		for _, other := range all {
+			if other.Within(category.Name) {
+				add(other)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// categoryPath function trims spaces around the names of the path and drops empty ones,
+// so "Engineering / Backend/" becomes "Engineering/Backend".
+func categoryPath(name string) string {
+	var segments []string
+	for _, segment := range strings.Split(name, models.CategorySeparator) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, models.CategorySeparator)
+}
+
+// parentPath function returns path of the parent category, it is empty for root categories.
+func parentPath(name string) string {
+	if i := strings.LastIndex(name, models.CategorySeparator); i >= 0 {
+		return name[:i]
+	}
+
+	return ""
+}
+
+// normalizeMetadata function checks metadata of models.Category and brings it to the stored form:
+// spaces around the texts are trimmed and the color is lowercased.
+func normalizeMetadata(metadata *models.CategoryMetadata) error {
+	metadata.Description = strings.TrimSpace(metadata.Description)
+	if utf8.RuneCountInString(metadata.Description) > maxCategoryDescriptionLength {
+		return newValidationError("category description is longer than %d characters", maxCategoryDescriptionLength)
+	}
+
+	metadata.Color = strings.ToLower(strings.TrimSpace(metadata.Color))
+	if metadata.Color != "" && !categoryColorRe.MatchString(metadata.Color) {
+		return newValidationError("category color %q is not a hex color like #1e90ff", metadata.Color)
+	}
+
+	metadata.Icon = strings.TrimSpace(metadata.Icon)
+	if utf8.RuneCountInString(metadata.Icon) > maxCategoryIconLength {
+		return newValidationError("category icon is longer than %d characters", maxCategoryIconLength)
+	}
+	if strings.ContainsFunc(metadata.Icon, unicode.IsSpace) {
+		return newValidationError("category icon cannot contain spaces")
+	}
+
+	// NaN fails every comparison, so it is rejected explicitly.
+	retention := metadata.Scheduling.TargetRetention
+	if retention != nil && (math.IsNaN(*retention) || *retention <= 0 || *retention >= 1) {
+		return newValidationError("target retention must be between 0 and 1")
+	}
+
+	limit := metadata.Scheduling.DailyNewItemsLimit
+	if limit != nil && (*limit < 0 || *limit > maxCategoryDailyNewItemsLimit) {
+		return newValidationError("daily new items limit must be between 0 and %d", maxCategoryDailyNewItemsLimit)
+	}
+
 	return nil
 }
@@ -2,6 +2,8 @@ package services_test
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
@@ -22,9 +24,9 @@ func TestCategoryService_CreateOrGetCategory_Create(t *testing.T) {
 		ID:   15,
 		Name: expectedCategoryName,
 	}
-	repo.EXPECT().FindByName(expectedCategoryName).Return(expectedCategory, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(expectedCategory, nil)
 
-	cat, err := s.CreateOrGetCategory(expectedCategoryName)
+	cat, err := s.CreateOrGetCategory("alice", expectedCategoryName)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -46,7 +48,7 @@ func TestCategoryService_CreateOrGetCategory_Existing(t *testing.T) {
 	expectedCategoryName := "expectedCategoryName"
 	var expectedCategoryID int64 = 15
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, nil)
 	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(category *models.Category) (int64, error) {
 		if category.Name != expectedCategoryName {
 			t.Errorf("Category name: expected %s, got %s", expectedCategoryName, category.Name)
@@ -57,7 +59,7 @@ func TestCategoryService_CreateOrGetCategory_Existing(t *testing.T) {
 		return expectedCategoryID, nil
 	})
 
-	cat, err := s.CreateOrGetCategory(expectedCategoryName)
+	cat, err := s.CreateOrGetCategory("alice", expectedCategoryName)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,8 +81,8 @@ func TestCategoryService_CreateOrGetCategory_TooShortName(t *testing.T) {
 	expectedErrorName := "category name is too short"
 	expectedCategoryName := "s"
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, nil)
-	_, err := s.CreateOrGetCategory(expectedCategoryName)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, nil)
+	_, err := s.CreateOrGetCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -99,8 +101,8 @@ func TestCategoryService_CreateOrGetCategory_RepoFindByNameError(t *testing.T) {
 	expectedError := errors.New("expected error")
 	expectedCategoryName := "s"
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, expectedError)
-	_, err := s.CreateOrGetCategory(expectedCategoryName)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, expectedError)
+	_, err := s.CreateOrGetCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -120,7 +122,7 @@ func TestCategoryService_CreateOrGetCategory_RepoCreateError(t *testing.T) {
 	expectedCategoryName := "expectedCategoryName"
 	var expectedCategoryID int64 = 15
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, nil)
 	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(category *models.Category) (int64, error) {
 		if category.Name != expectedCategoryName {
 			t.Errorf("Category name: expected %s, got %s", expectedCategoryName, category.Name)
@@ -131,7 +133,7 @@ func TestCategoryService_CreateOrGetCategory_RepoCreateError(t *testing.T) {
 		return expectedCategoryID, expectedError
 	})
 
-	_, err := s.CreateOrGetCategory(expectedCategoryName)
+	_, err := s.CreateOrGetCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -153,10 +155,11 @@ func TestCategoryService_DeleteCategory_Success(t *testing.T) {
 		Name: expectedCategoryName,
 	}
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(expectedCategory, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(expectedCategory, nil)
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Category{expectedCategory}, nil)
 	repo.EXPECT().Delete(expectedCategory).Return(nil)
 
-	err := s.DeleteCategory(expectedCategoryName)
+	err := s.DeleteCategory("alice", expectedCategoryName)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -172,9 +175,9 @@ func TestCategoryService_DeleteCategory_NotExisting(t *testing.T) {
 	expectedCategoryName := "expectedCategoryName"
 	expectedErrorMessage := "category not exists"
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, nil)
 
-	err := s.DeleteCategory(expectedCategoryName)
+	err := s.DeleteCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -193,9 +196,9 @@ func TestCategoryService_DeleteCategory_RepoFindByNameError(t *testing.T) {
 	expectedCategoryName := "expectedCategoryName"
 	expectedError := errors.New("expected error")
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(nil, expectedError)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, expectedError)
 
-	err := s.DeleteCategory(expectedCategoryName)
+	err := s.DeleteCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -218,10 +221,11 @@ func TestCategoryService_DeleteCategory_RepoDeleteError(t *testing.T) {
 		Name: expectedCategoryName,
 	}
 
-	repo.EXPECT().FindByName(expectedCategoryName).Return(expectedCategory, nil)
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(expectedCategory, nil)
+	repo.EXPECT().FindByOwner("alice").Return(nil, nil)
 	repo.EXPECT().Delete(expectedCategory).Return(expectedError)
 
-	err := s.DeleteCategory(expectedCategoryName)
+	err := s.DeleteCategory("alice", expectedCategoryName)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -229,3 +233,405 @@ func TestCategoryService_DeleteCategory_RepoDeleteError(t *testing.T) {
 		t.Errorf("Category name: expected %s, got %s", expectedError.Error(), err.Error())
 	}
 }
+
+func TestCategoryService_GetCategory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	expectedCategory := &models.Category{
+		ID:   15,
+		Name: "expectedCategoryName",
+	}
+
+	repo.EXPECT().FindByName("alice", expectedCategory.Name).Return(expectedCategory, nil)
+
+	cat, err := s.GetCategory("alice", expectedCategory.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat != expectedCategory {
+		t.Errorf("Category: expected %+v, got %+v", expectedCategory, cat)
+	}
+}
+
+func TestCategoryService_GetCategory_NotExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	expectedCategoryName := "expectedCategoryName"
+	expectedErrorMessage := "category not exists"
+
+	repo.EXPECT().FindByName("alice", expectedCategoryName).Return(nil, nil)
+
+	_, err := s.GetCategory("alice", expectedCategoryName)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expectedErrorMessage {
+		t.Errorf("Category name: expected %s, got %s", expectedErrorMessage, err.Error())
+	}
+}
+
+func TestCategoryService_ValidateName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewCategoryService(mock.NewMockCategoriesRepo(ctrl))
+
+	if err := s.ValidateName("expectedCategoryName"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := s.ValidateName("s")
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestCategoryService_CreateOrGetCategory_CreatesAncestors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	engineering := &models.Category{ID: 1, Owner: "alice", Name: "Engineering"}
+
+	repo.EXPECT().FindByName("alice", "Engineering/Backend/Databases").Return(nil, nil)
+	repo.EXPECT().FindByName("alice", "Engineering/Backend").Return(nil, nil)
+	repo.EXPECT().FindByName("alice", "Engineering").Return(engineering, nil)
+
+	var nextID int64 = 2
+	repo.EXPECT().Create(gomock.Any()).Times(2).DoAndReturn(func(category *models.Category) (int64, error) {
+		if category.ParentID != nextID-1 {
+			t.Errorf("Category %s parent: expected %d, got %d", category.Name, nextID-1, category.ParentID)
+		}
+		nextID++
+
+		return nextID - 1, nil
+	})
+
+	cat, err := s.CreateOrGetCategory("alice", " Engineering / Backend /Databases/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.Name != "Engineering/Backend/Databases" {
+		t.Errorf("Category name: expected %s, got %s", "Engineering/Backend/Databases", cat.Name)
+	}
+	if cat.ID != 3 || cat.ParentID != 2 {
+		t.Errorf("Category: expected ID 3 with parent 2, got %+v", cat)
+	}
+}
+
+func TestCategoryService_DeleteCategory_HasSubcategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	backend := &models.Category{ID: 2, Owner: "alice", Name: "Engineering/Backend", ParentID: 1}
+	databases := &models.Category{ID: 3, Owner: "alice", Name: "Engineering/Backend/Databases", ParentID: 2}
+
+	repo.EXPECT().FindByName("alice", backend.Name).Return(backend, nil)
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Category{backend, databases}, nil)
+
+	err := s.DeleteCategory("alice", backend.Name)
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestCategoryService_MoveCategory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	engineering := &models.Category{ID: 1, Owner: "alice", Name: "Engineering"}
+	databases := &models.Category{ID: 2, Owner: "alice", Name: "Databases"}
+	indexes := &models.Category{ID: 3, Owner: "alice", Name: "Databases/Indexes", ParentID: 2}
+	backend := &models.Category{ID: 4, Owner: "alice", Name: "Engineering/Backend", ParentID: 1}
+
+	repo.EXPECT().FindByName("alice", "Databases").Return(databases, nil)
+	repo.EXPECT().FindByName("alice", "Engineering/Backend/Databases").Return(nil, nil)
+	repo.EXPECT().FindByName("alice", "Engineering/Backend").Return(backend, nil)
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Category{engineering, databases, indexes, backend}, nil)
+	repo.EXPECT().Save(indexes).Return(nil)
+	repo.EXPECT().Save(databases).Return(nil)
+
+	cat, err := s.MoveCategory("alice", "Databases", "Engineering/Backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.Name != "Engineering/Backend/Databases" || cat.ParentID != backend.ID {
+		t.Errorf("Category: expected moved under %s, got %+v", backend.Name, cat)
+	}
+	if indexes.Name != "Engineering/Backend/Databases/Indexes" || indexes.ParentID != databases.ID {
+		t.Errorf("Subcategory: expected moved with the parent, got %+v", indexes)
+	}
+}
+
+func TestCategoryService_MoveCategory_ToRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	backend := &models.Category{ID: 2, Owner: "alice", Name: "Engineering/Backend", ParentID: 1}
+
+	repo.EXPECT().FindByName("alice", backend.Name).Return(backend, nil)
+	repo.EXPECT().FindByName("alice", "Backend").Return(nil, nil)
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Category{backend}, nil)
+	repo.EXPECT().Save(backend).Return(nil)
+
+	cat, err := s.MoveCategory("alice", backend.Name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.Name != "Backend" || cat.ParentID != 0 {
+		t.Errorf("Category: expected root category, got %+v", cat)
+	}
+}
+
+func TestCategoryService_MoveCategory_Cycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	engineering := &models.Category{ID: 1, Owner: "alice", Name: "Engineering"}
+
+	for _, parent := range []string{"Engineering", "Engineering/Backend"} {
+		repo.EXPECT().FindByName("alice", engineering.Name).Return(engineering, nil)
+
+		_, err := s.MoveCategory("alice", engineering.Name, parent)
+		var validationErr *services.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Parent %s: expected validation error, got %v", parent, err)
+		}
+	}
+}
+
+func TestCategoryService_MoveCategory_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	databases := &models.Category{ID: 2, Owner: "alice", Name: "Databases"}
+	existing := &models.Category{ID: 5, Owner: "alice", Name: "Engineering/Databases", ParentID: 1}
+
+	repo.EXPECT().FindByName("alice", databases.Name).Return(databases, nil)
+	repo.EXPECT().FindByName("alice", existing.Name).Return(existing, nil)
+
+	_, err := s.MoveCategory("alice", databases.Name, "Engineering")
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestCategoryService_WithDescendants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	engineering := &models.Category{ID: 1, Owner: "alice", Name: "Engineering"}
+	backend := &models.Category{ID: 2, Owner: "alice", Name: "Engineering/Backend", ParentID: 1}
+	databases := &models.Category{ID: 3, Owner: "alice", Name: "Engineering/Backend/Databases", ParentID: 2}
+	engineeringManagement := &models.Category{ID: 4, Owner: "alice", Name: "Engineering Management"}
+
+	repo.EXPECT().FindByOwner("alice").
+		Return([]*models.Category{engineering, backend, databases, engineeringManagement}, nil)
+
+	categories, err := s.WithDescendants("alice", []*models.Category{backend, databases})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 || categories[0] != backend || categories[1] != databases {
+		t.Errorf("Categories: expected %+v and %+v, got %+v", backend, databases, categories)
+	}
+
+	repo.EXPECT().FindByOwner("alice").
+		Return([]*models.Category{engineering, backend, databases, engineeringManagement}, nil)
+
+	categories, err = s.WithDescendants("alice", []*models.Category{engineering})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 3 {
+		t.Errorf("Categories: expected the category with 2 descendants, got %+v", categories)
+	}
+}
+
+func TestCategoryService_GetCategoryByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	expectedCategory := &models.Category{ID: 15, Owner: "alice", Name: "expectedCategoryName"}
+
+	repo.EXPECT().FindByID(expectedCategory.ID).Return(expectedCategory, nil)
+	repo.EXPECT().FindByID(int64(16)).Return(nil, nil)
+
+	cat, err := s.GetCategoryByID("alice", expectedCategory.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat != expectedCategory {
+		t.Errorf("Category: expected %+v, got %+v", expectedCategory, cat)
+	}
+
+	if _, err = s.GetCategoryByID("alice", 16); err == nil || err.Error() != "category not exists" {
+		t.Errorf("expected category not exists error, got %v", err)
+	}
+}
+
+func TestCategoryService_ListCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Category{
+		{ID: 1, Owner: "alice", Name: "Engineering", CategoryMetadata: models.CategoryMetadata{SortOrder: 2}},
+		{ID: 2, Owner: "alice", Name: "Engineering/Frontend", ParentID: 1},
+		{ID: 3, Owner: "alice", Name: "Engineering/Backend", ParentID: 1},
+		{ID: 4, Owner: "alice", Name: "Languages", CategoryMetadata: models.CategoryMetadata{SortOrder: 1}},
+		{ID: 5, Owner: "alice", Name: "Engineering/Backend/Databases", ParentID: 3},
+		{
+			ID: 6, Owner: "alice", Name: "Engineering/Design", ParentID: 1,
+			CategoryMetadata: models.CategoryMetadata{SortOrder: 1},
+		},
+	}, nil)
+
+	categories, err := s.ListCategories("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+
+	expected := "Languages,Engineering,Engineering/Backend,Engineering/Backend/Databases," +
+		"Engineering/Frontend,Engineering/Design"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Categories: expected %s, got %s", expected, strings.Join(names, ","))
+	}
+}
+
+func TestCategoryService_UpdateCategory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	category := &models.Category{ID: 1, Owner: "alice", Name: "Engineering"}
+	retention := 0.85
+	limit := 0
+
+	repo.EXPECT().FindByName("alice", category.Name).Return(category, nil)
+	repo.EXPECT().Save(category).Return(nil)
+
+	cat, err := s.UpdateCategory("alice", category.Name, models.CategoryMetadata{
+		Description: " Everything about building software ",
+		Color:       "#1E90FF",
+		Icon:        "⚙",
+		SortOrder:   3,
+		Scheduling: models.CategoryScheduling{
+			TargetRetention:    &retention,
+			DailyNewItemsLimit: &limit,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.Description != "Everything about building software" || cat.Color != "#1e90ff" || cat.Icon != "⚙" {
+		t.Errorf("Category: expected normalized metadata, got %+v", cat.CategoryMetadata)
+	}
+	if cat.SortOrder != 3 || *cat.Scheduling.TargetRetention != retention || *cat.Scheduling.DailyNewItemsLimit != 0 {
+		t.Errorf("Category: expected metadata to be stored, got %+v", cat.CategoryMetadata)
+	}
+}
+
+func TestCategoryService_UpdateCategory_InvalidMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	retention := 1.0
+	nan := math.NaN()
+	limit := -1
+	cases := map[string]models.CategoryMetadata{
+		"color name":        {Color: "blue"},
+		"color without #":   {Color: "1e90ff"},
+		"color of 4 digits": {Color: "#1e90"},
+		"color not hex":     {Color: "#gggggg"},
+		"icon with spaces":  {Icon: "two words"},
+		"long icon":         {Icon: strings.Repeat("i", 33)},
+		"long description":  {Description: strings.Repeat("d", 2001)},
+		"retention":         {Scheduling: models.CategoryScheduling{TargetRetention: &retention}},
+		"NaN retention":     {Scheduling: models.CategoryScheduling{TargetRetention: &nan}},
+		"limit":             {Scheduling: models.CategoryScheduling{DailyNewItemsLimit: &limit}},
+	}
+
+	for name, metadata := range cases {
+		repo.EXPECT().FindByName("alice", "Engineering").
+			Return(&models.Category{ID: 1, Owner: "alice", Name: "Engineering"}, nil)
+
+		_, err := s.UpdateCategory("alice", "Engineering", metadata)
+		var validationErr *services.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestCategoryService_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewCategoryService(repo)
+
+	category := &models.Category{ID: 3, Owner: "bob", Name: "Golang"}
+
+	repo.EXPECT().FindByID(category.ID).Return(category, nil)
+	repo.EXPECT().FindByOwner("alice").Return(nil, nil)
+
+	if _, err := s.GetCategoryByID("alice", category.ID); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("GetCategoryByID: expected ErrForbidden, got %v", err)
+	}
+	if _, err := s.WithDescendants("alice", []*models.Category{category}); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("WithDescendants: expected ErrForbidden, got %v", err)
+	}
+
+	_, err := s.CreateOrGetCategory("", "Golang")
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("CreateOrGetCategory: expected validation error for empty owner, got %v", err)
+	}
+}
@@ -0,0 +1,460 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/diff"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const maxDeckNameLength = 128
+const maxDeckDescriptionLength = 1000
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_deck_service.go -source=deck_service.go DeckService
+
+// DeckService represents a service that provides functionality related to the models.Deck.
+// Subscribers get copies of the items of the deck, so they review them with own progress and may edit them.
+// Changes of the deck reach the subscribers as models.DeckUpdate, each one is accepted or dismissed.
+// Accepted updates change the copies in place, so their review progress is kept.
+// Categories and subscriptions of other owners are ErrForbidden.
+type DeckService interface {
+	PublishDeck(owner, publisher string, categoryID int64, name, description string) (*models.Deck, error)
+	ListDecks() ([]*models.Deck, error)
+	Subscribe(owner string, deckID int64) (*models.DeckSubscription, error)
+	ListSubscriptions(owner string) ([]*models.DeckSubscription, error)
+	ListUpdates(owner string, subscriptionID int64) ([]*models.DeckUpdate, error)
+	AcceptUpdate(owner string, subscriptionID, sourceID int64) (*models.KnowledgeItem, error)
+	DismissUpdate(owner string, subscriptionID, sourceID int64) error
+	ReplaceItem(owner string, sourceID, targetID int64) error
+}
+
+// deckService is a set of business rules & actions related to the Deck.
+type deckService struct {
+	repo              repositories.DecksRepo
+	subscriptionsRepo repositories.DeckSubscriptionsRepo
+	itemsRepo         repositories.KnowledgeItemsRepo
+	categoriesRepo    repositories.CategoriesRepo
+}
+
+// NewDeckService function makes new instance of DeckService.
+func NewDeckService(
+	repo repositories.DecksRepo,
+	subscriptionsRepo repositories.DeckSubscriptionsRepo,
+	itemsRepo repositories.KnowledgeItemsRepo,
+	categoriesRepo repositories.CategoriesRepo,
+) DeckService {
+	return &deckService{
+		repo:              repo,
+		subscriptionsRepo: subscriptionsRepo,
+		itemsRepo:         itemsRepo,
+		categoriesRepo:    categoriesRepo,
+	}
+}
+
+// PublishDeck function stores new models.Deck of the category of the owner. The name of the deck becomes
+// the name of the root category of the copies, so it can't contain the category separator.
+func (s *deckService) PublishDeck(
+	owner, publisher string,
+	categoryID int64,
+	name, description string,
+) (*models.Deck, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxDeckNameLength {
+		return nil, newValidationError("name must be 1 to %d characters", maxDeckNameLength)
+	}
+	if strings.Contains(name, models.CategorySeparator) {
+		return nil, newValidationError("name cannot contain %q", models.CategorySeparator)
+	}
+
+	if len(description) > maxDeckDescriptionLength {
+		return nil, newValidationError("description must be at most %d characters", maxDeckDescriptionLength)
+	}
+
+	category, err := s.categoriesRepo.FindByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, errCategoryNotExists
+	}
+	if category.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	createdAt := time.Now()
+
+	deck := &models.Deck{
+		Owner:       owner,
+		CategoryID:  category.ID,
+		Name:        name,
+		Description: description,
+		PublishedBy: publisher,
+		CreatedAt:   &createdAt,
+	}
+
+	deck.ID, err = s.repo.Create(deck)
+	if err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ListDecks function returns all the published decks.
+func (s *deckService) ListDecks() ([]*models.Deck, error) {
+	return s.repo.FindAll()
+}
+
+// Subscribe function copies the items of the deck into new root category of the owner named after the deck.
+func (s *deckService) Subscribe(owner string, deckID int64) (*models.DeckSubscription, error) {
+	deck, err := s.repo.FindByID(deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.Owner == owner {
+		return nil, newValidationError("deck is published from own category")
+	}
+
+	subscriptions, err := s.subscriptionsRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(subscriptions, func(sub *models.DeckSubscription) bool { return sub.DeckID == deckID }) {
+		return nil, newValidationError("deck %q is already subscribed", deck.Name)
+	}
+
+	existing, err := s.categoriesRepo.FindByName(owner, deck.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, newValidationError("category %q already exists", deck.Name)
+	}
+
+	category := &models.Category{Owner: owner, Name: deck.Name}
+	category.ID, err = s.categoriesRepo.Create(category)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := itemsWithin(s.itemsRepo, s.categoriesRepo, deck.Owner, []int64{deck.CategoryID})
+	if err != nil {
+		return nil, s.discard(err, category, nil)
+	}
+
+	createdAt := time.Now()
+
+	copies := make([]*models.KnowledgeItem, 0, len(sources))
+	for _, source := range sources {
+		copies = append(copies, copyItem(owner, source, category, createdAt))
+	}
+
+	subscription := &models.DeckSubscription{
+		DeckID:     deck.ID,
+		Owner:      owner,
+		CategoryID: category.ID,
+		Items:      make([]*models.DeckItemCopy, 0, len(sources)),
+		CreatedAt:  &createdAt,
+	}
+
+	if len(copies) > 0 {
+		ids, err := s.itemsRepo.CreateBatch(copies)
+		if err != nil {
+			return nil, s.discard(err, category, nil)
+		}
+
+		for i, source := range sources {
+			copies[i].ID = ids[i]
+			subscription.Items = append(subscription.Items, newItemCopy(source, ids[i]))
+		}
+	}
+
+	subscription.ID, err = s.subscriptionsRepo.Create(subscription)
+	if err != nil {
+		return nil, s.discard(err, category, copies)
+	}
+
+	return subscription, nil
+}
+
+// discard function deletes the category and the copies created by the failed subscription,
+// otherwise the deck could not be subscribed again because of the existing category.
+func (s *deckService) discard(failure error, category *models.Category, copies []*models.KnowledgeItem) error {
+	errs := []error{failure}
+	for _, item := range copies {
+		errs = append(errs, s.itemsRepo.Delete(item))
+	}
+
+	return errors.Join(append(errs, s.categoriesRepo.Delete(category))...)
+}
+
+// ListSubscriptions function returns the decks subscribed by the owner.
+func (s *deckService) ListSubscriptions(owner string) ([]*models.DeckSubscription, error) {
+	return s.subscriptionsRepo.FindByOwner(owner)
+}
+
+// ListUpdates function returns the items of the deck changed or added since the last synchronization.
+// Copies deleted by the subscriber get no updates.
+func (s *deckService) ListUpdates(owner string, subscriptionID int64) ([]*models.DeckUpdate, error) {
+	subscription, err := s.subscription(owner, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updates(subscription)
+}
+
+// AcceptUpdate function applies the upstream content to the copy of the item, the item added to the deck
+// is copied into the category of the subscription.
+func (s *deckService) AcceptUpdate(owner string, subscriptionID, sourceID int64) (*models.KnowledgeItem, error) {
+	subscription, update, err := s.update(owner, subscriptionID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var item *models.KnowledgeItem
+	if update.ItemID == 0 {
+		item, err = s.copyNew(subscription, update, now)
+	} else {
+		item, err = s.itemsRepo.FindByID(update.ItemID)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Title = update.Title
+		item.Anchor = update.Anchor
+		item.Data = update.Data
+		item.Tags = slices.Clone(update.Tags)
+		item.UpdatedAt = &now
+
+		err = s.itemsRepo.Save(item)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.synchronize(subscription, update, item.ID)
+
+	if err = s.subscriptionsRepo.Save(subscription); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DismissUpdate function keeps the copy as it is, the update isn't listed anymore
+// until the item of the deck changes again.
+func (s *deckService) DismissUpdate(owner string, subscriptionID, sourceID int64) error {
+	subscription, update, err := s.update(owner, subscriptionID, sourceID)
+	if err != nil {
+		return err
+	}
+
+	s.synchronize(subscription, update, update.ItemID)
+
+	return s.subscriptionsRepo.Save(subscription)
+}
+
+// ReplaceItem function points the copies of the subscriptions of the owner kept as the source item to the target
+// one, so updates of the deck reach the item the source is merged into. The copy is left pointing to the source
+// when the subscription has a copy kept as the target already, then it gets no updates like a deleted copy.
+func (s *deckService) ReplaceItem(owner string, sourceID, targetID int64) error {
+	subscriptions, err := s.subscriptionsRepo.FindByOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		source, target := keptAs(subscription, sourceID), keptAs(subscription, targetID)
+		if source == nil || target != nil {
+			continue
+		}
+
+		source.ItemID = targetID
+
+		if err = s.subscriptionsRepo.Save(subscription); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keptAs function returns the copy of the subscription kept as the item or nil.
+func keptAs(subscription *models.DeckSubscription, itemID int64) *models.DeckItemCopy {
+	i := slices.IndexFunc(subscription.Items, func(c *models.DeckItemCopy) bool { return c.ItemID == itemID })
+	if i < 0 {
+		return nil
+	}
+
+	return subscription.Items[i]
+}
+
+// subscription function returns the subscription of the owner.
+func (s *deckService) subscription(owner string, subscriptionID int64) (*models.DeckSubscription, error) {
+	subscription, err := s.subscriptionsRepo.FindByID(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	return subscription, nil
+}
+
+// update function returns the subscription of the owner and its update of the item of the deck.
+func (s *deckService) update(
+	owner string,
+	subscriptionID, sourceID int64,
+) (*models.DeckSubscription, *models.DeckUpdate, error) {
+	subscription, err := s.subscription(owner, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updates, err := s.updates(subscription)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i := slices.IndexFunc(updates, func(u *models.DeckUpdate) bool { return u.SourceID == sourceID })
+	if i < 0 {
+		return nil, nil, newValidationError("item %d of the deck has no updates", sourceID)
+	}
+
+	return subscription, updates[i], nil
+}
+
+// updates function compares the items of the deck with their copies.
+func (s *deckService) updates(subscription *models.DeckSubscription) ([]*models.DeckUpdate, error) {
+	deck, err := s.repo.FindByID(subscription.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := itemsWithin(s.itemsRepo, s.categoriesRepo, deck.Owner, []int64{deck.CategoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []*models.DeckUpdate
+	for _, source := range sources {
+		synced := subscription.Copy(source.ID)
+		if synced != nil && synced.Matches(source) {
+			continue
+		}
+
+		update := &models.DeckUpdate{
+			SourceID: source.ID,
+			Title:    source.Title,
+			Anchor:   source.Anchor,
+			Data:     source.Data,
+			Tags:     slices.Clone(source.Tags),
+		}
+
+		local := ""
+		if synced != nil && synced.ItemID != 0 {
+			item, err := s.itemsRepo.FindByID(synced.ItemID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if item.Owner != subscription.Owner {
+				continue
+			}
+
+			update.ItemID = item.ID
+			update.LocalEdits = !synced.Matches(item)
+			local = item.Data
+		}
+
+		update.Diff = diff.Lines(local, update.Data)
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// copyNew function copies the item added to the deck into the category of the subscription.
+func (s *deckService) copyNew(
+	subscription *models.DeckSubscription,
+	update *models.DeckUpdate,
+	now time.Time,
+) (*models.KnowledgeItem, error) {
+	category, err := s.categoriesRepo.FindByID(subscription.CategoryID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	source := &models.KnowledgeItem{Title: update.Title, Anchor: update.Anchor, Data: update.Data, Tags: update.Tags}
+	item := copyItem(subscription.Owner, source, category, now)
+
+	item.ID, err = s.itemsRepo.Create(item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// synchronize function remembers the upstream content of the update as the content of the copy.
+func (s *deckService) synchronize(subscription *models.DeckSubscription, update *models.DeckUpdate, itemID int64) {
+	source := &models.KnowledgeItem{Title: update.Title, Anchor: update.Anchor, Data: update.Data, Tags: update.Tags}
+
+	synced := newItemCopy(source, itemID)
+	synced.SourceID = update.SourceID
+
+	if existing := subscription.Copy(update.SourceID); existing != nil {
+		*existing = *synced
+		return
+	}
+
+	subscription.Items = append(subscription.Items, synced)
+}
+
+// copyItem function builds the copy of the item of the deck owned by the subscriber,
+// the copy is put into the category of the subscription if it still exists.
+func copyItem(
+	owner string,
+	source *models.KnowledgeItem,
+	category *models.Category,
+	now time.Time,
+) *models.KnowledgeItem {
+	item := &models.KnowledgeItem{
+		Owner:     owner,
+		Title:     source.Title,
+		Anchor:    source.Anchor,
+		Data:      source.Data,
+		Tags:      slices.Clone(source.Tags),
+		CreatedAt: &now,
+	}
+	if category != nil {
+		item.Categories = []*models.Category{category}
+	}
+
+	return item
+}
+
+// newItemCopy function builds the copy of the item of the deck synchronized with its content.
+func newItemCopy(source *models.KnowledgeItem, itemID int64) *models.DeckItemCopy {
+	return &models.DeckItemCopy{
+		SourceID: source.ID,
+		ItemID:   itemID,
+		Title:    source.Title,
+		Anchor:   source.Anchor,
+		Data:     source.Data,
+		Tags:     slices.Clone(source.Tags),
+	}
+}
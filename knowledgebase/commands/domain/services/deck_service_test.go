@@ -0,0 +1,352 @@
+package services_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+// expectDeckItems makes the category 2 of alice hold the items of the deck.
+func expectDeckItems(
+	categoriesRepo *mock.MockCategoriesRepo,
+	itemsRepo *mock.MockKnowledgeItemsRepo,
+	items ...*models.KnowledgeItem,
+) {
+	categoriesRepo.EXPECT().FindByOwner("alice").
+		Return([]*models.Category{{ID: 2, Owner: "alice", Name: "Go"}, {ID: 3, Owner: "alice", Name: "Rust"}}, nil).
+		AnyTimes()
+	itemsRepo.EXPECT().FindByCategoryIDs([]int64{2}).Return(items, nil).AnyTimes()
+}
+
+func TestDeckService_PublishDeck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(
+		repo,
+		mock.NewMockDeckSubscriptionsRepo(ctrl),
+		mock.NewMockKnowledgeItemsRepo(ctrl),
+		categoriesRepo,
+	)
+
+	categoriesRepo.EXPECT().FindByID(int64(2)).Return(&models.Category{ID: 2, Owner: "alice", Name: "Go"}, nil).AnyTimes()
+	categoriesRepo.EXPECT().FindByID(int64(9)).Return(nil, nil)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(4), nil)
+
+	deck, err := s.PublishDeck("alice", "alice", 2, " Go basics ", "Syntax and idioms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.ID != 4 || deck.Name != "Go basics" || deck.CategoryID != 2 || deck.PublishedBy != "alice" {
+		t.Errorf("unexpected deck %+v", deck)
+	}
+
+	if _, err = s.PublishDeck("bob", "bob", 2, "Go", ""); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if _, err = s.PublishDeck("alice", "alice", 9, "Go", ""); err == nil {
+		t.Error("expected error for missing category")
+	}
+
+	var validationErr *services.ValidationError
+	for _, name := range []string{" ", "Go/basics"} {
+		if _, err = s.PublishDeck("alice", "alice", 2, name, ""); !errors.As(err, &validationErr) {
+			t.Errorf("%q: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestDeckService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(repo, subscriptionsRepo, itemsRepo, categoriesRepo)
+
+	deck := &models.Deck{ID: 4, Owner: "alice", CategoryID: 2, Name: "Go basics"}
+	repo.EXPECT().FindByID(int64(4)).Return(deck, nil).AnyTimes()
+	subscriptionsRepo.EXPECT().FindByOwner("bob").Return(nil, nil)
+	categoriesRepo.EXPECT().FindByName("bob", "Go basics").Return(nil, nil)
+	categoriesRepo.EXPECT().Create(gomock.Any()).Return(int64(8), nil)
+	expectDeckItems(categoriesRepo, itemsRepo,
+		&models.KnowledgeItem{ID: 11, Owner: "alice", Title: "Slices", Data: "len and cap", Tags: []string{"go"}},
+		&models.KnowledgeItem{ID: 10, Owner: "alice", Title: "Maps", Data: "hash tables", LastMark: 9},
+	)
+	itemsRepo.EXPECT().CreateBatch(gomock.Any()).DoAndReturn(func(items []*models.KnowledgeItem) ([]int64, error) {
+		for _, item := range items {
+			if item.Owner != "bob" || len(item.Categories) != 1 || item.Categories[0].ID != 8 || item.LastMark != 0 {
+				t.Errorf("expected fresh copy of bob, got %+v", item)
+			}
+		}
+
+		return []int64{20, 21}, nil
+	})
+	subscriptionsRepo.EXPECT().Create(gomock.Any()).Return(int64(6), nil)
+
+	subscription, err := s.Subscribe("bob", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.ID != 6 || subscription.CategoryID != 8 || len(subscription.Items) != 2 {
+		t.Fatalf("unexpected subscription %+v", subscription)
+	}
+	if c := subscription.Copy(10); c == nil || c.ItemID != 20 || c.Title != "Maps" {
+		t.Errorf("expected Maps to be copied to 20, got %+v", c)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.Subscribe("alice", 4); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for own deck, got %v", err)
+	}
+
+	subscriptionsRepo.EXPECT().FindByOwner("carol").Return([]*models.DeckSubscription{{DeckID: 4}}, nil)
+	if _, err = s.Subscribe("carol", 4); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for subscribed deck, got %v", err)
+	}
+}
+
+func TestDeckService_Subscribe_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(repo, subscriptionsRepo, itemsRepo, categoriesRepo)
+
+	expectedErr := errors.New("disk is full")
+
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Deck{ID: 4, Owner: "alice", CategoryID: 2, Name: "Go"}, nil).
+		AnyTimes()
+	subscriptionsRepo.EXPECT().FindByOwner("bob").Return(nil, nil).Times(2)
+	categoriesRepo.EXPECT().FindByName("bob", "Go").Return(nil, nil).Times(2)
+	categoriesRepo.EXPECT().Create(gomock.Any()).Return(int64(8), nil).Times(2)
+	expectDeckItems(categoriesRepo, itemsRepo,
+		&models.KnowledgeItem{ID: 11, Owner: "alice", Title: "Slices", Data: "len and cap"},
+		&models.KnowledgeItem{ID: 10, Owner: "alice", Title: "Maps", Data: "hash tables"},
+	)
+	categoriesRepo.EXPECT().Delete(gomock.Any()).DoAndReturn(func(category *models.Category) error {
+		if category.ID != 8 || category.Owner != "bob" {
+			t.Errorf("expected category 8 of bob to be deleted, got %+v", category)
+		}
+
+		return nil
+	}).Times(2)
+
+	itemsRepo.EXPECT().CreateBatch(gomock.Any()).Return(nil, expectedErr)
+	if _, err := s.Subscribe("bob", 4); !errors.Is(err, expectedErr) {
+		t.Errorf("expected %v, got %v", expectedErr, err)
+	}
+
+	var deleted []int64
+	itemsRepo.EXPECT().CreateBatch(gomock.Any()).Return([]int64{20, 21}, nil)
+	subscriptionsRepo.EXPECT().Create(gomock.Any()).Return(int64(0), expectedErr)
+	itemsRepo.EXPECT().Delete(gomock.Any()).DoAndReturn(func(item *models.KnowledgeItem) error {
+		deleted = append(deleted, item.ID)
+		return nil
+	}).Times(2)
+
+	if _, err := s.Subscribe("bob", 4); !errors.Is(err, expectedErr) {
+		t.Errorf("expected %v, got %v", expectedErr, err)
+	}
+	if !slices.Equal(deleted, []int64{20, 21}) {
+		t.Errorf("expected copies 20 and 21 to be deleted, got %v", deleted)
+	}
+}
+
+func TestDeckService_ListUpdates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(repo, subscriptionsRepo, itemsRepo, categoriesRepo)
+
+	subscription := &models.DeckSubscription{
+		ID: 6, DeckID: 4, Owner: "bob", CategoryID: 8,
+		Items: []*models.DeckItemCopy{
+			{SourceID: 10, ItemID: 20, Title: "Maps", Data: "hash tables"},
+			{SourceID: 11, ItemID: 21, Title: "Slices", Data: "len"},
+			{SourceID: 12, ItemID: 22, Title: "Channels", Data: "chan"},
+			{SourceID: 13, ItemID: 23, Title: "Defer", Data: "defer"},
+		},
+	}
+	subscriptionsRepo.EXPECT().FindByID(int64(6)).Return(subscription, nil).AnyTimes()
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Deck{ID: 4, Owner: "alice", CategoryID: 2}, nil)
+	expectDeckItems(categoriesRepo, itemsRepo,
+		&models.KnowledgeItem{ID: 10, Owner: "alice", Title: "Maps", Data: "hash tables"},
+		&models.KnowledgeItem{ID: 11, Owner: "alice", Title: "Slices", Data: "len\ncap"},
+		&models.KnowledgeItem{ID: 12, Owner: "alice", Title: "Channels", Data: "chan\nselect"},
+		&models.KnowledgeItem{ID: 13, Owner: "alice", Title: "Defer", Data: "defer\nrecover"},
+		&models.KnowledgeItem{ID: 14, Owner: "alice", Title: "Generics", Data: "any"},
+	)
+	itemsRepo.EXPECT().FindByID(int64(21)).
+		Return(&models.KnowledgeItem{ID: 21, Owner: "bob", Title: "Slices", Data: "len"}, nil)
+	itemsRepo.EXPECT().FindByID(int64(22)).
+		Return(&models.KnowledgeItem{ID: 22, Owner: "bob", Title: "Channels", Data: "chan\nclose"}, nil)
+	itemsRepo.EXPECT().FindByID(int64(23)).Return(nil, repositories.ErrNotFound)
+
+	updates, err := s.ListUpdates("bob", 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("expected updates of Slices, Channels and Generics, got %d", len(updates))
+	}
+	if u := updates[0]; u.SourceID != 11 || u.ItemID != 21 || u.LocalEdits || len(u.Diff) != 2 {
+		t.Errorf("unexpected update of Slices %+v", u)
+	}
+	if u := updates[1]; u.SourceID != 12 || !u.LocalEdits {
+		t.Errorf("expected local edits of Channels, got %+v", u)
+	}
+	if u := updates[2]; u.SourceID != 14 || u.ItemID != 0 || u.Data != "any" {
+		t.Errorf("expected new item Generics, got %+v", u)
+	}
+
+	if _, err = s.ListUpdates("carol", 6); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestDeckService_AcceptUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(repo, subscriptionsRepo, itemsRepo, categoriesRepo)
+
+	subscription := &models.DeckSubscription{
+		ID: 6, DeckID: 4, Owner: "bob", CategoryID: 8,
+		Items: []*models.DeckItemCopy{{SourceID: 11, ItemID: 21, Title: "Slices", Data: "len"}},
+	}
+	local := &models.KnowledgeItem{ID: 21, Owner: "bob", Title: "Slices", Data: "len", LastMark: 7, Reviews: 3}
+
+	subscriptionsRepo.EXPECT().FindByID(int64(6)).Return(subscription, nil).AnyTimes()
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Deck{ID: 4, Owner: "alice", CategoryID: 2}, nil).AnyTimes()
+	expectDeckItems(categoriesRepo, itemsRepo,
+		&models.KnowledgeItem{ID: 11, Owner: "alice", Title: "Slices", Data: "len\ncap", Tags: []string{"go"}},
+		&models.KnowledgeItem{ID: 14, Owner: "alice", Title: "Generics", Data: "any"},
+	)
+	itemsRepo.EXPECT().FindByID(int64(21)).Return(local, nil).AnyTimes()
+	itemsRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(item *models.KnowledgeItem) error {
+		if item.ID != 21 || item.Data != "len\ncap" || item.LastMark != 7 || item.Reviews != 3 {
+			t.Errorf("expected the copy to keep its progress, got %+v", item)
+		}
+
+		return nil
+	})
+	categoriesRepo.EXPECT().FindByID(int64(8)).Return(&models.Category{ID: 8, Owner: "bob", Name: "Go basics"}, nil)
+	itemsRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(item *models.KnowledgeItem) (int64, error) {
+		if item.Owner != "bob" || item.Title != "Generics" || item.Categories[0].ID != 8 {
+			t.Errorf("unexpected copy %+v", item)
+		}
+
+		return 24, nil
+	})
+	subscriptionsRepo.EXPECT().Save(subscription).Return(nil).Times(2)
+
+	item, err := s.AcceptUpdate("bob", 6, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ID != 21 {
+		t.Errorf("expected the copy to be updated, got %+v", item)
+	}
+	if c := subscription.Copy(11); c.Data != "len\ncap" || len(c.Tags) != 1 {
+		t.Errorf("expected the copy to be synchronized, got %+v", c)
+	}
+
+	item, err = s.AcceptUpdate("bob", 6, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := subscription.Copy(14); item.ID != 24 || c == nil || c.ItemID != 24 {
+		t.Errorf("expected new copy 24, got %+v %+v", item, c)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.AcceptUpdate("bob", 6, 10); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for item without updates, got %v", err)
+	}
+}
+
+func TestDeckService_DismissUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockDecksRepo(ctrl)
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	categoriesRepo := mock.NewMockCategoriesRepo(ctrl)
+	s := services.NewDeckService(repo, subscriptionsRepo, itemsRepo, categoriesRepo)
+
+	subscription := &models.DeckSubscription{ID: 6, DeckID: 4, Owner: "bob", CategoryID: 8}
+
+	subscriptionsRepo.EXPECT().FindByID(int64(6)).Return(subscription, nil)
+	repo.EXPECT().FindByID(int64(4)).Return(&models.Deck{ID: 4, Owner: "alice", CategoryID: 2}, nil)
+	expectDeckItems(categoriesRepo, itemsRepo,
+		&models.KnowledgeItem{ID: 14, Owner: "alice", Title: "Generics", Data: "any"},
+	)
+	subscriptionsRepo.EXPECT().Save(subscription).Return(nil)
+
+	if err := s.DismissUpdate("bob", 6, 14); err != nil {
+		t.Fatal(err)
+	}
+	if c := subscription.Copy(14); c == nil || c.ItemID != 0 || c.Data != "any" {
+		t.Errorf("expected declined copy of Generics, got %+v", c)
+	}
+}
+
+func TestDeckService_ReplaceItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionsRepo := mock.NewMockDeckSubscriptionsRepo(ctrl)
+	s := services.NewDeckService(
+		mock.NewMockDecksRepo(ctrl),
+		subscriptionsRepo,
+		mock.NewMockKnowledgeItemsRepo(ctrl),
+		mock.NewMockCategoriesRepo(ctrl),
+	)
+
+	subscriptionsRepo.EXPECT().FindByOwner("bob").Return([]*models.DeckSubscription{
+		{ID: 1, Owner: "bob", Items: []*models.DeckItemCopy{{SourceID: 10, ItemID: 20}, {SourceID: 11, ItemID: 0}}},
+		// the target is a copy of this subscription already, so the copy of the source isn't pointed to it.
+		{ID: 2, Owner: "bob", Items: []*models.DeckItemCopy{{SourceID: 30, ItemID: 20}, {SourceID: 31, ItemID: 21}}},
+		{ID: 3, Owner: "bob", Items: []*models.DeckItemCopy{{SourceID: 40, ItemID: 22}}},
+	}, nil)
+	subscriptionsRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(subscription *models.DeckSubscription) error {
+		if subscription.ID != 1 {
+			t.Errorf("unexpected subscription %d saved", subscription.ID)
+		}
+		if c := subscription.Copy(10); c.ItemID != 21 {
+			t.Errorf("expected copy kept as the target, got %+v", c)
+		}
+		if c := subscription.Copy(11); c.ItemID != 0 {
+			t.Errorf("expected declined copy to stay declined, got %+v", c)
+		}
+
+		return nil
+	})
+
+	if err := s.ReplaceItem("bob", 20, 21); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,114 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"sort"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/similarity"
+)
+
+// duplicateThreshold is the minimal similarity score of the items considered duplicates.
+const duplicateThreshold = 0.5
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_duplicate_service.go -source=duplicate_service.go DuplicateService
+
+// DuplicateService represents a service that finds models.KnowledgeItem containing the same fact.
+// Items are compared with the items of the same owner only.
+type DuplicateService interface {
+	FindSimilar(item *models.KnowledgeItem) ([]*models.Duplicate, error)
+	FindDuplicates(owner string) ([]*models.DuplicatePair, error)
+}
+
+// duplicateService is a set of business rules related to duplicates of the Knowledge Items.
+type duplicateService struct {
+	repo        repositories.KnowledgeItemsRepo
+	similarRepo repositories.SimilarItemsRepo
+}
+
+// NewDuplicateService function makes new instance of DuplicateService.
+func NewDuplicateService(
+	repo repositories.KnowledgeItemsRepo,
+	similarRepo repositories.SimilarItemsRepo,
+) DuplicateService {
+	return &duplicateService{
+		repo:        repo,
+		similarRepo: similarRepo,
+	}
+}
+
+// FindSimilar function returns existing items of the item owner similar to the given one, the most similar go first.
+// The item doesn't have to be stored yet, a stored item is never reported as a duplicate of itself.
+// Only candidates of the similarity index are compared, so it is cheap enough to run on every added item.
+func (s *duplicateService) FindSimilar(item *models.KnowledgeItem) ([]*models.Duplicate, error) {
+	items, err := s.similarRepo.FindCandidates(item)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := similarity.ItemFingerprint(item)
+
+	var duplicates []*models.Duplicate
+	for _, existing := range items {
+		if item.ID != 0 && existing.ID == item.ID {
+			continue
+		}
+
+		sim := similarity.Compare(fp, similarity.ItemFingerprint(existing))
+		if sim.Score >= duplicateThreshold {
+			duplicates = append(duplicates, &models.Duplicate{Item: existing, Similarity: sim})
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity.Score > duplicates[j].Similarity.Score
+	})
+
+	return duplicates, nil
+}
+
+// FindDuplicates function returns all the pairs of similar items of the owner, the most similar go first.
+// Only candidates of the similarity index are compared, so the report is cheap for big knowledge bases.
+func (s *duplicateService) FindDuplicates(owner string) ([]*models.DuplicatePair, error) {
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*models.KnowledgeItem, len(items))
+	index := similarity.NewIndex()
+	for _, item := range items {
+		byID[item.ID] = item
+		index.Add(item.ID, similarity.ItemFingerprint(item))
+	}
+
+	var pairs []*models.DuplicatePair
+	for _, item := range items {
+		fp := index.Fingerprint(item.ID)
+		for _, id := range index.Candidates(fp) {
+			// every pair is compared once, from the item with the lower ID.
+			if id <= item.ID {
+				continue
+			}
+
+			sim := similarity.Compare(fp, index.Fingerprint(id))
+			if sim.Score >= duplicateThreshold {
+				pairs = append(pairs, &models.DuplicatePair{First: item, Second: byID[id], Similarity: sim})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].Similarity.Score != pairs[j].Similarity.Score {
+			return pairs[i].Similarity.Score > pairs[j].Similarity.Score
+		}
+		if pairs[i].First.ID != pairs[j].First.ID {
+			return pairs[i].First.ID < pairs[j].First.ID
+		}
+
+		return pairs[i].Second.ID < pairs[j].Second.ID
+	})
+
+	return pairs, nil
+}
@@ -0,0 +1,103 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func duplicateTestItems() []*models.KnowledgeItem {
+	return []*models.KnowledgeItem{
+		{
+			ID:     1,
+			Owner:  "alice",
+			Title:  "What is a goroutine?",
+			Anchor: "goroutine",
+			Data:   "Goroutine is a lightweight thread managed by the Go runtime.",
+		},
+		{
+			ID:     2,
+			Owner:  "alice",
+			Title:  "What is a channel?",
+			Anchor: "channel",
+			Data:   "Channel is a typed conduit through which you send and receive values.",
+		},
+		{
+			ID:     3,
+			Owner:  "alice",
+			Title:  "Goroutines",
+			Anchor: "lightweight thread",
+			Data:   "Goroutine is a lightweight thread, managed by the Go runtime!",
+		},
+		{
+			ID:     4,
+			Owner:  "alice",
+			Title:  "What is a channel",
+			Anchor: "channel",
+			Data:   "Channels are typed conduits, values are sent and received through them.",
+		},
+	}
+}
+
+func TestDuplicateService_FindSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	similarRepo := mock.NewMockSimilarItemsRepo(ctrl)
+	similarRepo.EXPECT().FindCandidates(gomock.Any()).Return(duplicateTestItems(), nil).Times(2)
+
+	s := services.NewDuplicateService(mock.NewMockKnowledgeItemsRepo(ctrl), similarRepo)
+
+	duplicates, err := s.FindSimilar(&models.KnowledgeItem{
+		Owner:  "alice",
+		Title:  "Goroutine",
+		Anchor: "goroutine",
+		Data:   "A goroutine is a lightweight thread managed by the Go runtime.",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duplicates) != 2 || duplicates[0].Item.ID != 1 || duplicates[1].Item.ID != 3 {
+		t.Fatalf("expected items 1 and 3, got %v", duplicates)
+	}
+	if duplicates[0].Similarity.Score < duplicates[1].Similarity.Score {
+		t.Errorf("expected the most similar item first")
+	}
+
+	// stored item is never a duplicate of itself.
+	duplicates, err = s.FindSimilar(duplicateTestItems()[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duplicates) != 1 || duplicates[0].Item.ID != 4 {
+		t.Errorf("expected item 4, got %v", duplicates)
+	}
+}
+
+func TestDuplicateService_FindDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(duplicateTestItems(), nil)
+
+	s := services.NewDuplicateService(repo, mock.NewMockSimilarItemsRepo(ctrl))
+
+	pairs, err := s.FindDuplicates("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %d", len(pairs))
+	}
+	if pairs[0].First.ID != 1 || pairs[0].Second.ID != 3 {
+		t.Errorf("expected pair of items 1 and 3 first, got %d and %d", pairs[0].First.ID, pairs[0].Second.ID)
+	}
+	if pairs[1].First.ID != 2 || pairs[1].Second.ID != 4 {
+		t.Errorf("expected pair of items 2 and 4, got %d and %d", pairs[1].First.ID, pairs[1].Second.ID)
+	}
+}
@@ -0,0 +1,7 @@
+// Package services contains domain business rules.
+package services
+
+import "errors"
+
+// ErrForbidden is returned when the user acts on the entity owned by another user.
+var ErrForbidden = errors.New("forbidden")
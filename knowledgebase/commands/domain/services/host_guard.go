@@ -0,0 +1,18 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrHostBlocked is returned for the hosts resolving to the addresses the server must not connect to.
+var ErrHostBlocked = errors.New("address is not allowed")
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_host_guard.go -source=host_guard.go HostGuard
+
+// HostGuard represents the policy deciding which hosts the server may send requests to on behalf of the users.
+// CheckHost returns ErrHostBlocked when the host resolves to an address of the internal network of the server.
+type HostGuard interface {
+	CheckHost(ctx context.Context, host string) error
+}
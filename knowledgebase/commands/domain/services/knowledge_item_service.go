@@ -2,12 +2,12 @@
 package services
 
 import (
-	"errors"
-	"fmt"
+	"slices"
 	"time"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/tags"
 )
 
 const minTitleLength = 3
@@ -22,44 +22,89 @@ const maxMark = 10
 //go:generate mockgen -package=mock -destination=../../mock/mock_knowledge_item_service.go -source=knowledge_item_service.go KnowledgeItemService
 
 // KnowledgeItemService interface represents a service that performs actions related to the models.KnowledgeItem.
+// Every action is done on behalf of the owner, items and categories of other users are ErrForbidden.
+// Items are returned with the review state of the user, marks are stored as models.LearnerProgress of the learner.
 type KnowledgeItemService interface {
 	NewItem(
-		title, anchor, data string,
+		owner, title, anchor, data string,
 		tags []string,
 		categories []*models.Category,
 	) (*models.KnowledgeItem, error)
 
+	NewItems(owner string, items []*models.KnowledgeItem) ([]*models.KnowledgeItem, error)
+
+	ValidateItem(title, anchor, data string, tags []string) error
+
 	UpdateItem(
+		owner string,
 		itemID int64,
 		title, anchor, data string,
 		tags []string,
 		categories []*models.Category,
 	) (*models.KnowledgeItem, error)
 
-	DeleteItem(itemID int64) error
+	DeleteItem(owner string, itemID int64) error
+
+	GetItem(owner string, itemID int64) (*models.KnowledgeItem, error)
+
+	FindItemByTitle(owner, title string) (*models.KnowledgeItem, error)
+
+	ListItems(owner string, categories []*models.Category) ([]*models.KnowledgeItem, error)
+
+	SetLatestMark(owner, learner string, itemID, mark int64) (*models.KnowledgeItem, error)
 
-	SetLatestMark(itemID, mark int64) (*models.KnowledgeItem, error)
+	ReplayMarks(learner string, itemID int64, reviews []*models.Review) (*models.KnowledgeItem, error)
+
+	ValidateReviewState(score, lastMark int64) error
+
+	RestoreReviewState(
+		learner string,
+		itemID, score, lastMark int64,
+		lastCheckAt *time.Time,
+	) (*models.KnowledgeItem, error)
+
+	MergeItems(owner string, targetID, sourceID int64) (*models.KnowledgeItem, error)
 }
 
 // knowledgeItemService is a scope of business rules & actions related to the Knowledge Item.
 type knowledgeItemService struct {
-	repo repositories.KnowledgeItemsRepo
+	repo         repositories.KnowledgeItemsRepo
+	progressRepo repositories.LearnerProgressRepo
+	normalizer   *tags.Normalizer
+}
+
+// NewKnowledgeItemService function makes new instance of KnowledgeItemService
+// which normalizes tags with tags.DefaultNormalizer.
+func NewKnowledgeItemService(
+	repo repositories.KnowledgeItemsRepo,
+	progressRepo repositories.LearnerProgressRepo,
+) KnowledgeItemService {
+	return NewKnowledgeItemServiceWithNormalizer(repo, progressRepo, tags.DefaultNormalizer())
 }
 
-// NewKnowledgeItemService function makes new instance of KnowledgeItemService.
-func NewKnowledgeItemService(repo repositories.KnowledgeItemsRepo) KnowledgeItemService {
+// NewKnowledgeItemServiceWithNormalizer function makes new instance of KnowledgeItemService
+// which normalizes tags with the given normalizer.
+func NewKnowledgeItemServiceWithNormalizer(
+	repo repositories.KnowledgeItemsRepo,
+	progressRepo repositories.LearnerProgressRepo,
+	normalizer *tags.Normalizer,
+) KnowledgeItemService {
 	return &knowledgeItemService{
-		repo: repo,
+		repo:         repo,
+		progressRepo: progressRepo,
+		normalizer:   normalizer,
 	}
 }
 
-// NewItem function builds new models.KnowledgeItem instance.
+// NewItem function builds new models.KnowledgeItem instance of the owner.
 func (s *knowledgeItemService) NewItem(
-	title, anchor, data string,
+	owner, title, anchor, data string,
 	tags []string,
 	categories []*models.Category,
 ) (*models.KnowledgeItem, error) {
-	err := s.validate(title, anchor, data, tags, categories)
+	tags = s.normalizer.NormalizeAll(tags)
+
+	err := s.validate(owner, title, anchor, data, tags, categories)
 	if err != nil {
 		return nil, err //TODO:
 	}
@@ -67,6 +112,7 @@ func (s *knowledgeItemService) NewItem(
 	createdAt := time.Now()
 
 	item := &models.KnowledgeItem{
+		Owner:      owner,
 		Title:      title,
 		Anchor:     anchor,
 		Data:       data,
@@ -83,19 +129,58 @@ func (s *knowledgeItemService) NewItem(
 	return item, nil
 }
 
+// NewItems function stores a batch of new models.KnowledgeItem of the owner at once.
+// Nothing is stored if any of the items violates the rules.
+func (s *knowledgeItemService) NewItems(owner string, items []*models.KnowledgeItem) ([]*models.KnowledgeItem, error) {
+	for _, item := range items {
+		item.Tags = s.normalizer.NormalizeAll(item.Tags)
+
+		err := s.validate(owner, item.Title, item.Anchor, item.Data, item.Tags, item.Categories)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	createdAt := time.Now()
+	for _, item := range items {
+		item.Owner = owner
+		item.CreatedAt = &createdAt
+	}
+
+	ids, err := s.repo.CreateBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		item.ID = ids[i]
+	}
+
+	return items, nil
+}
+
+// ValidateItem function checks content of the models.KnowledgeItem without storing it.
+// Categories aren't checked since they might not exist yet.
+func (s *knowledgeItemService) ValidateItem(title, anchor, data string, tags []string) error {
+	return s.validateContent(title, anchor, data, s.normalizer.NormalizeAll(tags))
+}
+
 // UpdateItem function updates existing models.KnowledgeItem instance.
 func (s *knowledgeItemService) UpdateItem(
+	owner string,
 	itemID int64,
 	title, anchor, data string,
 	tags []string,
 	categories []*models.Category,
 ) (*models.KnowledgeItem, error) {
-	item, err := s.repo.FindByID(itemID)
+	item, err := s.GetItem(owner, itemID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.validate(title, anchor, data, tags, categories)
+	tags = s.normalizer.NormalizeAll(tags)
+
+	err = s.validate(owner, title, anchor, data, tags, categories)
 	if err != nil {
 		return nil, err //TODO:
 	}
@@ -117,45 +202,120 @@ func (s *knowledgeItemService) UpdateItem(
 	return item, nil
 }
 
-// DeleteItem function deletes existing models.KnowledgeItem.
-func (s *knowledgeItemService) DeleteItem(itemID int64) error {
-	item, err := s.repo.FindByID(itemID)
+// DeleteItem function deletes existing models.KnowledgeItem along with the progress of all its learners.
+func (s *knowledgeItemService) DeleteItem(owner string, itemID int64) error {
+	item, err := s.GetItem(owner, itemID)
 	if err != nil {
 		return err
 	}
 
-	return s.repo.Delete(item)
+	if err = s.repo.Delete(item); err != nil {
+		return err
+	}
+
+	return s.progressRepo.DeleteByItemID(item.ID)
 }
 
-func (s *knowledgeItemService) validate(
-	title, anchor, data string,
-	tags []string,
-	categories []*models.Category,
-) error {
-	if len(title) <= minTitleLength {
-		return errors.New("title is too short")
+// GetItem function returns existing models.KnowledgeItem with the review state of the owner,
+// items of other users are ErrForbidden.
+func (s *knowledgeItemService) GetItem(owner string, itemID int64) (*models.KnowledgeItem, error) {
+	return s.learnerItem(owner, owner, itemID)
+}
+
+// learnerItem function returns existing models.KnowledgeItem of the owner with the review state of the learner,
+// items of other owners are ErrForbidden.
+func (s *knowledgeItemService) learnerItem(owner, learner string, itemID int64) (*models.KnowledgeItem, error) {
+	item, err := s.repo.FindByID(itemID)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(anchor) <= minAnchorLength {
-		return errors.New("anchor is too short")
+	if item.Owner != owner {
+		return nil, ErrForbidden
 	}
 
-	if len(data) <= minDataLength {
-		return errors.New("data is too short")
+	progress, err := s.progressRepo.Find(learner, item.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, tag := range tags {
-		if len(tag) <= minTagLength {
-			return errors.New("tag is too short")
+	applyProgress(item, progress)
+
+	return item, nil
+}
+
+// FindItemByTitle function returns models.KnowledgeItem of the owner with the given title
+// or nil if there is no such item.
+func (s *knowledgeItemService) FindItemByTitle(owner, title string) (*models.KnowledgeItem, error) {
+	item, err := s.repo.FindByTitle(owner, title)
+	if err != nil || item == nil {
+		return nil, err
+	}
+
+	progress, err := s.progressRepo.Find(owner, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyProgress(item, progress)
+
+	return item, nil
+}
+
+// ListItems function returns models.KnowledgeItem which belong to any of the given categories.
+// All knowledge items of the owner are returned when no categories are given.
+func (s *knowledgeItemService) ListItems(owner string, categories []*models.Category) ([]*models.KnowledgeItem, error) {
+	items, err := s.listItems(owner, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	return withProgress(s.progressRepo, owner, items)
+}
+
+func (s *knowledgeItemService) listItems(owner string, categories []*models.Category) ([]*models.KnowledgeItem, error) {
+	if len(categories) == 0 {
+		return s.repo.FindByOwner(owner)
+	}
+
+	categoryIDs := make([]int64, 0, len(categories))
+	for _, category := range categories {
+		if category == nil {
+			return nil, newValidationError("category cannot be empty")
+		}
+		if category.Owner != owner {
+			return nil, ErrForbidden
 		}
+
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+
+	return s.repo.FindByCategoryIDs(categoryIDs)
+}
+
+func (s *knowledgeItemService) validate(
+	owner, title, anchor, data string,
+	tags []string,
+	categories []*models.Category,
+) error {
+	if owner == "" {
+		return newValidationError("owner cannot be empty")
+	}
+
+	err := s.validateContent(title, anchor, data, tags)
+	if err != nil {
+		return err
 	}
 
 	for _, category := range categories {
 		if category == nil {
-			return errors.New("category cannot be empty")
+			return newValidationError("category cannot be empty")
 		}
 		if category.ID == 0 {
-			return errors.New("category doesn't exist")
+			return newValidationError("category doesn't exist")
+		}
+		if category.Owner != owner {
+			return ErrForbidden
 		}
 	}
 
@@ -164,20 +324,47 @@ func (s *knowledgeItemService) validate(
 	return nil
 }
 
+func (s *knowledgeItemService) validateContent(title, anchor, data string, tags []string) error {
+	if len(title) <= minTitleLength {
+		return newValidationError("title is too short")
+	}
+
+	if len(anchor) <= minAnchorLength {
+		return newValidationError("anchor is too short")
+	}
+
+	if len(data) <= minDataLength {
+		return newValidationError("data is too short")
+	}
+
+	for _, tag := range tags {
+		if len(tag) <= minTagLength {
+			return newValidationError("tag is too short")
+		}
+	}
+
+	return nil
+}
+
 func (s *knowledgeItemService) validateMark(mark int64) error {
 	if mark < minMark {
-		return fmt.Errorf("mark cannot be less than %d", minMark)
+		return newValidationError("mark cannot be less than %d", minMark)
 	}
 	if mark > maxMark {
-		return fmt.Errorf("mark cannot be more than %d", maxMark)
+		return newValidationError("mark cannot be more than %d", maxMark)
 	}
 
 	return nil
 }
 
-// SetLatestMark sets last testing result to the knowledge item and updates score.
-func (s *knowledgeItemService) SetLatestMark(itemID, mark int64) (*models.KnowledgeItem, error) {
-	item, err := s.repo.FindByID(itemID)
+// SetLatestMark sets last testing result of the learner to the knowledge item and updates score.
+// Only the progress of the learner is changed, the item of the owner is shared by all its learners,
+// like the members of the workspace curating it.
+func (s *knowledgeItemService) SetLatestMark(
+	owner, learner string,
+	itemID, mark int64,
+) (*models.KnowledgeItem, error) {
+	item, err := s.learnerItem(owner, learner, itemID)
 	if err != nil {
 		return nil, err
 	}
@@ -186,8 +373,160 @@ func (s *knowledgeItemService) SetLatestMark(itemID, mark int64) (*models.Knowle
 		return nil, err
 	}
 
-	lastCheckAt := time.Now()
-	item.LastCheckAt = &lastCheckAt
+	s.applyMark(item, mark, time.Now())
+
+	err = s.progressRepo.Save(progressOf(learner, item))
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ReplayMarks applies historical testing results to the knowledge item in the given order.
+// It is used to carry over review history from other systems, so LastCheckAt is taken from the reviews.
+func (s *knowledgeItemService) ReplayMarks(
+	learner string,
+	itemID int64,
+	reviews []*models.Review,
+) (*models.KnowledgeItem, error) {
+	item, err := s.GetItem(learner, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, review := range reviews {
+		if err = s.validateMark(review.Mark); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, review := range reviews {
+		s.applyMark(item, review.Mark, review.CheckedAt)
+	}
+
+	err = s.progressRepo.Save(progressOf(learner, item))
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ValidateReviewState function checks previously exported score and last testing result without storing them.
+func (s *knowledgeItemService) ValidateReviewState(score, lastMark int64) error {
+	if score < minScore || score > maxScore {
+		return newValidationError("score must be between %d and %d", minScore, maxScore)
+	}
+
+	return s.validateMark(lastMark)
+}
+
+// RestoreReviewState sets previously exported score and last testing result of the learner to the knowledge item.
+func (s *knowledgeItemService) RestoreReviewState(
+	learner string,
+	itemID, score, lastMark int64,
+	lastCheckAt *time.Time,
+) (*models.KnowledgeItem, error) {
+	item, err := s.GetItem(learner, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ValidateReviewState(score, lastMark); err != nil {
+		return nil, err
+	}
+
+	item.Score = score
+	item.LastMark = lastMark
+	item.LastCheckAt = lastCheckAt
+
+	err = s.progressRepo.Save(progressOf(learner, item))
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// MergeItems combines the source knowledge item into the target one and deletes the source.
+// The target keeps its content and gets normalized tags and categories of both items. The review state of the owner
+// is taken from the item checked last, since it tells the most about the current knowledge, and the creation time
+// is the earliest one. Progress of other learners of the source is dropped along with it.
+func (s *knowledgeItemService) MergeItems(owner string, targetID, sourceID int64) (*models.KnowledgeItem, error) {
+	if targetID == sourceID {
+		return nil, newValidationError("item cannot be merged into itself")
+	}
+
+	target, err := s.GetItem(owner, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.GetItem(owner, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target.Tags = s.normalizer.NormalizeAll(append(target.Tags, source.Tags...))
+
+	for _, category := range source.Categories {
+		if !slices.ContainsFunc(target.Categories, func(c *models.Category) bool { return c.ID == category.ID }) {
+			target.Categories = append(target.Categories, category)
+		}
+	}
+
+	if checkedLater(source, target) {
+		target.Score = source.Score
+		target.LastMark = source.LastMark
+		target.LastCheckAt = source.LastCheckAt
+	}
+	target.Reviews += source.Reviews
+
+	if source.CreatedAt != nil && (target.CreatedAt == nil || source.CreatedAt.Before(*target.CreatedAt)) {
+		target.CreatedAt = source.CreatedAt
+	}
+
+	updatedAt := time.Now()
+	target.UpdatedAt = &updatedAt
+
+	if err = s.repo.Save(target); err != nil {
+		return nil, err
+	}
+
+	if err = s.progressRepo.Save(progressOf(owner, target)); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.Delete(source); err != nil {
+		return nil, err
+	}
+
+	if err = s.progressRepo.DeleteByItemID(source.ID); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// checkedLater function reports whether item a has more recent review than item b,
+// equally recent reviews are told apart by the score.
+func checkedLater(a, b *models.KnowledgeItem) bool {
+	switch {
+	case a.LastCheckAt == nil:
+		return false
+	case b.LastCheckAt == nil:
+		return true
+	case !a.LastCheckAt.Equal(*b.LastCheckAt):
+		return a.LastCheckAt.After(*b.LastCheckAt)
+	default:
+		return a.Score > b.Score
+	}
+}
+
+// applyMark updates score of the knowledge item according to the testing result.
+func (s *knowledgeItemService) applyMark(item *models.KnowledgeItem, mark int64, checkedAt time.Time) {
+	item.LastCheckAt = &checkedAt
 
 	// wipe Score in case of worst mark.
 	// means knowledge item has been completely forgotten.
@@ -213,11 +552,5 @@ func (s *knowledgeItemService) SetLatestMark(itemID, mark int64) (*models.Knowle
 	}
 
 	item.LastMark = mark
-
-	err = s.repo.Save(item)
-	if err != nil {
-		return nil, err
-	}
-
-	return item, nil
+	item.Reviews++
 }
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
 	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/tags"
 	"github.com/96solutions/neurography/knowledgebase/commands/mock"
 	"go.uber.org/mock/gomock"
 )
@@ -16,24 +18,28 @@ func TestKnowledgeItemService_NewItem_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 
 	var expectedItemID int64 = 11
 	expectedTitle := "expectedTitle"
 	expectedAnchor := "expectedAnchor"
 	expectedData := "expectedData and something else"
-	expectedTags := []string{"expectedTag1", "expectedTag2", "expectedTag3"}
+	expectedTags := []string{"expected-tag1", "expected-tag2", "expected-tag3"}
 	expectedCategories := []*models.Category{
 		&models.Category{
-			ID:   1,
-			Name: "expectedCategory1",
+			Owner: "alice",
+			ID:    1,
+			Name:  "expectedCategory1",
 		},
 		&models.Category{
-			ID:   2,
-			Name: "expectedCategory2",
+			Owner: "alice",
+			ID:    2,
+			Name:  "expectedCategory2",
 		},
 		&models.Category{
-			ID:   3,
-			Name: "expectedCategory3",
+			Owner: "alice",
+			ID:    3,
+			Name:  "expectedCategory3",
 		},
 	}
 
@@ -70,8 +76,8 @@ func TestKnowledgeItemService_NewItem_Success(t *testing.T) {
 		return expectedItemID, nil
 	})
 
-	s := services.NewKnowledgeItemService(repo)
-	item, err := s.NewItem(expectedTitle, expectedAnchor, expectedData, expectedTags, expectedCategories)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	item, err := s.NewItem("alice", expectedTitle, expectedAnchor, expectedData, expectedTags, expectedCategories)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -100,24 +106,28 @@ func TestKnowledgeItemService_NewItem_RepoCreateError(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 
 	var expectedItemID int64 = 11
 	expectedTitle := "expectedTitle"
 	expectedAnchor := "expectedAnchor"
 	expectedData := "expectedData and something else"
-	expectedTags := []string{"expectedTag1", "expectedTag2", "expectedTag3"}
+	expectedTags := []string{"expected-tag1", "expected-tag2", "expected-tag3"}
 	expectedCategories := []*models.Category{
 		&models.Category{
-			ID:   1,
-			Name: "expectedCategory1",
+			Owner: "alice",
+			ID:    1,
+			Name:  "expectedCategory1",
 		},
 		&models.Category{
-			ID:   2,
-			Name: "expectedCategory2",
+			Owner: "alice",
+			ID:    2,
+			Name:  "expectedCategory2",
 		},
 		&models.Category{
-			ID:   3,
-			Name: "expectedCategory3",
+			Owner: "alice",
+			ID:    3,
+			Name:  "expectedCategory3",
 		},
 	}
 	expectedError := errors.New("expected error")
@@ -155,8 +165,8 @@ func TestKnowledgeItemService_NewItem_RepoCreateError(t *testing.T) {
 		return expectedItemID, expectedError
 	})
 
-	s := services.NewKnowledgeItemService(repo)
-	_, err := s.NewItem(expectedTitle, expectedAnchor, expectedData, expectedTags, expectedCategories)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.NewItem("alice", expectedTitle, expectedAnchor, expectedData, expectedTags, expectedCategories)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -170,6 +180,7 @@ func TestKnowledgeItemService_NewItem_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 
 	testCases := []struct {
 		name               string
@@ -239,8 +250,9 @@ func TestKnowledgeItemService_NewItem_ValidationError(t *testing.T) {
 			expectedData:   "expectedData and something",
 			expectedTags:   []string{"expectedTag1", "expectedTag2", "expectedTag3"},
 			expectedCategories: []*models.Category{&models.Category{
-				ID:   0, // not exists
-				Name: "Category Name",
+				Owner: "alice",
+				ID:    0, // not exists
+				Name:  "Category Name",
 			}},
 			expectedError: errors.New("category doesn't exist"),
 		},
@@ -248,8 +260,9 @@ func TestKnowledgeItemService_NewItem_ValidationError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			s := services.NewKnowledgeItemService(repo)
-			_, err := s.NewItem(tc.expectedTitle, tc.expectedAnchor, tc.expectedData, tc.expectedTags, tc.expectedCategories)
+			s := services.NewKnowledgeItemService(repo, progressRepo)
+			_, err := s.NewItem("alice", tc.expectedTitle, tc.expectedAnchor, tc.expectedData,
+				tc.expectedTags, tc.expectedCategories)
 			if err.Error() != tc.expectedError.Error() {
 				t.Fatalf("expected error: %s, got: %s", tc.expectedError.Error(), err.Error())
 			}
@@ -263,6 +276,7 @@ func TestKnowledgeItemService_UpdateItem_Success(t *testing.T) {
 
 	var expectedItemID int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -274,18 +288,21 @@ func TestKnowledgeItemService_UpdateItem_Success(t *testing.T) {
 	expectedData := "expected data and something more"
 	categories := []*models.Category{
 		&models.Category{
-			ID:   1,
-			Name: "Category Name1",
+			Owner: "alice",
+			ID:    1,
+			Name:  "Category Name1",
 		},
 	}
 	tags := []string{"tag1", "tag2", "tag3"}
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
 	repo.EXPECT().Save(item).Return(nil)
 
-	s := services.NewKnowledgeItemService(repo)
-	result, err := s.UpdateItem(expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	result, err := s.UpdateItem("alice", expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -331,6 +348,7 @@ func TestKnowledgeItemService_UpdateItem_RepoError(t *testing.T) {
 
 	var expectedItemID int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -342,19 +360,22 @@ func TestKnowledgeItemService_UpdateItem_RepoError(t *testing.T) {
 	expectedData := "expected data and something more"
 	categories := []*models.Category{
 		&models.Category{
-			ID:   1,
-			Name: "Category Name1",
+			Owner: "alice",
+			ID:    1,
+			Name:  "Category Name1",
 		},
 	}
 	tags := []string{"tag1", "tag2", "tag3"}
 	expectedError := errors.New("expected error")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
 	repo.EXPECT().Save(item).Return(expectedError)
 
-	s := services.NewKnowledgeItemService(repo)
-	_, err := s.UpdateItem(expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.UpdateItem("alice", expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -369,6 +390,7 @@ func TestKnowledgeItemService_UpdateItem_RepoNotFoundError(t *testing.T) {
 
 	var expectedItemID int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -380,18 +402,20 @@ func TestKnowledgeItemService_UpdateItem_RepoNotFoundError(t *testing.T) {
 	expectedData := "expected data and something more"
 	categories := []*models.Category{
 		&models.Category{
-			ID:   1,
-			Name: "Category Name1",
+			Owner: "alice",
+			ID:    1,
+			Name:  "Category Name1",
 		},
 	}
 	tags := []string{"tag1", "tag2", "tag3"}
 	expectedError := errors.New("expected not found error")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, expectedError)
 
-	s := services.NewKnowledgeItemService(repo)
-	_, err := s.UpdateItem(expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.UpdateItem("alice", expectedItemID, expectedTitle, expectedAnchor, expectedData, tags, categories)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -405,6 +429,7 @@ func TestKnowledgeItemService_UpdateItem_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
 		Data:   "Test Data and Something more",
@@ -412,6 +437,7 @@ func TestKnowledgeItemService_UpdateItem_ValidationError(t *testing.T) {
 	}
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 
 	testCases := []struct {
 		name               string
@@ -488,8 +514,9 @@ func TestKnowledgeItemService_UpdateItem_ValidationError(t *testing.T) {
 			expectedData:   "expectedData and something",
 			expectedTags:   []string{"expectedTag1", "expectedTag2", "expectedTag3"},
 			expectedCategories: []*models.Category{&models.Category{
-				ID:   0, // not exists
-				Name: "Category Name",
+				Owner: "alice",
+				ID:    0, // not exists
+				Name:  "Category Name",
 			}},
 			expectedError: errors.New("category doesn't exist"),
 		},
@@ -498,8 +525,10 @@ func TestKnowledgeItemService_UpdateItem_ValidationError(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			repo.EXPECT().FindByID(tc.expectedItemID).Return(tc.item, nil)
-			s := services.NewKnowledgeItemService(repo)
+			progressRepo.EXPECT().Find("alice", tc.item.ID).Return(nil, nil)
+			s := services.NewKnowledgeItemService(repo, progressRepo)
 			_, err := s.UpdateItem(
+				"alice",
 				tc.expectedItemID, tc.expectedTitle, tc.expectedAnchor,
 				tc.expectedData, tc.expectedTags, tc.expectedCategories)
 			if err.Error() != tc.expectedError.Error() {
@@ -515,6 +544,7 @@ func TestKnowledgeItemService_DeleteItem_Success(t *testing.T) {
 
 	var expectedItemID int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -523,11 +553,14 @@ func TestKnowledgeItemService_DeleteItem_Success(t *testing.T) {
 	}
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
 	repo.EXPECT().Delete(item).Return(nil)
+	progressRepo.EXPECT().DeleteByItemID(expectedItemID).Return(nil)
 
-	s := services.NewKnowledgeItemService(repo)
-	err := s.DeleteItem(expectedItemID)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	err := s.DeleteItem("alice", expectedItemID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -541,10 +574,11 @@ func TestKnowledgeItemService_DeleteItem_ItemNotExists(t *testing.T) {
 	expectedError := errors.New("not found error")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(nil, expectedError)
 
-	s := services.NewKnowledgeItemService(repo)
-	err := s.DeleteItem(expectedItemID)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	err := s.DeleteItem("alice", expectedItemID)
 	if err == nil {
 		t.Fatal(err)
 	}
@@ -559,6 +593,7 @@ func TestKnowledgeItemService_DeleteItem_RepoError(t *testing.T) {
 
 	var expectedItemID int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -568,11 +603,13 @@ func TestKnowledgeItemService_DeleteItem_RepoError(t *testing.T) {
 	expectedError := errors.New("expected error")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
 	repo.EXPECT().Delete(item).Return(expectedError)
 
-	s := services.NewKnowledgeItemService(repo)
-	err := s.DeleteItem(expectedItemID)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	err := s.DeleteItem("alice", expectedItemID)
 	if err == nil {
 		t.Fatal(err)
 	}
@@ -586,6 +623,7 @@ func TestKnowledgeItemService_SetLatestMark(t *testing.T) {
 	defer ctrl.Finish()
 
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     0,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -594,7 +632,8 @@ func TestKnowledgeItemService_SetLatestMark(t *testing.T) {
 	}
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
-	s := services.NewKnowledgeItemService(repo)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
 
 	testCases := []struct {
 		name          string
@@ -711,15 +750,25 @@ func TestKnowledgeItemService_SetLatestMark(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.item.ID = tc.itemID
-			tc.item.Score = tc.exScore
-			tc.item.LastMark = tc.exMark
 
 			repo.EXPECT().FindByID(tc.itemID).Return(tc.item, nil)
+			progressRepo.EXPECT().Find("alice", tc.itemID).Return(&models.LearnerProgress{
+				ItemID:   tc.itemID,
+				Learner:  "alice",
+				Score:    tc.exScore,
+				LastMark: tc.exMark,
+			}, nil)
 			if tc.expectedError == nil {
-				repo.EXPECT().Save(tc.item).Return(nil)
+				progressRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(p *models.LearnerProgress) error {
+					if p.ItemID != tc.itemID || p.Learner != "alice" || p.Score != tc.expectedScore {
+						t.Errorf("unexpected progress %+v", p)
+					}
+
+					return nil
+				})
 			}
 
-			resultItem, err := s.SetLatestMark(tc.itemID, tc.mark)
+			resultItem, err := s.SetLatestMark("alice", "alice", tc.itemID, tc.mark)
 			// error expected
 			if tc.expectedError != nil {
 				if err.Error() != tc.expectedError.Error() {
@@ -748,6 +797,7 @@ func TestKnowledgeItemService_SetLatestMark_NotFoundError(t *testing.T) {
 
 	var expectedItemID int64 = 51
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -757,10 +807,11 @@ func TestKnowledgeItemService_SetLatestMark_NotFoundError(t *testing.T) {
 	expectedError := errors.New("item not found")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, expectedError)
 
-	s := services.NewKnowledgeItemService(repo)
-	_, err := s.SetLatestMark(expectedItemID, 5)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.SetLatestMark("alice", "alice", expectedItemID, 5)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -776,6 +827,7 @@ func TestKnowledgeItemService_SetLatestMark_SaveError(t *testing.T) {
 	var expectedItemID int64 = 51
 	var expectedMark int64 = 5
 	item := &models.KnowledgeItem{
+		Owner:  "alice",
 		ID:     expectedItemID,
 		Title:  "Test Item",
 		Anchor: "Test Anchor",
@@ -785,20 +837,121 @@ func TestKnowledgeItemService_SetLatestMark_SaveError(t *testing.T) {
 	expectedError := errors.New("item not saved")
 
 	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
 	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
-	repo.EXPECT().Save(item).DoAndReturn(func(i *models.KnowledgeItem) error {
-		if i.ID != expectedItemID {
-			t.Fatalf("expected ID: %d, got: %d", expectedItemID, i.ID)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
+	progressRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(p *models.LearnerProgress) error {
+		if p.ItemID != expectedItemID {
+			t.Fatalf("expected ID: %d, got: %d", expectedItemID, p.ItemID)
 		}
-		if i.LastMark != expectedMark {
-			t.Fatalf("expected LastMark: %d, got: %d", expectedMark, i.LastMark)
+		if p.LastMark != expectedMark {
+			t.Fatalf("expected LastMark: %d, got: %d", expectedMark, p.LastMark)
 		}
 
 		return expectedError
 	})
 
-	s := services.NewKnowledgeItemService(repo)
-	_, err := s.SetLatestMark(expectedItemID, expectedMark)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.SetLatestMark("alice", "alice", expectedItemID, expectedMark)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expectedError.Error() {
+		t.Fatalf("expected error: %s, got: %s", expectedError.Error(), err.Error())
+	}
+}
+
+func TestKnowledgeItemService_NewItem_ValidationErrorType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.NewItem("alice", "e", "expectedAnchor", "expectedData and something", nil, nil)
+
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+}
+
+func TestKnowledgeItemService_ReplayMarks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var expectedItemID int64 = 51
+	item := &models.KnowledgeItem{
+		Owner:  "alice",
+		ID:     expectedItemID,
+		Title:  "Test Item",
+		Anchor: "Test Anchor",
+		Data:   "Test Data and Something more",
+	}
+
+	firstCheckAt := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	lastCheckAt := time.Date(2023, 1, 5, 10, 0, 0, 0, time.UTC)
+	reviews := []*models.Review{
+		{Mark: 6, CheckedAt: firstCheckAt},
+		{Mark: 8, CheckedAt: firstCheckAt.Add(24 * time.Hour)},
+		{Mark: 4, CheckedAt: lastCheckAt},
+	}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
+	progressRepo.EXPECT().Save(&models.LearnerProgress{
+		ItemID:      expectedItemID,
+		Learner:     "alice",
+		Score:       10,
+		LastMark:    4,
+		LastCheckAt: &lastCheckAt,
+		Reviews:     3,
+	}).Return(nil)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	resultItem, err := s.ReplayMarks("alice", expectedItemID, reviews)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 6 + 8 + (4 - 8)
+	var expectedScore int64 = 10
+	if resultItem.Score != expectedScore {
+		t.Fatalf("expected score: %d, got: %d", expectedScore, resultItem.Score)
+	}
+	if resultItem.LastMark != 4 {
+		t.Fatalf("expected mark: %d, got: %d", 4, resultItem.LastMark)
+	}
+	if resultItem.LastCheckAt == nil || !resultItem.LastCheckAt.Equal(lastCheckAt) {
+		t.Fatalf("expected last check at: %s, got: %v", lastCheckAt, resultItem.LastCheckAt)
+	}
+}
+
+func TestKnowledgeItemService_ReplayMarks_InvalidMark(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var expectedItemID int64 = 51
+	item := &models.KnowledgeItem{
+		Owner: "alice",
+		ID:    expectedItemID,
+	}
+	expectedError := fmt.Errorf("mark cannot be more than %d", 10)
+
+	// progress isn't saved, so the score stays untouched.
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(&models.LearnerProgress{Score: 25}, nil)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.ReplayMarks("alice", expectedItemID, []*models.Review{
+		{Mark: 5, CheckedAt: time.Now()},
+		{Mark: 11, CheckedAt: time.Now()},
+	})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -806,3 +959,441 @@ func TestKnowledgeItemService_SetLatestMark_SaveError(t *testing.T) {
 		t.Fatalf("expected error: %s, got: %s", expectedError.Error(), err.Error())
 	}
 }
+
+func TestKnowledgeItemService_ListItems_All(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedItems := []*models.KnowledgeItem{{ID: 1}, {ID: 2}}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(expectedItems, nil)
+	progressRepo.EXPECT().FindByLearner("alice").Return([]*models.LearnerProgress{
+		{ItemID: 2, Learner: "alice", Score: 30, LastMark: 6},
+	}, nil)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	items, err := s.ListItems("alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != len(expectedItems) {
+		t.Fatalf("expected %d items, got %d", len(expectedItems), len(items))
+	}
+	if items[0].Score != 0 || items[1].Score != 30 || items[1].LastMark != 6 {
+		t.Errorf("expected review state of the learner, got %+v", items)
+	}
+}
+
+func TestKnowledgeItemService_ListItems_ByCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	categories := []*models.Category{{ID: 3, Owner: "alice", Name: "first"}, {ID: 5, Owner: "alice", Name: "second"}}
+	expectedItems := []*models.KnowledgeItem{{ID: 1, Categories: categories[:1]}}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().FindByCategoryIDs([]int64{3, 5}).Return(expectedItems, nil)
+	progressRepo.EXPECT().FindByLearner("alice").Return(nil, nil)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	items, err := s.ListItems("alice", categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0] != expectedItems[0] {
+		t.Fatalf("expected items %+v, got %+v", expectedItems, items)
+	}
+}
+
+func TestKnowledgeItemService_ListItems_EmptyCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.ListItems("alice", []*models.Category{nil})
+	if err == nil || err.Error() != "category cannot be empty" {
+		t.Fatalf("expected error: %s, got: %v", "category cannot be empty", err)
+	}
+}
+
+func TestKnowledgeItemService_NewItems_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	category := &models.Category{ID: 3, Owner: "alice", Name: "expectedCategory"}
+	items := []*models.KnowledgeItem{
+		{Title: "first title", Anchor: "first anchor", Data: "first data and something"},
+		{Title: "second title", Anchor: "second anchor", Data: "second data and something", Categories: []*models.Category{category}},
+	}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().CreateBatch(items).DoAndReturn(func(batch []*models.KnowledgeItem) ([]int64, error) {
+		for _, item := range batch {
+			if item.CreatedAt == nil {
+				t.Errorf("expected CreatedAt to be set for %s", item.Title)
+			}
+		}
+
+		return []int64{11, 12}, nil
+	})
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	result, err := s.NewItems("alice", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[0].ID != 11 || result[1].ID != 12 {
+		t.Errorf("expected IDs 11 and 12, got %d and %d", result[0].ID, result[1].ID)
+	}
+}
+
+func TestKnowledgeItemService_NewItems_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := []*models.KnowledgeItem{
+		{Title: "first title", Anchor: "first anchor", Data: "first data and something"},
+		{Title: "second title", Anchor: "second anchor", Data: "too short"},
+	}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.NewItems("alice", items)
+	if err == nil || err.Error() != "data is too short" {
+		t.Fatalf("expected error: %s, got: %v", "data is too short", err)
+	}
+}
+
+func TestKnowledgeItemService_NewItems_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := []*models.KnowledgeItem{
+		{Title: "first title", Anchor: "first anchor", Data: "first data and something"},
+	}
+	expectedError := errors.New("expected error")
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().CreateBatch(items).Return(nil, expectedError)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	_, err := s.NewItems("alice", items)
+	if !errors.Is(err, expectedError) {
+		t.Fatalf("expected error: %s, got: %v", expectedError, err)
+	}
+}
+
+func TestKnowledgeItemService_ValidateItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewKnowledgeItemService(mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	err := s.ValidateItem("expectedTitle", "expectedAnchor", "expectedData and something", []string{"tag1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.ValidateItem("expectedTitle", "expectedAnchor", "expectedData and something", []string{"e"})
+	if err == nil || err.Error() != "tag is too short" {
+		t.Fatalf("expected error: %s, got: %v", "tag is too short", err)
+	}
+}
+
+func TestKnowledgeItemService_NewItem_NormalizesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+
+	normalizer := tags.NewNormalizer(tags.NFC, tags.FoldCase, tags.DashSpaces, tags.Aliases(map[string]string{
+		"golang": "go",
+	}))
+	s := services.NewKnowledgeItemServiceWithNormalizer(repo, progressRepo, normalizer)
+
+	item, err := s.NewItem("alice", "expectedTitle", "expectedAnchor", "expectedData and something",
+		[]string{" Machine  Learning ", "machine-learning", "GoLang", "go"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(item.Tags) != "[machine-learning go]" {
+		t.Errorf("expected tags [machine-learning go], got %v", item.Tags)
+	}
+}
+
+func TestKnowledgeItemService_RestoreReviewState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var expectedItemID int64 = 51
+	lastCheckAt := time.Date(2023, 1, 5, 10, 0, 0, 0, time.UTC)
+	item := &models.KnowledgeItem{Owner: "alice", ID: expectedItemID}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	repo.EXPECT().FindByID(expectedItemID).Return(item, nil)
+	progressRepo.EXPECT().Find("alice", expectedItemID).Return(nil, nil)
+	progressRepo.EXPECT().Save(&models.LearnerProgress{
+		ItemID:      expectedItemID,
+		Learner:     "alice",
+		Score:       42,
+		LastMark:    7,
+		LastCheckAt: &lastCheckAt,
+	}).Return(nil)
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+	resultItem, err := s.RestoreReviewState("alice", expectedItemID, 42, 7, &lastCheckAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resultItem.Score != 42 || resultItem.LastMark != 7 || resultItem.LastCheckAt != &lastCheckAt {
+		t.Fatalf("unexpected review state %+v", resultItem)
+	}
+}
+
+func TestKnowledgeItemService_RestoreReviewState_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name          string
+		score         int64
+		mark          int64
+		expectedError string
+	}{
+		{name: "score above max", score: 101, mark: 5, expectedError: "score must be between 0 and 100"},
+		{name: "negative score", score: -1, mark: 5, expectedError: "score must be between 0 and 100"},
+		{name: "mark above max", score: 10, mark: 11, expectedError: "mark cannot be more than 10"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+			progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+			repo.EXPECT().FindByID(int64(1)).Return(&models.KnowledgeItem{Owner: "alice", ID: 1}, nil)
+			progressRepo.EXPECT().Find("alice", int64(1)).Return(nil, nil)
+
+			s := services.NewKnowledgeItemService(repo, progressRepo)
+			_, err := s.RestoreReviewState("alice", 1, tc.score, tc.mark, nil)
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("expected error: %s, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestKnowledgeItemService_MergeItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+
+	golang := &models.Category{ID: 1, Owner: "alice", Name: "Golang"}
+	concurrency := &models.Category{ID: 2, Owner: "alice", Name: "Concurrency"}
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	earliest := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	target := &models.KnowledgeItem{
+		Owner:      "alice",
+		ID:         1,
+		Title:      "What is a goroutine?",
+		Tags:       []string{"go"},
+		Categories: []*models.Category{golang},
+		CreatedAt:  &newer,
+	}
+	source := &models.KnowledgeItem{
+		Owner:      "alice",
+		ID:         2,
+		Title:      "Goroutine",
+		Tags:       []string{"Go", "concurrency"},
+		Categories: []*models.Category{golang, concurrency},
+		CreatedAt:  &earliest,
+	}
+
+	repo.EXPECT().FindByID(int64(1)).Return(target, nil)
+	repo.EXPECT().FindByID(int64(2)).Return(source, nil)
+	progressRepo.EXPECT().Find("alice", int64(1)).
+		Return(&models.LearnerProgress{ItemID: 1, Learner: "alice", Score: 40, LastMark: 8, LastCheckAt: &older}, nil)
+	progressRepo.EXPECT().Find("alice", int64(2)).
+		Return(&models.LearnerProgress{ItemID: 2, Learner: "alice", Score: 12, LastMark: 3, LastCheckAt: &newer}, nil)
+	gomock.InOrder(
+		repo.EXPECT().Save(target).Return(nil),
+		progressRepo.EXPECT().
+			Save(&models.LearnerProgress{ItemID: 1, Learner: "alice", Score: 12, LastMark: 3, LastCheckAt: &newer}).
+			Return(nil),
+		repo.EXPECT().Delete(source).Return(nil),
+		progressRepo.EXPECT().DeleteByItemID(int64(2)).Return(nil),
+	)
+
+	item, err := s.MergeItems("alice", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Title != "What is a goroutine?" {
+		t.Errorf("expected target content to be kept, got %q", item.Title)
+	}
+	if fmt.Sprint(item.Tags) != "[go concurrency]" {
+		t.Errorf("expected tags [go concurrency], got %v", item.Tags)
+	}
+	if len(item.Categories) != 2 || item.Categories[1].ID != concurrency.ID {
+		t.Errorf("expected categories of both items, got %v", item.Categories)
+	}
+	if item.Score != 12 || item.LastMark != 3 || !item.LastCheckAt.Equal(newer) {
+		t.Errorf("expected review state of the item checked last, got %d %d %v",
+			item.Score, item.LastMark, item.LastCheckAt)
+	}
+	if !item.CreatedAt.Equal(earliest) {
+		t.Errorf("expected earliest creation time, got %v", item.CreatedAt)
+	}
+	if item.UpdatedAt == nil {
+		t.Error("expected update time to be set")
+	}
+}
+
+func TestKnowledgeItemService_MergeItems_KeepsTargetReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+
+	checkedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	target := &models.KnowledgeItem{Owner: "alice", ID: 1}
+	source := &models.KnowledgeItem{Owner: "alice", ID: 2}
+
+	repo.EXPECT().FindByID(int64(1)).Return(target, nil)
+	repo.EXPECT().FindByID(int64(2)).Return(source, nil)
+	progressRepo.EXPECT().Find("alice", int64(1)).
+		Return(&models.LearnerProgress{Score: 40, LastMark: 8, LastCheckAt: &checkedAt}, nil)
+	progressRepo.EXPECT().Find("alice", int64(2)).Return(&models.LearnerProgress{Score: 90, LastMark: 10}, nil)
+	repo.EXPECT().Save(target).Return(nil)
+	progressRepo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(source).Return(nil)
+	progressRepo.EXPECT().DeleteByItemID(int64(2)).Return(nil)
+
+	item, err := s.MergeItems("alice", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Score != 40 || item.LastMark != 8 {
+		t.Errorf("expected review state of the checked item, got %d %d", item.Score, item.LastMark)
+	}
+}
+
+func TestKnowledgeItemService_MergeItems_Itself(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewKnowledgeItemService(mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	_, err := s.MergeItems("alice", 1, 1)
+
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestKnowledgeItemService_SetLatestMark_WorkspaceLearner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	item := &models.KnowledgeItem{Owner: "workspace:5", ID: 7, Title: "Raft"}
+	checkedAt := time.Now().Add(-24 * time.Hour)
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByID(item.ID).Return(item, nil)
+
+	// the progress of bob is changed, the item of the workspace isn't.
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	progressRepo.EXPECT().Find("bob", item.ID).Return(&models.LearnerProgress{
+		ItemID: item.ID, Learner: "bob", Score: 6, LastMark: 6, LastCheckAt: &checkedAt, Reviews: 1,
+	}, nil)
+	progressRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(p *models.LearnerProgress) error {
+		if p.Learner != "bob" || p.Score != 14 || p.LastMark != 8 || p.Reviews != 2 {
+			t.Errorf("unexpected progress %+v", p)
+		}
+
+		return nil
+	})
+
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+
+	result, err := s.SetLatestMark("workspace:5", "bob", item.ID, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Reviews != 2 {
+		t.Errorf("expected 2 reviews, got %d", result.Reviews)
+	}
+}
+
+func TestKnowledgeItemService_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+
+	item := &models.KnowledgeItem{Owner: "bob", ID: 7}
+	repo.EXPECT().FindByID(item.ID).Return(item, nil).Times(4)
+
+	if _, err := s.GetItem("alice", item.ID); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("GetItem: expected ErrForbidden, got %v", err)
+	}
+	if err := s.DeleteItem("alice", item.ID); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("DeleteItem: expected ErrForbidden, got %v", err)
+	}
+	if _, err := s.SetLatestMark("alice", "alice", item.ID, 5); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("SetLatestMark: expected ErrForbidden, got %v", err)
+	}
+	if _, err := s.MergeItems("alice", item.ID, 8); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("MergeItems: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestKnowledgeItemService_OtherOwnerCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewKnowledgeItemService(repo, progressRepo)
+
+	category := &models.Category{ID: 3, Owner: "bob", Name: "Golang"}
+
+	_, err := s.NewItem("alice", "expectedTitle", "expectedAnchor", "expectedData and something", nil,
+		[]*models.Category{category})
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("NewItem: expected ErrForbidden, got %v", err)
+	}
+
+	if _, err = s.ListItems("alice", []*models.Category{category}); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("ListItems: expected ErrForbidden, got %v", err)
+	}
+
+	_, err = s.NewItem("", "expectedTitle", "expectedAnchor", "expectedData and something", nil, nil)
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("NewItem: expected validation error for empty owner, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// withProgress function fills review state of the items with the progress of the learner,
+// items never reviewed by the learner get zero state.
+func withProgress(
+	repo repositories.LearnerProgressRepo,
+	learner string,
+	items []*models.KnowledgeItem,
+) ([]*models.KnowledgeItem, error) {
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	progress, err := repo.FindByLearner(learner)
+	if err != nil {
+		return nil, err
+	}
+
+	byItem := make(map[int64]*models.LearnerProgress, len(progress))
+	for _, p := range progress {
+		byItem[p.ItemID] = p
+	}
+
+	for _, item := range items {
+		applyProgress(item, byItem[item.ID])
+	}
+
+	return items, nil
+}
+
+// applyProgress function replaces review state of the item with the progress, nil progress resets it.
+func applyProgress(item *models.KnowledgeItem, progress *models.LearnerProgress) {
+	if progress == nil {
+		progress = &models.LearnerProgress{}
+	}
+
+	item.Score = progress.Score
+	item.LastMark = progress.LastMark
+	item.LastCheckAt = progress.LastCheckAt
+	item.Reviews = progress.Reviews
+}
+
+// progressOf function returns review state of the item as the progress of the learner.
+func progressOf(learner string, item *models.KnowledgeItem) *models.LearnerProgress {
+	return &models.LearnerProgress{
+		ItemID:      item.ID,
+		Learner:     learner,
+		Score:       item.Score,
+		LastMark:    item.LastMark,
+		LastCheckAt: item.LastCheckAt,
+		Reviews:     item.Reviews,
+	}
+}
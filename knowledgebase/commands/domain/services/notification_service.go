@@ -0,0 +1,29 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_notification_service.go -source=notification_service.go NotificationService
+
+// NotificationService represents a service that provides functionality related to the models.Notification.
+type NotificationService interface {
+	ListNotifications(recipient string) ([]*models.Notification, error)
+}
+
+// notificationService is a set of business rules & actions related to the Notification.
+type notificationService struct {
+	repo repositories.NotificationsRepo
+}
+
+// NewNotificationService function makes new instance of NotificationService.
+func NewNotificationService(repo repositories.NotificationsRepo) NotificationService {
+	return &notificationService{repo: repo}
+}
+
+// ListNotifications function returns the notifications of the user, the latest first.
+func (s *notificationService) ListNotifications(recipient string) ([]*models.Notification, error) {
+	return s.repo.FindByRecipient(recipient)
+}
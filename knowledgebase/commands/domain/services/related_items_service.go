@@ -0,0 +1,185 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/similarity"
+)
+
+// Weights of the relations in the score of the related item, the text tells the most about the topic.
+const (
+	textRelationWeight     = 0.6
+	tagRelationWeight      = 0.25
+	categoryRelationWeight = 0.15
+	// maxExplainedTerms is the number of shared terms given in the explanation of the text relation.
+	maxExplainedTerms = 5
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_related_items_service.go -source=related_items_service.go RelatedItemsService
+
+// RelatedItemsService represents a service that suggests models.KnowledgeItem to be linked with each other.
+// Suggestions are calculated locally from the knowledge base, nothing is sent anywhere.
+// Only items of the owner are suggested, items of other users are ErrForbidden.
+type RelatedItemsService interface {
+	SuggestRelated(owner string, itemID int64, limit int) ([]*models.RelatedItem, error)
+}
+
+// relatedItemsService is a set of business rules related to the relations of the Knowledge Items.
+type relatedItemsService struct {
+	repo repositories.KnowledgeItemsRepo
+}
+
+// NewRelatedItemsService function makes new instance of RelatedItemsService.
+func NewRelatedItemsService(repo repositories.KnowledgeItemsRepo) RelatedItemsService {
+	return &relatedItemsService{
+		repo: repo,
+	}
+}
+
+// SuggestRelated function returns up to limit items related to the given one, the most related go first.
+// The score combines TF-IDF cosine similarity of the data with the shared tags and categories,
+// which are weighted by their rarity, so a shared niche tag means more than a shared popular one.
+func (s *relatedItemsService) SuggestRelated(owner string, itemID int64, limit int) ([]*models.RelatedItem, error) {
+	item, err := s.repo.FindByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make(map[int64]string, len(items)+1)
+	tagCounts := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	for _, other := range items {
+		texts[other.ID] = other.Data
+		for _, tag := range tagSet(other) {
+			tagCounts[tag]++
+		}
+		for _, category := range categorySet(other) {
+			categoryCounts[category]++
+		}
+	}
+	texts[item.ID] = item.Data
+
+	corpus := similarity.NewCorpus(texts)
+
+	var related []*models.RelatedItem
+	for _, other := range items {
+		if other.ID == item.ID {
+			continue
+		}
+
+		var explanations []*models.Explanation
+
+		cosine, shared := corpus.Cosine(item.ID, other.ID)
+		if cosine > 0 {
+			explanations = append(explanations, &models.Explanation{
+				Kind:   models.RelationText,
+				Values: shared[:min(len(shared), maxExplainedTerms)],
+				Score:  textRelationWeight * cosine,
+			})
+		}
+
+		if score, shared := overlap(tagSet(item), tagSet(other), tagCounts, len(items)); score > 0 {
+			explanations = append(explanations, &models.Explanation{
+				Kind:   models.RelationTags,
+				Values: shared,
+				Score:  tagRelationWeight * score,
+			})
+		}
+
+		if score, shared := overlap(categorySet(item), categorySet(other), categoryCounts, len(items)); score > 0 {
+			explanations = append(explanations, &models.Explanation{
+				Kind:   models.RelationCategories,
+				Values: shared,
+				Score:  categoryRelationWeight * score,
+			})
+		}
+
+		if len(explanations) == 0 {
+			continue
+		}
+
+		suggestion := &models.RelatedItem{Item: other, Explanations: explanations}
+		for _, explanation := range explanations {
+			suggestion.Score += explanation.Score
+		}
+		related = append(related, suggestion)
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+
+		return related[i].Item.ID < related[j].Item.ID
+	})
+
+	return related[:min(len(related), limit)], nil
+}
+
+// overlap function returns weighted Jaccard similarity of the sets and their shared values.
+// Every value weighs its inverse document frequency among n items, counts tell how many items have the value.
+func overlap(a, b []string, counts map[string]int, n int) (float64, []string) {
+	weight := func(value string) float64 {
+		return math.Log(float64(1+n)/float64(1+counts[value])) + 1
+	}
+
+	var shared []string
+	var common, union float64
+	for _, value := range a {
+		union += weight(value)
+		if slices.Contains(b, value) {
+			shared = append(shared, value)
+			common += weight(value)
+		}
+	}
+	for _, value := range b {
+		if !slices.Contains(a, value) {
+			union += weight(value)
+		}
+	}
+
+	if union == 0 {
+		return 0, nil
+	}
+
+	return common / union, shared
+}
+
+// tagSet function returns distinct lowercased tags of the item.
+func tagSet(item *models.KnowledgeItem) []string {
+	var set []string
+	for _, tag := range item.Tags {
+		if tag = strings.ToLower(tag); !slices.Contains(set, tag) {
+			set = append(set, tag)
+		}
+	}
+
+	return set
+}
+
+// categorySet function returns distinct category names of the item.
+func categorySet(item *models.KnowledgeItem) []string {
+	var set []string
+	for _, category := range item.Categories {
+		if !slices.Contains(set, category.Name) {
+			set = append(set, category.Name)
+		}
+	}
+
+	return set
+}
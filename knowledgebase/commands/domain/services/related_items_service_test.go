@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func relatedTestItems() []*models.KnowledgeItem {
+	golang := &models.Category{ID: 1, Name: "Golang"}
+	databases := &models.Category{ID: 2, Name: "Databases"}
+
+	return []*models.KnowledgeItem{
+		{
+			ID:         1,
+			Owner:      "alice",
+			Data:       "Goroutine is a lightweight thread managed by the Go runtime scheduler.",
+			Tags:       []string{"go", "concurrency"},
+			Categories: []*models.Category{golang},
+		},
+		{
+			ID:         2,
+			Owner:      "alice",
+			Data:       "The runtime scheduler multiplexes goroutines onto operating system threads.",
+			Tags:       []string{"Go", "scheduler"},
+			Categories: []*models.Category{golang},
+		},
+		{
+			ID:         3,
+			Owner:      "alice",
+			Data:       "Mutex protects shared memory from concurrent access.",
+			Tags:       []string{"concurrency"},
+			Categories: []*models.Category{databases},
+		},
+		{
+			ID:         4,
+			Owner:      "alice",
+			Data:       "B-tree keeps the database index sorted.",
+			Tags:       []string{"storage"},
+			Categories: []*models.Category{databases},
+		},
+		{
+			ID:    5,
+			Owner: "alice",
+			Data:  "Channel is a typed conduit.",
+			Tags:  []string{"go"},
+		},
+	}
+}
+
+func TestRelatedItemsService_SuggestRelated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := relatedTestItems()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(1)).Return(items[0], nil)
+	repo.EXPECT().FindByOwner("alice").Return(items, nil)
+
+	s := services.NewRelatedItemsService(repo)
+
+	related, err := s.SuggestRelated("alice", 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(related) != 3 {
+		t.Fatalf("expected 3 suggestions, got %d", len(related))
+	}
+
+	// item 2 shares text, tag and category, item 3 shares the rare tag, item 5 shares the popular tag only.
+	var ids []int64
+	for _, r := range related {
+		ids = append(ids, r.Item.ID)
+	}
+	if ids[0] != 2 || ids[1] != 3 || ids[2] != 5 {
+		t.Errorf("expected suggestions [2 3 5], got %v", ids)
+	}
+
+	best := related[0]
+	if len(best.Explanations) != 3 {
+		t.Fatalf("expected text, tags and categories explanations, got %d", len(best.Explanations))
+	}
+
+	var total float64
+	for _, explanation := range best.Explanations {
+		total += explanation.Score
+	}
+	if total != best.Score || best.Score <= 0 || best.Score > 1 {
+		t.Errorf("expected score to be the sum of explanations, got %v and %v", best.Score, total)
+	}
+
+	kinds := map[string][]string{}
+	for _, explanation := range best.Explanations {
+		kinds[explanation.Kind] = explanation.Values
+	}
+	if len(kinds[models.RelationTags]) != 1 || kinds[models.RelationTags][0] != "go" {
+		t.Errorf("expected shared tag go, got %v", kinds[models.RelationTags])
+	}
+	if len(kinds[models.RelationCategories]) != 1 || kinds[models.RelationCategories][0] != "Golang" {
+		t.Errorf("expected shared category Golang, got %v", kinds[models.RelationCategories])
+	}
+	if len(kinds[models.RelationText]) == 0 {
+		t.Error("expected shared terms of the text")
+	}
+	if related[1].Score <= related[2].Score {
+		t.Errorf("expected rare shared tag to weigh more than popular one")
+	}
+}
+
+func TestRelatedItemsService_SuggestRelated_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(9)).Return(nil, repositories.ErrNotFound)
+
+	s := services.NewRelatedItemsService(repo)
+
+	if _, err := s.SuggestRelated("alice", 9, 3); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRelatedItemsService_SuggestRelated_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(1)).Return(relatedTestItems()[0], nil)
+
+	s := services.NewRelatedItemsService(repo)
+
+	if _, err := s.SuggestRelated("bob", 1, 3); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
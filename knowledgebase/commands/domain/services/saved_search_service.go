@@ -0,0 +1,180 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/filter"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const minSavedSearchNameLength = 1
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_saved_search_service.go -source=saved_search_service.go SavedSearchService
+
+// SavedSearchService represents a service that provides functionality related to the models.SavedSearch.
+// Saved searches are visible to their owners only, searches of other users are ErrForbidden.
+type SavedSearchService interface {
+	NewSavedSearch(owner, name, query string) (*models.SavedSearch, error)
+	UpdateSavedSearch(owner string, searchID int64, name, query string) (*models.SavedSearch, error)
+	DeleteSavedSearch(owner string, searchID int64) error
+	GetSavedSearch(owner string, searchID int64) (*models.SavedSearch, error)
+	ListSavedSearches(owner string) ([]*models.SavedSearch, error)
+	FindItems(owner string, search *models.SavedSearch) ([]*models.KnowledgeItem, error)
+}
+
+// savedSearchService is a set of business rules & actions related to the SavedSearch.
+type savedSearchService struct {
+	repo         repositories.SavedSearchesRepo
+	itemsRepo    repositories.KnowledgeItemsRepo
+	progressRepo repositories.LearnerProgressRepo
+}
+
+// NewSavedSearchService function makes new instance of SavedSearchService.
+func NewSavedSearchService(
+	repo repositories.SavedSearchesRepo,
+	itemsRepo repositories.KnowledgeItemsRepo,
+	progressRepo repositories.LearnerProgressRepo,
+) SavedSearchService {
+	return &savedSearchService{
+		repo:         repo,
+		itemsRepo:    itemsRepo,
+		progressRepo: progressRepo,
+	}
+}
+
+// NewSavedSearch function stores new models.SavedSearch of the owner.
+func (s *savedSearchService) NewSavedSearch(owner, name, query string) (*models.SavedSearch, error) {
+	if err := s.validate(owner, 0, name, query); err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+
+	search := &models.SavedSearch{
+		Owner:     owner,
+		Name:      name,
+		Filter:    query,
+		CreatedAt: &createdAt,
+	}
+
+	var err error
+	search.ID, err = s.repo.Create(search)
+	if err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch function renames existing models.SavedSearch and replaces its filter.
+func (s *savedSearchService) UpdateSavedSearch(
+	owner string,
+	searchID int64,
+	name, query string,
+) (*models.SavedSearch, error) {
+	search, err := s.GetSavedSearch(owner, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.validate(owner, searchID, name, query); err != nil {
+		return nil, err
+	}
+
+	search.Name = name
+	search.Filter = query
+
+	updatedAt := time.Now()
+	search.UpdatedAt = &updatedAt
+
+	if err = s.repo.Save(search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch function deletes existing models.SavedSearch, the items stay untouched.
+func (s *savedSearchService) DeleteSavedSearch(owner string, searchID int64) error {
+	search, err := s.GetSavedSearch(owner, searchID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(search)
+}
+
+// GetSavedSearch function returns existing models.SavedSearch of the owner.
+func (s *savedSearchService) GetSavedSearch(owner string, searchID int64) (*models.SavedSearch, error) {
+	search, err := s.repo.FindByID(searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	return search, nil
+}
+
+// ListSavedSearches function returns all the models.SavedSearch of the owner.
+func (s *savedSearchService) ListSavedSearches(owner string) ([]*models.SavedSearch, error) {
+	return s.repo.FindByOwner(owner)
+}
+
+// FindItems function returns models.KnowledgeItem of the owner matching the filter of the search at the moment.
+// The owner is the search owner for the personal knowledge base or the workspace the search is run in.
+// Review state of the items is the progress of the search owner, so it is matched by the filter as well.
+func (s *savedSearchService) FindItems(owner string, search *models.SavedSearch) ([]*models.KnowledgeItem, error) {
+	expr, err := filter.Parse(search.Filter, time.Now())
+	if err != nil {
+		return nil, newValidationError("filter is invalid: %s", err)
+	}
+
+	items, err := s.itemsRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if items, err = withProgress(s.progressRepo, search.Owner, items); err != nil {
+		return nil, err
+	}
+
+	var matched []*models.KnowledgeItem
+	for _, item := range items {
+		if expr.Match(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched, nil
+}
+
+// validate function checks the search, name has to be unique among the searches of the owner.
+func (s *savedSearchService) validate(owner string, searchID int64, name, query string) error {
+	if owner == "" {
+		return newValidationError("owner cannot be empty")
+	}
+
+	if len(name) <= minSavedSearchNameLength {
+		return newValidationError("saved search name is too short")
+	}
+
+	if _, err := filter.Parse(query, time.Now()); err != nil {
+		return newValidationError("filter is invalid: %s", err)
+	}
+
+	existing, err := s.repo.FindByName(owner, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != searchID {
+		return newValidationError("saved search %q already exists", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSavedSearchService_NewSavedSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSavedSearchesRepo(ctrl)
+	s := services.NewSavedSearchService(repo, mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	repo.EXPECT().FindByName("alice", "Weak Go topics").Return(nil, nil)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(search *models.SavedSearch) (int64, error) {
+		if search.Owner != "alice" || search.Filter != "tag:go score<40" {
+			t.Errorf("unexpected search %v", search)
+		}
+		if search.CreatedAt == nil {
+			t.Error("expected creation time to be set")
+		}
+
+		return 7, nil
+	})
+
+	search, err := s.NewSavedSearch("alice", "Weak Go topics", "tag:go score<40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.ID != 7 {
+		t.Errorf("expected ID 7, got %d", search.ID)
+	}
+}
+
+func TestSavedSearchService_NewSavedSearch_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSavedSearchesRepo(ctrl)
+	s := services.NewSavedSearchService(repo, mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	repo.EXPECT().FindByName("alice", "Duplicate").Return(&models.SavedSearch{ID: 3}, nil)
+
+	cases := []struct {
+		owner, name, query string
+		message            string
+	}{
+		{"", "Weak Go topics", "tag:go", "owner cannot be empty"},
+		{"alice", "W", "tag:go", "saved search name is too short"},
+		{"alice", "Weak Go topics", "tag:go color:red", `filter is invalid: unknown field "color" at position 8`},
+		{"alice", "Duplicate", "tag:go", `saved search "Duplicate" already exists`},
+	}
+
+	for _, c := range cases {
+		_, err := s.NewSavedSearch(c.owner, c.name, c.query)
+
+		var validationErr *services.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got %v", err)
+			continue
+		}
+		if err.Error() != c.message {
+			t.Errorf("expected %q, got %q", c.message, err.Error())
+		}
+	}
+}
+
+func TestSavedSearchService_UpdateSavedSearch_KeepsName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSavedSearchesRepo(ctrl)
+	s := services.NewSavedSearchService(repo, mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	existing := &models.SavedSearch{ID: 3, Owner: "alice", Name: "Weak", Filter: "score<40"}
+	repo.EXPECT().FindByID(int64(3)).Return(existing, nil)
+	repo.EXPECT().FindByName("alice", "Weak").Return(existing, nil)
+	repo.EXPECT().Save(existing).Return(nil)
+
+	search, err := s.UpdateSavedSearch("alice", 3, "Weak", "score<30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.Filter != "score<30" || search.UpdatedAt == nil {
+		t.Errorf("unexpected search %v", search)
+	}
+}
+
+func TestSavedSearchService_GetSavedSearch_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSavedSearchesRepo(ctrl)
+	s := services.NewSavedSearchService(repo, mock.NewMockKnowledgeItemsRepo(ctrl), mock.NewMockLearnerProgressRepo(ctrl))
+
+	repo.EXPECT().FindByID(int64(3)).Return(&models.SavedSearch{ID: 3, Owner: "bob"}, nil)
+
+	_, err := s.GetSavedSearch("alice", 3)
+	if !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestSavedSearchService_FindItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	s := services.NewSavedSearchService(mock.NewMockSavedSearchesRepo(ctrl), itemsRepo, progressRepo)
+
+	items := []*models.KnowledgeItem{
+		{ID: 1, Tags: []string{"go"}},
+		{ID: 2, Tags: []string{"go"}},
+		{ID: 3, Tags: []string{"go", "deprecated"}},
+		{ID: 4, Tags: []string{"rust"}},
+	}
+	itemsRepo.EXPECT().FindByOwner("alice").Return(items, nil).Times(2)
+
+	// review state is matched against the progress of the search owner.
+	checkedAt := time.Now().Add(-40 * 24 * time.Hour)
+	progressRepo.EXPECT().FindByLearner("alice").Return([]*models.LearnerProgress{
+		{ItemID: 1, Learner: "alice", Score: 30, LastCheckAt: &checkedAt},
+		{ItemID: 2, Learner: "alice", Score: 60, LastCheckAt: &checkedAt},
+		{ItemID: 3, Learner: "alice", Score: 10},
+	}, nil).Times(2)
+
+	found, err := s.FindItems("alice", &models.SavedSearch{Owner: "alice", Filter: "tag:go score<40 -tag:deprecated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != 1 {
+		t.Errorf("expected item 1, got %v", found)
+	}
+
+	found, err = s.FindItems("alice", &models.SavedSearch{Owner: "alice", Filter: "checked:never OR checked:>30d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 4 {
+		t.Errorf("expected all the items, got %d", len(found))
+	}
+
+	_, err = s.FindItems("alice", &models.SavedSearch{Filter: "score<"})
+	if err == nil || !strings.HasPrefix(err.Error(), "filter is invalid") {
+		t.Errorf("expected invalid filter error, got %v", err)
+	}
+}
+
+func TestSavedSearchService_FindItems_Workspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	itemsRepo.EXPECT().FindByOwner("workspace:5").Return([]*models.KnowledgeItem{
+		{ID: 1, Owner: "workspace:5"},
+		{ID: 2, Owner: "workspace:5"},
+	}, nil)
+
+	// items of the workspace are matched against the progress of the learner who saved the search.
+	progressRepo := mock.NewMockLearnerProgressRepo(ctrl)
+	progressRepo.EXPECT().FindByLearner("bob").Return([]*models.LearnerProgress{
+		{ItemID: 2, Learner: "bob", Score: 80},
+	}, nil)
+
+	s := services.NewSavedSearchService(mock.NewMockSavedSearchesRepo(ctrl), itemsRepo, progressRepo)
+
+	found, err := s.FindItems("workspace:5", &models.SavedSearch{Owner: "bob", Filter: "score<50"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != 1 {
+		t.Errorf("expected item 1, got %v", found)
+	}
+}
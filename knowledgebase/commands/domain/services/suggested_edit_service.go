@@ -0,0 +1,251 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/diff"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const maxEditCommentLength = 1000
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_suggested_edit_service.go -source=suggested_edit_service.go SuggestedEditService
+
+// SuggestedEditService represents a service that provides functionality related to the models.SuggestedEdit.
+// Edits are returned with the diff of their data. The author of the edit is notified once it is reviewed.
+type SuggestedEditService interface {
+	ProposeEdit(
+		author string,
+		item *models.KnowledgeItem,
+		title, anchor, data, comment string,
+	) (*models.SuggestedEdit, error)
+
+	ListPendingEdits(owner string) ([]*models.SuggestedEdit, error)
+	GetEdit(owner string, editID int64) (*models.SuggestedEdit, error)
+
+	ValidateApproval(edit *models.SuggestedEdit, item *models.KnowledgeItem, comment string) error
+
+	ApproveEdit(
+		reviewer string,
+		edit *models.SuggestedEdit,
+		item *models.KnowledgeItem,
+		comment string,
+	) (*models.SuggestedEdit, error)
+
+	RejectEdit(reviewer, owner string, editID int64, comment string) (*models.SuggestedEdit, error)
+
+	ValidateMerge(owner string, sourceID int64) error
+}
+
+// suggestedEditService is a set of business rules & actions related to the SuggestedEdit.
+type suggestedEditService struct {
+	repo              repositories.SuggestedEditsRepo
+	notificationsRepo repositories.NotificationsRepo
+}
+
+// NewSuggestedEditService function makes new instance of SuggestedEditService.
+func NewSuggestedEditService(
+	repo repositories.SuggestedEditsRepo,
+	notificationsRepo repositories.NotificationsRepo,
+) SuggestedEditService {
+	return &suggestedEditService{
+		repo:              repo,
+		notificationsRepo: notificationsRepo,
+	}
+}
+
+// ProposeEdit function stores new pending models.SuggestedEdit of the item. The content is expected
+// to be validated as the content of the item already, the edit has to change it.
+func (s *suggestedEditService) ProposeEdit(
+	author string,
+	item *models.KnowledgeItem,
+	title, anchor, data, comment string,
+) (*models.SuggestedEdit, error) {
+	if title == item.Title && anchor == item.Anchor && data == item.Data {
+		return nil, newValidationError("edit doesn't change the item")
+	}
+
+	if len(comment) > maxEditCommentLength {
+		return nil, newValidationError("comment must be at most %d characters", maxEditCommentLength)
+	}
+
+	createdAt := time.Now()
+
+	edit := &models.SuggestedEdit{
+		ItemID:     item.ID,
+		Owner:      item.Owner,
+		Author:     author,
+		Comment:    comment,
+		Title:      title,
+		Anchor:     anchor,
+		Data:       data,
+		BaseTitle:  item.Title,
+		BaseAnchor: item.Anchor,
+		BaseData:   item.Data,
+		Status:     models.EditPending,
+		CreatedAt:  &createdAt,
+	}
+
+	var err error
+	edit.ID, err = s.repo.Create(edit)
+	if err != nil {
+		return nil, err
+	}
+
+	return withDiff(edit), nil
+}
+
+// ListPendingEdits function returns the edits of the items of the owner waiting for the review.
+func (s *suggestedEditService) ListPendingEdits(owner string) ([]*models.SuggestedEdit, error) {
+	edits, err := s.repo.FindPending(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, edit := range edits {
+		withDiff(edit)
+	}
+
+	return edits, nil
+}
+
+// GetEdit function returns existing models.SuggestedEdit, edits of the items of other owners are ErrForbidden.
+func (s *suggestedEditService) GetEdit(owner string, editID int64) (*models.SuggestedEdit, error) {
+	edit, err := s.repo.FindByID(editID)
+	if err != nil {
+		return nil, err
+	}
+
+	if edit.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	return withDiff(edit), nil
+}
+
+// ValidateApproval function checks that the pending edit can be approved without storing anything.
+// The item has to keep the content the edit was proposed against, so changes made since then aren't overwritten.
+func (s *suggestedEditService) ValidateApproval(
+	edit *models.SuggestedEdit,
+	item *models.KnowledgeItem,
+	comment string,
+) error {
+	if err := checkReview(edit, comment); err != nil {
+		return err
+	}
+
+	if !edit.Applies(item) {
+		return newValidationError("item was changed after the edit was proposed")
+	}
+
+	return nil
+}
+
+// ApproveEdit function records approval of the pending edit and notifies its author.
+// The item is the one the edit was checked against, applying the edit to it is left to the caller,
+// which should do it before the approval, so the author isn't notified of the change that failed.
+func (s *suggestedEditService) ApproveEdit(
+	reviewer string,
+	edit *models.SuggestedEdit,
+	item *models.KnowledgeItem,
+	comment string,
+) (*models.SuggestedEdit, error) {
+	if err := s.ValidateApproval(edit, item, comment); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s approved your edit of %q", reviewer, edit.BaseTitle)
+
+	return s.review(edit, reviewer, comment, models.EditApproved, models.NotificationEditApproved, message)
+}
+
+// RejectEdit function records rejection of the pending edit and notifies its author.
+func (s *suggestedEditService) RejectEdit(
+	reviewer, owner string,
+	editID int64,
+	comment string,
+) (*models.SuggestedEdit, error) {
+	edit, err := s.GetEdit(owner, editID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkReview(edit, comment); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s rejected your edit of %q", reviewer, edit.BaseTitle)
+
+	return s.review(edit, reviewer, comment, models.EditRejected, models.NotificationEditRejected, message)
+}
+
+// ValidateMerge function checks that the item of the owner can be merged into another one.
+// Pending edits of the item would be left without the content they were proposed against,
+// so they have to be reviewed before the merge.
+func (s *suggestedEditService) ValidateMerge(owner string, sourceID int64) error {
+	edits, err := s.repo.FindPending(owner)
+	if err != nil {
+		return err
+	}
+
+	for _, edit := range edits {
+		if edit.ItemID == sourceID {
+			return newValidationError("item has pending suggested edits, they have to be reviewed first")
+		}
+	}
+
+	return nil
+}
+
+// review function stores the outcome of the review and notifies the author of the edit.
+func (s *suggestedEditService) review(
+	edit *models.SuggestedEdit,
+	reviewer, comment, status, kind, message string,
+) (*models.SuggestedEdit, error) {
+	reviewedAt := time.Now()
+
+	edit.Status = status
+	edit.Reviewer = reviewer
+	edit.ReviewComment = comment
+	edit.ReviewedAt = &reviewedAt
+
+	if err := s.repo.Save(edit); err != nil {
+		return nil, err
+	}
+
+	_, err := s.notificationsRepo.Create(&models.Notification{
+		Recipient: edit.Author,
+		Kind:      kind,
+		SubjectID: edit.ID,
+		Message:   message,
+		CreatedAt: &reviewedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return withDiff(edit), nil
+}
+
+// checkReview function checks the edit still waits for the review and the comment of the reviewer.
+func checkReview(edit *models.SuggestedEdit, comment string) error {
+	if !edit.Pending() {
+		return newValidationError("edit is already %s", edit.Status)
+	}
+
+	if len(comment) > maxEditCommentLength {
+		return newValidationError("comment must be at most %d characters", maxEditCommentLength)
+	}
+
+	return nil
+}
+
+// withDiff function fills the diff of the data of the edit.
+func withDiff(edit *models.SuggestedEdit) *models.SuggestedEdit {
+	edit.Diff = diff.Lines(edit.BaseData, edit.Data)
+
+	return edit
+}
@@ -0,0 +1,230 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func sharedItem() *models.KnowledgeItem {
+	return &models.KnowledgeItem{
+		ID:     7,
+		Owner:  "workspace:5",
+		Title:  "Raft leader election",
+		Anchor: "raft",
+		Data:   "Leader is elected\nby 51% of votes.",
+	}
+}
+
+func pendingEdit() *models.SuggestedEdit {
+	item := sharedItem()
+
+	return &models.SuggestedEdit{
+		ID:         3,
+		ItemID:     item.ID,
+		Owner:      item.Owner,
+		Author:     "bob",
+		Title:      item.Title,
+		Anchor:     item.Anchor,
+		Data:       "Leader is elected\nby the majority of votes.",
+		BaseTitle:  item.Title,
+		BaseAnchor: item.Anchor,
+		BaseData:   item.Data,
+		Status:     models.EditPending,
+	}
+}
+
+func TestSuggestedEditService_ProposeEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSuggestedEditsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(edit *models.SuggestedEdit) (int64, error) {
+		if edit.Owner != "workspace:5" || edit.Author != "bob" || edit.Status != models.EditPending {
+			t.Errorf("unexpected edit %+v", edit)
+		}
+		if edit.BaseData != sharedItem().Data {
+			t.Errorf("expected base data of the item, got %q", edit.BaseData)
+		}
+
+		return 3, nil
+	})
+
+	s := services.NewSuggestedEditService(repo, mock.NewMockNotificationsRepo(ctrl))
+
+	item := sharedItem()
+	edit, err := s.ProposeEdit("bob", item, item.Title, item.Anchor, "Leader is elected\nby the majority.", "typo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit.ID != 3 {
+		t.Errorf("expected ID 3, got %d", edit.ID)
+	}
+
+	expected := []models.DiffLine{
+		{Op: models.DiffEqual, Text: "Leader is elected"},
+		{Op: models.DiffDelete, Text: "by 51% of votes."},
+		{Op: models.DiffInsert, Text: "by the majority."},
+	}
+	if len(edit.Diff) != len(expected) {
+		t.Fatalf("expected %d diff lines, got %d", len(expected), len(edit.Diff))
+	}
+	for i, line := range edit.Diff {
+		if *line != expected[i] {
+			t.Errorf("expected diff line %+v, got %+v", expected[i], *line)
+		}
+	}
+}
+
+func TestSuggestedEditService_ProposeEdit_NoChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewSuggestedEditService(mock.NewMockSuggestedEditsRepo(ctrl), mock.NewMockNotificationsRepo(ctrl))
+
+	item := sharedItem()
+	_, err := s.ProposeEdit("bob", item, item.Title, item.Anchor, item.Data, "")
+
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestSuggestedEditService_GetEdit_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSuggestedEditsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(3)).Return(pendingEdit(), nil)
+
+	s := services.NewSuggestedEditService(repo, mock.NewMockNotificationsRepo(ctrl))
+
+	if _, err := s.GetEdit("alice", 3); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestSuggestedEditService_ApproveEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSuggestedEditsRepo(ctrl)
+	repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(edit *models.SuggestedEdit) error {
+		if edit.Status != models.EditApproved || edit.Reviewer != "alice" || edit.ReviewedAt == nil {
+			t.Errorf("expected edit approved by alice, got %+v", edit)
+		}
+
+		return nil
+	})
+
+	notifications := mock.NewMockNotificationsRepo(ctrl)
+	notifications.EXPECT().Create(gomock.Any()).DoAndReturn(func(notification *models.Notification) (int64, error) {
+		if notification.Recipient != "bob" || notification.Kind != models.NotificationEditApproved {
+			t.Errorf("expected bob to be notified of the approval, got %+v", notification)
+		}
+		if notification.SubjectID != 3 {
+			t.Errorf("expected notification about the edit 3, got %d", notification.SubjectID)
+		}
+
+		return 1, nil
+	})
+
+	s := services.NewSuggestedEditService(repo, notifications)
+
+	edit, err := s.ApproveEdit("alice", pendingEdit(), sharedItem(), "thanks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit.ReviewComment != "thanks" {
+		t.Errorf("expected review comment, got %q", edit.ReviewComment)
+	}
+}
+
+func TestSuggestedEditService_ApproveEdit_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewSuggestedEditService(mock.NewMockSuggestedEditsRepo(ctrl), mock.NewMockNotificationsRepo(ctrl))
+
+	reviewed := pendingEdit()
+	reviewed.Status = models.EditRejected
+
+	changed := sharedItem()
+	changed.Data = "Leader is elected by the quorum."
+
+	cases := []struct {
+		name string
+		edit *models.SuggestedEdit
+		item *models.KnowledgeItem
+	}{
+		{name: "already reviewed", edit: reviewed, item: sharedItem()},
+		{name: "item changed", edit: pendingEdit(), item: changed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validationErr *services.ValidationError
+			if err := s.ValidateApproval(tc.edit, tc.item, ""); !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+
+			_, err := s.ApproveEdit("alice", tc.edit, tc.item, "")
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSuggestedEditService_RejectEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSuggestedEditsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(3)).Return(pendingEdit(), nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+
+	notifications := mock.NewMockNotificationsRepo(ctrl)
+	notifications.EXPECT().Create(gomock.Any()).DoAndReturn(func(notification *models.Notification) (int64, error) {
+		if notification.Recipient != "bob" || notification.Kind != models.NotificationEditRejected {
+			t.Errorf("expected bob to be notified of the rejection, got %+v", notification)
+		}
+
+		return 1, nil
+	})
+
+	s := services.NewSuggestedEditService(repo, notifications)
+
+	edit, err := s.RejectEdit("alice", "workspace:5", 3, "the number is right")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit.Status != models.EditRejected {
+		t.Errorf("expected rejected edit, got %s", edit.Status)
+	}
+}
+
+func TestSuggestedEditService_ValidateMerge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockSuggestedEditsRepo(ctrl)
+	repo.EXPECT().FindPending("workspace:5").Return([]*models.SuggestedEdit{pendingEdit()}, nil).Times(2)
+
+	s := services.NewSuggestedEditService(repo, mock.NewMockNotificationsRepo(ctrl))
+
+	var validationErr *services.ValidationError
+	if err := s.ValidateMerge("workspace:5", sharedItem().ID); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for the item with pending edit, got %v", err)
+	}
+
+	// pending edits of other items don't prevent the merge.
+	if err := s.ValidateMerge("workspace:5", 8); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/tags"
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_tag_service.go -source=tag_service.go TagService
+
+// TagService represents a service that manages tags of all the models.KnowledgeItem of the owner at once.
+// Tags are compared after the normalization, so "Machine Learning" and "machine-learning" are the same tag,
+// and every rewritten item gets all its tags normalized.
+type TagService interface {
+	ListTags(owner string) ([]*models.TagUsage, error)
+	RenameTag(owner, from, to string) (*models.TagUsage, error)
+	MergeTags(owner string, sources []string, target string) (*models.TagUsage, error)
+	DeleteTag(owner, tag string) error
+}
+
+// tagService is a set of business rules related to the tags of the Knowledge Items.
+type tagService struct {
+	repo       repositories.KnowledgeItemsRepo
+	normalizer *tags.Normalizer
+}
+
+// NewTagService function makes new instance of TagService, the normalizer has to be the same
+// as the one of KnowledgeItemService.
+func NewTagService(repo repositories.KnowledgeItemsRepo, normalizer *tags.Normalizer) TagService {
+	return &tagService{
+		repo:       repo,
+		normalizer: normalizer,
+	}
+}
+
+// ListTags function returns all the tags in use, the most used go first.
+func (s *tagService) ListTags(owner string) ([]*models.TagUsage, error) {
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, item := range items {
+		for _, tag := range s.normalizer.NormalizeAll(item.Tags) {
+			counts[tag]++
+		}
+	}
+
+	usages := make([]*models.TagUsage, 0, len(counts))
+	for tag, count := range counts {
+		usages = append(usages, &models.TagUsage{Tag: tag, Count: count})
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Count != usages[j].Count {
+			return usages[i].Count > usages[j].Count
+		}
+
+		return usages[i].Tag < usages[j].Tag
+	})
+
+	return usages, nil
+}
+
+// RenameTag function replaces the tag with the new name in all the items.
+// The new name must not be in use, tags are combined by MergeTags.
+func (s *tagService) RenameTag(owner, from, to string) (*models.TagUsage, error) {
+	from, to = s.normalizer.Normalize(from), s.normalizer.Normalize(to)
+	if err := s.validateTag(to); err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, newValidationError("new tag name has to differ from the old one")
+	}
+
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if slices.Contains(s.normalizer.NormalizeAll(item.Tags), to) {
+			return nil, newValidationError("tag %q already exists", to)
+		}
+	}
+
+	return s.replace(items, []string{from}, to)
+}
+
+// MergeTags function replaces all the source tags with the target one in all the items.
+// The target may be in use already, then the items get it once.
+func (s *tagService) MergeTags(owner string, sources []string, target string) (*models.TagUsage, error) {
+	target = s.normalizer.Normalize(target)
+	if err := s.validateTag(target); err != nil {
+		return nil, err
+	}
+
+	var normalized []string
+	for _, source := range s.normalizer.NormalizeAll(sources) {
+		if source != target {
+			normalized = append(normalized, source)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, newValidationError("tags to merge cannot be empty")
+	}
+
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replace(items, normalized, target)
+}
+
+// DeleteTag function removes the tag from all the items, the items themselves are kept.
+func (s *tagService) DeleteTag(owner, tag string) error {
+	tag = s.normalizer.Normalize(tag)
+
+	items, err := s.repo.FindByOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	affected := 0
+	for _, item := range items {
+		itemTags := s.normalizer.NormalizeAll(item.Tags)
+		if !slices.Contains(itemTags, tag) {
+			continue
+		}
+
+		item.Tags = slices.DeleteFunc(itemTags, func(t string) bool { return t == tag })
+		if err = s.save(item); err != nil {
+			return err
+		}
+		affected++
+	}
+
+	if affected == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+// replace function rewrites the items having any of the sources, so they have the target instead.
+// It returns usage of the target after the rewrite, ErrNotFound is returned when no item has the sources.
+func (s *tagService) replace(items []*models.KnowledgeItem, sources []string, target string) (*models.TagUsage, error) {
+	usage := &models.TagUsage{Tag: target}
+	affected := 0
+	for _, item := range items {
+		itemTags := s.normalizer.NormalizeAll(item.Tags)
+
+		found := false
+		for i, tag := range itemTags {
+			if slices.Contains(sources, tag) {
+				itemTags[i] = target
+				found = true
+			}
+		}
+
+		if found {
+			item.Tags = s.normalizer.NormalizeAll(itemTags)
+			if err := s.save(item); err != nil {
+				return nil, err
+			}
+			affected++
+		}
+
+		if slices.Contains(itemTags, target) {
+			usage.Count++
+		}
+	}
+
+	if affected == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	return usage, nil
+}
+
+func (s *tagService) save(item *models.KnowledgeItem) error {
+	updatedAt := time.Now()
+	item.UpdatedAt = &updatedAt
+
+	return s.repo.Save(item)
+}
+
+func (s *tagService) validateTag(tag string) error {
+	if len(tag) <= minTagLength {
+		return newValidationError("tag is too short")
+	}
+
+	return nil
+}
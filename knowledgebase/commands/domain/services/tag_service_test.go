@@ -0,0 +1,175 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/tags"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func tagTestItems() []*models.KnowledgeItem {
+	return []*models.KnowledgeItem{
+		{ID: 1, Tags: []string{"Go", "concurrency"}},
+		{ID: 2, Tags: []string{"golang", "go"}},
+		{ID: 3, Tags: []string{"Machine Learning"}},
+		{ID: 4, Tags: []string{"go", "machine-learning"}},
+	}
+}
+
+// savedTags function collects tags of the saved items by their IDs.
+func savedTags(repo *mock.MockKnowledgeItemsRepo) map[int64]string {
+	saved := make(map[int64]string)
+	repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(item *models.KnowledgeItem) error {
+		if item.UpdatedAt == nil {
+			return errors.New("expected update time to be set")
+		}
+		saved[item.ID] = fmt.Sprint(item.Tags)
+
+		return nil
+	}).AnyTimes()
+
+	return saved
+}
+
+func TestTagService_ListTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil)
+
+	usages, err := services.NewTagService(repo, tags.DefaultNormalizer()).ListTags("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, usage := range usages {
+		got = append(got, fmt.Sprintf("%s:%d", usage.Tag, usage.Count))
+	}
+	if fmt.Sprint(got) != "[go:3 machine-learning:2 concurrency:1 golang:1]" {
+		t.Errorf("unexpected tags %v", got)
+	}
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil)
+	saved := savedTags(repo)
+
+	usage, err := services.NewTagService(repo, tags.DefaultNormalizer()).RenameTag("alice", "machine learning", "ML")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Tag != "ml" || usage.Count != 2 {
+		t.Errorf("expected ml used by 2 items, got %s used by %d", usage.Tag, usage.Count)
+	}
+	if fmt.Sprint(saved) != "map[3:[ml] 4:[go ml]]" {
+		t.Errorf("unexpected saved items %v", saved)
+	}
+}
+
+func TestTagService_RenameTag_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil).AnyTimes()
+
+	s := services.NewTagService(repo, tags.DefaultNormalizer())
+
+	var validationErr *services.ValidationError
+	if _, err := s.RenameTag("alice", "golang", "Go"); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for existing tag, got %v", err)
+	}
+	if _, err := s.RenameTag("alice", "Go", "go"); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for the same name, got %v", err)
+	}
+	if _, err := s.RenameTag("alice", "go", "g"); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for short name, got %v", err)
+	}
+	if _, err := s.RenameTag("alice", "rust", "rust-lang"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil)
+	saved := savedTags(repo)
+
+	usage, err := services.NewTagService(repo, tags.DefaultNormalizer()).MergeTags("alice", []string{"GoLang", "go"}, "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Tag != "go" || usage.Count != 3 {
+		t.Errorf("expected go used by 3 items, got %s used by %d", usage.Tag, usage.Count)
+	}
+	if fmt.Sprint(saved) != "map[2:[go]]" {
+		t.Errorf("unexpected saved items %v", saved)
+	}
+}
+
+func TestTagService_MergeTags_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := services.NewTagService(mock.NewMockKnowledgeItemsRepo(ctrl), tags.DefaultNormalizer())
+
+	var validationErr *services.ValidationError
+	if _, err := s.MergeTags("alice", []string{"Go"}, "go"); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestTagService_DeleteTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil).Times(2)
+	saved := savedTags(repo)
+
+	s := services.NewTagService(repo, tags.DefaultNormalizer())
+
+	if err := s.DeleteTag("alice", "GO"); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(saved) != "map[1:[concurrency] 2:[golang] 4:[machine-learning]]" {
+		t.Errorf("unexpected saved items %v", saved)
+	}
+
+	if err := s.DeleteTag("alice", "rust"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestTagService_SaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByOwner("alice").Return(tagTestItems(), nil)
+	repo.EXPECT().Save(gomock.Any()).Return(expectedError)
+
+	err := services.NewTagService(repo, tags.DefaultNormalizer()).DeleteTag("alice", "go")
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected %v, got %v", expectedError, err)
+	}
+}
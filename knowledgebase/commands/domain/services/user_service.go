@@ -0,0 +1,158 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// userLoginRe matches logins of 3 to 32 lowercase letters, digits, dots, dashes and underscores.
+var userLoginRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,31}$`)
+
+// userLoginCharsRe matches runs of characters not allowed in logins.
+var userLoginCharsRe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// maxLoginSuffix limits attempts to find free login for the provisioned user.
+const maxLoginSuffix = 100
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_user_service.go -source=user_service.go UserService
+
+// UserService represents a service that provides functionality related to the models.User.
+type UserService interface {
+	NewUser(login, name, email string) (*models.User, error)
+	GetUser(login string) (*models.User, error)
+	ProvisionUser(issuer, subject, username, name, email string) (*models.User, error)
+}
+
+// userService is a set of business rules & actions related to the User.
+type userService struct {
+	repo repositories.UsersRepo
+}
+
+// NewUserService function makes new instance of UserService.
+func NewUserService(repo repositories.UsersRepo) UserService {
+	return &userService{
+		repo: repo,
+	}
+}
+
+// NewUser function stores new models.User, the login is lowercased and has to be unique.
+func (s *userService) NewUser(login, name, email string) (*models.User, error) {
+	login = strings.ToLower(strings.TrimSpace(login))
+	if !userLoginRe.MatchString(login) {
+		return nil, newValidationError("login must be 3 to 32 letters, digits, dots, dashes or underscores")
+	}
+
+	email = strings.TrimSpace(email)
+	if email != "" && !strings.Contains(email, "@") {
+		return nil, newValidationError("email %q is invalid", email)
+	}
+
+	existing, err := s.repo.FindByLogin(login)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, newValidationError("user %q already exists", login)
+	}
+
+	createdAt := time.Now()
+
+	user := &models.User{
+		Login:     login,
+		Name:      strings.TrimSpace(name),
+		Email:     email,
+		CreatedAt: &createdAt,
+	}
+
+	user.ID, err = s.repo.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ProvisionUser function returns the user of the identity given by the OpenID Connect provider
+// and creates it on the first sign in. Login of the new user is derived from the username or the email,
+// a number is appended when the login is taken, so identities never take over existing users.
+func (s *userService) ProvisionUser(issuer, subject, username, name, email string) (*models.User, error) {
+	if issuer == "" || subject == "" {
+		return nil, newValidationError("issuer and subject of the identity are required")
+	}
+
+	user, err := s.repo.FindByIdentity(issuer, subject)
+	if err != nil || user != nil {
+		return user, err
+	}
+
+	base := username
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	base = strings.Trim(userLoginCharsRe.ReplaceAllString(strings.ToLower(base), "-"), "._-")
+	if len(base) > 28 {
+		base = base[:28]
+	}
+	for len(base) < 3 {
+		base += "0"
+	}
+
+	email = strings.TrimSpace(email)
+	if !strings.Contains(email, "@") {
+		email = ""
+	}
+
+	for i := 1; i <= maxLoginSuffix; i++ {
+		login := base
+		if i > 1 {
+			login += strconv.Itoa(i)
+		}
+
+		existing, err := s.repo.FindByLogin(login)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		createdAt := time.Now()
+		user = &models.User{
+			Login:     login,
+			Name:      strings.TrimSpace(name),
+			Email:     email,
+			Issuer:    issuer,
+			Subject:   subject,
+			CreatedAt: &createdAt,
+		}
+
+		user.ID, err = s.repo.Create(user)
+		if err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+
+	return nil, newValidationError("no free login is found for %q", base)
+}
+
+// GetUser function returns existing models.User by login, ErrNotFound is returned for unknown one.
+func (s *userService) GetUser(login string) (*models.User, error) {
+	user, err := s.repo.FindByLogin(strings.ToLower(strings.TrimSpace(login)))
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, repositories.ErrNotFound
+	}
+
+	return user, nil
+}
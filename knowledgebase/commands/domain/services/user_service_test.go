@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_NewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUsersRepo(ctrl)
+	s := services.NewUserService(repo)
+
+	repo.EXPECT().FindByLogin("alice").Return(nil, nil)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(user *models.User) (int64, error) {
+		if user.Login != "alice" || user.Name != "Alice" || user.Email != "alice@example.com" {
+			t.Errorf("unexpected user %+v", user)
+		}
+		if user.CreatedAt == nil {
+			t.Error("expected creation time to be set")
+		}
+
+		return 4, nil
+	})
+
+	user, err := s.NewUser(" Alice ", "Alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 4 {
+		t.Errorf("expected ID 4, got %d", user.ID)
+	}
+}
+
+func TestUserService_NewUser_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUsersRepo(ctrl)
+	s := services.NewUserService(repo)
+
+	repo.EXPECT().FindByLogin("bob").Return(&models.User{ID: 2, Login: "bob"}, nil)
+
+	cases := []struct {
+		login, email string
+	}{
+		{"al", ""},
+		{"alice smith", ""},
+		{"_alice", ""},
+		{"alice", "alice.example.com"},
+		{"bob", ""},
+	}
+
+	for _, tc := range cases {
+		_, err := s.NewUser(tc.login, "", tc.email)
+		var validationErr *services.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s %s: expected validation error, got %v", tc.login, tc.email, err)
+		}
+	}
+}
+
+func TestUserService_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUsersRepo(ctrl)
+	s := services.NewUserService(repo)
+
+	expected := &models.User{ID: 2, Login: "bob"}
+	repo.EXPECT().FindByLogin("bob").Return(expected, nil)
+	repo.EXPECT().FindByLogin("carol").Return(nil, nil)
+
+	user, err := s.GetUser("Bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != expected {
+		t.Errorf("expected %+v, got %+v", expected, user)
+	}
+
+	if _, err = s.GetUser("carol"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUserService_ProvisionUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUsersRepo(ctrl)
+	s := services.NewUserService(repo)
+
+	known := &models.User{ID: 1, Login: "alice", Issuer: "https://id.example.com", Subject: "1"}
+	repo.EXPECT().FindByIdentity("https://id.example.com", "1").Return(known, nil)
+
+	user, err := s.ProvisionUser("https://id.example.com", "1", "Alice", "Alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != known {
+		t.Errorf("expected known user %+v, got %+v", known, user)
+	}
+
+	// login taken by another user gets a number.
+	repo.EXPECT().FindByIdentity("https://id.example.com", "2").Return(nil, nil)
+	repo.EXPECT().FindByLogin("alice.smith").Return(&models.User{ID: 2, Login: "alice.smith"}, nil)
+	repo.EXPECT().FindByLogin("alice.smith2").Return(nil, nil)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(u *models.User) (int64, error) {
+		if u.Login != "alice.smith2" || u.Subject != "2" || u.Email != "Alice.Smith@example.com" {
+			t.Errorf("unexpected user %+v", u)
+		}
+
+		return 3, nil
+	})
+
+	user, err = s.ProvisionUser("https://id.example.com", "2", "", "Alice Smith", "Alice.Smith@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 3 {
+		t.Errorf("expected ID 3, got %d", user.ID)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.ProvisionUser("", "3", "bob", "", ""); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+// Package services contains domain business rules.
+package services
+
+import "fmt"
+
+// ValidationError represents violation of the business rules by the input data.
+// It allows callers to tell invalid input apart from storage failures.
+type ValidationError struct {
+	message string
+}
+
+// newValidationError function builds new ValidationError with formatted message.
+func newValidationError(format string, args ...any) *ValidationError {
+	return &ValidationError{
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error function returns description of the violated rule.
+func (e *ValidationError) Error() string {
+	return e.message
+}
@@ -0,0 +1,364 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const (
+	// webhookSecretPrefix marks secrets of the webhooks, so they aren't mistaken for the API tokens.
+	webhookSecretPrefix    = "whs_"
+	webhookSecretLength    = 32
+	maxWebhookURLLength    = 2048
+	maxDeliveryAttempts    = 8
+	firstRetryDelay        = 30 * time.Second
+	maxRetryDelay          = time.Hour
+	webhookFailuresToPause = 10
+	webhookResolveTimeout  = 5 * time.Second
+)
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_webhook_service.go -source=webhook_service.go WebhookService
+
+// WebhookService represents a service that provides functionality related to the models.Webhook.
+// Published events become pending models.WebhookDelivery of the subscribed webhooks, the background worker
+// sends the due ones and records every attempt. Failed attempts are retried with exponential backoff,
+// webhooks failing too many times in a row are disabled. Webhooks of other owners are ErrForbidden.
+type WebhookService interface {
+	NewWebhook(owner, creator, endpoint string, events []string) (*models.Webhook, string, error)
+	ListWebhooks(owner string) ([]*models.Webhook, error)
+	DeleteWebhook(owner string, webhookID int64) error
+	EnableWebhook(owner string, webhookID int64) (*models.Webhook, error)
+	ListDeliveries(owner string, webhookID int64) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(owner string, deliveryID int64) (*models.WebhookDelivery, error)
+	Publish(event *models.WebhookEvent) error
+	DueDeliveries(at time.Time) ([]*models.DueDelivery, error)
+	RecordAttempt(delivery *models.WebhookDelivery, responseStatus int, failure error, at time.Time) error
+}
+
+// webhookService is a set of business rules & actions related to the Webhook.
+type webhookService struct {
+	repo           repositories.WebhooksRepo
+	deliveriesRepo repositories.WebhookDeliveriesRepo
+	guard          HostGuard
+}
+
+// NewWebhookService function makes new instance of WebhookService, the guard checks the hosts of the webhook URLs.
+func NewWebhookService(
+	repo repositories.WebhooksRepo,
+	deliveriesRepo repositories.WebhookDeliveriesRepo,
+	guard HostGuard,
+) WebhookService {
+	return &webhookService{
+		repo:           repo,
+		deliveriesRepo: deliveriesRepo,
+		guard:          guard,
+	}
+}
+
+// NewWebhook function stores new models.Webhook of the owner and returns it with its secret.
+// The secret is used to sign the payloads, receivers verify the signatures with it.
+// URLs resolving to loopback, link-local or private addresses are rejected, so the webhooks can't reach
+// the internal network of the server.
+func (s *webhookService) NewWebhook(
+	owner, creator, endpoint string,
+	events []string,
+) (*models.Webhook, string, error) {
+	if len(endpoint) > maxWebhookURLLength {
+		return nil, "", newValidationError("url must be at most %d characters", maxWebhookURLLength)
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", newValidationError("url must be an absolute http or https URL")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+	defer cancel()
+
+	if err = s.guard.CheckHost(ctx, u.Hostname()); err != nil {
+		if errors.Is(err, ErrHostBlocked) {
+			return nil, "", newValidationError("url must not point to a loopback, link-local or private address")
+		}
+
+		return nil, "", newValidationError("url host can't be resolved")
+	}
+
+	if len(events) == 0 {
+		return nil, "", newValidationError("at least one event is required")
+	}
+	for _, event := range events {
+		if !slices.Contains(models.Events, event) {
+			return nil, "", newValidationError("event %q is unknown", event)
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	createdAt := time.Now()
+
+	webhook := &models.Webhook{
+		Owner:     owner,
+		URL:       u.String(),
+		Secret:    secret,
+		Events:    unique(events),
+		CreatedBy: creator,
+		CreatedAt: &createdAt,
+	}
+
+	webhook.ID, err = s.repo.Create(webhook)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return webhook, secret, nil
+}
+
+// ListWebhooks function returns the webhooks of the owner.
+func (s *webhookService) ListWebhooks(owner string) ([]*models.Webhook, error) {
+	return s.repo.FindByOwner(owner)
+}
+
+// DeleteWebhook function deletes the webhook of the owner along with its delivery log.
+func (s *webhookService) DeleteWebhook(owner string, webhookID int64) error {
+	webhook, err := s.webhook(owner, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.deliveriesRepo.DeleteByWebhookID(webhook.ID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(webhook)
+}
+
+// EnableWebhook function enables the webhook disabled for failing too often, its pending deliveries are resumed.
+func (s *webhookService) EnableWebhook(owner string, webhookID int64) (*models.Webhook, error) {
+	webhook, err := s.webhook(owner, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.DisabledAt = nil
+	webhook.Failures = 0
+
+	if err = s.repo.Save(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// ListDeliveries function returns the delivery log of the webhook of the owner.
+func (s *webhookService) ListDeliveries(owner string, webhookID int64) ([]*models.WebhookDelivery, error) {
+	webhook, err := s.webhook(owner, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.deliveriesRepo.FindByWebhookID(webhook.ID)
+}
+
+// ReplayDelivery function sends the payload of the delivery once again as new delivery,
+// the original one stays in the log unchanged.
+func (s *webhookService) ReplayDelivery(owner string, deliveryID int64) (*models.WebhookDelivery, error) {
+	original, err := s.deliveriesRepo.FindByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.webhook(owner, original.WebhookID); err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.DeliveryPending,
+		ReplayOf:      original.ID,
+		NextAttemptAt: &createdAt,
+		CreatedAt:     &createdAt,
+	}
+
+	delivery.ID, err = s.deliveriesRepo.Create(delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish function queues the event for the enabled webhooks of its owner subscribed to it.
+func (s *webhookService) Publish(event *models.WebhookEvent) error {
+	if event.OccurredAt == nil {
+		occurredAt := time.Now()
+		event.OccurredAt = &occurredAt
+	}
+
+	webhooks, err := s.repo.FindByOwner(event.Owner)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if webhook.Disabled() || !webhook.Subscribed(event.Event) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+
+		createdAt := time.Now()
+
+		_, err = s.deliveriesRepo.Create(&models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         event.Event,
+			Payload:       payload,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &createdAt,
+			CreatedAt:     &createdAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DueDeliveries function returns the pending deliveries to be attempted at the time,
+// deliveries of the disabled webhooks wait until the webhooks are enabled.
+func (s *webhookService) DueDeliveries(at time.Time) ([]*models.DueDelivery, error) {
+	pending, err := s.deliveriesRepo.FindPending()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make(map[int64]*models.Webhook)
+
+	var due []*models.DueDelivery
+	for _, delivery := range pending {
+		if delivery.NextAttemptAt != nil && delivery.NextAttemptAt.After(at) {
+			continue
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repo.FindByID(delivery.WebhookID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			webhooks[webhook.ID] = webhook
+		}
+
+		if webhook.Disabled() {
+			continue
+		}
+
+		due = append(due, &models.DueDelivery{Webhook: webhook, Delivery: delivery})
+	}
+
+	return due, nil
+}
+
+// RecordAttempt function records the attempt to send the delivery made at the time, failure is nil
+// when the endpoint has accepted it. The failed delivery is scheduled for the next attempt
+// with the delay doubled every time, it fails for good after too many attempts.
+func (s *webhookService) RecordAttempt(
+	delivery *models.WebhookDelivery,
+	responseStatus int,
+	failure error,
+	at time.Time,
+) error {
+	webhook, err := s.repo.FindByID(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	delivery.NextAttemptAt = nil
+
+	if failure == nil {
+		delivery.Status = models.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &at
+		webhook.Failures = 0
+	} else {
+		delivery.LastError = failure.Error()
+		if delivery.Attempts < maxDeliveryAttempts {
+			next := at.Add(retryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &next
+		} else {
+			delivery.Status = models.DeliveryFailed
+		}
+
+		webhook.Failures++
+		if webhook.Failures >= webhookFailuresToPause && !webhook.Disabled() {
+			webhook.DisabledAt = &at
+		}
+	}
+
+	if err = s.deliveriesRepo.Save(delivery); err != nil {
+		return err
+	}
+
+	return s.repo.Save(webhook)
+}
+
+// webhook function returns the webhook of the owner.
+func (s *webhookService) webhook(owner string, webhookID int64) (*models.Webhook, error) {
+	webhook, err := s.repo.FindByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.Owner != owner {
+		return nil, ErrForbidden
+	}
+
+	return webhook, nil
+}
+
+// retryDelay function returns the delay before the next attempt after the given number of attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
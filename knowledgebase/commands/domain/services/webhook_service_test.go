@@ -0,0 +1,248 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookService_NewWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	guard := mock.NewMockHostGuard(ctrl)
+	s := services.NewWebhookService(repo, mock.NewMockWebhookDeliveriesRepo(ctrl), guard)
+
+	guard.EXPECT().CheckHost(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, host string) error {
+		switch host {
+		case "chat.example.com", "example.com":
+			return nil
+		case "internal.example":
+			return fmt.Errorf("%w: host %q resolves to 10.0.0.5", services.ErrHostBlocked, host)
+		default:
+			return errors.New("no such host")
+		}
+	}).AnyTimes()
+
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(webhook *models.Webhook) (int64, error) {
+		if webhook.Owner != "workspace:5" || webhook.CreatedBy != "alice" || webhook.Secret == "" {
+			t.Errorf("unexpected webhook %+v", webhook)
+		}
+
+		return 3, nil
+	})
+
+	webhook, secret, err := s.NewWebhook(
+		"workspace:5", "alice", "https://chat.example.com/hooks/1",
+		[]string{models.EventItemReviewed, models.EventItemCreated, models.EventItemReviewed},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.ID != 3 || secret != webhook.Secret || !strings.HasPrefix(secret, "whs_") {
+		t.Errorf("unexpected webhook %+v with secret %q", webhook, secret)
+	}
+	if len(webhook.Events) != 2 || !webhook.Subscribed(models.EventItemCreated) {
+		t.Errorf("expected unique events, got %v", webhook.Events)
+	}
+
+	invalid := []struct {
+		endpoint string
+		events   []string
+	}{
+		{"ftp://example.com/hook", []string{models.EventItemCreated}},
+		{"/hooks/1", []string{models.EventItemCreated}},
+		{"https://example.com/hook", nil},
+		{"https://example.com/hook", []string{"item.moved"}},
+		{"https://internal.example/hook", []string{models.EventItemCreated}},
+		{"https://unknown.example/hook", []string{models.EventItemCreated}},
+	}
+	for _, tc := range invalid {
+		var validationErr *services.ValidationError
+		if _, _, err = s.NewWebhook("alice", "alice", tc.endpoint, tc.events); !errors.As(err, &validationErr) {
+			t.Errorf("%s %v: expected validation error, got %v", tc.endpoint, tc.events, err)
+		}
+	}
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	deliveriesRepo := mock.NewMockWebhookDeliveriesRepo(ctrl)
+	s := services.NewWebhookService(repo, deliveriesRepo, mock.NewMockHostGuard(ctrl))
+
+	disabledAt := time.Now()
+	repo.EXPECT().FindByOwner("alice").Return([]*models.Webhook{
+		{ID: 1, Owner: "alice", Events: []string{models.EventItemCreated}},
+		{ID: 2, Owner: "alice", Events: []string{models.EventItemDeleted}},
+		{ID: 3, Owner: "alice", Events: []string{models.EventItemCreated}, DisabledAt: &disabledAt},
+	}, nil)
+	deliveriesRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(delivery *models.WebhookDelivery) (int64, error) {
+		if delivery.WebhookID != 1 || delivery.Status != models.DeliveryPending || delivery.NextAttemptAt == nil {
+			t.Errorf("unexpected delivery %+v", delivery)
+		}
+
+		var event models.WebhookEvent
+		if err := json.Unmarshal(delivery.Payload, &event); err != nil || event.Item.ID != 7 {
+			t.Errorf("expected payload of item 7, got %s", delivery.Payload)
+		}
+
+		return 9, nil
+	})
+
+	event := &models.WebhookEvent{Event: models.EventItemCreated, Owner: "alice", Item: &models.KnowledgeItem{ID: 7}}
+	if err := s.Publish(event); err != nil {
+		t.Fatal(err)
+	}
+	if event.OccurredAt == nil {
+		t.Error("expected the time of the event to be set")
+	}
+}
+
+func TestWebhookService_DueDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	deliveriesRepo := mock.NewMockWebhookDeliveriesRepo(ctrl)
+	s := services.NewWebhookService(repo, deliveriesRepo, mock.NewMockHostGuard(ctrl))
+
+	now := time.Now()
+	later := now.Add(time.Minute)
+	deliveriesRepo.EXPECT().FindPending().Return([]*models.WebhookDelivery{
+		{ID: 1, WebhookID: 1, NextAttemptAt: &now},
+		{ID: 2, WebhookID: 1, NextAttemptAt: &later},
+		{ID: 3, WebhookID: 2, NextAttemptAt: &now},
+		{ID: 4, WebhookID: 4, NextAttemptAt: &now},
+	}, nil)
+	repo.EXPECT().FindByID(int64(1)).Return(&models.Webhook{ID: 1}, nil)
+	repo.EXPECT().FindByID(int64(2)).Return(&models.Webhook{ID: 2, DisabledAt: &now}, nil)
+	repo.EXPECT().FindByID(int64(4)).Return(nil, repositories.ErrNotFound)
+
+	due, err := s.DueDeliveries(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].Delivery.ID != 1 || due[0].Webhook.ID != 1 {
+		t.Errorf("expected only delivery 1 to be due, got %+v", due)
+	}
+}
+
+func TestWebhookService_RecordAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	deliveriesRepo := mock.NewMockWebhookDeliveriesRepo(ctrl)
+	s := services.NewWebhookService(repo, deliveriesRepo, mock.NewMockHostGuard(ctrl))
+
+	webhook := &models.Webhook{ID: 1, Failures: 8}
+	repo.EXPECT().FindByID(int64(1)).Return(webhook, nil).AnyTimes()
+	repo.EXPECT().Save(webhook).Return(nil).AnyTimes()
+	deliveriesRepo.EXPECT().Save(gomock.Any()).Return(nil).AnyTimes()
+
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	failure := errors.New("endpoint responded 503")
+
+	delivery := &models.WebhookDelivery{ID: 5, WebhookID: 1, Status: models.DeliveryPending}
+	for attempt, delay := range []time.Duration{30 * time.Second, time.Minute} {
+		if err := s.RecordAttempt(delivery, 503, failure, at); err != nil {
+			t.Fatal(err)
+		}
+		if delivery.Attempts != attempt+1 || !delivery.NextAttemptAt.Equal(at.Add(delay)) {
+			t.Errorf("attempt %d: expected next attempt in %s, got %+v", attempt+1, delay, delivery)
+		}
+	}
+	if !webhook.Disabled() || webhook.Failures != 10 {
+		t.Errorf("expected the webhook to be disabled after 10 failures, got %+v", webhook)
+	}
+
+	delivery.Attempts = 7
+	if err := s.RecordAttempt(delivery, 0, failure, at); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliveryFailed || delivery.NextAttemptAt != nil {
+		t.Errorf("expected the delivery to fail for good, got %+v", delivery)
+	}
+
+	delivered := &models.WebhookDelivery{ID: 6, WebhookID: 1, Status: models.DeliveryPending}
+	if err := s.RecordAttempt(delivered, 204, nil, at); err != nil {
+		t.Fatal(err)
+	}
+	if delivered.Status != models.DeliveryDelivered || delivered.DeliveredAt == nil || webhook.Failures != 0 {
+		t.Errorf("expected the delivery to be delivered, got %+v", delivered)
+	}
+}
+
+func TestWebhookService_ReplayDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	deliveriesRepo := mock.NewMockWebhookDeliveriesRepo(ctrl)
+	s := services.NewWebhookService(repo, deliveriesRepo, mock.NewMockHostGuard(ctrl))
+
+	original := &models.WebhookDelivery{
+		ID: 5, WebhookID: 1, Event: models.EventItemDeleted, Payload: []byte(`{"event":"item.deleted"}`),
+		Status: models.DeliveryFailed, Attempts: 8,
+	}
+	deliveriesRepo.EXPECT().FindByID(int64(5)).Return(original, nil).Times(2)
+	repo.EXPECT().FindByID(int64(1)).Return(&models.Webhook{ID: 1, Owner: "alice"}, nil).Times(2)
+	deliveriesRepo.EXPECT().Create(gomock.Any()).Return(int64(6), nil)
+
+	delivery, err := s.ReplayDelivery("alice", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.ID != 6 || delivery.ReplayOf != 5 || delivery.Status != models.DeliveryPending || delivery.Attempts != 0 {
+		t.Errorf("unexpected replayed delivery %+v", delivery)
+	}
+	if string(delivery.Payload) != string(original.Payload) {
+		t.Errorf("expected the same payload, got %s", delivery.Payload)
+	}
+
+	if _, err = s.ReplayDelivery("bob", 5); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestWebhookService_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhooksRepo(ctrl)
+	deliveriesRepo := mock.NewMockWebhookDeliveriesRepo(ctrl)
+	s := services.NewWebhookService(repo, deliveriesRepo, mock.NewMockHostGuard(ctrl))
+
+	disabledAt := time.Now()
+	webhook := &models.Webhook{ID: 1, Owner: "alice", Failures: 10, DisabledAt: &disabledAt}
+	repo.EXPECT().FindByID(int64(1)).Return(webhook, nil).Times(2)
+	repo.EXPECT().Save(webhook).Return(nil)
+	deliveriesRepo.EXPECT().DeleteByWebhookID(int64(1)).Return(nil)
+	repo.EXPECT().Delete(webhook).Return(nil)
+
+	enabled, err := s.EnableWebhook("alice", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enabled.Disabled() || enabled.Failures != 0 {
+		t.Errorf("expected enabled webhook, got %+v", enabled)
+	}
+
+	if err = s.DeleteWebhook("alice", 1); err != nil {
+		t.Fatal(err)
+	}
+}
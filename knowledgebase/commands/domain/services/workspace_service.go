@@ -0,0 +1,242 @@
+// Package services contains domain business rules.
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+const maxWorkspaceNameLength = 64
+
+//go:generate mockgen -package=mock -destination=../../mock/mock_workspace_service.go -source=workspace_service.go WorkspaceService
+
+// WorkspaceService represents a service that provides functionality related to the models.Workspace.
+// Actions are allowed by the role of the acting member, actions not granted by the role are ErrForbidden.
+type WorkspaceService interface {
+	NewWorkspace(creator, name string) (*models.Workspace, error)
+	InviteMember(actor string, workspaceID int64, login, role string) (*models.WorkspaceInvitation, error)
+	ListInvitations(login string) ([]*models.WorkspaceInvitation, error)
+	AcceptInvitation(login string, invitationID int64) (*models.WorkspaceMember, error)
+	ChangeMemberRole(actor string, workspaceID int64, login, role string) (*models.WorkspaceMember, error)
+	Authorize(login string, workspaceID int64, permission string) (string, error)
+}
+
+// workspaceService is a set of business rules & actions related to the Workspace.
+type workspaceService struct {
+	repo            repositories.WorkspacesRepo
+	membersRepo     repositories.WorkspaceMembersRepo
+	invitationsRepo repositories.WorkspaceInvitationsRepo
+	usersRepo       repositories.UsersRepo
+}
+
+// NewWorkspaceService function makes new instance of WorkspaceService.
+func NewWorkspaceService(
+	repo repositories.WorkspacesRepo,
+	membersRepo repositories.WorkspaceMembersRepo,
+	invitationsRepo repositories.WorkspaceInvitationsRepo,
+	usersRepo repositories.UsersRepo,
+) WorkspaceService {
+	return &workspaceService{
+		repo:            repo,
+		membersRepo:     membersRepo,
+		invitationsRepo: invitationsRepo,
+		usersRepo:       usersRepo,
+	}
+}
+
+// NewWorkspace function stores new models.Workspace, the creator becomes its owner.
+func (s *workspaceService) NewWorkspace(creator, name string) (*models.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxWorkspaceNameLength {
+		return nil, newValidationError("name must be 1 to %d characters", maxWorkspaceNameLength)
+	}
+
+	createdAt := time.Now()
+
+	workspace := &models.Workspace{
+		Name:      name,
+		CreatedAt: &createdAt,
+	}
+
+	var err error
+	workspace.ID, err = s.repo.Create(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.membersRepo.Save(&models.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		Login:       creator,
+		Role:        models.RoleOwner,
+		JoinedAt:    &createdAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// InviteMember function stores new models.WorkspaceInvitation of the user to the workspace.
+// Only members allowed to manage the workspace invite others.
+func (s *workspaceService) InviteMember(
+	actor string,
+	workspaceID int64,
+	login, role string,
+) (*models.WorkspaceInvitation, error) {
+	if _, err := s.member(actor, workspaceID, models.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if !models.ValidRole(role) {
+		return nil, newValidationError("role %q is unknown", role)
+	}
+
+	user, err := s.usersRepo.FindByLogin(login)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, newValidationError("user %q doesn't exist", login)
+	}
+
+	existing, err := s.membersRepo.Find(workspaceID, login)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, newValidationError("user %q is already a member", login)
+	}
+
+	createdAt := time.Now()
+
+	invitation := &models.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Login:       login,
+		Role:        role,
+		InvitedBy:   actor,
+		CreatedAt:   &createdAt,
+	}
+
+	invitation.ID, err = s.invitationsRepo.Create(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations function returns the invitations waiting for the user.
+func (s *workspaceService) ListInvitations(login string) ([]*models.WorkspaceInvitation, error) {
+	return s.invitationsRepo.FindByLogin(login)
+}
+
+// AcceptInvitation function makes the invited user a member with the role of the invitation.
+// Invitations of other users are ErrForbidden.
+func (s *workspaceService) AcceptInvitation(login string, invitationID int64) (*models.WorkspaceMember, error) {
+	invitation, err := s.invitationsRepo.FindByID(invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Login != login {
+		return nil, ErrForbidden
+	}
+
+	joinedAt := time.Now()
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		Login:       login,
+		Role:        invitation.Role,
+		JoinedAt:    &joinedAt,
+	}
+
+	if err = s.membersRepo.Save(member); err != nil {
+		return nil, err
+	}
+
+	if err = s.invitationsRepo.Delete(invitation); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ChangeMemberRole function gives the member new role, the workspace always keeps at least one owner.
+func (s *workspaceService) ChangeMemberRole(
+	actor string,
+	workspaceID int64,
+	login, role string,
+) (*models.WorkspaceMember, error) {
+	if _, err := s.member(actor, workspaceID, models.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if !models.ValidRole(role) {
+		return nil, newValidationError("role %q is unknown", role)
+	}
+
+	members, err := s.membersRepo.FindByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.WorkspaceMember
+	owners := 0
+	for _, member := range members {
+		if member.Role == models.RoleOwner {
+			owners++
+		}
+		if member.Login == login {
+			target = member
+		}
+	}
+
+	if target == nil {
+		return nil, newValidationError("user %q is not a member", login)
+	}
+	if target.Role == models.RoleOwner && role != models.RoleOwner && owners == 1 {
+		return nil, newValidationError("workspace must keep an owner")
+	}
+
+	target.Role = role
+	if err = s.membersRepo.Save(target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// Authorize function checks the user is allowed to act in the workspace and returns the owner
+// the items and categories are kept under. Zero workspace ID is the personal knowledge base of the user,
+// so the login itself is returned. Users who aren't members or whose role doesn't grant the permission
+// are ErrForbidden.
+func (s *workspaceService) Authorize(login string, workspaceID int64, permission string) (string, error) {
+	if workspaceID == 0 {
+		return login, nil
+	}
+
+	if _, err := s.member(login, workspaceID, permission); err != nil {
+		return "", err
+	}
+
+	return models.WorkspaceOwner(workspaceID), nil
+}
+
+// member function returns the member of the workspace whose role grants the permission.
+func (s *workspaceService) member(login string, workspaceID int64, permission string) (*models.WorkspaceMember, error) {
+	member, err := s.membersRepo.Find(workspaceID, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil || !member.Can(permission) {
+		return nil, ErrForbidden
+	}
+
+	return member, nil
+}
@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorkspaceService_NewWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWorkspacesRepo(ctrl)
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	s := services.NewWorkspaceService(
+		repo,
+		membersRepo,
+		mock.NewMockWorkspaceInvitationsRepo(ctrl),
+		mock.NewMockUsersRepo(ctrl),
+	)
+
+	repo.EXPECT().Create(gomock.Any()).Return(int64(5), nil)
+	membersRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(member *models.WorkspaceMember) error {
+		if member.WorkspaceID != 5 || member.Login != "alice" || member.Role != models.RoleOwner {
+			t.Errorf("expected alice to own the workspace, got %+v", member)
+		}
+
+		return nil
+	})
+
+	workspace, err := s.NewWorkspace("alice", " Platform team ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspace.ID != 5 || workspace.Name != "Platform team" || workspace.Owner() != "workspace:5" {
+		t.Errorf("unexpected workspace %+v", workspace)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.NewWorkspace("alice", " "); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestWorkspaceService_InviteMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	invitationsRepo := mock.NewMockWorkspaceInvitationsRepo(ctrl)
+	usersRepo := mock.NewMockUsersRepo(ctrl)
+	s := services.NewWorkspaceService(mock.NewMockWorkspacesRepo(ctrl), membersRepo, invitationsRepo, usersRepo)
+
+	owner := &models.WorkspaceMember{WorkspaceID: 5, Login: "alice", Role: models.RoleOwner}
+	editor := &models.WorkspaceMember{WorkspaceID: 5, Login: "carol", Role: models.RoleEditor}
+	membersRepo.EXPECT().Find(int64(5), "alice").Return(owner, nil).AnyTimes()
+	membersRepo.EXPECT().Find(int64(5), "carol").Return(editor, nil).AnyTimes()
+	membersRepo.EXPECT().Find(int64(5), "bob").Return(nil, nil)
+	usersRepo.EXPECT().FindByLogin("bob").Return(&models.User{ID: 2, Login: "bob"}, nil)
+	usersRepo.EXPECT().FindByLogin("dave").Return(nil, nil)
+	usersRepo.EXPECT().FindByLogin("carol").Return(&models.User{ID: 3, Login: "carol"}, nil)
+	invitationsRepo.EXPECT().Create(gomock.Any()).Return(int64(9), nil)
+
+	invitation, err := s.InviteMember("alice", 5, "bob", models.RoleReviewer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invitation.ID != 9 || invitation.Role != models.RoleReviewer || invitation.InvitedBy != "alice" {
+		t.Errorf("unexpected invitation %+v", invitation)
+	}
+
+	// editors can't manage members.
+	if _, err = s.InviteMember("carol", 5, "bob", models.RoleViewer); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+
+	invalid := []struct{ login, role string }{{"bob", "admin"}, {"dave", models.RoleViewer}, {"carol", models.RoleViewer}}
+	for _, tc := range invalid {
+		var validationErr *services.ValidationError
+		if _, err = s.InviteMember("alice", 5, tc.login, tc.role); !errors.As(err, &validationErr) {
+			t.Errorf("%s %s: expected validation error, got %v", tc.login, tc.role, err)
+		}
+	}
+}
+
+func TestWorkspaceService_AcceptInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	invitationsRepo := mock.NewMockWorkspaceInvitationsRepo(ctrl)
+	s := services.NewWorkspaceService(
+		mock.NewMockWorkspacesRepo(ctrl),
+		membersRepo,
+		invitationsRepo,
+		mock.NewMockUsersRepo(ctrl),
+	)
+
+	invitation := &models.WorkspaceInvitation{ID: 9, WorkspaceID: 5, Login: "bob", Role: models.RoleReviewer}
+	invitationsRepo.EXPECT().FindByID(int64(9)).Return(invitation, nil).Times(2)
+	membersRepo.EXPECT().Save(gomock.Any()).Return(nil)
+	invitationsRepo.EXPECT().Delete(invitation).Return(nil)
+
+	if _, err := s.AcceptInvitation("carol", 9); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+
+	member, err := s.AcceptInvitation("bob", 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.WorkspaceID != 5 || member.Login != "bob" || member.Role != models.RoleReviewer || member.JoinedAt == nil {
+		t.Errorf("unexpected member %+v", member)
+	}
+}
+
+func TestWorkspaceService_ChangeMemberRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	s := services.NewWorkspaceService(
+		mock.NewMockWorkspacesRepo(ctrl),
+		membersRepo,
+		mock.NewMockWorkspaceInvitationsRepo(ctrl),
+		mock.NewMockUsersRepo(ctrl),
+	)
+
+	alice := &models.WorkspaceMember{WorkspaceID: 5, Login: "alice", Role: models.RoleOwner}
+	bob := &models.WorkspaceMember{WorkspaceID: 5, Login: "bob", Role: models.RoleViewer}
+	membersRepo.EXPECT().Find(int64(5), "alice").Return(alice, nil).AnyTimes()
+	membersRepo.EXPECT().FindByWorkspace(int64(5)).Return([]*models.WorkspaceMember{alice, bob}, nil).AnyTimes()
+	membersRepo.EXPECT().Save(bob).Return(nil)
+
+	member, err := s.ChangeMemberRole("alice", 5, "bob", models.RoleEditor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Role != models.RoleEditor {
+		t.Errorf("expected editor, got %s", member.Role)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err = s.ChangeMemberRole("alice", 5, "alice", models.RoleEditor); !errors.As(err, &validationErr) {
+		t.Errorf("expected the last owner to stay, got %v", err)
+	}
+	if _, err = s.ChangeMemberRole("alice", 5, "dave", models.RoleEditor); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for not a member, got %v", err)
+	}
+}
+
+func TestWorkspaceService_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	membersRepo := mock.NewMockWorkspaceMembersRepo(ctrl)
+	s := services.NewWorkspaceService(
+		mock.NewMockWorkspacesRepo(ctrl),
+		membersRepo,
+		mock.NewMockWorkspaceInvitationsRepo(ctrl),
+		mock.NewMockUsersRepo(ctrl),
+	)
+
+	membersRepo.EXPECT().Find(int64(5), "bob").
+		Return(&models.WorkspaceMember{WorkspaceID: 5, Login: "bob", Role: models.RoleReviewer}, nil).AnyTimes()
+	membersRepo.EXPECT().Find(int64(5), "eve").Return(nil, nil)
+
+	owner, err := s.Authorize("bob", 0, models.PermissionEdit)
+	if err != nil || owner != "bob" {
+		t.Errorf("expected personal knowledge base of bob, got %q %v", owner, err)
+	}
+
+	owner, err = s.Authorize("bob", 5, models.PermissionReview)
+	if err != nil || owner != "workspace:5" {
+		t.Errorf("expected workspace owner, got %q %v", owner, err)
+	}
+
+	if _, err = s.Authorize("bob", 5, models.PermissionEdit); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected reviewer not to edit, got %v", err)
+	}
+	if _, err = s.Authorize("eve", 5, models.PermissionView); !errors.Is(err, services.ErrForbidden) {
+		t.Errorf("expected stranger to be forbidden, got %v", err)
+	}
+}
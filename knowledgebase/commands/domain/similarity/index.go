@@ -0,0 +1,108 @@
+package similarity
+
+import (
+	"slices"
+	"sort"
+)
+
+// Index type finds candidate duplicates without comparing every pair of items. Items are candidates
+// when their data share a band of the MinHash signature or their normalized titles or anchors are equal.
+// Candidates still have to be compared, since the index lets through some dissimilar pairs.
+type Index struct {
+	fingerprints map[int64]*Fingerprint
+	buckets      map[bucket][]int64
+}
+
+// bucket represents the key items are grouped by, kind tells bands, titles and anchors apart.
+type bucket struct {
+	kind  int
+	band  int
+	hash  uint64
+	value string
+}
+
+const (
+	bandBucket = iota
+	titleBucket
+	anchorBucket
+)
+
+// NewIndex function builds new empty instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		fingerprints: make(map[int64]*Fingerprint),
+		buckets:      make(map[bucket][]int64),
+	}
+}
+
+// Add function puts the fingerprint of the item with the given ID into the index,
+// replacing the previously indexed fingerprint of the item.
+func (ix *Index) Add(id int64, fp *Fingerprint) {
+	ix.Remove(id)
+
+	ix.fingerprints[id] = fp
+	for _, key := range buckets(fp) {
+		ix.buckets[key] = append(ix.buckets[key], id)
+	}
+}
+
+// Remove function drops the fingerprint of the item with the given ID from the index.
+func (ix *Index) Remove(id int64) {
+	fp, ok := ix.fingerprints[id]
+	if !ok {
+		return
+	}
+
+	delete(ix.fingerprints, id)
+	for _, key := range buckets(fp) {
+		ix.buckets[key] = slices.DeleteFunc(ix.buckets[key], func(other int64) bool { return other == id })
+		if len(ix.buckets[key]) == 0 {
+			delete(ix.buckets, key)
+		}
+	}
+}
+
+// Len function returns the number of the indexed items.
+func (ix *Index) Len() int {
+	return len(ix.fingerprints)
+}
+
+// Fingerprint function returns the fingerprint of the item or nil.
+func (ix *Index) Fingerprint(id int64) *Fingerprint {
+	return ix.fingerprints[id]
+}
+
+// Candidates function returns IDs of the indexed items which might be similar to the fingerprint, ordered by ID.
+func (ix *Index) Candidates(fp *Fingerprint) []int64 {
+	seen := make(map[int64]bool)
+	for _, key := range buckets(fp) {
+		for _, id := range ix.buckets[key] {
+			seen[id] = true
+		}
+	}
+
+	ids := make([]int64, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func buckets(fp *Fingerprint) []bucket {
+	var keys []bucket
+	if fp.dataShingles > 0 {
+		for band, hash := range fp.data.bands() {
+			keys = append(keys, bucket{kind: bandBucket, band: band, hash: hash})
+		}
+	}
+	if fp.title != "" {
+		keys = append(keys, bucket{kind: titleBucket, value: fp.title})
+	}
+	if fp.anchor != "" {
+		keys = append(keys, bucket{kind: anchorBucket, value: fp.anchor})
+	}
+
+	return keys
+}
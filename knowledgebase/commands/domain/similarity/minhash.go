@@ -0,0 +1,104 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+const (
+	// numHashes is the size of the MinHash signature, the error of the estimate is about 1/sqrt(numHashes).
+	numHashes = 128
+	// numBands splits the signature for locality-sensitive hashing. Items with the similarity s share
+	// a band with the probability 1-(1-s^r)^b, it is about 0.5 for s=0.5 with r=4 rows per band.
+	numBands    = 32
+	rowsPerBand = numHashes / numBands
+	// shingleSize is the number of words in a shingle.
+	shingleSize = 3
+)
+
+// Signature represents MinHash of the set of shingles.
+type Signature [numHashes]uint64
+
+// NewSignature function builds MinHash signature of the shingles: for every of the hash functions
+// it keeps the minimal hash among the shingles.
+func NewSignature(shingles []string) Signature {
+	var sig Signature
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	for _, shingle := range shingles {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		base := h.Sum64()
+
+		for i := range sig {
+			sig[i] = min(sig[i], mix(base^seed(i)))
+		}
+	}
+
+	return sig
+}
+
+// Similarity function estimates Jaccard similarity of the shingle sets as the share of equal minimums.
+func (s Signature) Similarity(other Signature) float64 {
+	equal := 0
+	for i := range s {
+		if s[i] == other[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / numHashes
+}
+
+// bands function returns hashes of the signature bands, similar signatures are likely to share any of them.
+func (s Signature) bands() [numBands]uint64 {
+	var result [numBands]uint64
+	for band := range result {
+		h := uint64(band)
+		for _, value := range s[band*rowsPerBand : (band+1)*rowsPerBand] {
+			h = mix(h ^ value)
+		}
+		result[band] = h
+	}
+
+	return result
+}
+
+// wordShingles function returns sequences of shingleSize words of the normalized text.
+// Shorter texts make a single shingle of all their words.
+func wordShingles(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	ws := strings.Split(text, " ")
+	if len(ws) <= shingleSize {
+		return []string{text}
+	}
+
+	shingles := make([]string, 0, len(ws)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(ws); i++ {
+		shingles = append(shingles, strings.Join(ws[i:i+shingleSize], " "))
+	}
+
+	return shingles
+}
+
+// seed function returns the constant which turns the base hash into the i-th hash function.
+func seed(i int) uint64 {
+	return mix(uint64(i) + 0x9e3779b97f4a7c15)
+}
+
+// mix function is the SplitMix64 finalizer, it spreads bits of the value over the whole word.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
@@ -0,0 +1,122 @@
+// Package similarity contains measures of closeness of the knowledge items used to detect duplicates
+// and suggest related items. Titles and anchors are short, they are compared exactly by character
+// trigrams of the normalized text. Data is compared by MinHash signatures of word shingles, which
+// estimate the same Jaccard similarity and let the Index find candidate pairs without comparing
+// every item with every other. Topics of the texts are compared by TF-IDF vectors of the Corpus.
+package similarity
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+// Weights of the fields in the similarity score, the data matters most since it is the fact itself.
+const (
+	titleWeight  = 0.35
+	anchorWeight = 0.15
+	dataWeight   = 0.5
+)
+
+// Fingerprint represents prepared texts of the knowledge item, it is built once and compared many times.
+type Fingerprint struct {
+	title        string
+	anchor       string
+	titleGrams   map[string]struct{}
+	anchorGrams  map[string]struct{}
+	data         Signature
+	dataShingles int
+}
+
+// NewFingerprint function prepares texts of the item for comparison.
+func NewFingerprint(title, anchor, data string) *Fingerprint {
+	fp := &Fingerprint{
+		title:  Normalize(title),
+		anchor: Normalize(anchor),
+	}
+	fp.titleGrams = trigrams(fp.title)
+	fp.anchorGrams = trigrams(fp.anchor)
+
+	shingles := wordShingles(Normalize(data))
+	fp.data = NewSignature(shingles)
+	fp.dataShingles = len(shingles)
+
+	return fp
+}
+
+// ItemFingerprint function prepares texts of the knowledge item for comparison.
+func ItemFingerprint(item *models.KnowledgeItem) *Fingerprint {
+	return NewFingerprint(item.Title, item.Anchor, item.Data)
+}
+
+// Compare function measures similarity of two fingerprints. The score is the weighted sum of the fields,
+// but the same data alone is enough, since it is the same fact under another title.
+func Compare(a, b *Fingerprint) *models.Similarity {
+	s := &models.Similarity{
+		Title:  textSimilarity(a.title, b.title, a.titleGrams, b.titleGrams),
+		Anchor: textSimilarity(a.anchor, b.anchor, a.anchorGrams, b.anchorGrams),
+	}
+	if a.dataShingles > 0 && b.dataShingles > 0 {
+		s.Data = a.data.Similarity(b.data)
+	}
+
+	s.Score = max(titleWeight*s.Title+anchorWeight*s.Anchor+dataWeight*s.Data, s.Data)
+
+	return s
+}
+
+// Normalize function lowercases the text and keeps only words of letters and digits separated by single spaces,
+// so punctuation and formatting don't make the same text look different.
+func Normalize(text string) string {
+	return strings.Join(words(text), " ")
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// textSimilarity function returns Jaccard similarity of the trigram sets, empty texts are never similar.
+func textSimilarity(a, b string, aGrams, bGrams map[string]struct{}) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	return jaccard(aGrams, bGrams)
+}
+
+// trigrams function returns character trigrams of the normalized text padded with spaces,
+// so short words still produce grams and word boundaries count.
+func trigrams(text string) map[string]struct{} {
+	grams := make(map[string]struct{})
+	if text == "" {
+		return grams
+	}
+
+	runes := []rune(" " + text + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = struct{}{}
+	}
+
+	return grams
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for gram := range a {
+		if _, ok := b[gram]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
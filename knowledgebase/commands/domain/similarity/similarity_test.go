@@ -0,0 +1,152 @@
+package similarity_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/similarity"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"What is a Goroutine?":        "what is a goroutine",
+		"  go-lang,\tGO   runtime!  ": "go lang go runtime",
+		"Ünïcode Straße":              "ünïcode straße",
+		"":                            "",
+		"***":                         "",
+	}
+
+	for text, expected := range cases {
+		if normalized := similarity.Normalize(text); normalized != expected {
+			t.Errorf("expected %q for %q, got %q", expected, text, normalized)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	goroutine := similarity.NewFingerprint(
+		"What is a goroutine?",
+		"goroutine",
+		"Goroutine is a lightweight thread managed by the Go runtime, goroutines are multiplexed onto OS threads.",
+	)
+
+	cases := []struct {
+		name               string
+		other              *similarity.Fingerprint
+		minScore, maxScore float64
+		minTitle, minData  float64
+	}{
+		{
+			name: "same text with different formatting",
+			other: similarity.NewFingerprint(
+				"what is a GOROUTINE",
+				"Goroutine",
+				"Goroutine is a lightweight thread, managed by the Go runtime; goroutines are multiplexed onto OS threads!",
+			),
+			minScore: 1, maxScore: 1, minTitle: 1, minData: 1,
+		},
+		{
+			name: "same data under another title",
+			other: similarity.NewFingerprint(
+				"Lightweight threads",
+				"go",
+				"Goroutine is a lightweight thread managed by the Go runtime, goroutines are multiplexed onto OS threads.",
+			),
+			minScore: 1, maxScore: 1, minData: 1,
+		},
+		{
+			name: "slightly reworded",
+			other: similarity.NewFingerprint(
+				"What's a goroutine?",
+				"goroutine",
+				"Goroutine is a lightweight thread managed by the Go runtime, goroutines are scheduled onto OS threads.",
+			),
+			minScore: 0.6, maxScore: 0.95, minTitle: 0.6, minData: 0.4,
+		},
+		{
+			name: "different fact",
+			other: similarity.NewFingerprint(
+				"What is a channel?",
+				"channel",
+				"Channel is a typed conduit through which you can send and receive values with the channel operator.",
+			),
+			maxScore: 0.3,
+		},
+	}
+
+	for _, c := range cases {
+		sim := similarity.Compare(goroutine, c.other)
+		if sim.Score < c.minScore || sim.Score > c.maxScore {
+			t.Errorf("%s: expected score between %v and %v, got %v", c.name, c.minScore, c.maxScore, sim.Score)
+		}
+		if sim.Title < c.minTitle {
+			t.Errorf("%s: expected title similarity at least %v, got %v", c.name, c.minTitle, sim.Title)
+		}
+		if sim.Data < c.minData {
+			t.Errorf("%s: expected data similarity at least %v, got %v", c.name, c.minData, sim.Data)
+		}
+
+		reversed := similarity.Compare(c.other, goroutine)
+		if *reversed != *sim {
+			t.Errorf("%s: expected symmetric similarity, got %v and %v", c.name, sim, reversed)
+		}
+	}
+}
+
+func TestCompare_Empty(t *testing.T) {
+	sim := similarity.Compare(similarity.NewFingerprint("", "", ""), similarity.NewFingerprint("", "", ""))
+	if sim.Score != 0 {
+		t.Errorf("expected empty items not to be similar, got %v", sim)
+	}
+}
+
+func TestSignature_Similarity(t *testing.T) {
+	// sets of 100 shingles sharing 60 have Jaccard similarity 60/140.
+	var a, b []string
+	for i := 0; i < 100; i++ {
+		a = append(a, fmt.Sprintf("shingle %d", i))
+		b = append(b, fmt.Sprintf("shingle %d", i+40))
+	}
+
+	estimate := similarity.NewSignature(a).Similarity(similarity.NewSignature(b))
+	if exact := 60.0 / 140.0; math.Abs(estimate-exact) > 0.15 {
+		t.Errorf("expected estimate close to %v, got %v", exact, estimate)
+	}
+}
+
+func TestIndex_Candidates(t *testing.T) {
+	index := similarity.NewIndex()
+	index.Add(1, similarity.NewFingerprint("Goroutine", "goroutine",
+		"Goroutine is a lightweight thread managed by the Go runtime."))
+	index.Add(2, similarity.NewFingerprint("Green threads", "threads",
+		"Goroutine is a lightweight thread managed by the Go runtime."))
+	index.Add(3, similarity.NewFingerprint("Goroutine", "go statement",
+		"The go statement starts execution of a function call as an independent concurrent thread."))
+	index.Add(4, similarity.NewFingerprint("Channel", "channel",
+		"Channel is a typed conduit through which you send and receive values."))
+
+	candidates := index.Candidates(index.Fingerprint(1))
+	if !slices.Equal(candidates, []int64{1, 2, 3}) {
+		t.Errorf("expected candidates [1 2 3], got %v", candidates)
+	}
+
+	candidates = index.Candidates(index.Fingerprint(4))
+	if !slices.Equal(candidates, []int64{4}) {
+		t.Errorf("expected candidates [4], got %v", candidates)
+	}
+
+	// added again, the item is indexed by its new fingerprint only.
+	index.Add(2, similarity.NewFingerprint("Channel", "channel", "Channels connect goroutines."))
+	candidates = index.Candidates(index.Fingerprint(1))
+	if !slices.Equal(candidates, []int64{1, 3}) {
+		t.Errorf("expected candidates [1 3] after update, got %v", candidates)
+	}
+
+	index.Remove(4)
+	candidates = index.Candidates(index.Fingerprint(2))
+	if !slices.Equal(candidates, []int64{2}) || index.Fingerprint(4) != nil || index.Len() != 3 {
+		t.Errorf("expected removed item to be dropped, got candidates %v", candidates)
+	}
+}
@@ -0,0 +1,107 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// minTermLength is the minimal number of letters of a term, shorter words rarely tell the topic.
+const minTermLength = 3
+
+// stopWords are frequent English words which don't tell the topic of the text.
+var stopWords = map[string]bool{
+	"and": true, "are": true, "but": true, "for": true, "from": true, "has": true, "have": true,
+	"into": true, "its": true, "not": true, "such": true, "that": true, "the": true, "their": true,
+	"then": true, "there": true, "these": true, "they": true, "this": true, "was": true, "which": true,
+	"will": true, "with": true, "you": true,
+}
+
+// Corpus type keeps TF-IDF vectors of the texts, so texts can be compared by the cosine similarity.
+// Terms frequent in the whole corpus weigh less than the ones specific to a few texts.
+type Corpus struct {
+	vectors map[int64]map[string]float64
+}
+
+// NewCorpus function builds TF-IDF vectors of the texts given by IDs.
+func NewCorpus(texts map[int64]string) *Corpus {
+	counts := make(map[int64]map[string]int, len(texts))
+	df := make(map[string]int)
+	for id, text := range texts {
+		tf := make(map[string]int)
+		for _, term := range terms(text) {
+			if tf[term] == 0 {
+				df[term]++
+			}
+			tf[term]++
+		}
+		counts[id] = tf
+	}
+
+	n := float64(len(texts))
+	c := &Corpus{vectors: make(map[int64]map[string]float64, len(texts))}
+	for id, tf := range counts {
+		vector := make(map[string]float64, len(tf))
+
+		var norm float64
+		for term, count := range tf {
+			idf := math.Log((1+n)/(1+float64(df[term]))) + 1
+			weight := (1 + math.Log(float64(count))) * idf
+			vector[term] = weight
+			norm += weight * weight
+		}
+
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+
+		c.vectors[id] = vector
+	}
+
+	return c
+}
+
+// Cosine function returns the cosine similarity of two texts and their shared terms,
+// the terms contributing the most go first.
+func (c *Corpus) Cosine(a, b int64) (float64, []string) {
+	va, vb := c.vectors[a], c.vectors[b]
+	if len(vb) < len(va) {
+		va, vb = vb, va
+	}
+
+	contributions := make(map[string]float64)
+	var total float64
+	for term, weight := range va {
+		if other, ok := vb[term]; ok {
+			contributions[term] = weight * other
+			total += weight * other
+		}
+	}
+
+	shared := make([]string, 0, len(contributions))
+	for term := range contributions {
+		shared = append(shared, term)
+	}
+	sort.Slice(shared, func(i, j int) bool {
+		if contributions[shared[i]] != contributions[shared[j]] {
+			return contributions[shared[i]] > contributions[shared[j]]
+		}
+
+		return shared[i] < shared[j]
+	})
+
+	return min(total, 1), shared
+}
+
+// terms function returns normalized words of the text which tell its topic.
+func terms(text string) []string {
+	var result []string
+	for _, word := range words(text) {
+		if utf8.RuneCountInString(word) >= minTermLength && !stopWords[word] {
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+package similarity_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/similarity"
+)
+
+func TestCorpus_Cosine(t *testing.T) {
+	corpus := similarity.NewCorpus(map[int64]string{
+		1: "Goroutine is a lightweight thread managed by the Go runtime.",
+		2: "The Go runtime schedules goroutines onto operating system threads.",
+		3: "Channel is a typed conduit which connects goroutines.",
+		4: "B-tree keeps the database index sorted.",
+		5: "",
+	})
+
+	scheduling, shared := corpus.Cosine(1, 2)
+	if scheduling <= 0 || scheduling >= 1 {
+		t.Errorf("expected partial similarity, got %v", scheduling)
+	}
+	if !slices.Contains(shared, "runtime") || slices.Contains(shared, "the") || slices.Contains(shared, "go") {
+		t.Errorf("expected shared topic terms without stop words and short words, got %v", shared)
+	}
+
+	unrelated, shared := corpus.Cosine(1, 4)
+	if unrelated != 0 || len(shared) != 0 {
+		t.Errorf("expected no similarity, got %v with %v", unrelated, shared)
+	}
+
+	if self, _ := corpus.Cosine(3, 3); self < 0.999 {
+		t.Errorf("expected text to be similar to itself, got %v", self)
+	}
+
+	if empty, _ := corpus.Cosine(1, 5); empty != 0 {
+		t.Errorf("expected empty text not to be similar, got %v", empty)
+	}
+}
@@ -0,0 +1,93 @@
+// Package tags contains normalization of the tags, so the same tag typed differently is stored once.
+package tags
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Step is a single transformation of the tag in the normalization pipeline.
+type Step func(tag string) string
+
+// Normalizer type applies the configured steps to the tags in order.
+type Normalizer struct {
+	steps []Step
+}
+
+// NewNormalizer function builds new instance of Normalizer with the given steps,
+// the tag is trimmed before and after the steps anyway.
+func NewNormalizer(steps ...Step) *Normalizer {
+	return &Normalizer{steps: steps}
+}
+
+// DefaultNormalizer function builds new instance of Normalizer used when nothing else is configured,
+// it composes Unicode characters, folds the case and replaces whitespace with dashes.
+func DefaultNormalizer() *Normalizer {
+	return NewNormalizer(NFC, FoldCase, DashSpaces)
+}
+
+// Normalize function returns the normalized tag.
+func (n *Normalizer) Normalize(tag string) string {
+	tag = strings.TrimSpace(tag)
+	for _, step := range n.steps {
+		tag = step(tag)
+	}
+
+	return strings.TrimSpace(tag)
+}
+
+// NormalizeAll function returns the normalized tags without duplicates, keeping the order of the first occurrences.
+func (n *Normalizer) NormalizeAll(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = n.Normalize(tag)
+		if seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// Equal function reports whether the tags are the same after the normalization.
+func (n *Normalizer) Equal(a, b string) bool {
+	return n.Normalize(a) == n.Normalize(b)
+}
+
+// NFC step composes Unicode characters, so "é" typed as "e" with a combining accent equals precomposed "é".
+func NFC(tag string) string {
+	return norm.NFC.String(tag)
+}
+
+// FoldCase step makes the tag caseless, so "Go", "GO" and "go" are the same tag.
+func FoldCase(tag string) string {
+	return cases.Fold().String(tag)
+}
+
+// DashSpaces step replaces every run of whitespace with a single dash, so "machine learning"
+// becomes "machine-learning".
+func DashSpaces(tag string) string {
+	return strings.Join(strings.Fields(tag), "-")
+}
+
+// Aliases function builds a step replacing the tags with their canonical names, like "golang" with "go".
+// Keys are matched against the tag normalized by the preceding steps, so the step goes last.
+func Aliases(aliases map[string]string) Step {
+	return func(tag string) string {
+		if canonical, ok := aliases[tag]; ok {
+			return canonical
+		}
+
+		return tag
+	}
+}
@@ -0,0 +1,64 @@
+package tags_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/tags"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	cases := []struct {
+		name     string
+		steps    []tags.Step
+		tag      string
+		expected string
+	}{
+		{name: "no steps only trim", tag: "  Go Lang ", expected: "Go Lang"},
+		{name: "composed accents", steps: []tags.Step{tags.NFC}, tag: "café", expected: "café"},
+		{name: "case folding", steps: []tags.Step{tags.FoldCase}, tag: "Straße", expected: "strasse"},
+		{
+			name:     "whitespace to dash",
+			steps:    []tags.Step{tags.DashSpaces},
+			tag:      "machine \t learning",
+			expected: "machine-learning",
+		},
+		{
+			name:     "aliases after other steps",
+			steps:    []tags.Step{tags.FoldCase, tags.Aliases(map[string]string{"golang": "go"})},
+			tag:      "GoLang",
+			expected: "go",
+		},
+		{
+			name:     "unknown alias",
+			steps:    []tags.Step{tags.Aliases(map[string]string{"golang": "go"})},
+			tag:      "rust",
+			expected: "rust",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tags.NewNormalizer(tc.steps...).Normalize(tc.tag); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizer_NormalizeAll(t *testing.T) {
+	normalizer := tags.DefaultNormalizer()
+
+	got := normalizer.NormalizeAll([]string{"Machine Learning", "go", "machine-learning", "GO"})
+	if fmt.Sprint(got) != "[machine-learning go]" {
+		t.Errorf("expected duplicates to be dropped, got %v", got)
+	}
+
+	if got := normalizer.NormalizeAll(nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+
+	if !normalizer.Equal("Machine Learning", "machine-learning") {
+		t.Error("expected tags to be equal")
+	}
+}
@@ -0,0 +1,205 @@
+package anki
+
+import "strings"
+
+const (
+	schemaVersion    = 11
+	defaultDeckID    = 1
+	defaultConfID    = 1
+	basicModelID     = 1342697561419
+	firstDeckID      = 1342697561420
+	deckSeparator    = "::"
+	noUpdateSequence = -1
+)
+
+// collectionSchema contains tables and indexes of the Anki collection in the legacy schema 11.
+var collectionSchema = []string{
+	`CREATE TABLE col (
+		id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+		ver integer not null, dty integer not null, usn integer not null, ls integer not null,
+		conf text not null, models text not null, decks text not null, dconf text not null, tags text not null)`,
+	`CREATE TABLE notes (
+		id integer primary key, guid text not null, mid integer not null, mod integer not null,
+		usn integer not null, tags text not null, flds text not null, sfld integer not null,
+		csum integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE cards (
+		id integer primary key, nid integer not null, did integer not null, ord integer not null,
+		mod integer not null, usn integer not null, type integer not null, queue integer not null,
+		due integer not null, ivl integer not null, factor integer not null, reps integer not null,
+		lapses integer not null, left integer not null, odue integer not null, odid integer not null,
+		flags integer not null, data text not null)`,
+	`CREATE TABLE revlog (
+		id integer primary key, cid integer not null, usn integer not null, ease integer not null,
+		ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null,
+		type integer not null)`,
+	`CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)`,
+	`CREATE INDEX ix_notes_usn ON notes (usn)`,
+	`CREATE INDEX ix_cards_usn ON cards (usn)`,
+	`CREATE INDEX ix_revlog_usn ON revlog (usn)`,
+	`CREATE INDEX ix_cards_nid ON cards (nid)`,
+	`CREATE INDEX ix_cards_sched ON cards (did, queue, due)`,
+	`CREATE INDEX ix_revlog_cid ON revlog (cid)`,
+	`CREATE INDEX ix_notes_csum ON notes (csum)`,
+}
+
+// collectionConf represents global settings of the collection (col.conf).
+type collectionConf struct {
+	ActiveDecks   []int64 `json:"activeDecks"`
+	CurDeck       int64   `json:"curDeck"`
+	NewSpread     int64   `json:"newSpread"`
+	CollapseTime  int64   `json:"collapseTime"`
+	TimeLim       int64   `json:"timeLim"`
+	EstTimes      bool    `json:"estTimes"`
+	DueCounts     bool    `json:"dueCounts"`
+	CurModel      int64   `json:"curModel"`
+	NextPos       int64   `json:"nextPos"`
+	SortType      string  `json:"sortType"`
+	SortBackwards bool    `json:"sortBackwards"`
+	AddToCur      bool    `json:"addToCur"`
+}
+
+// noteType represents Anki note type (col.models).
+type noteType struct {
+	ID        int64           `json:"id"`
+	Name      string          `json:"name"`
+	Type      int64           `json:"type"`
+	Mod       int64           `json:"mod"`
+	Usn       int64           `json:"usn"`
+	Sortf     int64           `json:"sortf"`
+	Did       int64           `json:"did"`
+	Tmpls     []*cardTemplate `json:"tmpls"`
+	Flds      []*noteField    `json:"flds"`
+	CSS       string          `json:"css"`
+	LatexPre  string          `json:"latexPre"`
+	LatexPost string          `json:"latexPost"`
+	Latexsvg  bool            `json:"latexsvg"`
+	Req       [][]any         `json:"req"`
+	Tags      []string        `json:"tags"`
+	Vers      []int64         `json:"vers"`
+}
+
+type cardTemplate struct {
+	Name  string `json:"name"`
+	Ord   int64  `json:"ord"`
+	Qfmt  string `json:"qfmt"`
+	Afmt  string `json:"afmt"`
+	Bqfmt string `json:"bqfmt"`
+	Bafmt string `json:"bafmt"`
+	Did   *int64 `json:"did"`
+	Bfont string `json:"bfont"`
+	Bsize int64  `json:"bsize"`
+}
+
+type noteField struct {
+	Name   string   `json:"name"`
+	Ord    int64    `json:"ord"`
+	Sticky bool     `json:"sticky"`
+	Rtl    bool     `json:"rtl"`
+	Font   string   `json:"font"`
+	Size   int64    `json:"size"`
+	Media  []string `json:"media"`
+}
+
+// deckEntry represents Anki deck (col.decks).
+type deckEntry struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	Mod              int64   `json:"mod"`
+	Usn              int64   `json:"usn"`
+	LrnToday         []int64 `json:"lrnToday"`
+	RevToday         []int64 `json:"revToday"`
+	NewToday         []int64 `json:"newToday"`
+	TimeToday        []int64 `json:"timeToday"`
+	Collapsed        bool    `json:"collapsed"`
+	BrowserCollapsed bool    `json:"browserCollapsed"`
+	Desc             string  `json:"desc"`
+	Dyn              int64   `json:"dyn"`
+	Conf             int64   `json:"conf"`
+	ExtendNew        int64   `json:"extendNew"`
+	ExtendRev        int64   `json:"extendRev"`
+}
+
+// deckConf represents options group of the decks (col.dconf).
+type deckConf struct {
+	ID       int64          `json:"id"`
+	Name     string         `json:"name"`
+	Mod      int64          `json:"mod"`
+	Usn      int64          `json:"usn"`
+	MaxTaken int64          `json:"maxTaken"`
+	Autoplay bool           `json:"autoplay"`
+	Timer    int64          `json:"timer"`
+	Replayq  bool           `json:"replayq"`
+	Dyn      bool           `json:"dyn"`
+	New      map[string]any `json:"new"`
+	Rev      map[string]any `json:"rev"`
+	Lapse    map[string]any `json:"lapse"`
+}
+
+func newBasicNoteType(mod int64) *noteType {
+	return &noteType{
+		ID:    basicModelID,
+		Name:  "Basic",
+		Mod:   mod,
+		Usn:   noUpdateSequence,
+		Did:   defaultDeckID,
+		Tmpls: []*cardTemplate{{Name: "Card 1", Qfmt: "{{Front}}", Afmt: "{{FrontSide}}\n\n<hr id=answer>\n\n{{Back}}"}},
+		Flds: []*noteField{
+			{Name: "Front", Ord: 0, Font: "Arial", Size: 20, Media: []string{}},
+			{Name: "Back", Ord: 1, Font: "Arial", Size: 20, Media: []string{}},
+		},
+		CSS: ".card {\n font-family: arial;\n font-size: 20px;\n text-align: center;\n color: black;\n" +
+			" background-color: white;\n}\n",
+		LatexPre: "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n" +
+			"\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		LatexPost: "\\end{document}",
+		Req:       [][]any{{0, "any", []int64{0}}},
+		Tags:      []string{},
+		Vers:      []int64{},
+	}
+}
+
+func newDeckEntry(id int64, name string, mod int64) *deckEntry {
+	return &deckEntry{
+		ID:        id,
+		Name:      name,
+		Mod:       mod,
+		Usn:       noUpdateSequence,
+		LrnToday:  []int64{0, 0},
+		RevToday:  []int64{0, 0},
+		NewToday:  []int64{0, 0},
+		TimeToday: []int64{0, 0},
+		Conf:      defaultConfID,
+	}
+}
+
+func newDefaultDeckConf() *deckConf {
+	return &deckConf{
+		ID:       defaultConfID,
+		Name:     "Default",
+		MaxTaken: 60,
+		Autoplay: true,
+		Replayq:  true,
+		New: map[string]any{
+			"delays": []int64{1, 10}, "ints": []int64{1, 4, 0}, "initialFactor": 2500,
+			"order": 1, "perDay": 20, "bury": false,
+		},
+		Rev: map[string]any{
+			"perDay": 200, "ease4": 1.3, "ivlFct": 1, "maxIvl": 36500, "bury": false, "hardFactor": 1.2,
+		},
+		Lapse: map[string]any{
+			"delays": []int64{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 1,
+		},
+	}
+}
+
+// deckAncestors function returns names of all parent decks, Anki requires them to exist.
+func deckAncestors(name string) []string {
+	parts := strings.Split(name, deckSeparator)
+
+	ancestors := make([]string, 0, len(parts)-1)
+	for i := 1; i < len(parts); i++ {
+		ancestors = append(ancestors, strings.Join(parts[:i], deckSeparator))
+	}
+
+	return ancestors
+}
@@ -0,0 +1,32 @@
+package anki
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	lineBreaksRe = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</li>`)
+	tagsRe       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// fieldToText function converts HTML content of the Anki note field to the plain text.
+func fieldToText(field string) string {
+	text := lineBreaksRe.ReplaceAllString(field, "\n")
+	text = tagsRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// textToField function converts plain text to the HTML content of the Anki note field.
+func textToField(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
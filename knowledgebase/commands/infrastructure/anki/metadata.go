@@ -0,0 +1,77 @@
+package anki
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+// Notes are written with the stock basic note type, so the title and the review state of the knowledge item
+// travel as hierarchical tags under metadataTagPrefix. Values are percent-encoded, since Anki tags can't
+// contain spaces.
+const (
+	metadataTagPrefix = "neurography" + deckSeparator
+	titleTag          = metadataTagPrefix + "title" + deckSeparator
+	scoreTag          = metadataTagPrefix + "score" + deckSeparator
+	lastMarkTag       = metadataTagPrefix + "last_mark" + deckSeparator
+)
+
+// metadataTags function returns tags which keep title and review state of the note.
+func metadataTags(note *models.AnkiNote) []string {
+	var tags []string
+	if note.Title != "" {
+		tags = append(tags, titleTag+url.PathEscape(note.Title))
+	}
+
+	if note.ReviewState != nil {
+		tags = append(tags,
+			scoreTag+strconv.FormatInt(note.ReviewState.Score, 10),
+			lastMarkTag+strconv.FormatInt(note.ReviewState.LastMark, 10),
+		)
+	}
+
+	return tags
+}
+
+// readMetadataTags function restores title and review state of the note from its tags and removes them
+// from the note tags. The tags which can't be decoded are dropped, the review state is kept only when both
+// score and the last mark are read.
+func readMetadataTags(note *models.AnkiNote) {
+	var (
+		tags            []string
+		score, lastMark *int64
+	)
+	for _, tag := range note.Tags {
+		if !strings.HasPrefix(tag, metadataTagPrefix) {
+			tags = append(tags, tag)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(tag, titleTag):
+			if title, err := url.PathUnescape(strings.TrimPrefix(tag, titleTag)); err == nil {
+				note.Title = title
+			}
+		case strings.HasPrefix(tag, scoreTag):
+			score = parseMetadataInt(strings.TrimPrefix(tag, scoreTag))
+		case strings.HasPrefix(tag, lastMarkTag):
+			lastMark = parseMetadataInt(strings.TrimPrefix(tag, lastMarkTag))
+		}
+	}
+
+	note.Tags = tags
+	if score != nil && lastMark != nil {
+		note.ReviewState = &models.AnkiReviewState{Score: *score, LastMark: *lastMark}
+	}
+}
+
+func parseMetadataInt(value string) *int64 {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
@@ -0,0 +1,309 @@
+// Package anki contains adapters between the knowledge base and Anki deck packages (.apkg).
+package anki
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+const (
+	collectionFile       = "collection.anki2"
+	collectionFileV21    = "collection.anki21"
+	collectionFileV21b   = "collection.anki21b"
+	mediaFile            = "media"
+	fieldsSeparator      = "\x1f"
+	minReviewEase        = 1
+	maxReviewEase        = 4
+	collectionTempPrefix = "neurography-anki-*"
+)
+
+// ErrUnsupportedPackage is returned when package doesn't contain a collection in the legacy schema.
+// Such packages are produced by recent Anki versions unless "Support older Anki versions" option is checked.
+var ErrUnsupportedPackage = errors.New("anki: package doesn't contain a supported collection")
+
+// PackageReader type reads Anki deck packages. It implements models.AnkiPackageReader.
+//
+// The package doesn't import any SQLite driver itself, the application has to register one
+// and pass its name to NewPackageReader.
+type PackageReader struct {
+	driverName string
+}
+
+// NewPackageReader function builds new instance of PackageReader.
+func NewPackageReader(driverName string) *PackageReader {
+	return &PackageReader{
+		driverName: driverName,
+	}
+}
+
+// Read function reads notes, decks, tags, review log and media list of the package located by path.
+func (r *PackageReader) Read(path string) (*models.AnkiPackage, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("anki: open package: %w", err)
+	}
+	defer archive.Close()
+
+	collection, err := findCollection(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := readMedia(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath, err := extract(collection)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(dbPath)
+
+	db, err := sql.Open(r.driverName, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("anki: open collection: %w", err)
+	}
+	defer db.Close()
+
+	notes, err := readNotes(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AnkiPackage{
+		Notes: notes,
+		Media: media,
+	}, nil
+}
+
+func findCollection(archive *zip.Reader) (*zip.File, error) {
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	if file, ok := files[collectionFileV21]; ok {
+		return file, nil
+	}
+
+	// collection.anki2 is only a placeholder asking to update Anki when the new format is present.
+	if _, ok := files[collectionFileV21b]; ok {
+		return nil, ErrUnsupportedPackage
+	}
+
+	if file, ok := files[collectionFile]; ok {
+		return file, nil
+	}
+
+	return nil, ErrUnsupportedPackage
+}
+
+func readMedia(archive *zip.Reader) ([]string, error) {
+	for _, file := range archive.File {
+		if file.Name != mediaFile {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("anki: open media list: %w", err)
+		}
+		defer rc.Close()
+
+		// media file maps names of the archive entries to the original file names.
+		var media map[string]string
+		if err = json.NewDecoder(rc).Decode(&media); err != nil {
+			return nil, fmt.Errorf("anki: decode media list: %w", err)
+		}
+
+		names := make([]string, 0, len(media))
+		for _, name := range media {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return names, nil
+	}
+
+	return nil, nil
+}
+
+// extract function copies collection database to the temporary file since SQLite can't read from the archive.
+func extract(file *zip.File) (string, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("anki: open collection: %w", err)
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp("", collectionTempPrefix)
+	if err != nil {
+		return "", fmt.Errorf("anki: extract collection: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err = io.Copy(tmp, rc); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("anki: extract collection: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+type deck struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func readDecks(db *sql.DB) (map[int64]string, error) {
+	var raw string
+	err := db.QueryRow("SELECT decks FROM col LIMIT 1").Scan(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("anki: read decks: %w", err)
+	}
+
+	var decks map[string]*deck
+	if err = json.Unmarshal([]byte(raw), &decks); err != nil {
+		return nil, fmt.Errorf("anki: decode decks: %w", err)
+	}
+
+	names := make(map[int64]string, len(decks))
+	for _, d := range decks {
+		// Anki default deck holds notes which were never sorted into any deck.
+		if d.ID == defaultDeckID {
+			continue
+		}
+
+		names[d.ID] = d.Name
+	}
+
+	return names, nil
+}
+
+func readNotes(db *sql.DB) ([]*models.AnkiNote, error) {
+	decks, err := readDecks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT id, tags, flds FROM notes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("anki: read notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*models.AnkiNote
+	index := make(map[int64]*models.AnkiNote)
+	for rows.Next() {
+		var (
+			id           int64
+			tags, fields string
+		)
+		if err = rows.Scan(&id, &tags, &fields); err != nil {
+			return nil, fmt.Errorf("anki: read notes: %w", err)
+		}
+
+		note := &models.AnkiNote{
+			ID: id,
+		}
+		if len(strings.TrimSpace(tags)) > 0 {
+			note.Tags = strings.Fields(tags)
+		}
+		readMetadataTags(note)
+
+		values := strings.Split(fields, fieldsSeparator)
+		note.Front = fieldToText(values[0])
+		if len(values) > 1 {
+			note.Back = fieldToText(values[1])
+		}
+
+		notes = append(notes, note)
+		index[id] = note
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("anki: read notes: %w", err)
+	}
+
+	if err = readNoteDecks(db, decks, index); err != nil {
+		return nil, err
+	}
+
+	if err = readReviews(db, index); err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// readNoteDecks function assigns deck of the first card to the note.
+// Cards placed into filtered decks keep their home deck in the odid column.
+func readNoteDecks(db *sql.DB, decks map[int64]string, notes map[int64]*models.AnkiNote) error {
+	rows, err := db.Query(
+		"SELECT nid, CASE WHEN odid != 0 THEN odid ELSE did END FROM cards ORDER BY nid, ord DESC")
+	if err != nil {
+		return fmt.Errorf("anki: read cards: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var noteID, deckID int64
+		if err = rows.Scan(&noteID, &deckID); err != nil {
+			return fmt.Errorf("anki: read cards: %w", err)
+		}
+
+		if note, ok := notes[noteID]; ok {
+			note.Deck = decks[deckID]
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("anki: read cards: %w", err)
+	}
+
+	return nil
+}
+
+// readReviews function reads review log of all note cards in chronological order.
+// Manual rescheduling entries don't have an answer and are ignored.
+func readReviews(db *sql.DB, notes map[int64]*models.AnkiNote) error {
+	rows, err := db.Query(`
+		SELECT r.id, c.nid, r.ease FROM revlog r
+		JOIN cards c ON c.id = r.cid
+		WHERE r.ease BETWEEN ? AND ?
+		ORDER BY r.id`, minReviewEase, maxReviewEase)
+	if err != nil {
+		return fmt.Errorf("anki: read review log: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewedAt, noteID, ease int64
+		if err = rows.Scan(&reviewedAt, &noteID, &ease); err != nil {
+			return fmt.Errorf("anki: read review log: %w", err)
+		}
+
+		if note, ok := notes[noteID]; ok {
+			note.Reviews = append(note.Reviews, &models.AnkiReview{
+				Ease:       ease,
+				ReviewedAt: time.UnixMilli(reviewedAt).UTC(),
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("anki: read review log: %w", err)
+	}
+
+	return nil
+}
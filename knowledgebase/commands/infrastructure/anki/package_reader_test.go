@@ -0,0 +1,199 @@
+package anki_test
+
+import (
+	"archive/zip"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/anki"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const testDriverName = "sqlite3"
+
+// buildTestPackage function builds .apkg from the SQL statements executed against an empty collection.
+func buildTestPackage(t *testing.T, entries map[string]string, statements ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "collection.anki2")
+
+	db, err := sql.Open(testDriverName, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := []string{
+		`CREATE TABLE col (id integer primary key, decks text not null)`,
+		`CREATE TABLE notes (id integer primary key, tags text not null, flds text not null)`,
+		`CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null,
+			ord integer not null, odid integer not null)`,
+		`CREATE TABLE revlog (id integer primary key, cid integer not null, ease integer not null)`,
+	}
+	for _, statement := range append(schema, statements...) {
+		if _, err = db.Exec(statement); err != nil {
+			t.Fatalf("%s: %s", statement, err)
+		}
+	}
+	if err = db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgPath := filepath.Join(dir, "deck.apkg")
+	file, err := os.Create(pkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	if _, ok := entries["collection.anki2"]; !ok {
+		entries["collection.anki2"] = string(collection)
+	}
+	for name, content := range entries {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return pkgPath
+}
+
+func TestPackageReader_Read(t *testing.T) {
+	path := buildTestPackage(t,
+		map[string]string{"media": `{"0": "diagram.png", "1": "audio.mp3"}`},
+		`INSERT INTO col VALUES (1, '{"1": {"id": 1, "name": "Default"}, "20": {"id": 20, "name": "Go::Runtime"}}')`,
+		`INSERT INTO notes VALUES
+			(100, ' go runtime ', 'What is a <b>goroutine</b>?'||char(31)||'Lightweight thread<br>managed by the Go&nbsp;runtime'),
+			(200, '', 'Single field note')`,
+		`INSERT INTO cards VALUES (1000, 100, 20, 0, 0), (2000, 200, 30, 0, 1)`,
+		`INSERT INTO revlog VALUES
+			(1677672000000, 1000, 3),
+			(1677758400000, 1000, 0),
+			(1677844800000, 1000, 1)`,
+	)
+
+	pkg, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkg.Media) != 2 || pkg.Media[0] != "audio.mp3" || pkg.Media[1] != "diagram.png" {
+		t.Errorf("unexpected media: %v", pkg.Media)
+	}
+
+	if len(pkg.Notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(pkg.Notes))
+	}
+
+	note := pkg.Notes[0]
+	if note.ID != 100 {
+		t.Errorf("expected note ID %d, got %d", 100, note.ID)
+	}
+	if note.Deck != "Go::Runtime" {
+		t.Errorf("expected deck %q, got %q", "Go::Runtime", note.Deck)
+	}
+	if note.Front != "What is a goroutine?" {
+		t.Errorf("unexpected front %q", note.Front)
+	}
+	if note.Back != "Lightweight thread\nmanaged by the Go runtime" {
+		t.Errorf("unexpected back %q", note.Back)
+	}
+	if len(note.Tags) != 2 || note.Tags[0] != "go" || note.Tags[1] != "runtime" {
+		t.Errorf("unexpected tags %v", note.Tags)
+	}
+	if len(note.Reviews) != 2 {
+		t.Fatalf("expected 2 reviews, got %d", len(note.Reviews))
+	}
+	if note.Reviews[0].Ease != 3 || !note.Reviews[0].ReviewedAt.Equal(time.UnixMilli(1677672000000)) {
+		t.Errorf("unexpected first review %+v", note.Reviews[0])
+	}
+	if note.Reviews[1].Ease != 1 {
+		t.Errorf("unexpected second review %+v", note.Reviews[1])
+	}
+
+	// card of the default deck moved into filtered deck and missing back field.
+	note = pkg.Notes[1]
+	if note.Deck != "" {
+		t.Errorf("expected no deck, got %q", note.Deck)
+	}
+	if note.Front != "Single field note" || note.Back != "" {
+		t.Errorf("unexpected fields %q / %q", note.Front, note.Back)
+	}
+	if len(note.Tags) != 0 {
+		t.Errorf("expected no tags, got %v", note.Tags)
+	}
+}
+
+func TestPackageReader_Read_MetadataTags(t *testing.T) {
+	path := buildTestPackage(t, map[string]string{},
+		`INSERT INTO col VALUES (1, '{"1": {"id": 1, "name": "Default"}}')`,
+		`INSERT INTO notes VALUES
+			(100, ' go neurography::title::Goroutines%20and%20threads neurography::score::35 neurography::last_mark::9 ',
+				'What is a goroutine?'||char(31)||'Lightweight thread'),
+			(200, ' neurography::score::35 neurography::last_mark::nine ', 'Broken state'||char(31)||'Dropped')`,
+		`INSERT INTO cards VALUES (1000, 100, 1, 0, 0), (2000, 200, 1, 0, 0)`,
+	)
+
+	pkg, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkg.Notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(pkg.Notes))
+	}
+
+	note := pkg.Notes[0]
+	if note.Title != "Goroutines and threads" {
+		t.Errorf("unexpected title %q", note.Title)
+	}
+	if note.ReviewState == nil || note.ReviewState.Score != 35 || note.ReviewState.LastMark != 9 {
+		t.Errorf("unexpected review state %+v", note.ReviewState)
+	}
+	if len(note.Tags) != 1 || note.Tags[0] != "go" {
+		t.Errorf("expected metadata tags to be removed, got %v", note.Tags)
+	}
+
+	// the state which can't be read is dropped along with its tags.
+	note = pkg.Notes[1]
+	if note.Title != "" || note.ReviewState != nil || len(note.Tags) != 0 {
+		t.Errorf("unexpected note %+v", note)
+	}
+}
+
+func TestPackageReader_Read_UnsupportedPackage(t *testing.T) {
+	path := buildTestPackage(t, map[string]string{"collection.anki21b": "zstd"})
+
+	_, err := anki.NewPackageReader(testDriverName).Read(path)
+	if !errors.Is(err, anki.ErrUnsupportedPackage) {
+		t.Fatalf("expected error %s, got %v", anki.ErrUnsupportedPackage, err)
+	}
+}
+
+func TestPackageReader_Read_NotAnArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.apkg")
+	if err := os.WriteFile(path, []byte("not a zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,289 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1" //nolint:gosec // Anki uses SHA-1 for the note checksums.
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+const (
+	guidPrefix       = "ng"
+	checksumLength   = 8
+	reviewTypeLearn  = 0
+	reviewTypeReview = 1
+)
+
+// PackageWriter type writes Anki deck packages with the basic note type. It implements models.AnkiPackageWriter.
+// Titles and review state of the notes are kept in the metadata tags.
+//
+// The package doesn't import any SQLite driver itself, the application has to register one
+// and pass its name to NewPackageWriter.
+type PackageWriter struct {
+	driverName string
+	now        func() time.Time
+}
+
+// NewPackageWriter function builds new instance of PackageWriter.
+// The clock is used for modification times of the collection entries.
+func NewPackageWriter(driverName string, now func() time.Time) *PackageWriter {
+	return &PackageWriter{
+		driverName: driverName,
+		now:        now,
+	}
+}
+
+// Write function writes notes of the package into the .apkg file located by path.
+func (w *PackageWriter) Write(path string, pkg *models.AnkiPackage) error {
+	dir, err := os.MkdirTemp("", collectionTempPrefix)
+	if err != nil {
+		return fmt.Errorf("anki: create collection: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, collectionFile)
+	if err = w.writeCollection(dbPath, pkg); err != nil {
+		return err
+	}
+
+	return writeArchive(path, dbPath)
+}
+
+func (w *PackageWriter) writeCollection(dbPath string, pkg *models.AnkiPackage) error {
+	db, err := sql.Open(w.driverName, dbPath)
+	if err != nil {
+		return fmt.Errorf("anki: create collection: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("anki: create collection: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit.
+
+	for _, statement := range collectionSchema {
+		if _, err = tx.Exec(statement); err != nil {
+			return fmt.Errorf("anki: create collection: %w", err)
+		}
+	}
+
+	now := w.now()
+	decks := buildDecks(pkg.Notes, now.Unix())
+
+	if err = writeCol(tx, decks, len(pkg.Notes), now); err != nil {
+		return err
+	}
+
+	for i, note := range pkg.Notes {
+		if err = writeNote(tx, note, decks[note.Deck].ID, int64(i+1), now.Unix()); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("anki: create collection: %w", err)
+	}
+
+	return db.Close()
+}
+
+// buildDecks function creates deck for each note deck and all their parents.
+// Notes without deck are placed into the Anki default deck.
+func buildDecks(notes []*models.AnkiNote, mod int64) map[string]*deckEntry {
+	names := make(map[string]bool)
+	for _, note := range notes {
+		if note.Deck == "" {
+			continue
+		}
+
+		names[note.Deck] = true
+		for _, ancestor := range deckAncestors(note.Deck) {
+			names[ancestor] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	decks := map[string]*deckEntry{
+		"": newDeckEntry(defaultDeckID, "Default", mod),
+	}
+	for i, name := range sorted {
+		decks[name] = newDeckEntry(firstDeckID+int64(i), name, mod)
+	}
+
+	return decks
+}
+
+func writeCol(tx *sql.Tx, decks map[string]*deckEntry, notesCount int, now time.Time) error {
+	conf := &collectionConf{
+		ActiveDecks:  []int64{defaultDeckID},
+		CurDeck:      defaultDeckID,
+		CollapseTime: 1200,
+		EstTimes:     true,
+		DueCounts:    true,
+		CurModel:     basicModelID,
+		NextPos:      int64(notesCount + 1),
+		SortType:     "noteFld",
+		AddToCur:     true,
+	}
+
+	decksByID := make(map[string]*deckEntry, len(decks))
+	for _, deck := range decks {
+		decksByID[strconv.FormatInt(deck.ID, 10)] = deck
+	}
+
+	values := make([]string, 0, 4)
+	for _, v := range []any{
+		conf,
+		map[string]*noteType{strconv.FormatInt(basicModelID, 10): newBasicNoteType(now.Unix())},
+		decksByID,
+		map[string]*deckConf{strconv.FormatInt(defaultConfID, 10): newDefaultDeckConf()},
+	} {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("anki: encode collection: %w", err)
+		}
+		values = append(values, string(raw))
+	}
+
+	year, month, day := now.Date()
+	created := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+
+	_, err := tx.Exec(
+		`INSERT INTO col VALUES (1, ?, ?, ?, ?, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		created.Unix(), now.UnixMilli(), now.UnixMilli(), schemaVersion,
+		values[0], values[1], values[2], values[3],
+	)
+	if err != nil {
+		return fmt.Errorf("anki: write collection: %w", err)
+	}
+
+	return nil
+}
+
+// writeNote function writes the note, its single card and review log.
+// Card ID equals to the note ID since basic note type produces exactly one card.
+func writeNote(tx *sql.Tx, note *models.AnkiNote, deckID, position, mod int64) error {
+	front := textToField(note.Front)
+	back := textToField(note.Back)
+	tags := append(slices.Clone(note.Tags), metadataTags(note)...)
+
+	_, err := tx.Exec(
+		`INSERT INTO notes VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, '')`,
+		note.ID, guidPrefix+strconv.FormatInt(note.ID, 36), basicModelID, mod, noUpdateSequence,
+		encodeTags(tags), front+fieldsSeparator+back, fieldToText(front), checksum(fieldToText(front)),
+	)
+	if err != nil {
+		return fmt.Errorf("anki: write note %d: %w", note.ID, err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO cards VALUES (?, ?, ?, 0, ?, ?, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+		note.ID, note.ID, deckID, mod, noUpdateSequence, position,
+	)
+	if err != nil {
+		return fmt.Errorf("anki: write card %d: %w", note.ID, err)
+	}
+
+	var lastReviewID int64
+	for i, review := range note.Reviews {
+		// review log is keyed by the timestamp in milliseconds, so it has to be unique.
+		reviewID := review.ReviewedAt.UnixMilli()
+		if reviewID <= lastReviewID {
+			reviewID = lastReviewID + 1
+		}
+		lastReviewID = reviewID
+
+		reviewType := reviewTypeReview
+		if i == 0 {
+			reviewType = reviewTypeLearn
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO revlog VALUES (?, ?, ?, ?, 0, 0, 0, 0, ?)`,
+			reviewID, note.ID, noUpdateSequence, review.Ease, reviewType,
+		)
+		if err != nil {
+			return fmt.Errorf("anki: write review log of note %d: %w", note.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// encodeTags function joins tags in the Anki way: space separated with surrounding spaces.
+func encodeTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	encoded := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		encoded = append(encoded, strings.Join(strings.Fields(tag), "_"))
+	}
+
+	return " " + strings.Join(encoded, " ") + " "
+}
+
+// checksum function calculates note checksum used by Anki to detect duplicates.
+func checksum(sortField string) int64 {
+	sum := sha1.Sum([]byte(sortField)) //nolint:gosec // Anki uses SHA-1 for the note checksums.
+	value, _ := strconv.ParseInt(fmt.Sprintf("%x", sum)[:checksumLength], 16, 64)
+
+	return value
+}
+
+func writeArchive(path, dbPath string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("anki: create package: %w", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	entry, err := archive.Create(collectionFile)
+	if err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+
+	collection, err := os.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+	defer collection.Close()
+
+	if _, err = io.Copy(entry, collection); err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+
+	entry, err = archive.Create(mediaFile)
+	if err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+	if _, err = entry.Write([]byte("{}")); err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+
+	if err = archive.Close(); err != nil {
+		return fmt.Errorf("anki: write package: %w", err)
+	}
+
+	return file.Close()
+}
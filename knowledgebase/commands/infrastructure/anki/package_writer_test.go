@@ -0,0 +1,199 @@
+package anki_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/anki"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func fixedClock() time.Time {
+	return time.Date(2024, 2, 10, 15, 30, 0, 0, time.UTC)
+}
+
+func testPackage() *models.AnkiPackage {
+	return &models.AnkiPackage{
+		Notes: []*models.AnkiNote{
+			{
+				ID:    5,
+				Deck:  "Engineering::Go",
+				Title: "Goroutines",
+				Front: "What is a goroutine?",
+				Back:  "Lightweight thread\nmanaged by the Go runtime & scheduler",
+				Tags:  []string{"go", "concurrency"},
+				Reviews: []*models.AnkiReview{
+					{Ease: 3, ReviewedAt: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)},
+					{Ease: 1, ReviewedAt: time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)},
+				},
+				ReviewState: &models.AnkiReviewState{Score: 35, LastMark: 1},
+			},
+			{
+				ID:    6,
+				Deck:  "Engineering::Databases",
+				Front: "What does <ACID> stand for?",
+				Back:  "Atomicity, Consistency, Isolation, Durability",
+			},
+			{
+				ID:    7,
+				Front: "Which deck holds notes without category?",
+				Back:  "The Anki default deck",
+				Tags:  []string{"anki"},
+			},
+		},
+	}
+}
+
+// dumpPackage function renders collection content of the package in a stable textual form.
+func dumpPackage(t *testing.T, path string) []byte {
+	t.Helper()
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	out := &bytes.Buffer{}
+	dir := t.TempDir()
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if file.Name != "collection.anki2" {
+			fmt.Fprintf(out, "== %s\n%s\n", file.Name, content)
+			continue
+		}
+
+		dbPath := filepath.Join(dir, file.Name)
+		if err = os.WriteFile(dbPath, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		dumpCollection(t, out, dbPath)
+	}
+
+	return out.Bytes()
+}
+
+func dumpCollection(t *testing.T, out io.Writer, dbPath string) {
+	t.Helper()
+
+	db, err := sql.Open(testDriverName, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, query := range []string{
+		"SELECT id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags FROM col",
+		"SELECT * FROM notes ORDER BY id",
+		"SELECT * FROM cards ORDER BY id",
+		"SELECT * FROM revlog ORDER BY id",
+	} {
+		fmt.Fprintf(out, "== %s\n", query)
+
+		rows, err := db.Query(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		columns, err := rows.Columns()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for rows.Next() {
+			values := make([]any, len(columns))
+			pointers := make([]any, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err = rows.Scan(pointers...); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, column := range columns {
+				if raw, ok := values[i].([]byte); ok {
+					values[i] = string(raw)
+				}
+				fmt.Fprintf(out, "%s: %q\n", column, fmt.Sprint(values[i]))
+			}
+			fmt.Fprintln(out)
+		}
+
+		if err = rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+	}
+}
+
+func TestPackageWriter_Write_Golden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.apkg")
+
+	err := anki.NewPackageWriter(testDriverName, fixedClock).Write(path, testPackage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := dumpPackage(t, path)
+	golden := filepath.Join("testdata", "package_writer.golden")
+
+	if *update {
+		if err = os.WriteFile(golden, actual, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("package content differs from %s, run tests with -update to review changes:\n%s", golden, actual)
+	}
+}
+
+func TestPackageWriter_Write_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.apkg")
+	expected := testPackage()
+
+	err := anki.NewPackageWriter(testDriverName, fixedClock).Write(path, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual.Notes) != len(expected.Notes) {
+		t.Fatalf("expected %d notes, got %d", len(expected.Notes), len(actual.Notes))
+	}
+
+	for i, note := range expected.Notes {
+		if !reflect.DeepEqual(note, actual.Notes[i]) {
+			t.Errorf("note %d: expected %+v, got %+v", i, note, actual.Notes[i])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package anki_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/anki"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.apkg")
+	expected := testPackage()
+
+	if err := anki.NewPackageWriter(testDriverName, fixedClock).Write(path, expected); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual.Notes) != len(expected.Notes) {
+		t.Fatalf("expected %d notes, got %d", len(expected.Notes), len(actual.Notes))
+	}
+
+	for i, want := range expected.Notes {
+		got := actual.Notes[i]
+		if got.ID != want.ID || got.Deck != want.Deck || got.Front != want.Front || got.Back != want.Back {
+			t.Errorf("note %d: expected %+v, got %+v", i, want, got)
+		}
+		if got.Title != want.Title {
+			t.Errorf("note %d: expected title %q, got %q", i, want.Title, got.Title)
+		}
+		if !reflect.DeepEqual(got.ReviewState, want.ReviewState) {
+			t.Errorf("note %d: expected review state %+v, got %+v", i, want.ReviewState, got.ReviewState)
+		}
+		if !reflect.DeepEqual(got.Tags, want.Tags) {
+			t.Errorf("note %d: expected tags %v, got %v", i, want.Tags, got.Tags)
+		}
+		if len(got.Reviews) != len(want.Reviews) {
+			t.Fatalf("note %d: expected %d reviews, got %d", i, len(want.Reviews), len(got.Reviews))
+		}
+		for j, review := range want.Reviews {
+			if got.Reviews[j].Ease != review.Ease || !got.Reviews[j].ReviewedAt.Equal(review.ReviewedAt) {
+				t.Errorf("note %d: expected review %+v, got %+v", i, review, got.Reviews[j])
+			}
+		}
+	}
+}
+
+func TestWriteRead_RoundTrip_TitleWithSpaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.apkg")
+	pkg := testPackage()
+	pkg.Notes[0].Title = "Goroutines & threads: 100% green"
+
+	if err := anki.NewPackageWriter(testDriverName, fixedClock).Write(path, pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := anki.NewPackageReader(testDriverName).Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.Notes[0].Title != pkg.Notes[0].Title {
+		t.Errorf("expected title %q, got %q", pkg.Notes[0].Title, actual.Notes[0].Title)
+	}
+}
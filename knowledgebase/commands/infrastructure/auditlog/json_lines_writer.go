@@ -0,0 +1,93 @@
+// Package auditlog contains destinations of the exported audit log.
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+// ErrOutsideDir is returned when the path of the export leads out of the export directory.
+var ErrOutsideDir = errors.New("audit log can only be exported into the export directory")
+
+// JSONLinesWriter type writes the audit log as JSON Lines, one entry per line in the order given.
+// Paths are relative to the export directory, the log is never written anywhere else.
+// It implements models.AuditLogWriter.
+type JSONLinesWriter struct {
+	dir string
+}
+
+// NewJSONLinesWriter function builds new instance of JSONLinesWriter which exports into the dir.
+func NewJSONLinesWriter(dir string) *JSONLinesWriter {
+	return &JSONLinesWriter{dir: dir}
+}
+
+// Write function replaces the file at the path within the export directory with the entries.
+func (w *JSONLinesWriter) Write(path string, entries []*models.AuditEntry) error {
+	target, err := w.resolve(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(f)
+	encoder := json.NewEncoder(buf)
+	for _, entry := range entries {
+		if err = encoder.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err = buf.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// resolve function returns the file the path refers to, symbolic links are followed,
+// so a link can't lead the export out of the directory either.
+func (w *JSONLinesWriter) resolve(path string) (string, error) {
+	if !filepath.IsLocal(path) {
+		return "", ErrOutsideDir
+	}
+
+	dir, err := filepath.EvalSymlinks(w.dir)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := filepath.EvalSymlinks(filepath.Join(dir, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		// a dangling link would be followed by the export to wherever it points.
+		if _, err = os.Lstat(filepath.Join(dir, path)); err == nil {
+			return "", ErrOutsideDir
+		}
+
+		// the file is created by the export, only the directory it goes into has to exist.
+		var parent string
+		parent, err = filepath.EvalSymlinks(filepath.Join(dir, filepath.Dir(path)))
+		target = filepath.Join(parent, filepath.Base(path))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(dir, target)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", ErrOutsideDir
+	}
+
+	return target, nil
+}
@@ -0,0 +1,92 @@
+package auditlog_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/auditlog"
+)
+
+func TestJSONLinesWriter_Write(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	entries := []*models.AuditEntry{
+		{ID: 1, Actor: "alice", Owner: "alice", Command: "AddKnowledgeItem", Payload: json.RawMessage(`{"title":"Go"}`),
+			Outcome: models.AuditSucceeded, ItemIDs: []int64{7}, CreatedAt: &at},
+		{ID: 2, Actor: "bob", Owner: "workspace:5", Command: "DeleteKnowledgeItem", Payload: json.RawMessage(`{"id":7}`),
+			Outcome: models.AuditFailed, Error: "forbidden", CreatedAt: &at},
+	}
+
+	if err := auditlog.NewJSONLinesWriter(dir).Write("audit.jsonl", entries); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var read []*models.AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := &models.AuditEntry{}
+		if err = json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			t.Fatalf("line %d is not JSON: %v", len(read)+1, err)
+		}
+		read = append(read, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read) != 2 {
+		t.Fatalf("expected two lines, got %d", len(read))
+	}
+	if read[0].Command != "AddKnowledgeItem" || string(read[0].Payload) != `{"title":"Go"}` || read[0].ItemIDs[0] != 7 {
+		t.Errorf("unexpected first entry %+v", read[0])
+	}
+	if read[1].Outcome != models.AuditFailed || read[1].Error != "forbidden" || !read[1].CreatedAt.Equal(at) {
+		t.Errorf("unexpected second entry %+v", read[1])
+	}
+}
+
+func TestJSONLinesWriter_Write_OutsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "exports")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(root, filepath.Join(dir, "up")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "linked.jsonl"), filepath.Join(dir, "linked.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := auditlog.NewJSONLinesWriter(dir)
+	for _, path := range []string{
+		"../audit.jsonl",
+		"nested/../../audit.jsonl",
+		filepath.Join(root, "audit.jsonl"),
+		"up/audit.jsonl",
+		"linked.jsonl",
+	} {
+		if err := writer.Write(path, nil); !errors.Is(err, auditlog.ErrOutsideDir) {
+			t.Errorf("expected ErrOutsideDir for %q, got %v", path, err)
+		}
+	}
+
+	for _, name := range []string{"audit.jsonl", "linked.jsonl"} {
+		if _, err := os.Stat(filepath.Join(root, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected nothing written outside the directory, got %s: %v", name, err)
+		}
+	}
+}
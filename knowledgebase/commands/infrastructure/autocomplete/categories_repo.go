@@ -0,0 +1,62 @@
+package autocomplete
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// CategoriesRepo type decorates repositories.CategoriesRepo and keeps category names of the Index up to date
+// with every category created, renamed or deleted through it. Reading methods are passed to the decorated repository.
+type CategoriesRepo struct {
+	repositories.CategoriesRepo
+	index *Index
+}
+
+// NewCategoriesRepo function builds new instance of CategoriesRepo.
+func NewCategoriesRepo(repo repositories.CategoriesRepo, index *Index) *CategoriesRepo {
+	return &CategoriesRepo{
+		CategoriesRepo: repo,
+		index:          index,
+	}
+}
+
+// Create function stores the category and makes its name suggested.
+func (r *CategoriesRepo) Create(category *models.Category) (int64, error) {
+	id, err := r.CategoriesRepo.Create(category)
+	if err != nil {
+		return 0, err
+	}
+
+	r.index.AddCategory(category.Owner, category.Name)
+
+	return id, nil
+}
+
+// Save function stores the category and renames it in suggestions when its name has changed.
+func (r *CategoriesRepo) Save(category *models.Category) error {
+	previous, err := r.CategoriesRepo.FindByID(category.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = r.CategoriesRepo.Save(category); err != nil {
+		return err
+	}
+
+	if previous != nil && previous.Name != category.Name {
+		r.index.RenameCategory(category.Owner, previous.Name, category.Name)
+	}
+
+	return nil
+}
+
+// Delete function deletes the category and drops its name from suggestions.
+func (r *CategoriesRepo) Delete(category *models.Category) error {
+	if err := r.CategoriesRepo.Delete(category); err != nil {
+		return err
+	}
+
+	r.index.RemoveCategory(category.Owner, category.Name)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package autocomplete_test
+
+import (
+	"fmt"
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/autocomplete"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoriesRepo_KeepsIndexUpToDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := autocomplete.NewIndex()
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+	repo.EXPECT().FindByID(int64(1)).Return(&models.Category{ID: 1, Name: "Networks"}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(gomock.Any()).Return(nil)
+
+	indexed := autocomplete.NewCategoriesRepo(repo, index)
+
+	category := &models.Category{Name: "Networks"}
+	if _, err := indexed.Create(category); err != nil {
+		t.Fatal(err)
+	}
+
+	got := suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "net"})
+	if fmt.Sprint(got) != "[category:Networks:0:0]" {
+		t.Errorf("expected created category to be suggested, got %v", got)
+	}
+
+	category.ID = 1
+	category.Name = "Engineering/Networks"
+	if err := indexed.Save(category); err != nil {
+		t.Fatal(err)
+	}
+
+	got = suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "eng"})
+	if fmt.Sprint(got) != "[category:Engineering/Networks:0:0]" {
+		t.Errorf("expected renamed category to be suggested, got %v", got)
+	}
+	if got = suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "net"}); len(got) != 0 {
+		t.Errorf("expected previous name to be dropped, got %v", got)
+	}
+
+	if err := indexed.Delete(category); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "eng"}); len(got) != 0 {
+		t.Errorf("expected deleted category to be dropped, got %v", got)
+	}
+}
@@ -0,0 +1,293 @@
+package autocomplete
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// kinds are the kinds of the indexed values in the order their suggestions are listed on a tie.
+var kinds = []string{appmodels.AutocompleteKindTag, appmodels.AutocompleteKindCategory}
+
+// entry keeps values used by the indexed item, so they can be uncounted when it changes.
+type entry struct {
+	owner      string
+	tags       []string
+	categories []string
+}
+
+// values function returns the values of the given kind.
+func (e *entry) values(kind string) []string {
+	if kind == appmodels.AutocompleteKindTag {
+		return e.tags
+	}
+
+	return e.categories
+}
+
+// scope keeps values of one owner, the values of other owners are never suggested.
+type scope struct {
+	tries  map[string]*trie
+	counts map[string]map[string]int
+	// categories are known to exist even when no item uses them, so they are suggested with zero count.
+	categories map[string]bool
+}
+
+func newScope() *scope {
+	sc := &scope{
+		tries:      make(map[string]*trie, len(kinds)),
+		counts:     make(map[string]map[string]int, len(kinds)),
+		categories: make(map[string]bool),
+	}
+	for _, kind := range kinds {
+		sc.tries[kind] = newTrie()
+		sc.counts[kind] = make(map[string]int)
+	}
+
+	return sc
+}
+
+// Index type is an in-memory index of the tags and category names used by the knowledge items.
+// It implements models.AutocompleteIndex. Values are kept per owner of the items and categories.
+//
+// The index doesn't persist anything, it is filled by Reindex on start and kept up to date
+// by the KnowledgeItemsRepo and CategoriesRepo decorators. Notes edited in the vault reach it through Add and Remove.
+type Index struct {
+	mu     sync.RWMutex
+	scopes map[string]*scope
+	items  map[int64]*entry
+}
+
+// NewIndex function builds new empty instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		scopes: make(map[string]*scope),
+		items:  make(map[int64]*entry),
+	}
+}
+
+// Reindex function replaces content of the index with the values of all the items of the repository.
+// Categories created through the CategoriesRepo decorator are kept even if no item uses them.
+func (ix *Index) Reindex(repo repositories.KnowledgeItemsRepo) error {
+	items, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	for id := range ix.items {
+		ix.remove(id)
+	}
+	for _, item := range items {
+		ix.add(item)
+	}
+
+	return nil
+}
+
+// Add function indexes values of the item, replacing the previously indexed version of it.
+func (ix *Index) Add(item *models.KnowledgeItem) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(item.ID)
+	ix.add(item)
+}
+
+// Remove function uncounts values of the item with the given ID.
+func (ix *Index) Remove(id int64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+// AddCategory function makes the category of the owner suggested even before any item uses it.
+func (ix *Index) AddCategory(owner, name string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	sc := ix.scope(owner)
+	sc.categories[name] = true
+	sc.tries[appmodels.AutocompleteKindCategory].add(name)
+}
+
+// RemoveCategory function drops the deleted category of the owner, including its use by the items.
+func (ix *Index) RemoveCategory(owner, name string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	sc := ix.scope(owner)
+	delete(sc.categories, name)
+	for _, e := range ix.items {
+		if e.owner != owner {
+			continue
+		}
+
+		e.categories = slices.DeleteFunc(e.categories, func(category string) bool {
+			return category == name
+		})
+	}
+	delete(sc.counts[appmodels.AutocompleteKindCategory], name)
+	sc.tries[appmodels.AutocompleteKindCategory].remove(name)
+}
+
+// RenameCategory function replaces the category name of the owner, including its use by the items.
+func (ix *Index) RenameCategory(owner, oldName, newName string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	sc := ix.scope(owner)
+	kind := appmodels.AutocompleteKindCategory
+	if sc.categories[oldName] {
+		delete(sc.categories, oldName)
+		sc.categories[newName] = true
+	}
+	for _, e := range ix.items {
+		if e.owner != owner {
+			continue
+		}
+
+		for i, category := range e.categories {
+			if category == oldName {
+				e.categories[i] = newName
+			}
+		}
+	}
+	if count, ok := sc.counts[kind][oldName]; ok {
+		delete(sc.counts[kind], oldName)
+		sc.counts[kind][newName] = count
+	}
+
+	sc.tries[kind].remove(oldName)
+	if sc.categories[newName] || sc.counts[kind][newName] > 0 {
+		sc.tries[kind].add(newName)
+	}
+}
+
+// Suggest function returns values of the query owner starting with the prefix,
+// exact matches go first, then the most used ones.
+// Longer prefixes tolerate more typos: none up to 2 letters, one up to 5 letters and two for longer ones.
+// Empty prefix suggests the most used values.
+func (ix *Index) Suggest(query *appmodels.AutocompleteQuery) ([]*appmodels.AutocompleteSuggestion, error) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	sc, ok := ix.scopes[query.Owner]
+	if !ok {
+		return nil, nil
+	}
+
+	maxDistance := typos(query.Prefix)
+
+	var suggestions []*appmodels.AutocompleteSuggestion
+	for _, kind := range kinds {
+		if query.Kind != "" && query.Kind != kind {
+			continue
+		}
+
+		for value, distance := range sc.tries[kind].search(query.Prefix, maxDistance) {
+			suggestions = append(suggestions, &appmodels.AutocompleteSuggestion{
+				Value:    value,
+				Kind:     kind,
+				Count:    sc.counts[kind][value],
+				Distance: distance,
+			})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+
+		return a.Kind == appmodels.AutocompleteKindTag && b.Kind != appmodels.AutocompleteKindTag
+	})
+
+	return suggestions[:min(len(suggestions), query.Limit)], nil
+}
+
+// typos function returns the number of typos tolerated in the prefix.
+func typos(prefix string) int {
+	switch length := utf8.RuneCountInString(fold(prefix)); {
+	case length < 3:
+		return 0
+	case length < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// scope function returns values of the owner, the scope is created on first use.
+func (ix *Index) scope(owner string) *scope {
+	sc, ok := ix.scopes[owner]
+	if !ok {
+		sc = newScope()
+		ix.scopes[owner] = sc
+	}
+
+	return sc
+}
+
+func (ix *Index) add(item *models.KnowledgeItem) {
+	e := &entry{owner: item.Owner}
+	for _, tag := range item.Tags {
+		if tag != "" && !slices.Contains(e.tags, tag) {
+			e.tags = append(e.tags, tag)
+		}
+	}
+	for _, category := range item.Categories {
+		if category != nil && category.Name != "" && !slices.Contains(e.categories, category.Name) {
+			e.categories = append(e.categories, category.Name)
+		}
+	}
+
+	sc := ix.scope(e.owner)
+	for _, kind := range kinds {
+		for _, value := range e.values(kind) {
+			sc.counts[kind][value]++
+			sc.tries[kind].add(value)
+		}
+	}
+
+	ix.items[item.ID] = e
+}
+
+func (ix *Index) remove(id int64) {
+	e, ok := ix.items[id]
+	if !ok {
+		return
+	}
+
+	sc := ix.scope(e.owner)
+	for _, kind := range kinds {
+		for _, value := range e.values(kind) {
+			sc.counts[kind][value]--
+			if sc.counts[kind][value] > 0 {
+				continue
+			}
+
+			delete(sc.counts[kind], value)
+			if kind == appmodels.AutocompleteKindTag || !sc.categories[value] {
+				sc.tries[kind].remove(value)
+			}
+		}
+	}
+
+	delete(ix.items, id)
+}
@@ -0,0 +1,168 @@
+package autocomplete_test
+
+import (
+	"fmt"
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/autocomplete"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	golang    = &models.Category{ID: 1, Name: "Golang"}
+	databases = &models.Category{ID: 2, Name: "Databases"}
+)
+
+func testItems() []*models.KnowledgeItem {
+	return []*models.KnowledgeItem{
+		{ID: 1, Tags: []string{"golang", "concurrency"}, Categories: []*models.Category{golang}},
+		{ID: 2, Tags: []string{"golang", "go-lang"}, Categories: []*models.Category{golang}},
+		{ID: 3, Tags: []string{"goroutines", "storage"}, Categories: []*models.Category{databases}},
+		{ID: 4, Tags: []string{"golang", "glossary"}},
+	}
+}
+
+func newTestIndex(t *testing.T) *autocomplete.Index {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindAll().Return(testItems(), nil)
+
+	index := autocomplete.NewIndex()
+	if err := index.Reindex(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	return index
+}
+
+func suggest(t *testing.T, index *autocomplete.Index, query *appmodels.AutocompleteQuery) []string {
+	t.Helper()
+
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	suggestions, err := index.Suggest(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		result = append(result, fmt.Sprintf("%s:%s:%d:%d", s.Kind, s.Value, s.Count, s.Distance))
+	}
+
+	return result
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	index := newTestIndex(t)
+
+	cases := []struct {
+		name     string
+		query    *appmodels.AutocompleteQuery
+		expected []string
+	}{
+		{
+			name:  "prefix ranked by usage",
+			query: &appmodels.AutocompleteQuery{Prefix: "go"},
+			expected: []string{
+				"tag:golang:3:0", "category:Golang:2:0", "tag:go-lang:1:0", "tag:goroutines:1:0",
+			},
+		},
+		{
+			name:     "case and punctuation are ignored",
+			query:    &appmodels.AutocompleteQuery{Prefix: "GO-L", Kind: appmodels.AutocompleteKindTag},
+			expected: []string{"tag:golang:3:0", "tag:go-lang:1:0", "tag:glossary:1:1", "tag:goroutines:1:1"},
+		},
+		{
+			name:     "kind narrows suggestions",
+			query:    &appmodels.AutocompleteQuery{Prefix: "go", Kind: appmodels.AutocompleteKindCategory},
+			expected: []string{"category:Golang:2:0"},
+		},
+		{
+			name:     "transposed letters",
+			query:    &appmodels.AutocompleteQuery{Prefix: "golnag", Kind: appmodels.AutocompleteKindTag},
+			expected: []string{"tag:golang:3:1", "tag:go-lang:1:1"},
+		},
+		{
+			name:     "typo in the prefix",
+			query:    &appmodels.AutocompleteQuery{Prefix: "stroa"},
+			expected: []string{"tag:storage:1:1"},
+		},
+		{
+			name:     "short prefix tolerates no typos",
+			query:    &appmodels.AutocompleteQuery{Prefix: "gp"},
+			expected: []string{},
+		},
+		{
+			name:     "empty prefix suggests the most used",
+			query:    &appmodels.AutocompleteQuery{Limit: 2},
+			expected: []string{"tag:golang:3:0", "category:Golang:2:0"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := suggest(t, index, tc.query)
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestIndex_KeepsCountsUpToDate(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.Remove(3)
+	if got := suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "stor"}); len(got) != 0 {
+		t.Errorf("expected unused tag to be dropped, got %v", got)
+	}
+
+	index.Add(&models.KnowledgeItem{ID: 4, Tags: []string{"golang"}, Categories: []*models.Category{golang}})
+	got := suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "gol", Limit: 3})
+	expected := []string{"category:Golang:3:0", "tag:golang:3:0", "tag:go-lang:1:0"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	index.AddCategory("", "Networks")
+	got = suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "net"})
+	if fmt.Sprint(got) != "[category:Networks:0:0]" {
+		t.Errorf("expected new category to be suggested, got %v", got)
+	}
+
+	index.RemoveCategory("", "Golang")
+	got = suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "gol", Kind: appmodels.AutocompleteKindCategory})
+	if len(got) != 0 {
+		t.Errorf("expected deleted category to be dropped, got %v", got)
+	}
+}
+
+func TestIndex_SeparatesOwners(t *testing.T) {
+	index := autocomplete.NewIndex()
+	index.Add(&models.KnowledgeItem{ID: 1, Owner: "alice", Tags: []string{"golang"}})
+	index.Add(&models.KnowledgeItem{ID: 2, Owner: "bob", Tags: []string{"gopher"}})
+	index.AddCategory("bob", "Golang")
+
+	got := suggest(t, index, &appmodels.AutocompleteQuery{Owner: "alice", Prefix: "go"})
+	if fmt.Sprint(got) != "[tag:golang:1:0]" {
+		t.Errorf("expected only values of the owner, got %v", got)
+	}
+
+	index.RenameCategory("alice", "Golang", "Go")
+	got = suggest(t, index, &appmodels.AutocompleteQuery{Owner: "bob", Prefix: "go"})
+	if fmt.Sprint(got) != "[tag:gopher:1:0 category:Golang:0:0]" {
+		t.Errorf("expected values of other owner to be kept, got %v", got)
+	}
+
+	if got = suggest(t, index, &appmodels.AutocompleteQuery{Owner: "carol", Prefix: "go"}); len(got) != 0 {
+		t.Errorf("expected nothing for unknown owner, got %v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package autocomplete
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// KnowledgeItemsRepo type decorates repositories.KnowledgeItemsRepo and keeps the Index up to date
+// with every item created, saved or deleted through it. Reading methods are passed to the decorated repository.
+type KnowledgeItemsRepo struct {
+	repositories.KnowledgeItemsRepo
+	index *Index
+}
+
+// NewKnowledgeItemsRepo function builds new instance of KnowledgeItemsRepo.
+func NewKnowledgeItemsRepo(repo repositories.KnowledgeItemsRepo, index *Index) *KnowledgeItemsRepo {
+	return &KnowledgeItemsRepo{
+		KnowledgeItemsRepo: repo,
+		index:              index,
+	}
+}
+
+// Create function stores the item and counts its values under the ID assigned by the storage.
+func (r *KnowledgeItemsRepo) Create(item *models.KnowledgeItem) (int64, error) {
+	id, err := r.KnowledgeItemsRepo.Create(item)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := *item
+	indexed.ID = id
+	r.index.Add(&indexed)
+
+	return id, nil
+}
+
+// CreateBatch function stores the items and counts their values under the IDs assigned by the storage.
+func (r *KnowledgeItemsRepo) CreateBatch(items []*models.KnowledgeItem) ([]int64, error) {
+	ids, err := r.KnowledgeItemsRepo.CreateBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		indexed := *item
+		indexed.ID = ids[i]
+		r.index.Add(&indexed)
+	}
+
+	return ids, nil
+}
+
+// Save function stores the item and recounts its values.
+func (r *KnowledgeItemsRepo) Save(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Save(item); err != nil {
+		return err
+	}
+
+	r.index.Add(item)
+
+	return nil
+}
+
+// Delete function deletes the item and uncounts its values.
+func (r *KnowledgeItemsRepo) Delete(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Delete(item); err != nil {
+		return err
+	}
+
+	r.index.Remove(item.ID)
+
+	return nil
+}
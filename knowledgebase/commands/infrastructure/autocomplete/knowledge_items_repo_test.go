@@ -0,0 +1,74 @@
+package autocomplete_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/autocomplete"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKnowledgeItemsRepo_KeepsIndexUpToDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := autocomplete.NewIndex()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+	repo.EXPECT().CreateBatch(gomock.Any()).Return([]int64{2, 3}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(gomock.Any()).Return(nil)
+
+	indexed := autocomplete.NewKnowledgeItemsRepo(repo, index)
+
+	if _, err := indexed.Create(&models.KnowledgeItem{Tags: []string{"golang"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := indexed.CreateBatch([]*models.KnowledgeItem{
+		{Tags: []string{"golang"}},
+		{Tags: []string{"gopher"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "go"})
+	if fmt.Sprint(got) != "[tag:golang:2:0 tag:gopher:1:0]" {
+		t.Errorf("expected tags of created items to be counted, got %v", got)
+	}
+
+	if err := indexed.Save(&models.KnowledgeItem{ID: 2, Tags: []string{"gopher"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexed.Delete(&models.KnowledgeItem{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	got = suggest(t, index, &appmodels.AutocompleteQuery{Prefix: "go"})
+	if fmt.Sprint(got) != "[tag:gopher:2:0]" {
+		t.Errorf("expected saved and deleted items to be recounted, got %v", got)
+	}
+}
+
+func TestKnowledgeItemsRepo_FailedCreateIsNotIndexed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := autocomplete.NewIndex()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(0), errors.New("storage is unavailable"))
+
+	indexed := autocomplete.NewKnowledgeItemsRepo(repo, index)
+	if _, err := indexed.Create(&models.KnowledgeItem{Tags: []string{"golang"}}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := suggest(t, index, &appmodels.AutocompleteQuery{}); len(got) != 0 {
+		t.Errorf("expected nothing to be indexed, got %v", got)
+	}
+}
@@ -0,0 +1,158 @@
+// Package autocomplete contains in-memory index of the tags and category names used for autocompletion.
+package autocomplete
+
+import (
+	"strings"
+	"unicode"
+)
+
+// node represents one letter of the folded keys, values are the spellings whose key ends at the node.
+type node struct {
+	children map[rune]*node
+	values   map[string]bool
+}
+
+func newNode() *node {
+	return &node{
+		children: make(map[rune]*node),
+		values:   make(map[string]bool),
+	}
+}
+
+// trie keeps values by their folded keys, so "Go-Lang" and "golang" end at the same node.
+type trie struct {
+	root *node
+}
+
+func newTrie() *trie {
+	return &trie{root: newNode()}
+}
+
+// fold function builds the key of the value: lowercased letters and digits only.
+// Values without letters and digits, like "++", are kept lowercased as they are.
+func fold(value string) string {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, value)
+	if key == "" {
+		return strings.ToLower(value)
+	}
+
+	return key
+}
+
+func (t *trie) add(value string) {
+	n := t.root
+	for _, r := range fold(value) {
+		child, ok := n.children[r]
+		if !ok {
+			child = newNode()
+			n.children[r] = child
+		}
+		n = child
+	}
+
+	n.values[value] = true
+}
+
+// remove function drops the value and prunes the branches left without values.
+func (t *trie) remove(value string) {
+	key := []rune(fold(value))
+
+	path := []*node{t.root}
+	for _, r := range key {
+		child, ok := path[len(path)-1].children[r]
+		if !ok {
+			return
+		}
+		path = append(path, child)
+	}
+
+	delete(path[len(path)-1].values, value)
+
+	for i := len(key); i > 0; i-- {
+		n := path[i]
+		if len(n.values) > 0 || len(n.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+}
+
+// search function returns values whose keys start with the prefix within maxDistance typos,
+// together with the number of typos. Typos are insertions, deletions, substitutions
+// and transpositions of adjacent letters, counted by the Damerau-Levenshtein distance.
+func (t *trie) search(prefix string, maxDistance int) map[string]int {
+	query := []rune(fold(prefix))
+	if prefix == "" {
+		query = nil
+	}
+
+	found := make(map[string]int)
+
+	row := make([]int, len(query)+1)
+	for i := range row {
+		row[i] = i
+	}
+	if row[len(query)] <= maxDistance {
+		collect(t.root, row[len(query)], found)
+	}
+
+	for r, child := range t.root.children {
+		t.walk(child, r, 0, query, nil, row, maxDistance, found)
+	}
+
+	return found
+}
+
+// walk function calculates the next row of the distance matrix for the letter of the node.
+// The last cell of the row is the distance between the query and the path to the node,
+// the descendants of the node complete the path, so they match with the same distance.
+func (t *trie) walk(n *node, r, previous rune, query []rune, before, row []int, maxDistance int, found map[string]int) {
+	next := make([]int, len(query)+1)
+	next[0] = row[0] + 1
+
+	best := next[0]
+	for i := 1; i <= len(query); i++ {
+		cost := 1
+		if query[i-1] == r {
+			cost = 0
+		}
+
+		next[i] = min(row[i]+1, next[i-1]+1, row[i-1]+cost)
+		if before != nil && i > 1 && query[i-1] == previous && query[i-2] == r {
+			next[i] = min(next[i], before[i-2]+1)
+		}
+
+		best = min(best, next[i])
+	}
+
+	if next[len(query)] <= maxDistance {
+		collect(n, next[len(query)], found)
+	}
+
+	if best > maxDistance {
+		return
+	}
+
+	for childRune, child := range n.children {
+		t.walk(child, childRune, r, query, row, next, maxDistance, found)
+	}
+}
+
+// collect function records all the values of the subtree with the distance, keeping the smallest one.
+func collect(n *node, distance int, found map[string]int) {
+	for value := range n.values {
+		if known, ok := found[value]; !ok || distance < known {
+			found[value] = distance
+		}
+	}
+
+	for _, child := range n.children {
+		collect(child, distance, found)
+	}
+}
@@ -0,0 +1,113 @@
+// Package duplicates contains in-memory similarity index of the knowledge items used to find duplicates.
+package duplicates
+
+import (
+	"sync"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/similarity"
+)
+
+// Index type is an in-memory similarity.Index of the knowledge items, the items are indexed per owner,
+// so the items of other owners are never candidates.
+//
+// The index doesn't persist anything, it is filled by Reindex on start and kept up to date
+// by the KnowledgeItemsRepo decorator.
+type Index struct {
+	mu      sync.RWMutex
+	indexes map[string]*similarity.Index
+	owners  map[int64]string
+}
+
+// NewIndex function builds new empty instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		indexes: make(map[string]*similarity.Index),
+		owners:  make(map[int64]string),
+	}
+}
+
+// Reindex function replaces content of the index with the fingerprints of all the items of the repository.
+func (ix *Index) Reindex(repo repositories.KnowledgeItemsRepo) error {
+	items, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	fingerprints := make([]*similarity.Fingerprint, len(items))
+	for i, item := range items {
+		fingerprints[i] = similarity.ItemFingerprint(item)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.indexes = make(map[string]*similarity.Index)
+	ix.owners = make(map[int64]string, len(items))
+	for i, item := range items {
+		ix.add(item, fingerprints[i])
+	}
+
+	return nil
+}
+
+// Add function indexes the fingerprint of the item, replacing the previously indexed version of it.
+func (ix *Index) Add(item *models.KnowledgeItem) {
+	fp := similarity.ItemFingerprint(item)
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(item.ID)
+	ix.add(item, fp)
+}
+
+// Remove function drops the fingerprint of the item with the given ID.
+func (ix *Index) Remove(id int64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+// Candidates function returns IDs of the indexed items of the item owner which might be similar to it,
+// ordered by ID. The item itself is among them once it is indexed.
+func (ix *Index) Candidates(item *models.KnowledgeItem) []int64 {
+	fp := similarity.ItemFingerprint(item)
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	index, ok := ix.indexes[item.Owner]
+	if !ok {
+		return nil
+	}
+
+	return index.Candidates(fp)
+}
+
+func (ix *Index) add(item *models.KnowledgeItem, fp *similarity.Fingerprint) {
+	index, ok := ix.indexes[item.Owner]
+	if !ok {
+		index = similarity.NewIndex()
+		ix.indexes[item.Owner] = index
+	}
+
+	index.Add(item.ID, fp)
+	ix.owners[item.ID] = item.Owner
+}
+
+func (ix *Index) remove(id int64) {
+	owner, ok := ix.owners[id]
+	if !ok {
+		return
+	}
+
+	index := ix.indexes[owner]
+	index.Remove(id)
+	if index.Len() == 0 {
+		delete(ix.indexes, owner)
+	}
+	delete(ix.owners, id)
+}
@@ -0,0 +1,65 @@
+package duplicates_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/duplicates"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func testItems() []*models.KnowledgeItem {
+	return []*models.KnowledgeItem{
+		{ID: 1, Owner: "alice", Title: "Goroutine", Data: "Goroutine is a lightweight thread managed by the Go runtime."},
+		{ID: 2, Owner: "alice", Title: "Channel", Data: "Channel is a typed conduit for values."},
+		{ID: 3, Owner: "bob", Title: "Goroutine", Data: "Goroutine is a lightweight thread managed by the Go runtime."},
+		{ID: 4, Owner: "alice", Title: "Green threads", Data: "Goroutine is a lightweight thread managed by the Go runtime."},
+	}
+}
+
+func newTestIndex(t *testing.T) *duplicates.Index {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindAll().Return(testItems(), nil)
+
+	index := duplicates.NewIndex()
+	if err := index.Reindex(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	return index
+}
+
+func TestIndex_Candidates(t *testing.T) {
+	index := newTestIndex(t)
+
+	candidates := index.Candidates(&models.KnowledgeItem{
+		Owner: "alice",
+		Title: "Goroutines",
+		Data:  "Goroutine is a lightweight thread managed by the Go runtime.",
+	})
+	if !slices.Equal(candidates, []int64{1, 4}) {
+		t.Errorf("expected candidates [1 4] of alice, got %v", candidates)
+	}
+
+	if candidates = index.Candidates(&models.KnowledgeItem{Owner: "carol", Title: "Goroutine"}); len(candidates) != 0 {
+		t.Errorf("expected no candidates of carol, got %v", candidates)
+	}
+}
+
+func TestIndex_KeepsFingerprintsUpToDate(t *testing.T) {
+	index := newTestIndex(t)
+	goroutine := testItems()[0]
+
+	index.Add(&models.KnowledgeItem{ID: 4, Owner: "alice", Title: "Mutex", Data: "Mutex is a mutual exclusion lock."})
+	index.Remove(1)
+	index.Add(&models.KnowledgeItem{ID: 5, Owner: "alice", Title: "Goroutine", Data: "Goroutines are cheap."})
+
+	if candidates := index.Candidates(goroutine); !slices.Equal(candidates, []int64{5}) {
+		t.Errorf("expected saved and deleted items to be reindexed, got %v", candidates)
+	}
+}
@@ -0,0 +1,97 @@
+package duplicates
+
+import (
+	"errors"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// KnowledgeItemsRepo type decorates repositories.KnowledgeItemsRepo and keeps the Index up to date
+// with every item created, saved or deleted through it. Reading methods are passed to the decorated repository.
+// It implements repositories.SimilarItemsRepo as well.
+type KnowledgeItemsRepo struct {
+	repositories.KnowledgeItemsRepo
+	index *Index
+}
+
+// NewKnowledgeItemsRepo function builds new instance of KnowledgeItemsRepo.
+func NewKnowledgeItemsRepo(repo repositories.KnowledgeItemsRepo, index *Index) *KnowledgeItemsRepo {
+	return &KnowledgeItemsRepo{
+		KnowledgeItemsRepo: repo,
+		index:              index,
+	}
+}
+
+// Create function stores the item and indexes it under the ID assigned by the storage.
+func (r *KnowledgeItemsRepo) Create(item *models.KnowledgeItem) (int64, error) {
+	id, err := r.KnowledgeItemsRepo.Create(item)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := *item
+	indexed.ID = id
+	r.index.Add(&indexed)
+
+	return id, nil
+}
+
+// CreateBatch function stores the items and indexes them under the IDs assigned by the storage.
+func (r *KnowledgeItemsRepo) CreateBatch(items []*models.KnowledgeItem) ([]int64, error) {
+	ids, err := r.KnowledgeItemsRepo.CreateBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		indexed := *item
+		indexed.ID = ids[i]
+		r.index.Add(&indexed)
+	}
+
+	return ids, nil
+}
+
+// Save function stores the item and reindexes it.
+func (r *KnowledgeItemsRepo) Save(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Save(item); err != nil {
+		return err
+	}
+
+	r.index.Add(item)
+
+	return nil
+}
+
+// Delete function deletes the item and drops it from the index.
+func (r *KnowledgeItemsRepo) Delete(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Delete(item); err != nil {
+		return err
+	}
+
+	r.index.Remove(item.ID)
+
+	return nil
+}
+
+// FindCandidates function returns the stored candidates of the index for the item,
+// the items deleted without the decorator are skipped.
+func (r *KnowledgeItemsRepo) FindCandidates(item *models.KnowledgeItem) ([]*models.KnowledgeItem, error) {
+	ids := r.index.Candidates(item)
+
+	candidates := make([]*models.KnowledgeItem, 0, len(ids))
+	for _, id := range ids {
+		candidate, err := r.KnowledgeItemsRepo.FindByID(id)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
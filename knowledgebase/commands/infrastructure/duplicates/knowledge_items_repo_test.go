@@ -0,0 +1,84 @@
+package duplicates_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/duplicates"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKnowledgeItemsRepo_KeepsIndexUpToDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := duplicates.NewIndex()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+	repo.EXPECT().CreateBatch(gomock.Any()).Return([]int64{2, 3}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(gomock.Any()).Return(nil)
+
+	indexed := duplicates.NewKnowledgeItemsRepo(repo, index)
+
+	goroutine := &models.KnowledgeItem{Owner: "alice", Title: "Goroutine", Data: "Lightweight thread."}
+	if _, err := indexed.Create(goroutine); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := indexed.CreateBatch([]*models.KnowledgeItem{
+		{Owner: "alice", Title: "Goroutine", Data: "Function running concurrently."},
+		{Owner: "alice", Title: "Channel", Data: "Typed conduit."},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if candidates := index.Candidates(goroutine); !slices.Equal(candidates, []int64{1, 2}) {
+		t.Errorf("expected created items to be indexed, got %v", candidates)
+	}
+
+	if err := indexed.Save(&models.KnowledgeItem{ID: 3, Owner: "alice", Title: "Goroutine"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexed.Delete(&models.KnowledgeItem{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if candidates := index.Candidates(goroutine); !slices.Equal(candidates, []int64{2, 3}) {
+		t.Errorf("expected saved and deleted items to be reindexed, got %v", candidates)
+	}
+}
+
+func TestKnowledgeItemsRepo_FindCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := duplicates.NewIndex()
+	for _, item := range testItems() {
+		index.Add(item)
+	}
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindByID(int64(1)).Return(nil, repositories.ErrNotFound)
+	repo.EXPECT().FindByID(int64(4)).Return(testItems()[3], nil)
+
+	indexed := duplicates.NewKnowledgeItemsRepo(repo, index)
+
+	candidates, err := indexed.FindCandidates(testItems()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 1 || candidates[0].ID != 4 {
+		t.Errorf("expected stored candidate 4 only, got %v", candidates)
+	}
+
+	expectedErr := errors.New("storage is unavailable")
+	repo.EXPECT().FindByID(int64(1)).Return(nil, expectedErr)
+	if _, err = indexed.FindCandidates(testItems()[0]); !errors.Is(err, expectedErr) {
+		t.Errorf("expected %v, got %v", expectedErr, err)
+	}
+}
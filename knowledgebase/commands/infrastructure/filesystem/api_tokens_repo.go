@@ -0,0 +1,183 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const tokensFile = "api_tokens.yaml"
+
+// apiTokenEntry represents one API token in the API tokens file, only the hash of its secret is kept.
+type apiTokenEntry struct {
+	ID        int64      `yaml:"id"`
+	Owner     string     `yaml:"owner"`
+	Name      string     `yaml:"name"`
+	Hash      string     `yaml:"hash"`
+	Scopes    []string   `yaml:"scopes"`
+	ExpiresAt *time.Time `yaml:"expires_at,omitempty"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+}
+
+// apiTokensRepo type implements repositories.APITokensRepo on top of the Store.
+// Tokens are kept in the API tokens file of the hidden .neurography directory, so secrets never reach the notes.
+type apiTokensRepo struct {
+	store *Store
+}
+
+// Create function adds the token to the API tokens file and returns its ID.
+func (r *apiTokensRepo) Create(token *models.APIToken) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneToken(token)
+	stored.ID = s.lastTokenID + 1
+	tokens := maps.Clone(s.tokens)
+	tokens[stored.ID] = stored
+	if err := s.saveTokens(tokens); err != nil {
+		return 0, err
+	}
+
+	s.tokens = tokens
+	s.lastTokenID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Delete function removes the token from the API tokens file.
+func (r *apiTokensRepo) Delete(token *models.APIToken) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[token.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	tokens := maps.Clone(s.tokens)
+	delete(tokens, token.ID)
+	if err := s.saveTokens(tokens); err != nil {
+		return err
+	}
+
+	s.tokens = tokens
+
+	return nil
+}
+
+// FindByID function returns the token or repositories.ErrNotFound.
+func (r *apiTokensRepo) FindByID(id int64) (*models.APIToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	token, ok := r.store.tokens[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneToken(token), nil
+}
+
+// FindByHash function returns the token with the hash of the secret or nil when it doesn't exist.
+func (r *apiTokensRepo) FindByHash(hash string) (*models.APIToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, token := range r.store.tokens {
+		if token.Hash == hash {
+			return cloneToken(token), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindByOwner function returns all the tokens of the owner ordered by ID.
+func (r *apiTokensRepo) FindByOwner(owner string) ([]*models.APIToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tokens []*models.APIToken
+	for _, token := range r.store.tokens {
+		if token.Owner == owner {
+			tokens = append(tokens, cloneToken(token))
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID < tokens[j].ID })
+
+	return tokens, nil
+}
+
+func cloneToken(token *models.APIToken) *models.APIToken {
+	clone := *token
+	clone.Scopes = slices.Clone(token.Scopes)
+	clone.ExpiresAt = cloneTime(token.ExpiresAt)
+	clone.CreatedAt = cloneTime(token.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadTokens() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, tokensFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*apiTokenEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.tokens[entry.ID] = &models.APIToken{
+			ID:        entry.ID,
+			Owner:     entry.Owner,
+			Name:      entry.Name,
+			Hash:      entry.Hash,
+			Scopes:    entry.Scopes,
+			ExpiresAt: entry.ExpiresAt,
+			CreatedAt: entry.CreatedAt,
+		}
+		s.lastTokenID = max(s.lastTokenID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveTokens function writes the given API tokens into the API tokens file.
+func (s *Store) saveTokens(tokens map[int64]*models.APIToken) error {
+	entries := make([]*apiTokenEntry, 0, len(tokens))
+	for _, token := range tokens {
+		entries = append(entries, &apiTokenEntry{
+			ID:        token.ID,
+			Owner:     token.Owner,
+			Name:      token.Name,
+			Hash:      token.Hash,
+			Scopes:    token.Scopes,
+			ExpiresAt: token.ExpiresAt,
+			CreatedAt: token.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, tokensFile), content)
+}
@@ -0,0 +1,60 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func TestStore_APITokens(t *testing.T) {
+	dir := t.TempDir()
+
+	repo := newStore(t, dir).APITokensRepo()
+	id, err := repo.Create(&models.APIToken{Owner: "alice", Name: "CI", Hash: "abc", Scopes: []string{"items:read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo = newStore(t, dir).APITokensRepo()
+
+	token, err := repo.FindByHash("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == nil || token.ID != id || token.Owner != "alice" || len(token.Scopes) != 1 {
+		t.Fatalf("expected stored token, got %+v", token)
+	}
+
+	if err = repo.Delete(token); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := newStore(t, dir).APITokensRepo().FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 0 {
+		t.Errorf("expected token to be deleted, got %+v", tokens)
+	}
+}
+
+func TestStore_APITokens_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the API tokens file keeps it from being written.
+	writeFile(t, dir, ".neurography/api_tokens.yaml/keep", "")
+
+	repo := store.APITokensRepo()
+	if _, err := repo.Create(&models.APIToken{Owner: "alice", Hash: "abc"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	token, err := repo.FindByHash("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != nil {
+		t.Errorf("expected no token, got %+v", token)
+	}
+}
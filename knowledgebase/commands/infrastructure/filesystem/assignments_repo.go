@@ -0,0 +1,187 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const lessonsFile = "assignments.yaml"
+
+// assignmentEntry represents one assignment in the assignments file.
+type assignmentEntry struct {
+	ID          int64      `yaml:"id"`
+	Owner       string     `yaml:"owner"`
+	Instructor  string     `yaml:"instructor"`
+	Title       string     `yaml:"title"`
+	ItemIDs     []int64    `yaml:"item_ids,omitempty"`
+	CategoryIDs []int64    `yaml:"category_ids,omitempty"`
+	Learners    []string   `yaml:"learners"`
+	DueAt       *time.Time `yaml:"due_at,omitempty"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty"`
+}
+
+// assignmentsRepo type implements repositories.AssignmentsRepo on top of the Store.
+// Assignments refer to the notes by the IDs of their items and categories, they are kept
+// in the assignments file of the hidden .neurography directory.
+type assignmentsRepo struct {
+	store *Store
+}
+
+// Create function adds the assignment to the assignments file and returns its ID.
+func (r *assignmentsRepo) Create(assignment *models.Assignment) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneAssignment(assignment)
+	stored.ID = s.lastAssignmentID + 1
+	lessons := maps.Clone(s.lessons)
+	lessons[stored.ID] = stored
+	if err := s.saveAssignments(lessons); err != nil {
+		return 0, err
+	}
+
+	s.lessons = lessons
+	s.lastAssignmentID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces the assignment in the assignments file.
+func (r *assignmentsRepo) Save(assignment *models.Assignment) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lessons[assignment.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	lessons := maps.Clone(s.lessons)
+	lessons[assignment.ID] = cloneAssignment(assignment)
+	if err := s.saveAssignments(lessons); err != nil {
+		return err
+	}
+
+	s.lessons = lessons
+
+	return nil
+}
+
+// FindByID function returns the assignment or repositories.ErrNotFound.
+func (r *assignmentsRepo) FindByID(id int64) (*models.Assignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	assignment, ok := r.store.lessons[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneAssignment(assignment), nil
+}
+
+// FindByOwner function returns all the assignments of the owner ordered by ID.
+func (r *assignmentsRepo) FindByOwner(owner string) ([]*models.Assignment, error) {
+	return r.find(func(assignment *models.Assignment) bool { return assignment.Owner == owner })
+}
+
+// FindByLearner function returns all the assignments given to the learner ordered by ID.
+func (r *assignmentsRepo) FindByLearner(learner string) ([]*models.Assignment, error) {
+	return r.find(func(assignment *models.Assignment) bool { return assignment.Assigned(learner) })
+}
+
+func (r *assignmentsRepo) find(match func(assignment *models.Assignment) bool) ([]*models.Assignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var assignments []*models.Assignment
+	for _, assignment := range r.store.lessons {
+		if match(assignment) {
+			assignments = append(assignments, cloneAssignment(assignment))
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].ID < assignments[j].ID })
+
+	return assignments, nil
+}
+
+func cloneAssignment(assignment *models.Assignment) *models.Assignment {
+	clone := *assignment
+	clone.ItemIDs = slices.Clone(assignment.ItemIDs)
+	clone.CategoryIDs = slices.Clone(assignment.CategoryIDs)
+	clone.Learners = slices.Clone(assignment.Learners)
+	clone.DueAt = cloneTime(assignment.DueAt)
+	clone.CreatedAt = cloneTime(assignment.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadAssignments() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, lessonsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*assignmentEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.lessons[entry.ID] = &models.Assignment{
+			ID:          entry.ID,
+			Owner:       entry.Owner,
+			Instructor:  entry.Instructor,
+			Title:       entry.Title,
+			ItemIDs:     entry.ItemIDs,
+			CategoryIDs: entry.CategoryIDs,
+			Learners:    entry.Learners,
+			DueAt:       entry.DueAt,
+			CreatedAt:   entry.CreatedAt,
+		}
+		s.lastAssignmentID = max(s.lastAssignmentID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveAssignments function writes the given assignments into the assignments file.
+func (s *Store) saveAssignments(lessons map[int64]*models.Assignment) error {
+	entries := make([]*assignmentEntry, 0, len(lessons))
+	for _, assignment := range lessons {
+		entries = append(entries, &assignmentEntry{
+			ID:          assignment.ID,
+			Owner:       assignment.Owner,
+			Instructor:  assignment.Instructor,
+			Title:       assignment.Title,
+			ItemIDs:     assignment.ItemIDs,
+			CategoryIDs: assignment.CategoryIDs,
+			Learners:    assignment.Learners,
+			DueAt:       assignment.DueAt,
+			CreatedAt:   assignment.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, lessonsFile), content)
+}
@@ -0,0 +1,81 @@
+package filesystem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func TestStore_Assignments(t *testing.T) {
+	dir := t.TempDir()
+
+	dueAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	id, err := newStore(t, dir).AssignmentsRepo().Create(&models.Assignment{
+		Owner:       "workspace:5",
+		Instructor:  "alice",
+		Title:       "Onboarding",
+		ItemIDs:     []int64{7},
+		CategoryIDs: []int64{2},
+		Learners:    []string{"bob", "carol"},
+		DueAt:       &dueAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newStore(t, dir).AssignmentsRepo()
+
+	assignment, err := repo.FindByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignment.Title != "Onboarding" || len(assignment.CategoryIDs) != 1 || !assignment.DueAt.Equal(dueAt) {
+		t.Errorf("expected stored assignment, got %+v", assignment)
+	}
+
+	assignments, err := repo.FindByLearner("carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assignments) != 1 || assignments[0].ID != id {
+		t.Errorf("expected the assignment of carol, got %+v", assignments)
+	}
+
+	if assignments, err = repo.FindByLearner("alice"); err != nil || len(assignments) != 0 {
+		t.Errorf("expected no assignments of alice, got %+v, %v", assignments, err)
+	}
+
+	assignment.ItemIDs = []int64{8}
+	if err = repo.Save(assignment); err != nil {
+		t.Fatal(err)
+	}
+
+	if assignment, err = newStore(t, dir).AssignmentsRepo().FindByID(id); err != nil {
+		t.Fatal(err)
+	}
+	if len(assignment.ItemIDs) != 1 || assignment.ItemIDs[0] != 8 {
+		t.Errorf("expected saved items, got %v", assignment.ItemIDs)
+	}
+}
+
+func TestStore_Assignments_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the assignments file keeps it from being written.
+	writeFile(t, dir, ".neurography/assignments.yaml/keep", "")
+
+	repo := store.AssignmentsRepo()
+	if _, err := repo.Create(&models.Assignment{Owner: "workspace:5", Learners: []string{"bob"}}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	assignments, err := repo.FindByLearner("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assignments) != 0 {
+		t.Errorf("expected no assignments, got %+v", assignments)
+	}
+}
@@ -0,0 +1,117 @@
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+)
+
+const auditFile = "audit_log.jsonl"
+
+// auditLogRepo type implements repositories.AuditLogRepo on top of the Store.
+// Entries are only ever appended, one JSON line each, to the audit log file of the hidden .neurography directory.
+type auditLogRepo struct {
+	store *Store
+}
+
+// Append function adds the entry to the end of the audit log file and returns its ID.
+func (r *auditLogRepo) Append(entry *models.AuditEntry) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneAuditEntry(entry)
+	stored.ID = s.lastAuditID + 1
+
+	if err := s.appendAuditEntry(stored); err != nil {
+		return 0, err
+	}
+
+	s.lastAuditID = stored.ID
+	s.audit = append(s.audit, stored)
+
+	return stored.ID, nil
+}
+
+// Find function returns the entries matching the filter ordered by ID.
+func (r *auditLogRepo) Find(filter *models.AuditFilter) ([]*models.AuditEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*models.AuditEntry
+	for _, entry := range r.store.audit {
+		if filter.Matches(entry) {
+			entries = append(entries, cloneAuditEntry(entry))
+		}
+	}
+
+	return entries, nil
+}
+
+func cloneAuditEntry(entry *models.AuditEntry) *models.AuditEntry {
+	clone := *entry
+	clone.Payload = json.RawMessage(slices.Clone([]byte(entry.Payload)))
+	clone.ItemIDs = slices.Clone(entry.ItemIDs)
+	clone.CategoryIDs = slices.Clone(entry.CategoryIDs)
+	clone.CreatedAt = cloneTime(entry.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadAuditLog() error {
+	path := filepath.Join(s.dir, metaDir, auditFile)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// every entry is appended together with its line break, so the final line without one
+	// is left by an interrupted append. It is cut off instead of failing the store,
+	// the next entry would be glued to it otherwise.
+	if end := bytes.LastIndexByte(content, '\n') + 1; end < len(content) {
+		if err = os.Truncate(path, int64(end)); err != nil {
+			return err
+		}
+		content = content[:end]
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		entry := &models.AuditEntry{}
+		if err = json.Unmarshal(line, entry); err != nil {
+			return err
+		}
+
+		s.audit = append(s.audit, entry)
+		s.lastAuditID = max(s.lastAuditID, entry.ID)
+	}
+
+	return scanner.Err()
+}
+
+// appendAuditEntry function writes the entry as new line of the audit log file.
+func (s *Store) appendAuditEntry(entry *models.AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return markdown.AppendFile(filepath.Join(s.dir, metaDir, auditFile), append(line, '\n'))
+}
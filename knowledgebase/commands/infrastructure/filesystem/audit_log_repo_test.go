@@ -0,0 +1,108 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/filesystem"
+)
+
+func TestStore_AuditLog(t *testing.T) {
+	dir := t.TempDir()
+
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := at.Add(time.Hour)
+
+	repo := newStore(t, dir).AuditLogRepo()
+	for _, entry := range []*models.AuditEntry{
+		{Actor: "alice", Owner: "alice", Command: "AddKnowledgeItem", Payload: []byte(`{"title":"Go"}`),
+			Outcome: models.AuditSucceeded, ItemIDs: []int64{7}, CreatedAt: &at},
+		{Actor: "bob", Owner: "workspace:5", Command: "DeleteKnowledgeItem", Payload: []byte(`{"id":7}`),
+			Outcome: models.AuditFailed, Error: "forbidden", ItemIDs: []int64{7}, CreatedAt: &later},
+	} {
+		if _, err := repo.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo = newStore(t, dir).AuditLogRepo()
+
+	id, err := repo.Append(&models.AuditEntry{Actor: "alice", Owner: "alice", Command: "MoveCategory", CreatedAt: &later})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 3 {
+		t.Errorf("expected ID 3, got %d", id)
+	}
+
+	entries, err := repo.Find(&models.AuditFilter{ItemID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Actor != "alice" || entries[1].Error != "forbidden" {
+		t.Fatalf("expected both entries of item 7, got %+v", entries)
+	}
+	if string(entries[0].Payload) != `{"title":"Go"}` || !entries[0].CreatedAt.Equal(at) {
+		t.Errorf("expected stored payload and time, got %+v", entries[0])
+	}
+
+	if entries, err = repo.Find(&models.AuditFilter{Actor: "alice", From: &later}); err != nil || len(entries) != 1 {
+		t.Errorf("expected the latest entry of alice, got %+v, %v", entries, err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".neurography", "audit_log.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 3 {
+		t.Errorf("expected three lines in the audit log, got %d", lines)
+	}
+}
+
+func TestStore_AuditLog_TornLine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".neurography", "audit_log.jsonl")
+
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := newStore(t, dir).AuditLogRepo().Append(&models.AuditEntry{
+		Actor: "alice", Owner: "alice", Command: "AddKnowledgeItem", CreatedAt: &at,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = file.WriteString(`{"id":2,"actor":"bo`); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	repo := newStore(t, dir).AuditLogRepo()
+	id, err := repo.Append(&models.AuditEntry{Actor: "bob", Owner: "alice", Command: "MoveCategory", CreatedAt: &at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 2 {
+		t.Errorf("expected ID 2, got %d", id)
+	}
+
+	entries, err := newStore(t, dir).AuditLogRepo().Find(&models.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Command != "MoveCategory" {
+		t.Errorf("expected torn line to be replaced by the next entry, got %+v", entries)
+	}
+
+	// corruption before the final line is not left by an append, so it still fails the store.
+	writeFile(t, dir, ".neurography/audit_log.jsonl", "{\"id\":1}\nnot json\n{\"id\":3}\n")
+	if _, err = filesystem.NewStore(dir); err == nil {
+		t.Error("expected error for corrupted audit log")
+	}
+}
@@ -0,0 +1,190 @@
+package filesystem
+
+import (
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// categoriesRepo type implements repositories.CategoriesRepo on top of the Store.
+type categoriesRepo struct {
+	store *Store
+}
+
+// FindByID function returns the category or nil when it doesn't exist.
+func (r *categoriesRepo) FindByID(id int64) (*models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	category, ok := r.store.categories[id]
+	if !ok {
+		return nil, nil
+	}
+
+	c := *category
+
+	return &c, nil
+}
+
+// FindByName function returns the category of the owner or nil when it doesn't exist.
+func (r *categoriesRepo) FindByName(owner, name string) (*models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, category := range r.store.categories {
+		if category.Owner == owner && category.Name == name {
+			c := *category
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindAll function returns all the categories ordered by ID.
+func (r *categoriesRepo) FindAll() ([]*models.Category, error) {
+	return r.find(func(*models.Category) bool { return true }), nil
+}
+
+// FindByOwner function returns all the categories of the owner ordered by ID.
+func (r *categoriesRepo) FindByOwner(owner string) ([]*models.Category, error) {
+	return r.find(func(category *models.Category) bool { return category.Owner == owner }), nil
+}
+
+func (r *categoriesRepo) find(match func(*models.Category) bool) []*models.Category {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var categories []*models.Category
+	for _, category := range r.store.categories {
+		if match(category) {
+			c := *category
+			categories = append(categories, &c)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+
+	return categories
+}
+
+// Create function adds the category to the categories file and returns its ID.
+func (r *categoriesRepo) Create(category *models.Category) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.lastCategoryID + 1
+	categories := maps.Clone(s.categories)
+	categories[id] = &models.Category{
+		ID:               id,
+		Owner:            category.Owner,
+		Name:             category.Name,
+		ParentID:         category.ParentID,
+		CategoryMetadata: category.CategoryMetadata,
+	}
+
+	if err := s.writeCategories(categories); err != nil {
+		return 0, err
+	}
+
+	s.categories = categories
+	s.lastCategoryID = id
+	s.categoriesChanged = false
+
+	return id, nil
+}
+
+// Save function updates the category with its metadata in the categories file. When the category is renamed,
+// notes of its items are rewritten after the file, so they move into the folder of the new name.
+func (r *categoriesRepo) Save(category *models.Category) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known, ok := s.categories[category.ID]
+	if !ok {
+		return repositories.ErrNotFound
+	}
+
+	categories := maps.Clone(s.categories)
+	categories[category.ID] = &models.Category{
+		ID:               category.ID,
+		Owner:            known.Owner,
+		Name:             category.Name,
+		ParentID:         category.ParentID,
+		CategoryMetadata: category.CategoryMetadata,
+	}
+
+	if err := s.writeCategories(categories); err != nil {
+		return err
+	}
+
+	// items share the category instances, so the known one is updated in place.
+	renamed := known.Name != category.Name
+	known.Name = category.Name
+	known.ParentID = category.ParentID
+	known.CategoryMetadata = category.CategoryMetadata
+	categories[category.ID] = known
+	s.categories = categories
+	s.categoriesChanged = false
+
+	if !renamed {
+		return nil
+	}
+
+	for _, item := range s.items {
+		if !slices.ContainsFunc(item.Categories, func(c *models.Category) bool { return c.ID == category.ID }) {
+			continue
+		}
+
+		if err := s.store(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete function removes the category and takes it away from all the items, their notes are rewritten.
+func (r *categoriesRepo) Delete(category *models.Category) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.categories[category.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	for _, item := range s.items {
+		kept := make([]*models.Category, 0, len(item.Categories))
+		for _, c := range item.Categories {
+			if c.ID != category.ID {
+				kept = append(kept, c)
+			}
+		}
+		if len(kept) == len(item.Categories) {
+			continue
+		}
+
+		updated := *item
+		updated.Categories = kept
+		if err := s.store(&updated); err != nil {
+			return err
+		}
+	}
+
+	categories := maps.Clone(s.categories)
+	delete(categories, category.ID)
+
+	if err := s.writeCategories(categories); err != nil {
+		return err
+	}
+
+	s.categories = categories
+	s.categoriesChanged = false
+
+	return nil
+}
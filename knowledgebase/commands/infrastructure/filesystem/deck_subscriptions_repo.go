@@ -0,0 +1,204 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const followsFile = "deck_subscriptions.yaml"
+
+// subscriptionEntry represents one deck subscription in the deck subscriptions file along with its copies.
+type subscriptionEntry struct {
+	ID         int64        `yaml:"id"`
+	DeckID     int64        `yaml:"deck_id"`
+	Owner      string       `yaml:"owner"`
+	CategoryID int64        `yaml:"category_id"`
+	Items      []*copyEntry `yaml:"items,omitempty"`
+	CreatedAt  *time.Time   `yaml:"created_at,omitempty"`
+}
+
+// copyEntry represents the copy of the item of the deck in the deck subscriptions file.
+type copyEntry struct {
+	SourceID int64    `yaml:"source_id"`
+	ItemID   int64    `yaml:"item_id,omitempty"`
+	Title    string   `yaml:"title"`
+	Anchor   string   `yaml:"anchor,omitempty"`
+	Data     string   `yaml:"data"`
+	Tags     []string `yaml:"tags,omitempty"`
+}
+
+// deckSubscriptionsRepo type implements repositories.DeckSubscriptionsRepo on top of the Store.
+// Subscriptions are kept in the deck subscriptions file of the hidden .neurography directory. Every copy keeps
+// the content of the deck item it was last synced with, so upstream updates are told apart from local edits.
+type deckSubscriptionsRepo struct {
+	store *Store
+}
+
+// Create function adds the subscription to the deck subscriptions file and returns its ID.
+func (r *deckSubscriptionsRepo) Create(subscription *models.DeckSubscription) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneSubscription(subscription)
+	stored.ID = s.lastFollowID + 1
+	follows := maps.Clone(s.follows)
+	follows[stored.ID] = stored
+	if err := s.saveSubscriptions(follows); err != nil {
+		return 0, err
+	}
+
+	s.follows = follows
+	s.lastFollowID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces the stored subscription along with its copies.
+func (r *deckSubscriptionsRepo) Save(subscription *models.DeckSubscription) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.follows[subscription.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	follows := maps.Clone(s.follows)
+	follows[subscription.ID] = cloneSubscription(subscription)
+	if err := s.saveSubscriptions(follows); err != nil {
+		return err
+	}
+
+	s.follows = follows
+
+	return nil
+}
+
+// FindByID function returns the subscription or repositories.ErrNotFound.
+func (r *deckSubscriptionsRepo) FindByID(id int64) (*models.DeckSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscription, ok := r.store.follows[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneSubscription(subscription), nil
+}
+
+// FindByOwner function returns all the subscriptions of the owner ordered by ID.
+func (r *deckSubscriptionsRepo) FindByOwner(owner string) ([]*models.DeckSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var subscriptions []*models.DeckSubscription
+	for _, subscription := range r.store.follows {
+		if subscription.Owner == owner {
+			subscriptions = append(subscriptions, cloneSubscription(subscription))
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+
+	return subscriptions, nil
+}
+
+func cloneSubscription(subscription *models.DeckSubscription) *models.DeckSubscription {
+	clone := *subscription
+	clone.Items = make([]*models.DeckItemCopy, 0, len(subscription.Items))
+	for _, c := range subscription.Items {
+		item := *c
+		item.Tags = slices.Clone(c.Tags)
+		clone.Items = append(clone.Items, &item)
+	}
+	clone.CreatedAt = cloneTime(subscription.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadSubscriptions() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, followsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*subscriptionEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		subscription := &models.DeckSubscription{
+			ID:         entry.ID,
+			DeckID:     entry.DeckID,
+			Owner:      entry.Owner,
+			CategoryID: entry.CategoryID,
+			Items:      make([]*models.DeckItemCopy, 0, len(entry.Items)),
+			CreatedAt:  entry.CreatedAt,
+		}
+		for _, c := range entry.Items {
+			subscription.Items = append(subscription.Items, &models.DeckItemCopy{
+				SourceID: c.SourceID,
+				ItemID:   c.ItemID,
+				Title:    c.Title,
+				Anchor:   c.Anchor,
+				Data:     c.Data,
+				Tags:     c.Tags,
+			})
+		}
+
+		s.follows[entry.ID] = subscription
+		s.lastFollowID = max(s.lastFollowID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveSubscriptions function writes the given subscriptions into the deck subscriptions file.
+func (s *Store) saveSubscriptions(follows map[int64]*models.DeckSubscription) error {
+	entries := make([]*subscriptionEntry, 0, len(follows))
+	for _, subscription := range follows {
+		entry := &subscriptionEntry{
+			ID:         subscription.ID,
+			DeckID:     subscription.DeckID,
+			Owner:      subscription.Owner,
+			CategoryID: subscription.CategoryID,
+			CreatedAt:  subscription.CreatedAt,
+		}
+		for _, c := range subscription.Items {
+			entry.Items = append(entry.Items, &copyEntry{
+				SourceID: c.SourceID,
+				ItemID:   c.ItemID,
+				Title:    c.Title,
+				Anchor:   c.Anchor,
+				Data:     c.Data,
+				Tags:     c.Tags,
+			})
+		}
+
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, followsFile), content)
+}
@@ -0,0 +1,144 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const decksFile = "decks.yaml"
+
+// deckEntry represents one published deck in the decks file.
+type deckEntry struct {
+	ID          int64      `yaml:"id"`
+	Owner       string     `yaml:"owner"`
+	CategoryID  int64      `yaml:"category_id"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description,omitempty"`
+	PublishedBy string     `yaml:"published_by"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty"`
+}
+
+// decksRepo type implements repositories.DecksRepo on top of the Store.
+// Decks only point to the categories they publish, so they are kept in the decks file
+// of the hidden .neurography directory.
+type decksRepo struct {
+	store *Store
+}
+
+// Create function adds the deck to the decks file and returns its ID.
+func (r *decksRepo) Create(deck *models.Deck) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneDeck(deck)
+	stored.ID = s.lastDeckID + 1
+	decks := maps.Clone(s.decks)
+	decks[stored.ID] = stored
+	if err := s.saveDecks(decks); err != nil {
+		return 0, err
+	}
+
+	s.decks = decks
+	s.lastDeckID = stored.ID
+
+	return stored.ID, nil
+}
+
+// FindByID function returns the deck or repositories.ErrNotFound.
+func (r *decksRepo) FindByID(id int64) (*models.Deck, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	deck, ok := r.store.decks[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneDeck(deck), nil
+}
+
+// FindAll function returns all the decks ordered by ID.
+func (r *decksRepo) FindAll() ([]*models.Deck, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	decks := make([]*models.Deck, 0, len(r.store.decks))
+	for _, deck := range r.store.decks {
+		decks = append(decks, cloneDeck(deck))
+	}
+	sort.Slice(decks, func(i, j int) bool { return decks[i].ID < decks[j].ID })
+
+	return decks, nil
+}
+
+func cloneDeck(deck *models.Deck) *models.Deck {
+	clone := *deck
+	clone.CreatedAt = cloneTime(deck.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadDecks() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, decksFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*deckEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.decks[entry.ID] = &models.Deck{
+			ID:          entry.ID,
+			Owner:       entry.Owner,
+			CategoryID:  entry.CategoryID,
+			Name:        entry.Name,
+			Description: entry.Description,
+			PublishedBy: entry.PublishedBy,
+			CreatedAt:   entry.CreatedAt,
+		}
+		s.lastDeckID = max(s.lastDeckID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveDecks function writes the given decks into the decks file.
+func (s *Store) saveDecks(decks map[int64]*models.Deck) error {
+	entries := make([]*deckEntry, 0, len(decks))
+	for _, deck := range decks {
+		entries = append(entries, &deckEntry{
+			ID:          deck.ID,
+			Owner:       deck.Owner,
+			CategoryID:  deck.CategoryID,
+			Name:        deck.Name,
+			Description: deck.Description,
+			PublishedBy: deck.PublishedBy,
+			CreatedAt:   deck.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, decksFile), content)
+}
@@ -0,0 +1,82 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func TestStore_Decks(t *testing.T) {
+	dir := t.TempDir()
+
+	deckID, err := newStore(t, dir).DecksRepo().Create(&models.Deck{
+		Owner: "alice", CategoryID: 2, Name: "Go basics", PublishedBy: "alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptionID, err := newStore(t, dir).DeckSubscriptionsRepo().Create(&models.DeckSubscription{
+		DeckID: deckID, Owner: "bob", CategoryID: 8,
+		Items: []*models.DeckItemCopy{{SourceID: 10, ItemID: 20, Title: "Maps", Data: "hash tables", Tags: []string{"go"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := newStore(t, dir)
+
+	decks, err := store.DecksRepo().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decks) != 1 || decks[0].Name != "Go basics" || decks[0].CategoryID != 2 {
+		t.Errorf("expected stored deck, got %+v", decks)
+	}
+
+	repo := store.DeckSubscriptionsRepo()
+
+	subscription, err := repo.FindByID(subscriptionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription.Items = append(subscription.Items, &models.DeckItemCopy{SourceID: 14, Title: "Generics", Data: "any"})
+	if err = repo.Save(subscription); err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptions, err := newStore(t, dir).DeckSubscriptionsRepo().FindByOwner("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || len(subscriptions[0].Items) != 2 {
+		t.Fatalf("expected subscription of bob with two copies, got %+v", subscriptions)
+	}
+	if c := subscriptions[0].Copy(10); c == nil || c.ItemID != 20 || c.Tags[0] != "go" {
+		t.Errorf("expected copy of Maps, got %+v", c)
+	}
+	if c := subscriptions[0].Copy(14); c == nil || c.ItemID != 0 {
+		t.Errorf("expected declined copy of Generics, got %+v", c)
+	}
+}
+
+func TestStore_Decks_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the decks file keeps it from being written.
+	writeFile(t, dir, ".neurography/decks.yaml/keep", "")
+
+	repo := store.DecksRepo()
+	if _, err := repo.Create(&models.Deck{Owner: "alice", CategoryID: 2, Name: "Go basics"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	decks, err := repo.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decks) != 0 {
+		t.Errorf("expected no decks, got %+v", decks)
+	}
+}
@@ -0,0 +1,174 @@
+package filesystem
+
+import (
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// knowledgeItemsRepo type implements repositories.KnowledgeItemsRepo on top of the Store.
+// Owner of the item is kept in the frontmatter of its note, categories are looked up among the ones of the owner.
+type knowledgeItemsRepo struct {
+	store *Store
+}
+
+// Create function writes new note of the item and returns its ID.
+func (r *knowledgeItemsRepo) Create(item *models.KnowledgeItem) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.create(item)
+}
+
+// CreateBatch function writes notes of all the items and returns their IDs in the same order.
+func (r *knowledgeItemsRepo) CreateBatch(items []*models.KnowledgeItem) ([]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		id, err := r.create(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *knowledgeItemsRepo) create(item *models.KnowledgeItem) (int64, error) {
+	s := r.store
+	restore := s.keepCategories()
+
+	stored := cloneItem(item)
+	stored.ID = s.lastItemID + 1
+	stored.Categories = s.resolveCategories(item.Owner, item.Categories)
+
+	if err := s.store(stored); err != nil {
+		restore()
+		return 0, err
+	}
+	s.lastItemID = stored.ID
+
+	return stored.ID, s.saveCategories()
+}
+
+// Save function rewrites note of the existing item, the note is moved when its title or first category changed.
+func (r *knowledgeItemsRepo) Save(item *models.KnowledgeItem) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[item.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	restore := s.keepCategories()
+
+	stored := cloneItem(item)
+	stored.Categories = s.resolveCategories(item.Owner, item.Categories)
+
+	if err := s.store(stored); err != nil {
+		restore()
+		return err
+	}
+
+	return s.saveCategories()
+}
+
+// Delete function removes note of the item.
+func (r *knowledgeItemsRepo) Delete(item *models.KnowledgeItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.remove(item.ID)
+}
+
+// FindByID function returns the item or repositories.ErrNotFound.
+func (r *knowledgeItemsRepo) FindByID(id int64) (*models.KnowledgeItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.items[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneItem(item), nil
+}
+
+// FindByTitle function returns the item with the lowest ID among items of the owner with the title, or nil.
+func (r *knowledgeItemsRepo) FindByTitle(owner, title string) (*models.KnowledgeItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var found *models.KnowledgeItem
+	for _, item := range r.store.items {
+		if item.Owner == owner && item.Title == title && (found == nil || item.ID < found.ID) {
+			found = item
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	return cloneItem(found), nil
+}
+
+// FindAll function returns all the items ordered by ID.
+func (r *knowledgeItemsRepo) FindAll() ([]*models.KnowledgeItem, error) {
+	return r.find(func(*models.KnowledgeItem) bool { return true }), nil
+}
+
+// FindByOwner function returns all the items of the owner ordered by ID.
+func (r *knowledgeItemsRepo) FindByOwner(owner string) ([]*models.KnowledgeItem, error) {
+	return r.find(func(item *models.KnowledgeItem) bool { return item.Owner == owner }), nil
+}
+
+// FindByCategoryIDs function returns items which belong to any of the categories ordered by ID.
+func (r *knowledgeItemsRepo) FindByCategoryIDs(categoryIDs []int64) ([]*models.KnowledgeItem, error) {
+	ids := make(map[int64]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		ids[id] = true
+	}
+
+	return r.find(func(item *models.KnowledgeItem) bool {
+		for _, category := range item.Categories {
+			if ids[category.ID] {
+				return true
+			}
+		}
+
+		return false
+	}), nil
+}
+
+func (r *knowledgeItemsRepo) find(match func(*models.KnowledgeItem) bool) []*models.KnowledgeItem {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var items []*models.KnowledgeItem
+	for _, item := range r.store.items {
+		if match(item) {
+			items = append(items, cloneItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+
+	return &c
+}
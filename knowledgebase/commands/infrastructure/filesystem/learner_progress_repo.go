@@ -0,0 +1,188 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const progressFile = "progress.yaml"
+
+// progressEntry represents review state of one learner for one item in the progress file.
+type progressEntry struct {
+	ItemID      int64      `yaml:"item_id"`
+	Learner     string     `yaml:"learner"`
+	Score       int64      `yaml:"score"`
+	LastMark    int64      `yaml:"last_mark"`
+	LastCheckAt *time.Time `yaml:"last_check_at,omitempty"`
+	Reviews     int64      `yaml:"reviews,omitempty"`
+}
+
+// progressKey type identifies review state of the learner for the item.
+type progressKey struct {
+	learner string
+	itemID  int64
+}
+
+// learnerProgressRepo type implements repositories.LearnerProgressRepo on top of the Store.
+// Notes carry no review state, it is kept per learner in the progress file of the hidden .neurography directory.
+// The state found in notes written before is moved into the progress of their owner when the vault is loaded.
+type learnerProgressRepo struct {
+	store *Store
+}
+
+// Save function creates or replaces the progress of the learner for the item.
+func (r *learnerProgressRepo) Save(progress *models.LearnerProgress) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := maps.Clone(s.progress)
+	all[progressKey{learner: progress.Learner, itemID: progress.ItemID}] = cloneProgress(progress)
+	if err := s.writeProgress(all); err != nil {
+		return err
+	}
+
+	s.progress = all
+	s.progressChanged = false
+
+	return nil
+}
+
+// Find function returns the progress of the learner for the item or nil when the item wasn't reviewed.
+func (r *learnerProgressRepo) Find(learner string, itemID int64) (*models.LearnerProgress, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	progress, ok := r.store.progress[progressKey{learner: learner, itemID: itemID}]
+	if !ok {
+		return nil, nil
+	}
+
+	return cloneProgress(progress), nil
+}
+
+// FindByLearner function returns all the progress of the learner ordered by item ID.
+func (r *learnerProgressRepo) FindByLearner(learner string) ([]*models.LearnerProgress, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var found []*models.LearnerProgress
+	for key, progress := range r.store.progress {
+		if key.learner == learner {
+			found = append(found, cloneProgress(progress))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ItemID < found[j].ItemID })
+
+	return found, nil
+}
+
+// DeleteByItemID function removes progress of all the learners for the item.
+func (r *learnerProgressRepo) DeleteByItemID(itemID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := maps.Clone(s.progress)
+	maps.DeleteFunc(all, func(key progressKey, _ *models.LearnerProgress) bool { return key.itemID == itemID })
+	if len(all) == len(s.progress) {
+		return nil
+	}
+
+	if err := s.writeProgress(all); err != nil {
+		return err
+	}
+
+	s.progress = all
+	s.progressChanged = false
+
+	return nil
+}
+
+func cloneProgress(progress *models.LearnerProgress) *models.LearnerProgress {
+	clone := *progress
+	clone.LastCheckAt = cloneTime(progress.LastCheckAt)
+
+	return &clone
+}
+
+func (s *Store) loadProgress() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, progressFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*progressEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.progress[progressKey{learner: entry.Learner, itemID: entry.ItemID}] = &models.LearnerProgress{
+			ItemID:      entry.ItemID,
+			Learner:     entry.Learner,
+			Score:       entry.Score,
+			LastMark:    entry.LastMark,
+			LastCheckAt: entry.LastCheckAt,
+			Reviews:     entry.Reviews,
+		}
+	}
+
+	return nil
+}
+
+// saveProgress function writes the progress file if the progress was changed since the last save.
+func (s *Store) saveProgress() error {
+	if !s.progressChanged {
+		return nil
+	}
+
+	if err := s.writeProgress(s.progress); err != nil {
+		return err
+	}
+
+	s.progressChanged = false
+
+	return nil
+}
+
+// writeProgress function writes the given progress into the progress file.
+func (s *Store) writeProgress(progress map[progressKey]*models.LearnerProgress) error {
+	entries := make([]*progressEntry, 0, len(progress))
+	for _, p := range progress {
+		entries = append(entries, &progressEntry{
+			ItemID:      p.ItemID,
+			Learner:     p.Learner,
+			Score:       p.Score,
+			LastMark:    p.LastMark,
+			LastCheckAt: p.LastCheckAt,
+			Reviews:     p.Reviews,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ItemID != entries[j].ItemID {
+			return entries[i].ItemID < entries[j].ItemID
+		}
+
+		return entries[i].Learner < entries[j].Learner
+	})
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, progressFile), content)
+}
@@ -0,0 +1,73 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func TestStore_LearnerProgress(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Golang/Goroutine.md",
+		"---\nid: 1\nowner: alice\nscore: 40\nlast_mark: 8\n---\n\nLightweight thread\n")
+
+	store := newStore(t, dir)
+	repo := store.LearnerProgressRepo()
+
+	// review state of notes written before is moved into the progress of the owner.
+	progress, err := repo.Find("alice", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress == nil || progress.Score != 40 || progress.LastMark != 8 {
+		t.Fatalf("expected migrated progress, got %+v", progress)
+	}
+
+	err = repo.Save(&models.LearnerProgress{ItemID: 1, Learner: "bob", Score: 10, LastMark: 2, Reviews: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo = newStore(t, dir).LearnerProgressRepo()
+
+	found, err := repo.FindByLearner("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ItemID != 1 || found[0].Score != 10 || found[0].Reviews != 3 {
+		t.Errorf("expected stored progress, got %+v", found)
+	}
+
+	if err = repo.DeleteByItemID(1); err != nil {
+		t.Fatal(err)
+	}
+
+	progress, err = newStore(t, dir).LearnerProgressRepo().Find("alice", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress != nil {
+		t.Errorf("expected progress to be deleted, got %+v", progress)
+	}
+}
+
+func TestStore_LearnerProgress_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the progress file keeps it from being written.
+	writeFile(t, dir, ".neurography/progress.yaml/keep", "")
+
+	repo := store.LearnerProgressRepo()
+	if err := repo.Save(&models.LearnerProgress{ItemID: 1, Learner: "bob", Score: 10}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	progress, err := repo.Find("bob", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress != nil {
+		t.Errorf("expected no progress, got %+v", progress)
+	}
+}
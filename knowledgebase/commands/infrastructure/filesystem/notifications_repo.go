@@ -0,0 +1,129 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const messagesFile = "notifications.yaml"
+
+// notificationEntry represents one notification in the notifications file.
+type notificationEntry struct {
+	ID        int64      `yaml:"id"`
+	Recipient string     `yaml:"recipient"`
+	Kind      string     `yaml:"kind"`
+	SubjectID int64      `yaml:"subject_id,omitempty"`
+	Message   string     `yaml:"message"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+}
+
+// notificationsRepo type implements repositories.NotificationsRepo on top of the Store.
+// Notifications are addressed to users rather than notes, so they are kept in the notifications file
+// of the hidden .neurography directory.
+type notificationsRepo struct {
+	store *Store
+}
+
+// Create function adds the notification to the notifications file and returns its ID.
+func (r *notificationsRepo) Create(notification *models.Notification) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneNotification(notification)
+	stored.ID = s.lastMessageID + 1
+	messages := maps.Clone(s.messages)
+	messages[stored.ID] = stored
+	if err := s.saveNotifications(messages); err != nil {
+		return 0, err
+	}
+
+	s.messages = messages
+	s.lastMessageID = stored.ID
+
+	return stored.ID, nil
+}
+
+// FindByRecipient function returns the notifications of the user, the latest first.
+func (r *notificationsRepo) FindByRecipient(recipient string) ([]*models.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var notifications []*models.Notification
+	for _, notification := range r.store.messages {
+		if notification.Recipient == recipient {
+			notifications = append(notifications, cloneNotification(notification))
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID > notifications[j].ID })
+
+	return notifications, nil
+}
+
+func cloneNotification(notification *models.Notification) *models.Notification {
+	clone := *notification
+	clone.CreatedAt = cloneTime(notification.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadNotifications() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, messagesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*notificationEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.messages[entry.ID] = &models.Notification{
+			ID:        entry.ID,
+			Recipient: entry.Recipient,
+			Kind:      entry.Kind,
+			SubjectID: entry.SubjectID,
+			Message:   entry.Message,
+			CreatedAt: entry.CreatedAt,
+		}
+		s.lastMessageID = max(s.lastMessageID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveNotifications function writes the given notifications into the notifications file.
+func (s *Store) saveNotifications(messages map[int64]*models.Notification) error {
+	entries := make([]*notificationEntry, 0, len(messages))
+	for _, notification := range messages {
+		entries = append(entries, &notificationEntry{
+			ID:        notification.ID,
+			Recipient: notification.Recipient,
+			Kind:      notification.Kind,
+			SubjectID: notification.SubjectID,
+			Message:   notification.Message,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, messagesFile), content)
+}
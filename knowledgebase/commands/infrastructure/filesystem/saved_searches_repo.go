@@ -0,0 +1,201 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const searchesFile = "saved_searches.yaml"
+
+// savedSearchEntry represents one saved search in the saved searches file.
+type savedSearchEntry struct {
+	ID        int64      `yaml:"id"`
+	Owner     string     `yaml:"owner"`
+	Name      string     `yaml:"name"`
+	Filter    string     `yaml:"filter"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `yaml:"updated_at,omitempty"`
+}
+
+// savedSearchesRepo type implements repositories.SavedSearchesRepo on top of the Store.
+// Searches are kept in the hidden .neurography directory of the vault next to the categories.
+type savedSearchesRepo struct {
+	store *Store
+}
+
+// Create function adds the search to the saved searches file and returns its ID.
+func (r *savedSearchesRepo) Create(search *models.SavedSearch) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneSearch(search)
+	stored.ID = s.lastSearchID + 1
+
+	searches := maps.Clone(s.searches)
+	searches[stored.ID] = stored
+	if err := s.saveSearches(searches); err != nil {
+		return 0, err
+	}
+
+	s.searches = searches
+	s.lastSearchID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces existing search.
+func (r *savedSearchesRepo) Save(search *models.SavedSearch) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.searches[search.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	searches := maps.Clone(s.searches)
+	searches[search.ID] = cloneSearch(search)
+	if err := s.saveSearches(searches); err != nil {
+		return err
+	}
+
+	s.searches = searches
+
+	return nil
+}
+
+// Delete function removes the search from the saved searches file.
+func (r *savedSearchesRepo) Delete(search *models.SavedSearch) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.searches[search.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	searches := maps.Clone(s.searches)
+	delete(searches, search.ID)
+	if err := s.saveSearches(searches); err != nil {
+		return err
+	}
+
+	s.searches = searches
+
+	return nil
+}
+
+// FindByID function returns the search or repositories.ErrNotFound.
+func (r *savedSearchesRepo) FindByID(id int64) (*models.SavedSearch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	search, ok := r.store.searches[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneSearch(search), nil
+}
+
+// FindByName function returns the search of the owner or nil when it doesn't exist.
+func (r *savedSearchesRepo) FindByName(owner, name string) (*models.SavedSearch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, search := range r.store.searches {
+		if search.Owner == owner && search.Name == name {
+			return cloneSearch(search), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindByOwner function returns all the searches of the owner ordered by ID.
+func (r *savedSearchesRepo) FindByOwner(owner string) ([]*models.SavedSearch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var searches []*models.SavedSearch
+	for _, search := range r.store.searches {
+		if search.Owner == owner {
+			searches = append(searches, cloneSearch(search))
+		}
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].ID < searches[j].ID })
+
+	return searches, nil
+}
+
+// cloneSearch function copies the search, so callers never share memory with the store.
+func cloneSearch(search *models.SavedSearch) *models.SavedSearch {
+	clone := *search
+	clone.CreatedAt = cloneTime(search.CreatedAt)
+	clone.UpdatedAt = cloneTime(search.UpdatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadSearches() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, searchesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*savedSearchEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.searches[entry.ID] = &models.SavedSearch{
+			ID:        entry.ID,
+			Owner:     entry.Owner,
+			Name:      entry.Name,
+			Filter:    entry.Filter,
+			CreatedAt: entry.CreatedAt,
+			UpdatedAt: entry.UpdatedAt,
+		}
+		s.lastSearchID = max(s.lastSearchID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveSearches function writes the given saved searches into the saved searches file.
+func (s *Store) saveSearches(searches map[int64]*models.SavedSearch) error {
+	entries := make([]*savedSearchEntry, 0, len(searches))
+	for _, search := range searches {
+		entries = append(entries, &savedSearchEntry{
+			ID:        search.ID,
+			Owner:     search.Owner,
+			Name:      search.Name,
+			Filter:    search.Filter,
+			CreatedAt: search.CreatedAt,
+			UpdatedAt: search.UpdatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, searchesFile), content)
+}
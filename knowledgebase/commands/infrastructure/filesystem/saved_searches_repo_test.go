@@ -0,0 +1,115 @@
+package filesystem_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+func TestStore_SavedSearches(t *testing.T) {
+	dir := t.TempDir()
+	repo := newStore(t, dir).SavedSearchesRepo()
+
+	weak := &models.SavedSearch{Owner: "alice", Name: "Weak Go topics", Filter: "tag:go score<40"}
+	id, err := repo.Create(weak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Errorf("expected ID 1, got %d", id)
+	}
+
+	if _, err = repo.Create(&models.SavedSearch{Owner: "bob", Name: "Stale", Filter: "checked:>30d"}); err != nil {
+		t.Fatal(err)
+	}
+
+	weak.ID = id
+	weak.Filter = "tag:go score<50"
+	if err = repo.Save(weak); err != nil {
+		t.Fatal(err)
+	}
+
+	// searches have to survive reopening of the vault.
+	repo = newStore(t, dir).SavedSearchesRepo()
+
+	searches, err := repo.FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(searches) != 1 || searches[0].Name != "Weak Go topics" || searches[0].Filter != "tag:go score<50" {
+		t.Fatalf("unexpected searches %v", searches)
+	}
+
+	found, err := repo.FindByName("bob", "Stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.ID != 2 {
+		t.Errorf("expected search 2, got %v", found)
+	}
+
+	found, err = repo.FindByName("alice", "Stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != nil {
+		t.Errorf("expected no search of another owner, got %v", found)
+	}
+
+	if err = repo.Delete(weak); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = repo.FindByID(weak.ID); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err = repo.Save(weak); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	id, err = repo.Create(&models.SavedSearch{Owner: "alice", Name: "Again", Filter: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 3 {
+		t.Errorf("expected IDs not to be reused, got %d", id)
+	}
+}
+
+func TestStore_SavedSearches_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	repo := newStore(t, dir).SavedSearchesRepo()
+
+	search := &models.SavedSearch{Owner: "alice", Name: "Weak Go topics", Filter: "tag:go score<40"}
+	id, err := repo.Create(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the directory in place of the saved searches file keeps it from being written.
+	if err = os.Remove(filepath.Join(dir, ".neurography", "saved_searches.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, ".neurography/saved_searches.yaml/keep", "")
+
+	search.ID = id
+	search.Filter = "tag:go score<50"
+	if err = repo.Save(search); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err = repo.Create(&models.SavedSearch{Owner: "alice", Name: "Stale"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// the searches stay as they were written the last time.
+	found, err := repo.FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Filter != "tag:go score<40" {
+		t.Errorf("unexpected searches %+v", found)
+	}
+}
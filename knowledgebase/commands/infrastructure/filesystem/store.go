@@ -0,0 +1,685 @@
+// Package filesystem contains repositories which keep the knowledge base in a directory of Markdown files.
+package filesystem
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	metaDir        = ".neurography"
+	categoriesFile = "categories.yaml"
+	noteExtension  = ".md"
+)
+
+// categoryEntry represents one category in the categories file.
+type categoryEntry struct {
+	ID                 int64    `yaml:"id"`
+	Owner              string   `yaml:"owner,omitempty"`
+	Name               string   `yaml:"name"`
+	ParentID           int64    `yaml:"parent_id,omitempty"`
+	Description        string   `yaml:"description,omitempty"`
+	Color              string   `yaml:"color,omitempty"`
+	Icon               string   `yaml:"icon,omitempty"`
+	SortOrder          int      `yaml:"sort_order,omitempty"`
+	TargetRetention    *float64 `yaml:"target_retention,omitempty"`
+	DailyNewItemsLimit *int     `yaml:"daily_new_items_limit,omitempty"`
+}
+
+// newCategoryEntry function builds the entry of the categories file for the category.
+func newCategoryEntry(category *models.Category) *categoryEntry {
+	return &categoryEntry{
+		ID:                 category.ID,
+		Owner:              category.Owner,
+		Name:               category.Name,
+		ParentID:           category.ParentID,
+		Description:        category.Description,
+		Color:              category.Color,
+		Icon:               category.Icon,
+		SortOrder:          category.SortOrder,
+		TargetRetention:    category.Scheduling.TargetRetention,
+		DailyNewItemsLimit: category.Scheduling.DailyNewItemsLimit,
+	}
+}
+
+// category function builds the category stored in the entry.
+func (e *categoryEntry) category() *models.Category {
+	return &models.Category{
+		ID:       e.ID,
+		Owner:    e.Owner,
+		Name:     e.Name,
+		ParentID: e.ParentID,
+		CategoryMetadata: models.CategoryMetadata{
+			Description: e.Description,
+			Color:       e.Color,
+			Icon:        e.Icon,
+			SortOrder:   e.SortOrder,
+			Scheduling: models.CategoryScheduling{
+				TargetRetention:    e.TargetRetention,
+				DailyNewItemsLimit: e.DailyNewItemsLimit,
+			},
+		},
+	}
+}
+
+// Store type keeps knowledge items as Markdown notes of the vault directory, the files are the source of truth.
+// Notes are placed into the folder of their first category, the same way the Markdown vault export does.
+// Categories are stored in the hidden .neurography directory of the vault, so they keep their IDs
+// and survive without any note.
+//
+// The vault is loaded into memory by NewStore, repositories write the files first and update the memory then.
+// Changes made by other programs are reconciled by Watch.
+type Store struct {
+	dir string
+
+	mu         sync.RWMutex
+	items      map[int64]*models.KnowledgeItem
+	paths      map[int64]string
+	byPath     map[string]int64
+	categories map[int64]*models.Category
+	searches   map[int64]*models.SavedSearch
+	users      map[int64]*models.User
+	progress   map[progressKey]*models.LearnerProgress
+	tokens     map[int64]*models.APIToken
+	workspaces map[int64]*models.Workspace
+	members    map[memberKey]*models.WorkspaceMember
+	invites    map[int64]*models.WorkspaceInvitation
+	edits      map[int64]*models.SuggestedEdit
+	messages   map[int64]*models.Notification
+	lessons    map[int64]*models.Assignment
+	decks      map[int64]*models.Deck
+	follows    map[int64]*models.DeckSubscription
+	audit      []*models.AuditEntry
+	webhooks   map[int64]*models.Webhook
+	calls      map[int64]*models.WebhookDelivery
+	// written keeps hashes of the files written by the store, so their watcher events are ignored.
+	written map[string][sha256.Size]byte
+	// touched collects IDs of the items changed by the watcher for its listeners, it's nil otherwise.
+	touched map[int64]bool
+
+	lastItemID        int64
+	lastCategoryID    int64
+	lastSearchID      int64
+	lastUserID        int64
+	lastTokenID       int64
+	lastWorkspaceID   int64
+	lastInvitationID  int64
+	lastEditID        int64
+	lastMessageID     int64
+	lastAssignmentID  int64
+	lastDeckID        int64
+	lastFollowID      int64
+	lastAuditID       int64
+	lastWebhookID     int64
+	lastCallID        int64
+	categoriesChanged bool
+	progressChanged   bool
+}
+
+// NewStore function loads the vault located in dir and builds new instance of Store.
+func NewStore(dir string) (*Store, error) {
+	s := &Store{
+		dir:        dir,
+		items:      make(map[int64]*models.KnowledgeItem),
+		paths:      make(map[int64]string),
+		byPath:     make(map[string]int64),
+		categories: make(map[int64]*models.Category),
+		searches:   make(map[int64]*models.SavedSearch),
+		users:      make(map[int64]*models.User),
+		progress:   make(map[progressKey]*models.LearnerProgress),
+		tokens:     make(map[int64]*models.APIToken),
+		workspaces: make(map[int64]*models.Workspace),
+		members:    make(map[memberKey]*models.WorkspaceMember),
+		invites:    make(map[int64]*models.WorkspaceInvitation),
+		edits:      make(map[int64]*models.SuggestedEdit),
+		messages:   make(map[int64]*models.Notification),
+		lessons:    make(map[int64]*models.Assignment),
+		decks:      make(map[int64]*models.Deck),
+		follows:    make(map[int64]*models.DeckSubscription),
+		webhooks:   make(map[int64]*models.Webhook),
+		calls:      make(map[int64]*models.WebhookDelivery),
+		written:    make(map[string][sha256.Size]byte),
+	}
+
+	if err := s.loadCategories(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadSearches(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadUsers(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadProgress(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadTokens(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadWorkspaces(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadInvitations(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadEdits(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadNotifications(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadAssignments(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadDecks(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadSubscriptions(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadAuditLog(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadWebhooks(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadDeliveries(); err != nil {
+		return nil, err
+	}
+
+	notes, err := markdown.NewVault().Read(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// notes with IDs go first, so the new IDs never clash with the ones stored in the files.
+	sort.SliceStable(notes, func(i, j int) bool { return notes[i].ID != 0 && notes[j].ID == 0 })
+	for _, note := range notes {
+		if err = s.reconcile(note); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.saveCategories(); err != nil {
+		return nil, err
+	}
+
+	if err = s.saveProgress(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// KnowledgeItemsRepo function returns repositories.KnowledgeItemsRepo backed by the store.
+func (s *Store) KnowledgeItemsRepo() repositories.KnowledgeItemsRepo {
+	return &knowledgeItemsRepo{store: s}
+}
+
+// CategoriesRepo function returns repositories.CategoriesRepo backed by the store.
+func (s *Store) CategoriesRepo() repositories.CategoriesRepo {
+	return &categoriesRepo{store: s}
+}
+
+// SavedSearchesRepo function returns repositories.SavedSearchesRepo backed by the store.
+func (s *Store) SavedSearchesRepo() repositories.SavedSearchesRepo {
+	return &savedSearchesRepo{store: s}
+}
+
+// UsersRepo function returns repositories.UsersRepo backed by the store.
+func (s *Store) UsersRepo() repositories.UsersRepo {
+	return &usersRepo{store: s}
+}
+
+// APITokensRepo function returns repositories.APITokensRepo backed by the store.
+func (s *Store) APITokensRepo() repositories.APITokensRepo {
+	return &apiTokensRepo{store: s}
+}
+
+// WorkspacesRepo function returns repositories.WorkspacesRepo backed by the store.
+func (s *Store) WorkspacesRepo() repositories.WorkspacesRepo {
+	return &workspacesRepo{store: s}
+}
+
+// WorkspaceMembersRepo function returns repositories.WorkspaceMembersRepo backed by the store.
+func (s *Store) WorkspaceMembersRepo() repositories.WorkspaceMembersRepo {
+	return &workspaceMembersRepo{store: s}
+}
+
+// WorkspaceInvitationsRepo function returns repositories.WorkspaceInvitationsRepo backed by the store.
+func (s *Store) WorkspaceInvitationsRepo() repositories.WorkspaceInvitationsRepo {
+	return &workspaceInvitationsRepo{store: s}
+}
+
+// SuggestedEditsRepo function returns repositories.SuggestedEditsRepo backed by the store.
+func (s *Store) SuggestedEditsRepo() repositories.SuggestedEditsRepo {
+	return &suggestedEditsRepo{store: s}
+}
+
+// NotificationsRepo function returns repositories.NotificationsRepo backed by the store.
+func (s *Store) NotificationsRepo() repositories.NotificationsRepo {
+	return &notificationsRepo{store: s}
+}
+
+// AssignmentsRepo function returns repositories.AssignmentsRepo backed by the store.
+func (s *Store) AssignmentsRepo() repositories.AssignmentsRepo {
+	return &assignmentsRepo{store: s}
+}
+
+// DecksRepo function returns repositories.DecksRepo backed by the store.
+func (s *Store) DecksRepo() repositories.DecksRepo {
+	return &decksRepo{store: s}
+}
+
+// DeckSubscriptionsRepo function returns repositories.DeckSubscriptionsRepo backed by the store.
+func (s *Store) DeckSubscriptionsRepo() repositories.DeckSubscriptionsRepo {
+	return &deckSubscriptionsRepo{store: s}
+}
+
+// AuditLogRepo function returns repositories.AuditLogRepo backed by the store.
+func (s *Store) AuditLogRepo() repositories.AuditLogRepo {
+	return &auditLogRepo{store: s}
+}
+
+// WebhooksRepo function returns repositories.WebhooksRepo backed by the store.
+func (s *Store) WebhooksRepo() repositories.WebhooksRepo {
+	return &webhooksRepo{store: s}
+}
+
+// WebhookDeliveriesRepo function returns repositories.WebhookDeliveriesRepo backed by the store.
+func (s *Store) WebhookDeliveriesRepo() repositories.WebhookDeliveriesRepo {
+	return &webhookDeliveriesRepo{store: s}
+}
+
+// LearnerProgressRepo function returns repositories.LearnerProgressRepo backed by the store.
+func (s *Store) LearnerProgressRepo() repositories.LearnerProgressRepo {
+	return &learnerProgressRepo{store: s}
+}
+
+func (s *Store) loadCategories() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, categoriesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*categoryEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	loaded := make([]*models.Category, 0, len(entries))
+	for _, entry := range entries {
+		category := entry.category()
+		s.categories[entry.ID] = category
+		s.lastCategoryID = max(s.lastCategoryID, entry.ID)
+		loaded = append(loaded, category)
+	}
+
+	// categories written before they formed a tree get their parents, missing ones are created.
+	for _, category := range loaded {
+		if category.ParentID == 0 {
+			category.ParentID = s.parentOf(category.Owner, category.Name)
+		}
+	}
+
+	return nil
+}
+
+// saveCategories function writes the categories file if categories were changed since the last save.
+func (s *Store) saveCategories() error {
+	if !s.categoriesChanged {
+		return nil
+	}
+
+	if err := s.writeCategories(s.categories); err != nil {
+		return err
+	}
+
+	s.categoriesChanged = false
+
+	return nil
+}
+
+// writeCategories function writes the given categories into the categories file.
+// Repositories pass the changed copy of the categories and keep it only when the file is written.
+func (s *Store) writeCategories(categories map[int64]*models.Category) error {
+	entries := make([]*categoryEntry, 0, len(categories))
+	for _, category := range categories {
+		entries = append(entries, newCategoryEntry(category))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, categoriesFile), content)
+}
+
+// keepCategories function returns the function which restores categories as they are now.
+// It drops the categories created for the item whose note couldn't be written.
+func (s *Store) keepCategories() (restore func()) {
+	categories := maps.Clone(s.categories)
+	lastID, changed := s.lastCategoryID, s.categoriesChanged
+
+	return func() {
+		s.categories, s.lastCategoryID, s.categoriesChanged = categories, lastID, changed
+	}
+}
+
+// reconcile function brings the note read from the vault into the memory.
+// The note keeps its ID unless it is missing or the note is a copy of another existing note.
+// Such notes get new ID which is written back into the file.
+func (s *Store) reconcile(note *appmodels.MarkdownNote) error {
+	id := note.ID
+	if known, ok := s.paths[id]; ok && known != note.Path && s.exists(known) {
+		id = 0
+	}
+
+	if previous, ok := s.byPath[note.Path]; ok && previous != id {
+		if id == 0 {
+			id = previous
+		} else {
+			s.forget(previous)
+		}
+	}
+
+	if id <= 0 {
+		s.lastItemID++
+		id = s.lastItemID
+	}
+	s.lastItemID = max(s.lastItemID, id)
+
+	item := &models.KnowledgeItem{
+		ID:        id,
+		Owner:     note.Owner,
+		Title:     note.Title,
+		Anchor:    note.Anchor,
+		Data:      note.Data,
+		Tags:      note.Tags,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+	for _, name := range noteCategories(note) {
+		item.Categories = append(item.Categories, s.categoryByName(note.Owner, name))
+	}
+
+	// review state of the note is moved into the progress of the owner and the note is written without it.
+	legacy := note.Score != 0 || note.LastMark != 0 || note.LastCheckAt != nil
+	key := progressKey{learner: note.Owner, itemID: id}
+	if _, ok := s.progress[key]; legacy && !ok {
+		s.progress[key] = &models.LearnerProgress{
+			ItemID:      id,
+			Learner:     note.Owner,
+			Score:       note.Score,
+			LastMark:    note.LastMark,
+			LastCheckAt: note.LastCheckAt,
+		}
+		s.progressChanged = true
+	}
+
+	if id != note.ID || legacy {
+		if err := s.writeFile(note.Path, item); err != nil {
+			return err
+		}
+	}
+
+	s.remember(item, note.Path)
+
+	return nil
+}
+
+// noteCategories function lists category names of the note, the category of its folder goes first.
+// The folder is matched against frontmatter categories by the file path they would produce,
+// since some characters of category names aren't allowed in file names.
+func noteCategories(note *appmodels.MarkdownNote) []string {
+	var names []string
+	if note.Folder != "" {
+		names = append(names, note.Folder)
+		for _, name := range note.Categories {
+			if folderOf(name) == note.Folder {
+				names[0] = name
+				break
+			}
+		}
+	}
+
+	for _, name := range note.Categories {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// folderOf function returns the vault folder where notes of the category are placed.
+func folderOf(category string) string {
+	folder := path.Dir(markdown.NotePath(&appmodels.MarkdownNote{Folder: category, Title: "note"}))
+	if folder == "." {
+		return ""
+	}
+
+	return folder
+}
+
+// categoryByName function finds the category of the owner by name and creates it when it doesn't exist.
+// Missing ancestors of the category are created as well.
+func (s *Store) categoryByName(owner, name string) *models.Category {
+	for _, category := range s.categories {
+		if category.Owner == owner && category.Name == name {
+			return category
+		}
+	}
+
+	parentID := s.parentOf(owner, name)
+
+	s.lastCategoryID++
+	category := &models.Category{ID: s.lastCategoryID, Owner: owner, Name: name, ParentID: parentID}
+	s.categories[category.ID] = category
+	s.categoriesChanged = true
+
+	return category
+}
+
+// parentOf function returns ID of the parent category of the named one, it is zero for root categories.
+func (s *Store) parentOf(owner, name string) int64 {
+	i := strings.LastIndex(name, models.CategorySeparator)
+	if i <= 0 {
+		return 0
+	}
+
+	return s.categoryByName(owner, name[:i]).ID
+}
+
+// resolveCategories function replaces categories given by callers with the ones of the store.
+// Categories missing in the store are looked up by name among the ones of the owner.
+func (s *Store) resolveCategories(owner string, categories []*models.Category) []*models.Category {
+	resolved := make([]*models.Category, 0, len(categories))
+	for _, category := range categories {
+		if known, ok := s.categories[category.ID]; ok {
+			resolved = append(resolved, known)
+			continue
+		}
+
+		resolved = append(resolved, s.categoryByName(owner, category.Name))
+	}
+
+	return resolved
+}
+
+// store function writes the item into the file built from its folder and title and remembers it.
+// The old file of the item is removed when the path has changed.
+// Review state of the item is dropped, it belongs to the progress of learners.
+func (s *Store) store(item *models.KnowledgeItem) error {
+	item.Score, item.LastMark, item.LastCheckAt, item.Reviews = 0, 0, nil, 0
+	previous, hasPrevious := s.paths[item.ID]
+
+	rel := markdown.NotePath(toNote(item))
+	if owner, ok := s.byPath[rel]; (ok && owner != item.ID) || (!ok && rel != previous && s.exists(rel)) {
+		rel = strings.TrimSuffix(rel, noteExtension) + " " + strconv.FormatInt(item.ID, 10) + noteExtension
+	}
+
+	if err := s.writeFile(rel, item); err != nil {
+		return err
+	}
+
+	if hasPrevious && previous != rel {
+		if err := os.Remove(s.abs(previous)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	s.remember(item, rel)
+
+	return nil
+}
+
+func (s *Store) writeFile(rel string, item *models.KnowledgeItem) error {
+	content, err := markdown.EncodeNote(toNote(item))
+	if err != nil {
+		return err
+	}
+
+	if err = markdown.WriteFile(s.abs(rel), content); err != nil {
+		return err
+	}
+
+	s.written[rel] = sha256.Sum256(content)
+
+	return nil
+}
+
+// remove function deletes the file of the item and forgets it.
+func (s *Store) remove(id int64) error {
+	rel, ok := s.paths[id]
+	if !ok {
+		return repositories.ErrNotFound
+	}
+
+	if err := os.Remove(s.abs(rel)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	s.forget(id)
+
+	return nil
+}
+
+func (s *Store) remember(item *models.KnowledgeItem, rel string) {
+	if previous, ok := s.paths[item.ID]; ok && previous != rel {
+		delete(s.byPath, previous)
+		delete(s.written, previous)
+	}
+
+	s.items[item.ID] = item
+	s.paths[item.ID] = rel
+	s.byPath[rel] = item.ID
+	s.touch(item.ID)
+}
+
+func (s *Store) forget(id int64) {
+	rel := s.paths[id]
+
+	delete(s.items, id)
+	delete(s.paths, id)
+	delete(s.byPath, rel)
+	delete(s.written, rel)
+	s.touch(id)
+}
+
+func (s *Store) touch(id int64) {
+	if s.touched != nil {
+		s.touched[id] = true
+	}
+}
+
+func (s *Store) abs(rel string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(rel))
+}
+
+func (s *Store) exists(rel string) bool {
+	_, err := os.Stat(s.abs(rel))
+
+	return err == nil
+}
+
+// toNote function converts the item into the note placed into the folder of its first category.
+// Review state is kept in the progress file, so it never gets into the note.
+func toNote(item *models.KnowledgeItem) *appmodels.MarkdownNote {
+	note := &appmodels.MarkdownNote{
+		ID:        item.ID,
+		Owner:     item.Owner,
+		Title:     item.Title,
+		Anchor:    item.Anchor,
+		Data:      item.Data,
+		Tags:      item.Tags,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+
+	for _, category := range item.Categories {
+		note.Categories = append(note.Categories, category.Name)
+	}
+	if len(note.Categories) > 0 {
+		note.Folder = note.Categories[0]
+	}
+
+	return note
+}
+
+// cloneItem function copies the item, so callers never share memory with the store.
+func cloneItem(item *models.KnowledgeItem) *models.KnowledgeItem {
+	clone := *item
+	clone.Tags = slices.Clone(item.Tags)
+	clone.LastCheckAt = cloneTime(item.LastCheckAt)
+	clone.CreatedAt = cloneTime(item.CreatedAt)
+	clone.UpdatedAt = cloneTime(item.UpdatedAt)
+
+	clone.Categories = nil
+	for _, category := range item.Categories {
+		c := *category
+		clone.Categories = append(clone.Categories, &c)
+	}
+
+	return &clone
+}
@@ -0,0 +1,589 @@
+package filesystem_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/filesystem"
+)
+
+func newStore(t *testing.T, dir string) *filesystem.Store {
+	t.Helper()
+
+	store, err := filesystem.NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func fileExists(dir, rel string) bool {
+	_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel)))
+
+	return err == nil
+}
+
+func createItem(t *testing.T, store *filesystem.Store, title string, categories ...string) *models.KnowledgeItem {
+	t.Helper()
+
+	item := &models.KnowledgeItem{
+		Title:  title,
+		Anchor: title,
+		Data:   "Description of " + title,
+		Tags:   []string{"go"},
+	}
+
+	for _, name := range categories {
+		category, err := store.CategoriesRepo().FindByName("", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if category == nil {
+			category = &models.Category{Name: name}
+			category.ID, err = store.CategoriesRepo().Create(category)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		item.Categories = append(item.Categories, category)
+	}
+
+	id, err := store.KnowledgeItemsRepo().Create(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.ID = id
+
+	return item
+}
+
+func TestStore_Create(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	item := &models.KnowledgeItem{
+		Title:     "What is a goroutine?",
+		Anchor:    "goroutine",
+		Data:      "Lightweight thread managed by the Go runtime",
+		Tags:      []string{"go", "concurrency"},
+		CreatedAt: &createdAt,
+	}
+
+	category := &models.Category{Name: "Golang"}
+	categoryID, err := store.CategoriesRepo().Create(category)
+	if err != nil {
+		t.Fatal(err)
+	}
+	category.ID = categoryID
+	item.Categories = []*models.Category{category}
+
+	id, err := store.KnowledgeItemsRepo().Create(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Errorf("expected ID 1, got %d", id)
+	}
+
+	content := readFile(t, dir, "Golang/What is a goroutine-.md")
+	if !strings.Contains(content, "id: 1\n") || !strings.Contains(content, "Lightweight thread managed by the Go runtime") {
+		t.Errorf("unexpected note content:\n%s", content)
+	}
+
+	// the vault is the source of truth, so another store sees the same state.
+	reopened := newStore(t, dir)
+
+	found, err := reopened.KnowledgeItemsRepo().FindByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Title != item.Title || found.Anchor != item.Anchor || found.Data != item.Data {
+		t.Errorf("expected item %+v, got %+v", item, found)
+	}
+	if len(found.Categories) != 1 || found.Categories[0].ID != categoryID || found.Categories[0].Name != "Golang" {
+		t.Errorf("unexpected categories %v", found.Categories)
+	}
+	if found.CreatedAt == nil || !found.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected created at %s, got %v", createdAt, found.CreatedAt)
+	}
+
+	byName, err := reopened.CategoriesRepo().FindByName("", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byName == nil || byName.ID != categoryID {
+		t.Errorf("expected category %d, got %v", categoryID, byName)
+	}
+}
+
+func TestStore_Create_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the file in place of the folder of the category keeps the note from being written.
+	writeFile(t, dir, "Golang", "not a folder")
+
+	_, err := store.KnowledgeItemsRepo().Create(&models.KnowledgeItem{
+		Title:      "Goroutine",
+		Categories: []*models.Category{{Name: "Golang"}},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	items, err := store.KnowledgeItemsRepo().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("expected no items, got %+v", items)
+	}
+
+	// the category created for the item is dropped along with it.
+	category, err := store.CategoriesRepo().FindByName("", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category != nil {
+		t.Errorf("expected no category, got %+v", category)
+	}
+
+	createItem(t, store, "Channel", "Concurrency")
+	if strings.Contains(readFile(t, dir, ".neurography/categories.yaml"), "Golang") {
+		t.Error("expected dropped category not to be written")
+	}
+}
+
+func TestStore_CreateCategory_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the categories file keeps it from being written.
+	writeFile(t, dir, ".neurography/categories.yaml/keep", "")
+
+	_, err := store.CategoriesRepo().Create(&models.Category{Name: "Golang"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	category, err := store.CategoriesRepo().FindByName("", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category != nil {
+		t.Errorf("expected no category, got %+v", category)
+	}
+}
+
+func TestStore_SaveCategory_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	createItem(t, store, "Goroutine", "Golang")
+
+	category, err := store.CategoriesRepo().FindByName("", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Remove(filepath.Join(dir, ".neurography", "categories.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, ".neurography/categories.yaml/keep", "")
+
+	category.Name = "Go"
+	if err = store.CategoriesRepo().Save(category); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if found, _ := store.CategoriesRepo().FindByName("", "Golang"); found == nil {
+		t.Error("expected category to keep its name")
+	}
+	if !fileExists(dir, "Golang/Goroutine.md") {
+		t.Error("expected note to stay in the folder of the category")
+	}
+}
+
+func TestStore_CreateBatch(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	ids, err := store.KnowledgeItemsRepo().CreateBatch([]*models.KnowledgeItem{
+		{Title: "Same title", Data: "First"},
+		{Title: "Same title", Data: "Second"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("unexpected IDs %v", ids)
+	}
+
+	// notes with the same title don't overwrite each other.
+	if !fileExists(dir, "Same title.md") || !fileExists(dir, "Same title 2.md") {
+		t.Error("expected both notes to be written")
+	}
+
+	found, err := store.KnowledgeItemsRepo().FindByTitle("", "Same title")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.ID != 1 {
+		t.Errorf("expected item 1, got %v", found)
+	}
+}
+
+func TestStore_Save(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	repo := store.KnowledgeItemsRepo()
+
+	item := createItem(t, store, "What is a channel?", "Golang")
+	concurrency := createItem(t, store, "Mutex", "Concurrency").Categories[0]
+
+	item.Title = "What is a buffered channel?"
+	item.Categories = []*models.Category{concurrency, item.Categories[0]}
+	item.Data = "Channel with a buffer"
+	item.Score = 7
+	if err := repo.Save(item); err != nil {
+		t.Fatal(err)
+	}
+
+	if fileExists(dir, "Golang/What is a channel-.md") {
+		t.Error("expected old note to be removed")
+	}
+	content := readFile(t, dir, "Concurrency/What is a buffered channel-.md")
+	if !strings.Contains(content, "Channel with a buffer") || strings.Contains(content, "score:") {
+		t.Errorf("unexpected note content:\n%s", content)
+	}
+
+	found, err := repo.FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Title != item.Title || found.Data != item.Data || found.Score != 0 {
+		t.Errorf("expected item %+v, got %+v", item, found)
+	}
+
+	err = repo.Save(&models.KnowledgeItem{ID: 100, Title: "Missing"})
+	if !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected error %s, got %v", repositories.ErrNotFound, err)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	repo := store.KnowledgeItemsRepo()
+
+	item := createItem(t, store, "Temporary", "Golang")
+
+	if err := repo.Delete(item); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(dir, "Golang/Temporary.md") {
+		t.Error("expected note to be removed")
+	}
+
+	_, err := repo.FindByID(item.ID)
+	if !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected error %s, got %v", repositories.ErrNotFound, err)
+	}
+
+	err = repo.Delete(item)
+	if !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected error %s, got %v", repositories.ErrNotFound, err)
+	}
+}
+
+func TestStore_FindByCategoryIDs(t *testing.T) {
+	store := newStore(t, t.TempDir())
+	repo := store.KnowledgeItemsRepo()
+
+	first := createItem(t, store, "Goroutine", "Golang")
+	createItem(t, store, "Index", "Databases")
+	third := createItem(t, store, "Channel", "Concurrency", "Golang")
+
+	items, err := repo.FindByCategoryIDs([]int64{first.Categories[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != first.ID || items[1].ID != third.ID {
+		t.Errorf("unexpected items %v", items)
+	}
+
+	all, err := repo.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 items, got %d", len(all))
+	}
+}
+
+func TestStore_DeleteCategory(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	item := createItem(t, store, "Channel", "Golang", "Concurrency")
+
+	if err := store.CategoriesRepo().Delete(item.Categories[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.Categories) != 1 || found.Categories[0].Name != "Concurrency" {
+		t.Errorf("unexpected categories %v", found.Categories)
+	}
+	if !fileExists(dir, "Concurrency/Channel.md") || fileExists(dir, "Golang/Channel.md") {
+		t.Error("expected note to be moved into the folder of the remaining category")
+	}
+
+	category, err := newStore(t, dir).CategoriesRepo().FindByName("", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category != nil {
+		t.Errorf("expected category to be deleted, got %v", category)
+	}
+}
+
+func TestNewStore_ExistingVault(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nid: 4\ncategories:\n  - Golang\n  - Concurrency\n---\n\nLightweight thread\n")
+	writeFile(t, dir, "Golang/Routine.md", "---\nid: 4\n---\n\nLightweight thread\n")
+	writeFile(t, dir, "Databases/Index.md", "Structure which speeds up lookups\n")
+	writeFile(t, dir, ".obsidian/workspace.md", "not a note\n")
+
+	store := newStore(t, dir)
+	repo := store.KnowledgeItemsRepo()
+
+	items, err := repo.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	if items[0].ID != 4 || items[0].Title != "Goroutine" {
+		t.Errorf("expected note to keep its ID, got %+v", items[0])
+	}
+
+	var names []string
+	for _, category := range items[0].Categories {
+		names = append(names, category.Name)
+	}
+	if strings.Join(names, ",") != "Golang,Concurrency" {
+		t.Errorf("unexpected categories %v", names)
+	}
+
+	// the copy and the note without ID get new IDs which are written back into the files.
+	for _, title := range []string{"Routine", "Index"} {
+		item := findByTitle(t, store, title)
+		if item == nil || item.ID <= 4 {
+			t.Errorf("expected new ID, got %+v", item)
+		}
+	}
+	if !strings.Contains(readFile(t, dir, "Databases/Index.md"), "id: ") {
+		t.Error("expected ID to be written into the note")
+	}
+}
+
+func TestStore_ConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	repo := store.KnowledgeItemsRepo()
+
+	item := createItem(t, store, "Shared", "Golang")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := repo.Create(&models.KnowledgeItem{Title: "Note", Categories: item.Categories})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+
+			found, err := repo.FindByID(item.ID)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			found.Data = fmt.Sprintf("Revision %d", i)
+			if err = repo.Save(found); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	items, err := newStore(t, dir).KnowledgeItemsRepo().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 11 {
+		t.Errorf("expected 11 items, got %d", len(items))
+	}
+}
+
+func TestStore_SaveCategory(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	item := createItem(t, store, "Index", "Databases")
+
+	limit := 15
+	category := item.Categories[0]
+	category.Name = "Engineering/Databases"
+	category.CategoryMetadata = models.CategoryMetadata{
+		Color:      "#336699",
+		SortOrder:  2,
+		Scheduling: models.CategoryScheduling{DailyNewItemsLimit: &limit},
+	}
+	category.ParentID = createItem(t, store, "Backend", "Engineering").Categories[0].ID
+	if err := store.CategoriesRepo().Save(category); err != nil {
+		t.Fatal(err)
+	}
+
+	if !fileExists(dir, "Engineering/Databases/Index.md") || fileExists(dir, "Databases/Index.md") {
+		t.Error("expected note to be moved into the folder of the renamed category")
+	}
+
+	found, err := newStore(t, dir).CategoriesRepo().FindByName("", "Engineering/Databases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.ID != category.ID || found.ParentID != category.ParentID {
+		t.Fatalf("expected renamed category %+v, got %+v", category, found)
+	}
+	if found.Color != "#336699" || found.SortOrder != 2 || found.Scheduling.TargetRetention != nil ||
+		found.Scheduling.DailyNewItemsLimit == nil || *found.Scheduling.DailyNewItemsLimit != limit {
+		t.Errorf("expected metadata to be stored, got %+v", found.CategoryMetadata)
+	}
+
+	byID, err := store.CategoriesRepo().FindByID(category.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byID == nil || byID.Name != category.Name {
+		t.Errorf("expected category %+v, got %+v", category, byID)
+	}
+
+	err = store.CategoriesRepo().Save(&models.Category{ID: 100, Name: "Unknown"})
+	if !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNewStore_NestedFolders(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Engineering/Backend/Databases/Index.md", "Structure which speeds up lookups\n")
+
+	categories, err := newStore(t, dir).CategoriesRepo().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 3 {
+		t.Fatalf("expected the category with its ancestors, got %+v", categories)
+	}
+
+	byName := make(map[string]*models.Category, len(categories))
+	for _, category := range categories {
+		byName[category.Name] = category
+	}
+
+	engineering, backend := byName["Engineering"], byName["Engineering/Backend"]
+	databases := byName["Engineering/Backend/Databases"]
+	if engineering == nil || backend == nil || databases == nil {
+		t.Fatalf("unexpected categories %+v", categories)
+	}
+	if engineering.ParentID != 0 || backend.ParentID != engineering.ID || databases.ParentID != backend.ID {
+		t.Errorf("unexpected parents %+v, %+v, %+v", engineering, backend, databases)
+	}
+}
+
+func TestNewStore_Owners(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nowner: alice\n---\n\nLightweight thread\n")
+	writeFile(t, dir, "Golang/Channel.md", "---\nowner: bob\n---\n\nTyped conduit\n")
+
+	store := newStore(t, dir)
+
+	items, err := store.KnowledgeItemsRepo().FindByOwner("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Title != "Channel" {
+		t.Fatalf("expected only the note of the owner, got %+v", items)
+	}
+
+	alice, err := store.CategoriesRepo().FindByName("alice", "Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice == nil || alice.ID == items[0].Categories[0].ID || items[0].Categories[0].Owner != "bob" {
+		t.Errorf("expected owners to have their own categories, got %+v and %+v", alice, items[0].Categories)
+	}
+
+	categories, err := store.CategoriesRepo().FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 1 || categories[0].ID != alice.ID {
+		t.Errorf("expected categories of the owner, got %+v", categories)
+	}
+
+	found, err := store.KnowledgeItemsRepo().FindByTitle("alice", "Channel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != nil {
+		t.Errorf("expected item of other owner not to be found, got %+v", found)
+	}
+}
@@ -0,0 +1,196 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const editsFile = "suggested_edits.yaml"
+
+// suggestedEditEntry represents one suggested edit in the suggested edits file.
+type suggestedEditEntry struct {
+	ID            int64      `yaml:"id"`
+	ItemID        int64      `yaml:"item_id"`
+	Owner         string     `yaml:"owner"`
+	Author        string     `yaml:"author"`
+	Comment       string     `yaml:"comment,omitempty"`
+	Title         string     `yaml:"title"`
+	Anchor        string     `yaml:"anchor"`
+	Data          string     `yaml:"data"`
+	BaseTitle     string     `yaml:"base_title"`
+	BaseAnchor    string     `yaml:"base_anchor"`
+	BaseData      string     `yaml:"base_data"`
+	Status        string     `yaml:"status"`
+	Reviewer      string     `yaml:"reviewer,omitempty"`
+	ReviewComment string     `yaml:"review_comment,omitempty"`
+	CreatedAt     *time.Time `yaml:"created_at,omitempty"`
+	ReviewedAt    *time.Time `yaml:"reviewed_at,omitempty"`
+}
+
+// suggestedEditsRepo type implements repositories.SuggestedEditsRepo on top of the Store.
+// Edits are kept apart from the notes in the suggested edits file of the hidden .neurography directory,
+// so a pending edit never changes the note before it is approved.
+type suggestedEditsRepo struct {
+	store *Store
+}
+
+// Create function adds the edit to the suggested edits file and returns its ID.
+func (r *suggestedEditsRepo) Create(edit *models.SuggestedEdit) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneEdit(edit)
+	stored.ID = s.lastEditID + 1
+	edits := maps.Clone(s.edits)
+	edits[stored.ID] = stored
+	if err := s.saveEdits(edits); err != nil {
+		return 0, err
+	}
+
+	s.edits = edits
+	s.lastEditID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces the edit in the suggested edits file.
+func (r *suggestedEditsRepo) Save(edit *models.SuggestedEdit) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.edits[edit.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	edits := maps.Clone(s.edits)
+	edits[edit.ID] = cloneEdit(edit)
+	if err := s.saveEdits(edits); err != nil {
+		return err
+	}
+
+	s.edits = edits
+
+	return nil
+}
+
+// FindByID function returns the edit or repositories.ErrNotFound.
+func (r *suggestedEditsRepo) FindByID(id int64) (*models.SuggestedEdit, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	edit, ok := r.store.edits[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneEdit(edit), nil
+}
+
+// FindPending function returns the edits of the items of the owner waiting for the review ordered by ID.
+func (r *suggestedEditsRepo) FindPending(owner string) ([]*models.SuggestedEdit, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var edits []*models.SuggestedEdit
+	for _, edit := range r.store.edits {
+		if edit.Owner == owner && edit.Pending() {
+			edits = append(edits, cloneEdit(edit))
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].ID < edits[j].ID })
+
+	return edits, nil
+}
+
+func cloneEdit(edit *models.SuggestedEdit) *models.SuggestedEdit {
+	clone := *edit
+	clone.Diff = nil
+	clone.CreatedAt = cloneTime(edit.CreatedAt)
+	clone.ReviewedAt = cloneTime(edit.ReviewedAt)
+
+	return &clone
+}
+
+func (s *Store) loadEdits() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, editsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*suggestedEditEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.edits[entry.ID] = &models.SuggestedEdit{
+			ID:            entry.ID,
+			ItemID:        entry.ItemID,
+			Owner:         entry.Owner,
+			Author:        entry.Author,
+			Comment:       entry.Comment,
+			Title:         entry.Title,
+			Anchor:        entry.Anchor,
+			Data:          entry.Data,
+			BaseTitle:     entry.BaseTitle,
+			BaseAnchor:    entry.BaseAnchor,
+			BaseData:      entry.BaseData,
+			Status:        entry.Status,
+			Reviewer:      entry.Reviewer,
+			ReviewComment: entry.ReviewComment,
+			CreatedAt:     entry.CreatedAt,
+			ReviewedAt:    entry.ReviewedAt,
+		}
+		s.lastEditID = max(s.lastEditID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveEdits function writes the given suggested edits into the suggested edits file.
+func (s *Store) saveEdits(edits map[int64]*models.SuggestedEdit) error {
+	entries := make([]*suggestedEditEntry, 0, len(edits))
+	for _, edit := range edits {
+		entries = append(entries, &suggestedEditEntry{
+			ID:            edit.ID,
+			ItemID:        edit.ItemID,
+			Owner:         edit.Owner,
+			Author:        edit.Author,
+			Comment:       edit.Comment,
+			Title:         edit.Title,
+			Anchor:        edit.Anchor,
+			Data:          edit.Data,
+			BaseTitle:     edit.BaseTitle,
+			BaseAnchor:    edit.BaseAnchor,
+			BaseData:      edit.BaseData,
+			Status:        edit.Status,
+			Reviewer:      edit.Reviewer,
+			ReviewComment: edit.ReviewComment,
+			CreatedAt:     edit.CreatedAt,
+			ReviewedAt:    edit.ReviewedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, editsFile), content)
+}
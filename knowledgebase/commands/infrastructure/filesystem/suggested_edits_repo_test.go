@@ -0,0 +1,89 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+func TestStore_SuggestedEdits(t *testing.T) {
+	dir := t.TempDir()
+
+	store := newStore(t, dir)
+	id, err := store.SuggestedEditsRepo().Create(&models.SuggestedEdit{
+		ItemID:   7,
+		Owner:    "workspace:5",
+		Author:   "bob",
+		Title:    "Raft",
+		Data:     "Leader is elected by the majority of votes.",
+		BaseData: "Leader is elected by 51% of votes.",
+		Status:   models.EditPending,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	edits, err := store.SuggestedEditsRepo().FindPending("workspace:5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 1 || edits[0].ID != id || edits[0].BaseData != "Leader is elected by 51% of votes." {
+		t.Fatalf("expected stored edit, got %+v", edits)
+	}
+
+	edits[0].Status = models.EditApproved
+	edits[0].Reviewer = "alice"
+	if err = store.SuggestedEditsRepo().Save(edits[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.NotificationsRepo().Create(&models.Notification{
+		Recipient: "bob", Kind: models.NotificationEditApproved, SubjectID: id, Message: "approved",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	if edits, err = store.SuggestedEditsRepo().FindPending("workspace:5"); err != nil || len(edits) != 0 {
+		t.Errorf("expected no pending edits, got %+v, %v", edits, err)
+	}
+
+	edit, err := store.SuggestedEditsRepo().FindByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit.Status != models.EditApproved || edit.Reviewer != "alice" {
+		t.Errorf("expected approved edit, got %+v", edit)
+	}
+
+	notifications, err := store.NotificationsRepo().FindByRecipient("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 1 || notifications[0].SubjectID != id {
+		t.Errorf("expected notification about the edit, got %+v", notifications)
+	}
+}
+
+func TestStore_SuggestedEdits_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the suggested edits file keeps it from being written.
+	writeFile(t, dir, ".neurography/suggested_edits.yaml/keep", "")
+
+	repo := store.SuggestedEditsRepo()
+	if _, err := repo.Create(&models.SuggestedEdit{ItemID: 7, Owner: "alice", Status: models.EditPending}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	edits, err := repo.FindPending("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 0 {
+		t.Errorf("expected no edits, got %+v", edits)
+	}
+}
@@ -0,0 +1,158 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const usersFile = "users.yaml"
+
+// userEntry represents one user in the users file.
+type userEntry struct {
+	ID        int64      `yaml:"id"`
+	Login     string     `yaml:"login"`
+	Name      string     `yaml:"name"`
+	Email     string     `yaml:"email,omitempty"`
+	Issuer    string     `yaml:"issuer,omitempty"`
+	Subject   string     `yaml:"subject,omitempty"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+}
+
+// usersRepo type implements repositories.UsersRepo on top of the Store.
+// Accounts don't belong to any note, so they are kept in the users file of the hidden .neurography directory.
+// Users signed in with OpenID Connect are found there by the issuer and the subject of their identity.
+type usersRepo struct {
+	store *Store
+}
+
+// Create function adds the user to the users file and returns its ID.
+func (r *usersRepo) Create(user *models.User) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneUser(user)
+	stored.ID = s.lastUserID + 1
+	users := maps.Clone(s.users)
+	users[stored.ID] = stored
+	if err := s.saveUsers(users); err != nil {
+		return 0, err
+	}
+
+	s.users = users
+	s.lastUserID = stored.ID
+
+	return stored.ID, nil
+}
+
+// FindByID function returns the user or repositories.ErrNotFound.
+func (r *usersRepo) FindByID(id int64) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneUser(user), nil
+}
+
+// FindByLogin function returns the user or nil when it doesn't exist.
+func (r *usersRepo) FindByLogin(login string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.users {
+		if user.Login == login {
+			return cloneUser(user), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindByIdentity function returns the user of the OpenID Connect identity or nil when it doesn't exist.
+func (r *usersRepo) FindByIdentity(issuer, subject string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.users {
+		if user.Issuer == issuer && user.Subject == subject {
+			return cloneUser(user), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func cloneUser(user *models.User) *models.User {
+	clone := *user
+	clone.CreatedAt = cloneTime(user.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadUsers() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, usersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*userEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.users[entry.ID] = &models.User{
+			ID:        entry.ID,
+			Login:     entry.Login,
+			Name:      entry.Name,
+			Email:     entry.Email,
+			Issuer:    entry.Issuer,
+			Subject:   entry.Subject,
+			CreatedAt: entry.CreatedAt,
+		}
+		s.lastUserID = max(s.lastUserID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveUsers function writes the given users into the users file.
+func (s *Store) saveUsers(users map[int64]*models.User) error {
+	entries := make([]*userEntry, 0, len(users))
+	for _, user := range users {
+		entries = append(entries, &userEntry{
+			ID:        user.ID,
+			Login:     user.Login,
+			Name:      user.Name,
+			Email:     user.Email,
+			Issuer:    user.Issuer,
+			Subject:   user.Subject,
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, usersFile), content)
+}
@@ -0,0 +1,66 @@
+package filesystem_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+func TestStore_Users(t *testing.T) {
+	dir := t.TempDir()
+
+	id, err := newStore(t, dir).UsersRepo().Create(&models.User{
+		Login:   "alice",
+		Name:    "Alice",
+		Issuer:  "https://id.example.com",
+		Subject: "42",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newStore(t, dir).UsersRepo()
+
+	user, err := repo.FindByLogin("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || user.ID != id || user.Name != "Alice" {
+		t.Errorf("expected stored user, got %+v", user)
+	}
+
+	if _, err = repo.FindByID(id + 1); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	user, err = repo.FindByIdentity("https://id.example.com", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || user.ID != id {
+		t.Errorf("expected user of the identity, got %+v", user)
+	}
+}
+
+func TestStore_Users_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directory in place of the users file keeps it from being written.
+	writeFile(t, dir, ".neurography/users.yaml/keep", "")
+
+	repo := store.UsersRepo()
+	if _, err := repo.Create(&models.User{Login: "alice"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	user, err := repo.FindByLogin("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Errorf("expected no user, got %+v", user)
+	}
+}
@@ -0,0 +1,288 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"github.com/fsnotify/fsnotify"
+)
+
+// settleDelay is the time the changed note has to stay unchanged before it is read.
+const settleDelay = 50 * time.Millisecond
+
+// Listener is notified about the items changed by other programs. Indexes built on top of the repositories,
+// like search or autocomplete, implement it to stay in sync with the vault.
+type Listener interface {
+	Add(item *models.KnowledgeItem)
+	Remove(id int64)
+}
+
+// Watch function follows changes of the vault made by other programs, like an external editor,
+// and reconciles them into the store until the context is done.
+//
+// Created and modified notes are read again, removed ones are forgotten. Renamed notes keep their items
+// since the ID is stored in the frontmatter, copied notes get new ID. Hidden files and directories are ignored,
+// so temporary files of atomic writes never reach the store.
+//
+// Notes are read once they stay unchanged for a short delay, so the ones written in several steps
+// by other programs aren't read half written.
+//
+// Created and updated items are passed to Add of the listeners, forgotten ones to Remove.
+//
+// Errors of the particular files, like a note with broken frontmatter, are passed to onError
+// and the watching goes on. The note is read again on its next change. onError may be nil.
+func (s *Store) Watch(ctx context.Context, onError func(error), listeners ...Listener) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err = s.watchTree(watcher, s.dir); err != nil {
+		return err
+	}
+
+	// changes made between loading and watching are picked up by the rescan.
+	s.report(onError, s.apply(listeners, func() error { return s.rescan("") }))
+
+	changed := make(chan string)
+	pending := make(map[string]*time.Timer)
+	for {
+		select {
+		case <-ctx.Done():
+			for _, timer := range pending {
+				timer.Stop()
+			}
+
+			return nil
+		case rel := <-changed:
+			delete(pending, rel)
+
+			s.report(onError, s.apply(listeners, func() error { return s.reload(rel) }))
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			rel, err := s.handle(watcher, event, listeners)
+			s.report(onError, err)
+			if rel == "" {
+				continue
+			}
+
+			if timer, ok := pending[rel]; ok {
+				timer.Reset(settleDelay)
+				continue
+			}
+			pending[rel] = time.AfterFunc(settleDelay, func() {
+				select {
+				case changed <- rel:
+				case <-ctx.Done():
+				}
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			s.report(onError, err)
+		}
+	}
+}
+
+func (s *Store) report(onError func(error), err error) {
+	if err != nil && onError != nil {
+		onError(err)
+	}
+}
+
+// apply function runs the change of the vault under the store lock and passes the items it touched
+// to the listeners. Listeners are called after the lock is released, so they may use the repositories.
+func (s *Store) apply(listeners []Listener, change func() error) error {
+	s.mu.Lock()
+	s.touched = make(map[int64]bool)
+	err := change()
+
+	added := make([]*models.KnowledgeItem, 0, len(s.touched))
+	var removed []int64
+	for id := range s.touched {
+		if item, ok := s.items[id]; ok {
+			added = append(added, cloneItem(item))
+		} else {
+			removed = append(removed, id)
+		}
+	}
+	s.touched = nil
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		for _, id := range removed {
+			listener.Remove(id)
+		}
+		for _, item := range added {
+			listener.Add(item)
+		}
+	}
+
+	return err
+}
+
+// watchTree function adds the directory and all its visible subdirectories to the watcher.
+func (s *Store) watchTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+		if p != s.dir && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(p)
+	})
+}
+
+// handle function applies removals and new directories right away
+// and returns slash separated path of the note which has to be read again.
+func (s *Store) handle(watcher *fsnotify.Watcher, event fsnotify.Event, listeners []Listener) (string, error) {
+	rel, err := filepath.Rel(s.dir, event.Name)
+	if err != nil {
+		return "", err
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, segment := range strings.Split(rel, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return "", nil
+		}
+	}
+
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		return "", s.apply(listeners, func() error { return s.removed(rel) })
+	}
+
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return "", nil
+	}
+
+	info, err := os.Stat(event.Name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// directory moved into the vault brings its notes without events of their own.
+	if info.IsDir() {
+		if err = s.watchTree(watcher, event.Name); err != nil {
+			return "", err
+		}
+
+		return "", s.apply(listeners, func() error { return s.rescan(rel) })
+	}
+
+	if !strings.EqualFold(filepath.Ext(rel), noteExtension) {
+		return "", nil
+	}
+
+	return rel, nil
+}
+
+// removed function forgets items of the removed or renamed away note or directory.
+// The path may exist again already, when an editor replaces the file by renaming, then nothing is forgotten.
+func (s *Store) removed(rel string) error {
+	if s.exists(rel) {
+		return nil
+	}
+
+	for id, p := range s.paths {
+		if p == rel || strings.HasPrefix(p, rel+"/") {
+			s.forget(id)
+		}
+	}
+
+	return nil
+}
+
+// reload function reads the note again unless its content is the one written by the store itself.
+func (s *Store) reload(rel string) error {
+	content, err := os.ReadFile(s.abs(rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if hash, ok := s.written[rel]; ok && hash == sha256.Sum256(content) {
+		return nil
+	}
+
+	note, err := markdown.ParseNote(rel, content)
+	if err != nil {
+		return err
+	}
+
+	s.written[rel] = sha256.Sum256(content)
+	if err = s.reconcile(note); err != nil {
+		return err
+	}
+
+	if err = s.saveCategories(); err != nil {
+		return err
+	}
+
+	return s.saveProgress()
+}
+
+// rescan function reconciles all the notes under the slash separated directory of the vault
+// and forgets items whose notes don't exist anymore.
+func (s *Store) rescan(rel string) error {
+	for id, p := range s.paths {
+		if (rel == "" || strings.HasPrefix(p, rel+"/")) && !s.exists(p) {
+			s.forget(id)
+		}
+	}
+
+	var errs []error
+	err := filepath.WalkDir(s.abs(rel), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") && p != s.abs(rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(p), noteExtension) {
+			return nil
+		}
+
+		noteRel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+
+		errs = append(errs, s.reload(filepath.ToSlash(noteRel)))
+
+		return nil
+	})
+
+	return errors.Join(append(errs, err)...)
+}
@@ -0,0 +1,364 @@
+package filesystem_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/autocomplete"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/duplicates"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/filesystem"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/search"
+)
+
+const watchTimeout = 5 * time.Second
+
+// watch function starts watching the store until the end of the test.
+func watch(t *testing.T, store *filesystem.Store, listeners ...filesystem.Listener) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- store.Watch(ctx, func(err error) { t.Log(err) }, listeners...)
+	}()
+
+	// let the watcher subscribe and finish the initial rescan, so it never reads a note the test is writing.
+	time.Sleep(100 * time.Millisecond)
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// eventually function waits until the condition is met by the watcher.
+func eventually(t *testing.T, message string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(watchTimeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// recordingListener type keeps the latest state of the items passed by the watcher.
+type recordingListener struct {
+	mu    sync.Mutex
+	items map[int64]*models.KnowledgeItem
+}
+
+func (l *recordingListener) Add(item *models.KnowledgeItem) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.items[item.ID] = item
+}
+
+func (l *recordingListener) Remove(id int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.items[id] = nil
+}
+
+func (l *recordingListener) item(id int64) (*models.KnowledgeItem, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	item, ok := l.items[id]
+
+	return item, ok
+}
+
+func findByTitle(t *testing.T, store *filesystem.Store, title string) *models.KnowledgeItem {
+	t.Helper()
+
+	item, err := store.KnowledgeItemsRepo().FindByTitle("", title)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return item
+}
+
+func TestStore_Watch_Edit(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+	watch(t, store)
+
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nid: 1\ntitle: Goroutine\nscore: 3\n---\n\nEdited in editor\n")
+
+	eventually(t, "expected edit to be picked up", func() bool {
+		found, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+		return err == nil && found.Data == "Edited in editor"
+	})
+
+	found, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.Categories) != 1 || found.Categories[0].Name != "Golang" {
+		t.Errorf("unexpected item %+v", found)
+	}
+
+	// review state written into the note by hand goes to the progress of the owner.
+	progress, err := store.LearnerProgressRepo().Find("", item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress == nil || progress.Score != 3 {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+}
+
+func TestStore_Watch_Rename(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+	watch(t, store)
+
+	err := os.MkdirAll(filepath.Join(dir, "Concurrency"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Rename(filepath.Join(dir, "Golang", "Goroutine.md"), filepath.Join(dir, "Concurrency", "Green thread.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "expected rename to be picked up", func() bool {
+		found, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+		return err == nil && len(found.Categories) > 0 && found.Categories[0].Name == "Concurrency"
+	})
+
+	found, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.Categories) != 2 || found.Categories[1].Name != "Golang" {
+		t.Errorf("expected moved note to keep its categories, got %v", found.Categories)
+	}
+
+	// saving the item writes the note where it is now instead of the old location.
+	if err = store.KnowledgeItemsRepo().Save(found); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(dir, "Golang/Goroutine.md") {
+		t.Error("expected old note not to be restored")
+	}
+}
+
+func TestStore_Watch_CreateAndCopy(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+	watch(t, store)
+
+	writeFile(t, dir, "Golang/Channel.md", "Typed conduit between goroutines\n")
+	writeFile(t, dir, "Golang/Goroutine copy.md", readFile(t, dir, "Golang/Goroutine.md"))
+
+	// the copy keeps frontmatter of the original, so it differs by ID only.
+	var items []*models.KnowledgeItem
+	eventually(t, "expected new notes to be picked up", func() bool {
+		var err error
+		items, err = store.KnowledgeItemsRepo().FindAll()
+		return err == nil && len(items) == 3
+	})
+
+	created := findByTitle(t, store, "Channel")
+	if created == nil || created.ID == item.ID || created.Categories[0].Name != "Golang" {
+		t.Fatalf("unexpected item %+v", created)
+	}
+
+	// notes are read in no particular order, so the copy is the one left.
+	copied := items[0]
+	for _, found := range items {
+		if found.ID != item.ID && found.ID != created.ID {
+			copied = found
+		}
+	}
+	if copied.ID == item.ID || copied.ID == created.ID || copied.Title != "Goroutine" {
+		t.Errorf("expected copy to get new ID, got %+v", copied)
+	}
+
+	eventually(t, "expected ID to be written into the new note", func() bool {
+		return strings.Contains(readFile(t, dir, "Golang/Channel.md"), "id: ")
+	})
+
+	original, err := store.KnowledgeItemsRepo().FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.Title != "Goroutine" {
+		t.Errorf("expected original item to be kept, got %+v", original)
+	}
+}
+
+func TestStore_Watch_Remove(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+	other := createItem(t, store, "Index", "Databases")
+	watch(t, store)
+
+	if err := os.Remove(filepath.Join(dir, "Golang", "Goroutine.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, "Databases")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []int64{item.ID, other.ID} {
+		eventually(t, "expected removal to be picked up", func() bool {
+			_, err := store.KnowledgeItemsRepo().FindByID(id)
+			return errors.Is(err, repositories.ErrNotFound)
+		})
+	}
+}
+
+func TestStore_Watch_Listeners(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	edited := createItem(t, store, "Goroutine", "Golang")
+	removed := createItem(t, store, "Index", "Databases")
+
+	listener := &recordingListener{items: make(map[int64]*models.KnowledgeItem)}
+	watch(t, store, listener)
+
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nid: 1\ntitle: Goroutine\n---\n\nEdited in editor\n")
+	if err := os.Remove(filepath.Join(dir, "Databases", "Index.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "Golang/Channel.md", "Typed conduit between goroutines\n")
+
+	eventually(t, "expected edit to reach the listener", func() bool {
+		item, _ := listener.item(edited.ID)
+		return item != nil && item.Data == "Edited in editor"
+	})
+	eventually(t, "expected removal to reach the listener", func() bool {
+		item, ok := listener.item(removed.ID)
+		return ok && item == nil
+	})
+	eventually(t, "expected new note to reach the listener", func() bool {
+		created := findByTitle(t, store, "Channel")
+		if created == nil {
+			return false
+		}
+
+		item, _ := listener.item(created.ID)
+		return item != nil && item.Title == "Channel"
+	})
+}
+
+func TestStore_Watch_SearchIndex(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+	removed := createItem(t, store, "Index", "Databases")
+
+	index := search.NewIndex()
+	if err := index.Reindex(store.KnowledgeItemsRepo()); err != nil {
+		t.Fatal(err)
+	}
+	watch(t, store, index)
+
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nid: 1\ntitle: Goroutine\n---\n\nMultiplexed onto threads\n")
+	if err := os.Remove(filepath.Join(dir, "Databases", "Index.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "expected edited note to be found by its new text", func() bool {
+		result, err := index.Search(&appmodels.SearchKnowledgeItemsQuery{Query: "multiplexed", Limit: 10})
+		return err == nil && len(result.Hits) == 1 && result.Hits[0].Item.ID == item.ID
+	})
+	eventually(t, "expected removed note to leave the index", func() bool {
+		result, err := index.Search(&appmodels.SearchKnowledgeItemsQuery{Query: removed.Title, Limit: 10})
+		return err == nil && result.Total == 0
+	})
+}
+
+func TestStore_Watch_DuplicatesIndex(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	item := createItem(t, store, "Goroutine", "Golang")
+
+	index := duplicates.NewIndex()
+	if err := index.Reindex(store.KnowledgeItemsRepo()); err != nil {
+		t.Fatal(err)
+	}
+	watch(t, store, index)
+
+	writeFile(t, dir, "Golang/Goroutine.md", "---\nid: 1\ntitle: Green thread\n---\n\nMultiplexed onto threads\n")
+
+	eventually(t, "expected edited note to be reindexed under its new title", func() bool {
+		candidates := index.Candidates(&models.KnowledgeItem{Title: "Green thread"})
+		return len(candidates) == 1 && candidates[0] == item.ID
+	})
+}
+
+func TestStore_Watch_AutocompleteIndex(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	createItem(t, store, "Goroutine", "Golang")
+
+	index := autocomplete.NewIndex()
+	if err := index.Reindex(store.KnowledgeItemsRepo()); err != nil {
+		t.Fatal(err)
+	}
+	watch(t, store, index)
+
+	writeFile(t, dir, "Golang/Goroutine.md",
+		"---\nid: 1\ntitle: Goroutine\ntags: [concurrency]\n---\n\nLightweight thread\n")
+
+	eventually(t, "expected tag of the edited note to be suggested", func() bool {
+		suggestions, err := index.Suggest(&appmodels.AutocompleteQuery{
+			Prefix: "concur",
+			Kind:   appmodels.AutocompleteKindTag,
+			Limit:  10,
+		})
+		return err == nil && len(suggestions) == 1 && suggestions[0].Value == "concurrency"
+	})
+}
+
+func TestStore_Watch_OwnWrites(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+	repo := store.KnowledgeItemsRepo()
+	watch(t, store)
+
+	item := createItem(t, store, "Goroutine", "Golang")
+	for i := 1; i <= 20; i++ {
+		item.Data = fmt.Sprintf("Revision %d", i)
+		if err := repo.Save(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// events of the store writes never bring older content back.
+	time.Sleep(100 * time.Millisecond)
+
+	found, err := repo.FindByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Data != "Revision 20" {
+		t.Errorf("expected revision 20, got %s", found.Data)
+	}
+}
@@ -0,0 +1,221 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const callsFile = "webhook_deliveries.yaml"
+
+// deliveryEntry represents one webhook delivery in the webhook deliveries file.
+type deliveryEntry struct {
+	ID             int64      `yaml:"id"`
+	WebhookID      int64      `yaml:"webhook_id"`
+	Event          string     `yaml:"event"`
+	Payload        string     `yaml:"payload"`
+	Status         string     `yaml:"status"`
+	Attempts       int        `yaml:"attempts,omitempty"`
+	ResponseStatus int        `yaml:"response_status,omitempty"`
+	LastError      string     `yaml:"last_error,omitempty"`
+	ReplayOf       int64      `yaml:"replay_of,omitempty"`
+	NextAttemptAt  *time.Time `yaml:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `yaml:"delivered_at,omitempty"`
+	CreatedAt      *time.Time `yaml:"created_at,omitempty"`
+}
+
+// webhookDeliveriesRepo type implements repositories.WebhookDeliveriesRepo on top of the Store.
+// Deliveries are kept with their payloads in the webhook deliveries file of the hidden .neurography directory,
+// so pending ones are retried after a restart and delivered ones can be replayed.
+type webhookDeliveriesRepo struct {
+	store *Store
+}
+
+// Create function adds the delivery to the webhook deliveries file and returns its ID.
+func (r *webhookDeliveriesRepo) Create(delivery *models.WebhookDelivery) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneDelivery(delivery)
+	stored.ID = s.lastCallID + 1
+	calls := maps.Clone(s.calls)
+	calls[stored.ID] = stored
+	if err := s.saveDeliveries(calls); err != nil {
+		return 0, err
+	}
+
+	s.calls = calls
+	s.lastCallID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces the stored delivery.
+func (r *webhookDeliveriesRepo) Save(delivery *models.WebhookDelivery) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.calls[delivery.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	calls := maps.Clone(s.calls)
+	calls[delivery.ID] = cloneDelivery(delivery)
+	if err := s.saveDeliveries(calls); err != nil {
+		return err
+	}
+
+	s.calls = calls
+
+	return nil
+}
+
+// FindByID function returns the delivery or repositories.ErrNotFound.
+func (r *webhookDeliveriesRepo) FindByID(id int64) (*models.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	delivery, ok := r.store.calls[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneDelivery(delivery), nil
+}
+
+// FindByWebhookID function returns all the deliveries of the webhook ordered by ID.
+func (r *webhookDeliveriesRepo) FindByWebhookID(webhookID int64) ([]*models.WebhookDelivery, error) {
+	return r.find(func(delivery *models.WebhookDelivery) bool { return delivery.WebhookID == webhookID })
+}
+
+// FindPending function returns all the pending deliveries ordered by ID.
+func (r *webhookDeliveriesRepo) FindPending() ([]*models.WebhookDelivery, error) {
+	return r.find(func(delivery *models.WebhookDelivery) bool { return delivery.Status == models.DeliveryPending })
+}
+
+// DeleteByWebhookID function removes all the deliveries of the webhook from the webhook deliveries file.
+func (r *webhookDeliveriesRepo) DeleteByWebhookID(webhookID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := maps.Clone(s.calls)
+	maps.DeleteFunc(calls, func(_ int64, delivery *models.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID
+	})
+	if len(calls) == len(s.calls) {
+		return nil
+	}
+
+	if err := s.saveDeliveries(calls); err != nil {
+		return err
+	}
+
+	s.calls = calls
+
+	return nil
+}
+
+func (r *webhookDeliveriesRepo) find(
+	match func(delivery *models.WebhookDelivery) bool,
+) ([]*models.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range r.store.calls {
+		if match(delivery) {
+			deliveries = append(deliveries, cloneDelivery(delivery))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+
+	return deliveries, nil
+}
+
+func cloneDelivery(delivery *models.WebhookDelivery) *models.WebhookDelivery {
+	clone := *delivery
+	clone.Payload = json.RawMessage(slices.Clone([]byte(delivery.Payload)))
+	clone.NextAttemptAt = cloneTime(delivery.NextAttemptAt)
+	clone.DeliveredAt = cloneTime(delivery.DeliveredAt)
+	clone.CreatedAt = cloneTime(delivery.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadDeliveries() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, callsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*deliveryEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.calls[entry.ID] = &models.WebhookDelivery{
+			ID:             entry.ID,
+			WebhookID:      entry.WebhookID,
+			Event:          entry.Event,
+			Payload:        json.RawMessage(entry.Payload),
+			Status:         entry.Status,
+			Attempts:       entry.Attempts,
+			ResponseStatus: entry.ResponseStatus,
+			LastError:      entry.LastError,
+			ReplayOf:       entry.ReplayOf,
+			NextAttemptAt:  entry.NextAttemptAt,
+			DeliveredAt:    entry.DeliveredAt,
+			CreatedAt:      entry.CreatedAt,
+		}
+		s.lastCallID = max(s.lastCallID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveDeliveries function writes the given deliveries into the webhook deliveries file.
+func (s *Store) saveDeliveries(calls map[int64]*models.WebhookDelivery) error {
+	entries := make([]*deliveryEntry, 0, len(calls))
+	for _, delivery := range calls {
+		entries = append(entries, &deliveryEntry{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			Event:          delivery.Event,
+			Payload:        string(delivery.Payload),
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+			ReplayOf:       delivery.ReplayOf,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			DeliveredAt:    delivery.DeliveredAt,
+			CreatedAt:      delivery.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, callsFile), content)
+}
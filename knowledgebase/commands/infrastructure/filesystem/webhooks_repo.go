@@ -0,0 +1,193 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const webhooksFile = "webhooks.yaml"
+
+// webhookEntry represents one webhook in the webhooks file.
+type webhookEntry struct {
+	ID         int64      `yaml:"id"`
+	Owner      string     `yaml:"owner"`
+	URL        string     `yaml:"url"`
+	Secret     string     `yaml:"secret"`
+	Events     []string   `yaml:"events"`
+	CreatedBy  string     `yaml:"created_by"`
+	Failures   int        `yaml:"failures,omitempty"`
+	DisabledAt *time.Time `yaml:"disabled_at,omitempty"`
+	CreatedAt  *time.Time `yaml:"created_at,omitempty"`
+}
+
+// webhooksRepo type implements repositories.WebhooksRepo on top of the Store.
+// Webhooks hold the secrets their deliveries are signed with, so they are kept in the webhooks file
+// of the hidden .neurography directory and never reach the notes.
+type webhooksRepo struct {
+	store *Store
+}
+
+// Create function adds the webhook to the webhooks file and returns its ID.
+func (r *webhooksRepo) Create(webhook *models.Webhook) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneWebhook(webhook)
+	stored.ID = s.lastWebhookID + 1
+	webhooks := maps.Clone(s.webhooks)
+	webhooks[stored.ID] = stored
+	if err := s.saveWebhooks(webhooks); err != nil {
+		return 0, err
+	}
+
+	s.webhooks = webhooks
+	s.lastWebhookID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Save function replaces the stored webhook.
+func (r *webhooksRepo) Save(webhook *models.Webhook) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[webhook.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	webhooks := maps.Clone(s.webhooks)
+	webhooks[webhook.ID] = cloneWebhook(webhook)
+	if err := s.saveWebhooks(webhooks); err != nil {
+		return err
+	}
+
+	s.webhooks = webhooks
+
+	return nil
+}
+
+// Delete function removes the webhook from the webhooks file.
+func (r *webhooksRepo) Delete(webhook *models.Webhook) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhooks := maps.Clone(s.webhooks)
+	delete(webhooks, webhook.ID)
+	if err := s.saveWebhooks(webhooks); err != nil {
+		return err
+	}
+
+	s.webhooks = webhooks
+
+	return nil
+}
+
+// FindByID function returns the webhook or repositories.ErrNotFound.
+func (r *webhooksRepo) FindByID(id int64) (*models.Webhook, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	webhook, ok := r.store.webhooks[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneWebhook(webhook), nil
+}
+
+// FindByOwner function returns all the webhooks of the owner ordered by ID.
+func (r *webhooksRepo) FindByOwner(owner string) ([]*models.Webhook, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var webhooks []*models.Webhook
+	for _, webhook := range r.store.webhooks {
+		if webhook.Owner == owner {
+			webhooks = append(webhooks, cloneWebhook(webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+
+	return webhooks, nil
+}
+
+func cloneWebhook(webhook *models.Webhook) *models.Webhook {
+	clone := *webhook
+	clone.Events = slices.Clone(webhook.Events)
+	clone.DisabledAt = cloneTime(webhook.DisabledAt)
+	clone.CreatedAt = cloneTime(webhook.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadWebhooks() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, webhooksFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*webhookEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.webhooks[entry.ID] = &models.Webhook{
+			ID:         entry.ID,
+			Owner:      entry.Owner,
+			URL:        entry.URL,
+			Secret:     entry.Secret,
+			Events:     entry.Events,
+			CreatedBy:  entry.CreatedBy,
+			Failures:   entry.Failures,
+			DisabledAt: entry.DisabledAt,
+			CreatedAt:  entry.CreatedAt,
+		}
+		s.lastWebhookID = max(s.lastWebhookID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveWebhooks function writes the given webhooks into the webhooks file.
+func (s *Store) saveWebhooks(webhooks map[int64]*models.Webhook) error {
+	entries := make([]*webhookEntry, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		entries = append(entries, &webhookEntry{
+			ID:         webhook.ID,
+			Owner:      webhook.Owner,
+			URL:        webhook.URL,
+			Secret:     webhook.Secret,
+			Events:     webhook.Events,
+			CreatedBy:  webhook.CreatedBy,
+			Failures:   webhook.Failures,
+			DisabledAt: webhook.DisabledAt,
+			CreatedAt:  webhook.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, webhooksFile), content)
+}
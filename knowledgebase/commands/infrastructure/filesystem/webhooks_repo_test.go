@@ -0,0 +1,132 @@
+package filesystem_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+func TestStore_Webhooks(t *testing.T) {
+	dir := t.TempDir()
+
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	next := at.Add(time.Minute)
+
+	store := newStore(t, dir)
+	webhookID, err := store.WebhooksRepo().Create(&models.Webhook{
+		Owner: "alice", URL: "https://example.com/hook", Secret: "whs_secret",
+		Events: []string{models.EventItemCreated}, CreatedBy: "alice", CreatedAt: &at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deliveryID, err := store.WebhookDeliveriesRepo().Create(&models.WebhookDelivery{
+		WebhookID: webhookID, Event: models.EventItemCreated, Payload: []byte(`{"event":"item.created"}`),
+		Status: models.DeliveryPending, NextAttemptAt: &next, CreatedAt: &at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	webhook, err := store.WebhooksRepo().FindByID(webhookID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.Secret != "whs_secret" || !webhook.Subscribed(models.EventItemCreated) || !webhook.CreatedAt.Equal(at) {
+		t.Errorf("expected stored webhook, got %+v", webhook)
+	}
+
+	webhook.Failures = 10
+	webhook.DisabledAt = &next
+	if err = store.WebhooksRepo().Save(webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := store.WebhookDeliveriesRepo().FindPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ID != deliveryID || string(pending[0].Payload) != `{"event":"item.created"}` {
+		t.Fatalf("expected pending delivery, got %+v", pending)
+	}
+
+	pending[0].Status = models.DeliveryFailed
+	pending[0].Attempts = 8
+	pending[0].LastError = "endpoint responded 500"
+	if err = store.WebhookDeliveriesRepo().Save(pending[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	webhooks, err := store.WebhooksRepo().FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 1 || !webhooks[0].Disabled() || webhooks[0].Failures != 10 {
+		t.Errorf("expected disabled webhook, got %+v", webhooks)
+	}
+
+	deliveries, err := store.WebhookDeliveriesRepo().FindByWebhookID(webhookID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != models.DeliveryFailed || deliveries[0].Attempts != 8 {
+		t.Errorf("expected failed delivery, got %+v", deliveries)
+	}
+
+	if err = store.WebhookDeliveriesRepo().DeleteByWebhookID(webhookID); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.WebhooksRepo().Delete(webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+	if _, err = store.WebhooksRepo().FindByID(webhookID); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected deleted webhook, got %v", err)
+	}
+	if deliveries, _ = store.WebhookDeliveriesRepo().FindByWebhookID(webhookID); len(deliveries) != 0 {
+		t.Errorf("expected deleted deliveries, got %+v", deliveries)
+	}
+}
+
+func TestStore_Webhooks_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	// the directories in place of the webhooks files keep them from being written.
+	writeFile(t, dir, ".neurography/webhooks.yaml/keep", "")
+	writeFile(t, dir, ".neurography/webhook_deliveries.yaml/keep", "")
+
+	_, err := store.WebhooksRepo().Create(&models.Webhook{Owner: "alice", URL: "https://example.com/hook"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	_, err = store.WebhookDeliveriesRepo().Create(&models.WebhookDelivery{WebhookID: 1, Status: models.DeliveryPending})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	webhooks, err := store.WebhooksRepo().FindByOwner("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 0 {
+		t.Errorf("expected no webhooks, got %+v", webhooks)
+	}
+
+	pending, err := store.WebhookDeliveriesRepo().FindPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no deliveries, got %+v", pending)
+	}
+}
@@ -0,0 +1,164 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const invitesFile = "workspace_invitations.yaml"
+
+// invitationEntry represents one invitation in the workspace invitations file.
+type invitationEntry struct {
+	ID          int64      `yaml:"id"`
+	WorkspaceID int64      `yaml:"workspace_id"`
+	Login       string     `yaml:"login"`
+	Role        string     `yaml:"role"`
+	InvitedBy   string     `yaml:"invited_by"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty"`
+}
+
+// workspaceInvitationsRepo type implements repositories.WorkspaceInvitationsRepo on top of the Store.
+// Pending invitations are kept in the workspace invitations file of the hidden .neurography directory,
+// an accepted invitation is deleted once its user becomes a member.
+type workspaceInvitationsRepo struct {
+	store *Store
+}
+
+// Create function adds the invitation to the workspace invitations file and returns its ID.
+func (r *workspaceInvitationsRepo) Create(invitation *models.WorkspaceInvitation) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneInvitation(invitation)
+	stored.ID = s.lastInvitationID + 1
+	invites := maps.Clone(s.invites)
+	invites[stored.ID] = stored
+	if err := s.saveInvitations(invites); err != nil {
+		return 0, err
+	}
+
+	s.invites = invites
+	s.lastInvitationID = stored.ID
+
+	return stored.ID, nil
+}
+
+// Delete function removes the invitation from the workspace invitations file.
+func (r *workspaceInvitationsRepo) Delete(invitation *models.WorkspaceInvitation) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.invites[invitation.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	invites := maps.Clone(s.invites)
+	delete(invites, invitation.ID)
+	if err := s.saveInvitations(invites); err != nil {
+		return err
+	}
+
+	s.invites = invites
+
+	return nil
+}
+
+// FindByID function returns the invitation or repositories.ErrNotFound.
+func (r *workspaceInvitationsRepo) FindByID(id int64) (*models.WorkspaceInvitation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	invitation, ok := r.store.invites[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneInvitation(invitation), nil
+}
+
+// FindByLogin function returns all the invitations of the user ordered by ID.
+func (r *workspaceInvitationsRepo) FindByLogin(login string) ([]*models.WorkspaceInvitation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var invitations []*models.WorkspaceInvitation
+	for _, invitation := range r.store.invites {
+		if invitation.Login == login {
+			invitations = append(invitations, cloneInvitation(invitation))
+		}
+	}
+	sort.Slice(invitations, func(i, j int) bool { return invitations[i].ID < invitations[j].ID })
+
+	return invitations, nil
+}
+
+func cloneInvitation(invitation *models.WorkspaceInvitation) *models.WorkspaceInvitation {
+	clone := *invitation
+	clone.CreatedAt = cloneTime(invitation.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadInvitations() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, invitesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*invitationEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.invites[entry.ID] = &models.WorkspaceInvitation{
+			ID:          entry.ID,
+			WorkspaceID: entry.WorkspaceID,
+			Login:       entry.Login,
+			Role:        entry.Role,
+			InvitedBy:   entry.InvitedBy,
+			CreatedAt:   entry.CreatedAt,
+		}
+		s.lastInvitationID = max(s.lastInvitationID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveInvitations function writes the given invitations into the workspace invitations file.
+func (s *Store) saveInvitations(invites map[int64]*models.WorkspaceInvitation) error {
+	entries := make([]*invitationEntry, 0, len(invites))
+	for _, invitation := range invites {
+		entries = append(entries, &invitationEntry{
+			ID:          invitation.ID,
+			WorkspaceID: invitation.WorkspaceID,
+			Login:       invitation.Login,
+			Role:        invitation.Role,
+			InvitedBy:   invitation.InvitedBy,
+			CreatedAt:   invitation.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, invitesFile), content)
+}
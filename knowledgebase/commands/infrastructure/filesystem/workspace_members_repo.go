@@ -0,0 +1,94 @@
+package filesystem
+
+import (
+	"maps"
+	"sort"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// memberKey type identifies the member of the workspace.
+type memberKey struct {
+	workspaceID int64
+	login       string
+}
+
+// workspaceMembersRepo type implements repositories.WorkspaceMembersRepo on top of the Store.
+// Members are kept next to their workspace in the workspaces file, so roles are written together with it.
+type workspaceMembersRepo struct {
+	store *Store
+}
+
+// Save function creates or replaces the member in the workspaces file.
+func (r *workspaceMembersRepo) Save(member *models.WorkspaceMember) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.workspaces[member.WorkspaceID]; !ok {
+		return repositories.ErrNotFound
+	}
+
+	members := maps.Clone(s.members)
+	members[memberKey{workspaceID: member.WorkspaceID, login: member.Login}] = cloneMember(member)
+	if err := s.saveWorkspaces(s.workspaces, members); err != nil {
+		return err
+	}
+
+	s.members = members
+
+	return nil
+}
+
+// Find function returns the member or nil when the user isn't a member of the workspace.
+func (r *workspaceMembersRepo) Find(workspaceID int64, login string) (*models.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	member, ok := r.store.members[memberKey{workspaceID: workspaceID, login: login}]
+	if !ok {
+		return nil, nil
+	}
+
+	return cloneMember(member), nil
+}
+
+// FindByWorkspace function returns all the members of the workspace ordered by login.
+func (r *workspaceMembersRepo) FindByWorkspace(workspaceID int64) ([]*models.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var members []*models.WorkspaceMember
+	for key, member := range r.store.members {
+		if key.workspaceID == workspaceID {
+			members = append(members, cloneMember(member))
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Login < members[j].Login })
+
+	return members, nil
+}
+
+// FindByLogin function returns all the memberships of the user ordered by workspace ID.
+func (r *workspaceMembersRepo) FindByLogin(login string) ([]*models.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var members []*models.WorkspaceMember
+	for key, member := range r.store.members {
+		if key.login == login {
+			members = append(members, cloneMember(member))
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].WorkspaceID < members[j].WorkspaceID })
+
+	return members, nil
+}
+
+func cloneMember(member *models.WorkspaceMember) *models.WorkspaceMember {
+	clone := *member
+	clone.JoinedAt = cloneTime(member.JoinedAt)
+
+	return &clone
+}
@@ -0,0 +1,153 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const workspacesFile = "workspaces.yaml"
+
+// workspaceEntry represents one workspace with its members in the workspaces file.
+type workspaceEntry struct {
+	ID        int64                   `yaml:"id"`
+	Name      string                  `yaml:"name"`
+	CreatedAt *time.Time              `yaml:"created_at,omitempty"`
+	Members   []*workspaceMemberEntry `yaml:"members"`
+}
+
+// workspaceMemberEntry represents one member of the workspace in the workspaces file.
+type workspaceMemberEntry struct {
+	Login    string     `yaml:"login"`
+	Role     string     `yaml:"role"`
+	JoinedAt *time.Time `yaml:"joined_at,omitempty"`
+}
+
+// workspacesRepo type implements repositories.WorkspacesRepo on top of the Store.
+// Workspaces are kept with their members in the workspaces file of the hidden .neurography directory.
+type workspacesRepo struct {
+	store *Store
+}
+
+// Create function adds the workspace to the workspaces file and returns its ID.
+func (r *workspacesRepo) Create(workspace *models.Workspace) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneWorkspace(workspace)
+	stored.ID = s.lastWorkspaceID + 1
+	workspaces := maps.Clone(s.workspaces)
+	workspaces[stored.ID] = stored
+	if err := s.saveWorkspaces(workspaces, s.members); err != nil {
+		return 0, err
+	}
+
+	s.workspaces = workspaces
+	s.lastWorkspaceID = stored.ID
+
+	return stored.ID, nil
+}
+
+// FindByID function returns the workspace or repositories.ErrNotFound.
+func (r *workspacesRepo) FindByID(id int64) (*models.Workspace, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	workspace, ok := r.store.workspaces[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return cloneWorkspace(workspace), nil
+}
+
+func cloneWorkspace(workspace *models.Workspace) *models.Workspace {
+	clone := *workspace
+	clone.CreatedAt = cloneTime(workspace.CreatedAt)
+
+	return &clone
+}
+
+func (s *Store) loadWorkspaces() error {
+	content, err := os.ReadFile(filepath.Join(s.dir, metaDir, workspacesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []*workspaceEntry
+	if err = yaml.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.workspaces[entry.ID] = &models.Workspace{
+			ID:        entry.ID,
+			Name:      entry.Name,
+			CreatedAt: entry.CreatedAt,
+		}
+		for _, member := range entry.Members {
+			s.members[memberKey{workspaceID: entry.ID, login: member.Login}] = &models.WorkspaceMember{
+				WorkspaceID: entry.ID,
+				Login:       member.Login,
+				Role:        member.Role,
+				JoinedAt:    member.JoinedAt,
+			}
+		}
+		s.lastWorkspaceID = max(s.lastWorkspaceID, entry.ID)
+	}
+
+	return nil
+}
+
+// saveWorkspaces function writes the given workspaces with their members into the workspaces file.
+func (s *Store) saveWorkspaces(
+	workspaces map[int64]*models.Workspace,
+	members map[memberKey]*models.WorkspaceMember,
+) error {
+	byID := make(map[int64]*workspaceEntry, len(workspaces))
+	entries := make([]*workspaceEntry, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		entry := &workspaceEntry{
+			ID:        workspace.ID,
+			Name:      workspace.Name,
+			CreatedAt: workspace.CreatedAt,
+			Members:   []*workspaceMemberEntry{},
+		}
+		byID[workspace.ID] = entry
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	for key, member := range members {
+		if entry, ok := byID[key.workspaceID]; ok {
+			entry.Members = append(entry.Members, &workspaceMemberEntry{
+				Login:    member.Login,
+				Role:     member.Role,
+				JoinedAt: member.JoinedAt,
+			})
+		}
+	}
+	for _, entry := range entries {
+		sort.Slice(entry.Members, func(i, j int) bool { return entry.Members[i].Login < entry.Members[j].Login })
+	}
+
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return markdown.WriteFile(filepath.Join(s.dir, metaDir, workspacesFile), content)
+}
@@ -0,0 +1,118 @@
+package filesystem_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+func TestStore_Workspaces(t *testing.T) {
+	dir := t.TempDir()
+
+	store := newStore(t, dir)
+	id, err := store.WorkspacesRepo().Create(&models.Workspace{Name: "Platform team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.WorkspaceMembersRepo().Save(&models.WorkspaceMember{
+		WorkspaceID: id, Login: "alice", Role: models.RoleOwner,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	invitationID, err := store.WorkspaceInvitationsRepo().Create(&models.WorkspaceInvitation{
+		WorkspaceID: id, Login: "bob", Role: models.RoleEditor, InvitedBy: "alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	workspace, err := store.WorkspacesRepo().FindByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspace.Name != "Platform team" {
+		t.Errorf("expected stored workspace, got %+v", workspace)
+	}
+
+	member, err := store.WorkspaceMembersRepo().Find(id, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member == nil || member.Role != models.RoleOwner {
+		t.Fatalf("expected stored member, got %+v", member)
+	}
+
+	invitations, err := store.WorkspaceInvitationsRepo().FindByLogin("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitations) != 1 || invitations[0].ID != invitationID || invitations[0].Role != models.RoleEditor {
+		t.Fatalf("expected stored invitation, got %+v", invitations)
+	}
+
+	if err = store.WorkspaceInvitationsRepo().Delete(invitations[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.WorkspaceMembersRepo().Save(&models.WorkspaceMember{
+		WorkspaceID: id, Login: "bob", Role: models.RoleEditor,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store = newStore(t, dir)
+
+	members, err := store.WorkspaceMembersRepo().FindByWorkspace(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[0].Login != "alice" || members[1].Login != "bob" {
+		t.Errorf("expected both members, got %+v", members)
+	}
+
+	if _, err = store.WorkspaceInvitationsRepo().FindByID(invitationID); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected invitation to be deleted, got %v", err)
+	}
+}
+
+func TestStore_Workspaces_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir)
+
+	id, err := store.WorkspacesRepo().Create(&models.Workspace{Name: "Platform team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the directory in place of the workspaces file keeps it from being written.
+	if err = os.Remove(filepath.Join(dir, ".neurography", "workspaces.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, ".neurography/workspaces.yaml/keep", "")
+
+	if _, err = store.WorkspacesRepo().Create(&models.Workspace{Name: "Data team"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err = store.WorkspacesRepo().FindByID(id + 1); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected workspace not to be created, got %v", err)
+	}
+
+	if err = store.WorkspaceMembersRepo().Save(&models.WorkspaceMember{
+		WorkspaceID: id, Login: "alice", Role: models.RoleOwner,
+	}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	member, err := store.WorkspaceMembersRepo().Find(id, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member != nil {
+		t.Errorf("expected no member, got %+v", member)
+	}
+}
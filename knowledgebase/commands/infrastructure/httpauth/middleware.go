@@ -0,0 +1,117 @@
+// Package httpauth contains HTTP middleware authenticating requests of scripts and integrations.
+package httpauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const bearerScheme = "bearer"
+
+// tokenContextKey is the key of the API token the request is authenticated with.
+type tokenContextKey struct{}
+
+// TokenFromContext function returns the API token the request is authenticated with.
+func TokenFromContext(ctx context.Context) (*models.APIToken, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(*models.APIToken)
+
+	return token, ok && token != nil
+}
+
+// Middleware type authenticates requests by the bearer API tokens.
+type Middleware struct {
+	tokens services.APITokenService
+	users  services.UserService
+}
+
+// NewMiddleware function builds new instance of Middleware.
+func NewMiddleware(tokens services.APITokenService, users services.UserService) *Middleware {
+	return &Middleware{
+		tokens: tokens,
+		users:  users,
+	}
+}
+
+// Authenticate function wraps the handler, so it is called only for requests with valid bearer token.
+// Owner of the token is put into the request context as the acting user of the usecases,
+// the token is put there as well for RequireScope. Other requests get 401 Unauthorized.
+func (m *Middleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "bearer token is required", http.StatusUnauthorized)
+			return
+		}
+
+		token, err := m.tokens.Authenticate(secret)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+
+		user, err := m.users.GetUser(token.Owner)
+		if errors.Is(err, repositories.ErrNotFound) {
+			err = services.ErrInvalidToken
+		}
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+
+		ctx := usecases.ContextWithUser(r.Context(), user)
+		ctx = context.WithValue(ctx, tokenContextKey{}, token)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope function wraps the handler, so requests authenticated by the token without the scope
+// get 403 Forbidden. Requests of users authenticated other way aren't limited by scopes,
+// requests without the acting user get 401 Unauthorized.
+func RequireScope(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := usecases.UserFromContext(r.Context()); !ok {
+			http.Error(w, usecases.ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if token, ok := TokenFromContext(r.Context()); ok && !token.HasScope(scope) {
+			http.Error(w, "api token has no "+scope+" scope", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken function returns the token of the Authorization header using the Bearer scheme.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// unauthorized function responds with 401 Unauthorized to invalid and expired tokens,
+// other errors are failures of the storage.
+func unauthorized(w http.ResponseWriter, err error) {
+	if !errors.Is(err, services.ErrInvalidToken) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
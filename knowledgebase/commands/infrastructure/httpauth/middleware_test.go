@@ -0,0 +1,99 @@
+package httpauth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/httpauth"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func serve(handler http.Handler, authorization string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestMiddleware_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	token := &models.APIToken{ID: 1, Owner: "alice", Scopes: []string{models.ScopeItemsRead}}
+	alice := &models.User{ID: 1, Login: "alice"}
+
+	tokens := mock.NewMockAPITokenService(ctrl)
+	tokens.EXPECT().Authenticate("ngt_secret").Return(token, nil).Times(2)
+	tokens.EXPECT().Authenticate("ngt_expired").Return(nil, services.ErrInvalidToken)
+	tokens.EXPECT().Authenticate("ngt_broken").Return(nil, errors.New("disk failure"))
+
+	users := mock.NewMockUserService(ctrl)
+	users.EXPECT().GetUser("alice").Return(alice, nil).Times(2)
+
+	var called int
+	m := httpauth.NewMiddleware(tokens, users)
+	list := m.Authenticate(httpauth.RequireScope(models.ScopeItemsRead,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			if user, ok := usecases.UserFromContext(r.Context()); !ok || user != alice {
+				t.Errorf("expected acting user alice, got %+v", user)
+			}
+		})))
+	add := m.Authenticate(httpauth.RequireScope(models.ScopeItemsWrite,
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called++ })))
+
+	cases := []struct {
+		handler       http.Handler
+		authorization string
+		status        int
+	}{
+		{list, "Bearer ngt_secret", http.StatusOK},
+		{list, "", http.StatusUnauthorized},
+		{list, "Basic YWxpY2U6c2VjcmV0", http.StatusUnauthorized},
+		{list, "Bearer ngt_expired", http.StatusUnauthorized},
+		{list, "Bearer ngt_broken", http.StatusInternalServerError},
+		{add, "bearer ngt_secret", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		w := serve(tc.handler, tc.authorization)
+		if w.Code != tc.status {
+			t.Errorf("%q: expected status %d, got %d", tc.authorization, tc.status, w.Code)
+		}
+		if tc.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%q: expected WWW-Authenticate header", tc.authorization)
+		}
+	}
+
+	if called != 1 {
+		t.Errorf("expected handler to be called once, got %d", called)
+	}
+}
+
+func TestRequireScope_WithoutToken(t *testing.T) {
+	handler := httpauth.RequireScope(models.ScopeAdmin, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	if w := serve(handler, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// users authenticated without tokens aren't limited by scopes.
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+	r = r.WithContext(usecases.ContextWithUser(r.Context(), &models.User{ID: 1, Login: "alice"}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
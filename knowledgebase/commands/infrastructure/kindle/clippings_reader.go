@@ -0,0 +1,163 @@
+// Package kindle contains adapters between the knowledge base and Kindle e-readers.
+package kindle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+const (
+	entrySeparator = "=========="
+	byteOrderMark  = "\ufeff"
+)
+
+var (
+	kindPattern     = regexp.MustCompile(`(?i)^-\s*(?:your\s+)?(highlight|note|bookmark)\b`)
+	pagePattern     = regexp.MustCompile(`(?i)\bpage\s+([\w-]+)`)
+	locationPattern = regexp.MustCompile(`(?i)\b(?:location|loc\.)\s+([\d-]+)`)
+	addedPattern    = regexp.MustCompile(`(?i)\badded\s+on\s+(.+)$`)
+)
+
+// addedLayouts are the date formats used by the English Kindle firmwares.
+var addedLayouts = []string{
+	"Monday, 2 January 2006 15:04:05",
+	"Monday, January 2, 2006 3:04:05 PM",
+	"Monday, January 2, 2006, 3:04 PM",
+}
+
+// ClippingsReader type reads the "My Clippings.txt" file of Kindle. It implements models.KindleClippingsReader.
+type ClippingsReader struct{}
+
+// NewClippingsReader function builds new instance of ClippingsReader.
+func NewClippingsReader() *ClippingsReader {
+	return &ClippingsReader{}
+}
+
+// Read function reads all the clippings of the file located by path in the order they were made.
+func (r *ClippingsReader) Read(path string) ([]*models.KindleClipping, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("kindle: open clippings: %w", err)
+	}
+	defer file.Close()
+
+	return parseClippings(file)
+}
+
+// parseClippings function parses clippings separated by the line of equal signs.
+// Every entry has the book line, the metadata line, an empty line and the content.
+func parseClippings(r io.Reader) ([]*models.KindleClipping, error) {
+	var (
+		clippings []*models.KindleClipping
+		lines     []string
+		entry     = 1
+	)
+
+	flush := func() error {
+		if len(strings.TrimSpace(strings.Join(lines, ""))) == 0 {
+			lines = nil
+			return nil
+		}
+
+		clipping, err := parseEntry(lines)
+		if err != nil {
+			return fmt.Errorf("kindle: clipping %d: %w", entry, err)
+		}
+
+		clippings = append(clippings, clipping)
+		lines = nil
+		entry++
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(strings.TrimPrefix(line, byteOrderMark)) == entrySeparator {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("kindle: read clippings: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return clippings, nil
+}
+
+func parseEntry(lines []string) (*models.KindleClipping, error) {
+	// some Kindle firmwares put the byte order mark in front of every book line, not only at the start of the file.
+	for len(lines) > 0 && strings.TrimSpace(strings.TrimPrefix(lines[0], byteOrderMark)) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) < 2 {
+		return nil, errors.New("metadata line is missing")
+	}
+
+	clipping := &models.KindleClipping{}
+	clipping.Book, clipping.Author = splitBookLine(strings.TrimSpace(strings.TrimPrefix(lines[0], byteOrderMark)))
+
+	meta := strings.TrimSpace(lines[1])
+	kind := kindPattern.FindStringSubmatch(meta)
+	if kind == nil {
+		return nil, fmt.Errorf("unknown clipping kind in %q", meta)
+	}
+	clipping.Kind = strings.ToLower(kind[1])
+
+	if m := pagePattern.FindStringSubmatch(meta); m != nil {
+		clipping.Page = m[1]
+	}
+	if m := locationPattern.FindStringSubmatch(meta); m != nil {
+		clipping.Location = m[1]
+	}
+	if m := addedPattern.FindStringSubmatch(meta); m != nil {
+		clipping.AddedAt = parseAdded(strings.TrimSpace(m[1]))
+	}
+
+	clipping.Content = strings.TrimSpace(strings.Join(lines[2:], "\n"))
+
+	return clipping, nil
+}
+
+// splitBookLine function splits "Title (Author)" line, the last parenthesized part is treated as author.
+func splitBookLine(line string) (string, string) {
+	if !strings.HasSuffix(line, ")") {
+		return line, ""
+	}
+
+	start := strings.LastIndex(line, " (")
+	if start <= 0 {
+		return line, ""
+	}
+
+	return strings.TrimSpace(line[:start]), strings.TrimSpace(line[start+2 : len(line)-1])
+}
+
+// parseAdded function parses date of the clipping, unknown formats are ignored.
+func parseAdded(value string) *time.Time {
+	for _, layout := range addedLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+
+	return nil
+}
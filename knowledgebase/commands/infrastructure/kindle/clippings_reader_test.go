@@ -0,0 +1,108 @@
+package kindle_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/kindle"
+)
+
+func TestClippingsReader_Read(t *testing.T) {
+	clippings, err := kindle.NewClippingsReader().Read(filepath.Join("testdata", "My Clippings.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goBook := "The Go Programming Language"
+	goAuthor := "Donovan, Alan A. A.; Kernighan, Brian W."
+	ddiaBook := "Designing Data-Intensive Applications"
+	ddiaAuthor := "Martin Kleppmann"
+
+	date := func(t time.Time) *time.Time { return &t }
+
+	expected := []*models.KindleClipping{
+		{
+			Book:     goBook,
+			Author:   goAuthor,
+			Kind:     models.ClippingKindHighlight,
+			Page:     "217",
+			Location: "3312-3314",
+			AddedAt:  date(time.Date(2024, 3, 10, 12, 5, 31, 0, time.UTC)),
+			Content:  "Goroutines are multiplexed onto a small number of operating system threads.",
+		},
+		{
+			Book:     goBook,
+			Author:   goAuthor,
+			Kind:     models.ClippingKindNote,
+			Page:     "217",
+			Location: "3314",
+			AddedAt:  date(time.Date(2024, 3, 10, 12, 6, 2, 0, time.UTC)),
+			Content:  "Compare with green threads",
+		},
+		{
+			Book:     ddiaBook,
+			Author:   ddiaAuthor,
+			Kind:     models.ClippingKindBookmark,
+			Location: "1205",
+			AddedAt:  date(time.Date(2024, 3, 11, 21, 15, 0, 0, time.UTC)),
+		},
+		{
+			Book:     ddiaBook,
+			Author:   ddiaAuthor,
+			Kind:     models.ClippingKindHighlight,
+			Location: "1210-1212",
+			AddedAt:  date(time.Date(2024, 3, 11, 21, 16, 40, 0, time.UTC)),
+			Content: "An index is an additional structure that is derived from the primary data.\n" +
+				"It speeds up reads but slows down writes.",
+		},
+	}
+
+	if len(clippings) != len(expected) {
+		t.Fatalf("expected %d clippings, got %d", len(expected), len(clippings))
+	}
+	for i, clipping := range expected {
+		if !reflect.DeepEqual(clipping, clippings[i]) {
+			t.Errorf("clipping %d: expected %+v, got %+v", i, clipping, clippings[i])
+		}
+	}
+}
+
+func TestClippingsReader_Read_OldFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "My Clippings.txt")
+	content := "Refactoring\n- Highlight Loc. 120-22  | Added on Friday, 7 June 2013 18:01:12\n\nSmall steps.\n==========\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	clippings, err := kindle.NewClippingsReader().Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(clippings) != 1 {
+		t.Fatalf("expected 1 clipping, got %d", len(clippings))
+	}
+	clipping := clippings[0]
+	if clipping.Book != "Refactoring" || clipping.Author != "" || clipping.Location != "120-22" ||
+		clipping.Kind != models.ClippingKindHighlight || clipping.Content != "Small steps." {
+		t.Errorf("unexpected clipping %+v", clipping)
+	}
+}
+
+func TestClippingsReader_Read_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "My Clippings.txt")
+	content := "Book\n- Your Highlight | Added on Friday, 7 June 2013 18:01:12\n\nText\n==========\nBook\n- Your Clip\n\nText\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := kindle.NewClippingsReader().Read(path)
+	if err == nil || !strings.HasPrefix(err.Error(), "kindle: clipping 2: unknown clipping kind") {
+		t.Errorf("expected malformed clipping error, got %v", err)
+	}
+}
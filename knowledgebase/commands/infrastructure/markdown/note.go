@@ -0,0 +1,110 @@
+// Package markdown contains adapters between the knowledge base and a vault of Markdown files.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"gopkg.in/yaml.v3"
+)
+
+const frontmatterDelimiter = "---"
+
+// frontmatter represents YAML header of the Markdown file.
+type frontmatter struct {
+	ID          int64      `yaml:"id,omitempty"`
+	Owner       string     `yaml:"owner,omitempty"`
+	Title       string     `yaml:"title,omitempty"`
+	Anchor      string     `yaml:"anchor,omitempty"`
+	Tags        []string   `yaml:"tags,omitempty"`
+	Categories  []string   `yaml:"categories,omitempty"`
+	Score       int64      `yaml:"score,omitempty"`
+	LastMark    int64      `yaml:"last_mark,omitempty"`
+	LastCheckAt *time.Time `yaml:"last_check_at,omitempty"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty"`
+	UpdatedAt   *time.Time `yaml:"updated_at,omitempty"`
+}
+
+// EncodeNote function renders the note as frontmatter followed by the data as body.
+func EncodeNote(note *models.MarkdownNote) ([]byte, error) {
+	header, err := yaml.Marshal(&frontmatter{
+		ID:          note.ID,
+		Owner:       note.Owner,
+		Title:       note.Title,
+		Anchor:      note.Anchor,
+		Tags:        note.Tags,
+		Categories:  note.Categories,
+		Score:       note.Score,
+		LastMark:    note.LastMark,
+		LastCheckAt: note.LastCheckAt,
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString(frontmatterDelimiter + "\n")
+	out.Write(header)
+	out.WriteString(frontmatterDelimiter + "\n\n")
+	out.WriteString(note.Data)
+	out.WriteString("\n")
+
+	return out.Bytes(), nil
+}
+
+// decodeNote function parses the file content. Files without frontmatter are read as data only,
+// title and anchor fall back to the file name then.
+func decodeNote(content []byte, name string) (*models.MarkdownNote, error) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	meta := &frontmatter{}
+	body := text
+	if strings.HasPrefix(text, frontmatterDelimiter+"\n") {
+		rest := text[len(frontmatterDelimiter)+1:]
+
+		end := strings.Index(rest, "\n"+frontmatterDelimiter)
+		if end < 0 {
+			return nil, errors.New("frontmatter is not closed")
+		}
+
+		if err := yaml.Unmarshal([]byte(rest[:end+1]), meta); err != nil {
+			return nil, fmt.Errorf("decode frontmatter: %w", err)
+		}
+
+		body = rest[end+1+len(frontmatterDelimiter):]
+		if i := strings.Index(body, "\n"); i >= 0 {
+			body = body[i+1:]
+		} else {
+			body = ""
+		}
+	}
+
+	note := &models.MarkdownNote{
+		ID:          meta.ID,
+		Owner:       meta.Owner,
+		Title:       meta.Title,
+		Anchor:      meta.Anchor,
+		Data:        strings.TrimSpace(body),
+		Tags:        meta.Tags,
+		Categories:  meta.Categories,
+		Score:       meta.Score,
+		LastMark:    meta.LastMark,
+		LastCheckAt: meta.LastCheckAt,
+		CreatedAt:   meta.CreatedAt,
+		UpdatedAt:   meta.UpdatedAt,
+	}
+	if note.Title == "" {
+		note.Title = name
+	}
+	if note.Anchor == "" {
+		note.Anchor = note.Title
+	}
+
+	return note, nil
+}
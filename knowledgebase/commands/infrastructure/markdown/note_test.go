@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+func TestEncodeNote(t *testing.T) {
+	lastCheckAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	note := &models.MarkdownNote{
+		ID:          5,
+		Owner:       "alice",
+		Title:       "Goroutines",
+		Anchor:      "What is a goroutine?",
+		Data:        "Lightweight thread managed by the Go runtime.\n\n- cheap\n- multiplexed",
+		Tags:        []string{"go", "concurrency"},
+		Categories:  []string{"Engineering/Go"},
+		Score:       30,
+		LastMark:    7,
+		LastCheckAt: &lastCheckAt,
+	}
+
+	content, err := EncodeNote(note)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `---
+id: 5
+owner: alice
+title: Goroutines
+anchor: What is a goroutine?
+tags:
+    - go
+    - concurrency
+categories:
+    - Engineering/Go
+score: 30
+last_mark: 7
+last_check_at: 2024-01-02T03:04:05Z
+---
+
+Lightweight thread managed by the Go runtime.
+
+- cheap
+- multiplexed
+`
+	if string(content) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	decoded, err := decodeNote(content, "ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, note) {
+		t.Errorf("expected %+v, got %+v", note, decoded)
+	}
+}
+
+func TestDecodeNote_WithoutFrontmatter(t *testing.T) {
+	note, err := decodeNote([]byte("Typed conduit between goroutines\r\n"), "Channels")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if note.Title != "Channels" || note.Anchor != "Channels" {
+		t.Errorf("expected title and anchor from the file name, got %q and %q", note.Title, note.Anchor)
+	}
+	if note.Data != "Typed conduit between goroutines" {
+		t.Errorf("unexpected data %q", note.Data)
+	}
+}
+
+func TestDecodeNote_Errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "not closed", content: "---\ntitle: Channels\n"},
+		{name: "invalid yaml", content: "---\ntags: [go\n---\nbody"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := decodeNote([]byte(tc.content), "Channels"); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package markdown
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+)
+
+const (
+	noteExtension  = ".md"
+	untitledName   = "Untitled"
+	dirPermissions = 0o755
+	filePermission = 0o644
+)
+
+// Vault type reads and writes Markdown notes in the Obsidian-style directory,
+// where nested folders represent categories. It implements models.MarkdownVaultReader
+// and models.MarkdownVaultWriter.
+type Vault struct{}
+
+// NewVault function builds new instance of Vault.
+func NewVault() *Vault {
+	return &Vault{}
+}
+
+// Read function reads all Markdown notes of the vault. Hidden directories like .obsidian or .git are skipped.
+func (v *Vault) Read(dir string) ([]*models.MarkdownNote, error) {
+	var notes []*models.MarkdownNote
+
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if p != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.EqualFold(filepath.Ext(p), noteExtension) {
+			return nil
+		}
+
+		note, err := ReadNote(dir, p)
+		if err != nil {
+			return err
+		}
+
+		notes = append(notes, note)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// ReadNote function reads one Markdown note located by path inside the vault directory.
+func ReadNote(dir, p string) (*models.MarkdownNote, error) {
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNote(filepath.ToSlash(rel), content)
+}
+
+// ParseNote function parses content of the note file located by slash separated path inside the vault.
+func ParseNote(rel string, content []byte) (*models.MarkdownNote, error) {
+	name := strings.TrimSuffix(path.Base(rel), path.Ext(rel))
+	note, err := decodeNote(content, name)
+	if err != nil {
+		return nil, fmt.Errorf("markdown: %s: %w", rel, err)
+	}
+
+	note.Path = rel
+	if folder := path.Dir(rel); folder != "." {
+		note.Folder = folder
+	}
+
+	return note, nil
+}
+
+// Write function writes every note into the folder of its category.
+// Existing files with the same names are overwritten, other files of the vault are kept.
+func (v *Vault) Write(dir string, notes []*models.MarkdownNote) error {
+	used := make(map[string]bool, len(notes))
+	for _, note := range notes {
+		rel := NotePath(note)
+		if used[strings.ToLower(rel)] {
+			rel = strings.TrimSuffix(rel, noteExtension) + " " + strconv.FormatInt(note.ID, 10) + noteExtension
+		}
+		used[strings.ToLower(rel)] = true
+
+		if err := WriteNote(dir, rel, note); err != nil {
+			return err
+		}
+
+		note.Path = rel
+	}
+
+	return nil
+}
+
+// WriteNote function writes one Markdown note to the path relative to the vault directory.
+func WriteNote(dir, rel string, note *models.MarkdownNote) error {
+	content, err := EncodeNote(note)
+	if err != nil {
+		return fmt.Errorf("markdown: %s: %w", rel, err)
+	}
+
+	return WriteFile(filepath.Join(dir, filepath.FromSlash(rel)), content)
+}
+
+// WriteFile function replaces the file atomically, so editors and watchers never see it half written.
+// The content is written into hidden temporary file of the same directory and renamed then.
+func WriteFile(p string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), dirPermissions); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(filePermission); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+// NotePath function builds slash separated path of the note file inside the vault from its folder and title.
+func NotePath(note *models.MarkdownNote) string {
+	var segments []string
+	for _, segment := range strings.Split(note.Folder, "/") {
+		if segment = sanitizeName(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	name := sanitizeName(note.Title)
+	if name == "" {
+		name = untitledName
+	}
+
+	return path.Join(append(segments, name+noteExtension)...)
+}
+
+// sanitizeName function replaces characters which aren't allowed in file names on common file systems.
+func sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+
+		return r
+	}, name)
+
+	return strings.Trim(name, " .")
+}
+
+// AppendFile function adds the content to the end of the file creating it when needed.
+// The file is synced before returning, so the appended content survives crashes.
+func AppendFile(p string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), dirPermissions); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE, filePermission)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
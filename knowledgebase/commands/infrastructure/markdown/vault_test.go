@@ -0,0 +1,101 @@
+package markdown_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/markdown"
+)
+
+func TestVault_WriteRead(t *testing.T) {
+	dir := t.TempDir()
+	notes := []*models.MarkdownNote{
+		{
+			Folder:     "Engineering/Go",
+			ID:         5,
+			Title:      "Goroutines",
+			Anchor:     "What is a goroutine?",
+			Data:       "Lightweight thread managed by the Go runtime",
+			Categories: []string{"Engineering/Go"},
+		},
+		{
+			Folder: "Engineering/Go",
+			ID:     6,
+			Title:  "goroutines",
+			Anchor: "How to stop a goroutine?",
+			Data:   "Cancel its context and let it return",
+		},
+		{
+			ID:     7,
+			Title:  "What is I/O: a <primer>?",
+			Anchor: "I/O primer",
+			Data:   "Reading and writing bytes",
+		},
+	}
+
+	vault := markdown.NewVault()
+	if err := vault.Write(dir, notes); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		"Engineering/Go/Goroutines.md",
+		"Engineering/Go/goroutines 6.md",
+		"What is I-O- a -primer--.md",
+	}
+	for i, p := range expectedPaths {
+		if notes[i].Path != p {
+			t.Errorf("expected path %q, got %q", p, notes[i].Path)
+		}
+	}
+
+	// Obsidian settings and files of other types are ignored.
+	if err := os.MkdirAll(filepath.Join(dir, ".obsidian"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".obsidian", "workspace.md"), []byte("ignored"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "diagram.png"), []byte("ignored"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := vault.Read(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != len(notes) {
+		t.Fatalf("expected %d notes, got %d", len(notes), len(read))
+	}
+
+	byID := make(map[int64]*models.MarkdownNote)
+	for _, note := range read {
+		byID[note.ID] = note
+	}
+
+	for _, note := range notes {
+		actual := byID[note.ID]
+		if actual == nil {
+			t.Fatalf("note %d is missing", note.ID)
+		}
+		if actual.Path != note.Path || actual.Folder != note.Folder {
+			t.Errorf("expected %q in %q, got %q in %q", note.Path, note.Folder, actual.Path, actual.Folder)
+		}
+		if actual.Title != note.Title || actual.Anchor != note.Anchor || actual.Data != note.Data {
+			t.Errorf("expected %+v, got %+v", note, actual)
+		}
+	}
+}
+
+func TestVault_Read_InvalidNote(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.md"), []byte("---\ntitle: broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := markdown.NewVault().Read(dir); err == nil {
+		t.Fatal("expected error")
+	}
+}
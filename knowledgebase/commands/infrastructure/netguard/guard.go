@@ -0,0 +1,103 @@
+// Package netguard contains the policy keeping requests the server makes on behalf of the users,
+// like webhook deliveries, from reaching the internal network of the server.
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// blockedPrefixes are the ranges which aren't private by net/netip but are not reachable on the internet either.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Resolver resolves the host names to their IP addresses, *net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Guard type decides which addresses the server may connect to. Only public unicast addresses are allowed,
+// so loopback, link-local including the cloud metadata endpoint, private, multicast and unspecified ones
+// are blocked. The allowed prefixes are exempt from the rules, tests allow their loopback receivers this way.
+// It implements services.HostGuard.
+type Guard struct {
+	resolver Resolver
+	allowed  []netip.Prefix
+}
+
+// NewGuard function builds new instance of Guard, nil resolver uses net.DefaultResolver.
+func NewGuard(resolver Resolver, allowed ...netip.Prefix) *Guard {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &Guard{
+		resolver: resolver,
+		allowed:  allowed,
+	}
+}
+
+// DefaultGuard function builds new instance of Guard used when nothing else is configured,
+// it resolves the hosts with net.DefaultResolver and allows public addresses only.
+func DefaultGuard() *Guard {
+	return NewGuard(nil)
+}
+
+// Allowed function tells whether the server may connect to the address.
+func (g *Guard) Allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// CheckHost function resolves the host and returns services.ErrHostBlocked when any of its addresses isn't allowed.
+// The check is made once, the host may resolve to other addresses later, so connections are checked as well.
+func (g *Guard) CheckHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return g.check(host, addr)
+	}
+
+	addrs, err := g.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("host %q can't be resolved: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("host %q has no addresses", host)
+	}
+
+	for _, addr := range addrs {
+		if err = g.check(host, addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Guard) check(host string, addr netip.Addr) error {
+	if !g.Allowed(addr) {
+		return fmt.Errorf("%w: host %q resolves to %s", services.ErrHostBlocked, host, addr)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package netguard_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/netguard"
+)
+
+type staticResolver map[string][]netip.Addr
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	return addrs, nil
+}
+
+func TestGuard_Allowed(t *testing.T) {
+	guard := netguard.DefaultGuard()
+
+	cases := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::1":   true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"fd00::1":              false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"224.0.0.1":            false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:93.184.216.34": true,
+	}
+
+	for address, expected := range cases {
+		if allowed := guard.Allowed(netip.MustParseAddr(address)); allowed != expected {
+			t.Errorf("%s: expected allowed %v, got %v", address, expected, allowed)
+		}
+	}
+
+	guard = netguard.NewGuard(nil, netip.MustParsePrefix("127.0.0.0/8"))
+	if !guard.Allowed(netip.MustParseAddr("127.0.0.1")) || guard.Allowed(netip.MustParseAddr("10.1.2.3")) {
+		t.Error("expected allowed prefix to exempt loopback only")
+	}
+}
+
+func TestGuard_CheckHost(t *testing.T) {
+	guard := netguard.NewGuard(staticResolver{
+		"hooks.example.com": {netip.MustParseAddr("93.184.216.34")},
+		"rebind.example":    {netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("169.254.169.254")},
+	})
+	ctx := context.Background()
+
+	if err := guard.CheckHost(ctx, "hooks.example.com"); err != nil {
+		t.Errorf("expected public host to be allowed, got %v", err)
+	}
+	if err := guard.CheckHost(ctx, "rebind.example"); !errors.Is(err, services.ErrHostBlocked) {
+		t.Errorf("expected host with a link-local address to be blocked, got %v", err)
+	}
+	if err := guard.CheckHost(ctx, "127.0.0.1"); !errors.Is(err, services.ErrHostBlocked) {
+		t.Errorf("expected loopback address to be blocked, got %v", err)
+	}
+
+	err := guard.CheckHost(ctx, "unknown.example")
+	if err == nil || errors.Is(err, services.ErrHostBlocked) {
+		t.Errorf("expected resolution error, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+const (
+	// SessionCookie is the name of the cookie keeping the session ID.
+	SessionCookie = "neurography_session"
+	// StateCookie is the name of the cookie binding the pending sign in to the browser.
+	StateCookie = "neurography_oidc_state"
+
+	defaultSessionTTL          = 24 * time.Hour
+	defaultKeysRefreshInterval = time.Minute
+	randomLength               = 32
+)
+
+var defaultScopes = []string{"openid", "profile", "email"}
+
+// Client type signs users in with the OpenID Connect provider and keeps their sessions.
+// Users are provisioned on their first sign in by services.UserService.
+type Client struct {
+	cfg      Config
+	http     *http.Client
+	meta     *metadata
+	verifier *verifier
+	users    services.UserService
+	store    *store
+	now      func() time.Time
+}
+
+// NewClient function reads the discovery document of the provider and builds new instance of Client.
+func NewClient(ctx context.Context, cfg Config, users services.UserService) (*Client, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("issuer, client ID and redirect URL are required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = defaultScopes
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = defaultSessionTTL
+	}
+	if cfg.KeysRefreshInterval <= 0 {
+		cfg.KeysRefreshInterval = defaultKeysRefreshInterval
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	meta, err := discover(ctx, client, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		cfg:  cfg,
+		http: client,
+		meta: meta,
+		verifier: &verifier{
+			issuer:   meta.Issuer,
+			clientID: cfg.ClientID,
+			keys:     newKeySet(client, meta.JWKSURI, cfg.KeysRefreshInterval, time.Now),
+			now:      time.Now,
+		},
+		users: users,
+		store: newStore(),
+		now:   time.Now,
+	}, nil
+}
+
+// Login function is the handler starting the sign in, it redirects the browser to the provider.
+// State, nonce and PKCE verifier of the sign in are kept by the client till the Callback.
+func (c *Client) Login(w http.ResponseWriter, r *http.Request) {
+	state, errState := randomString(randomLength)
+	nonce, errNonce := randomString(randomLength)
+	verifier, errVerifier := randomString(randomLength)
+	if err := errors.Join(errState, errNonce, errVerifier); err != nil {
+		c.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	now := c.now()
+	c.store.addLogin(state, &pendingLogin{
+		nonce:     nonce,
+		verifier:  verifier,
+		expiresAt: now.Add(loginTTL),
+	}, now)
+	c.setCookie(w, StateCookie, state, loginTTL)
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	http.Redirect(w, r, c.meta.AuthorizationEndpoint+separator(c.meta.AuthorizationEndpoint)+query.Encode(),
+		http.StatusFound)
+}
+
+// Callback function is the handler the provider redirects the browser to after the sign in.
+// The code is exchanged for the ID token, the user of the token is provisioned and the session is started.
+func (c *Client) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		c.fail(w, http.StatusUnauthorized, fmt.Errorf("sign in failed: %s %s", providerErr,
+			query.Get("error_description")))
+		return
+	}
+
+	state := query.Get("state")
+	cookie, err := r.Cookie(StateCookie)
+	if err != nil || state == "" || cookie.Value != state {
+		c.fail(w, http.StatusBadRequest, errors.New("state mismatch"))
+		return
+	}
+	c.setCookie(w, StateCookie, "", -1)
+
+	login, ok := c.store.takeLogin(state, c.now())
+	if !ok {
+		c.fail(w, http.StatusBadRequest, errors.New("sign in is expired"))
+		return
+	}
+
+	rawIDToken, err := c.exchange(r.Context(), query.Get("code"), login.verifier)
+	if err != nil {
+		c.fail(w, http.StatusBadGateway, err)
+		return
+	}
+
+	idToken, err := c.verifier.verify(r.Context(), rawIDToken, login.nonce)
+	if errors.Is(err, ErrInvalidIDToken) {
+		c.fail(w, http.StatusUnauthorized, err)
+		return
+	}
+	if err != nil {
+		c.fail(w, http.StatusBadGateway, err)
+		return
+	}
+
+	user, err := c.users.ProvisionUser(idToken.Issuer, idToken.Subject, idToken.PreferredUsername,
+		idToken.Name, idToken.Email)
+	if err != nil {
+		c.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := randomString(randomLength)
+	if err != nil {
+		c.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	now := c.now()
+	c.store.addSession(id, &session{login: user.Login, expiresAt: now.Add(c.cfg.SessionTTL)}, now)
+	c.setCookie(w, SessionCookie, id, c.cfg.SessionTTL)
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// Logout function is the handler ending the session of the browser.
+func (c *Client) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(SessionCookie); err == nil {
+		c.store.removeSession(cookie.Value)
+	}
+	c.setCookie(w, SessionCookie, "", -1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Authenticate function wraps the handler, so it is called only for requests with the session cookie
+// of the signed in user. The user is put into the request context as the acting user of the usecases,
+// other requests get 401 Unauthorized.
+func (c *Client) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(SessionCookie)
+		if err != nil {
+			http.Error(w, usecases.ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		sess, ok := c.store.session(cookie.Value, c.now())
+		if !ok {
+			http.Error(w, "session is expired", http.StatusUnauthorized)
+			return
+		}
+
+		user, err := c.users.GetUser(sess.login)
+		if errors.Is(err, repositories.ErrNotFound) {
+			c.store.removeSession(cookie.Value)
+			http.Error(w, usecases.ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			c.fail(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(usecases.ContextWithUser(r.Context(), user)))
+	})
+}
+
+// exchange function redeems the authorization code at the token endpoint and returns the raw ID token.
+func (c *Client) exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint responded with %s", resp.Status)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", err
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token endpoint returned no id token")
+	}
+
+	return tokens.IDToken, nil
+}
+
+// setCookie function sets the cookie for the ttl, the cookie is removed when the ttl is negative.
+func (c *Client) setCookie(w http.ResponseWriter, name, value string, ttl time.Duration) {
+	maxAge := int(ttl.Seconds())
+	if ttl < 0 {
+		maxAge = -1
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.cfg.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// fail function responds with the status, details of server side failures are logged instead of being shown.
+func (c *Client) fail(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		if c.cfg.ErrorLog != nil {
+			c.cfg.ErrorLog.Printf("oidc: %s", err)
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+// codeChallenge function returns the S256 PKCE challenge of the verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func separator(endpoint string) string {
+	if strings.Contains(endpoint, "?") {
+		return "&"
+	}
+
+	return "?"
+}
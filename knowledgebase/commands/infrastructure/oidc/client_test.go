@@ -0,0 +1,249 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/application/usecases"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/oidc"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func newClient(t *testing.T, provider *fakeProvider, users *mock.MockUserService) *oidc.Client {
+	t.Helper()
+
+	return newClientWithConfig(t, provider, users, oidc.Config{})
+}
+
+// newClientWithConfig function builds the client of the fake provider, the provider settings of cfg are replaced.
+func newClientWithConfig(
+	t *testing.T,
+	provider *fakeProvider,
+	users *mock.MockUserService,
+	cfg oidc.Config,
+) *oidc.Client {
+	t.Helper()
+
+	cfg.Issuer = provider.server.URL
+	cfg.ClientID = testClientID
+	cfg.ClientSecret = testClientSecret
+	cfg.RedirectURL = testRedirectURL
+
+	client, err := oidc.NewClient(context.Background(), cfg, users)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func cookieOf(t *testing.T, w *httptest.ResponseRecorder, name string) *http.Cookie {
+	t.Helper()
+
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	t.Fatalf("expected cookie %s to be set", name)
+
+	return nil
+}
+
+// signIn function walks the browser through the sign in and returns the response of the callback.
+func signIn(t *testing.T, client *oidc.Client) *httptest.ResponseRecorder {
+	t.Helper()
+
+	login := httptest.NewRecorder()
+	client.Login(login, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+	if login.Code != http.StatusFound {
+		t.Fatalf("expected redirect to the provider, got %d", login.Code)
+	}
+
+	browser := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := browser.Get(login.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, callback.String(), nil)
+	r.AddCookie(cookieOf(t, login, oidc.StateCookie))
+
+	w := httptest.NewRecorder()
+	client.Callback(w, r)
+
+	return w
+}
+
+func TestClient_SignIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newFakeProvider(t)
+	alice := &models.User{ID: 1, Login: "alice", Issuer: provider.server.URL, Subject: provider.subject}
+
+	users := mock.NewMockUserService(ctrl)
+	users.EXPECT().ProvisionUser(provider.server.URL, provider.subject, "alice", "Alice Smith", "alice@example.com").
+		Return(alice, nil)
+	users.EXPECT().GetUser("alice").Return(alice, nil)
+
+	client := newClient(t, provider, users)
+
+	w := signIn(t, client)
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected redirect after the sign in, got %d: %s", w.Code, w.Body)
+	}
+	session := cookieOf(t, w, oidc.SessionCookie)
+	if !session.HttpOnly || session.Value == "" {
+		t.Errorf("unexpected session cookie %+v", session)
+	}
+
+	var called bool
+	handler := client.Authenticate(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		called = true
+		if user, ok := usecases.UserFromContext(r.Context()); !ok || user != alice {
+			t.Errorf("expected acting user alice, got %+v", user)
+		}
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+	r.AddCookie(session)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if !called {
+		t.Fatal("expected handler to be called with the session")
+	}
+
+	client.Logout(httptest.NewRecorder(), r)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected session to be ended, got %d", w.Code)
+	}
+}
+
+func TestClient_SignIn_KeyRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newFakeProvider(t)
+
+	users := mock.NewMockUserService(ctrl)
+	users.EXPECT().ProvisionUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&models.User{ID: 1, Login: "alice"}, nil).Times(2)
+
+	client := newClientWithConfig(t, provider, users, oidc.Config{KeysRefreshInterval: time.Nanosecond})
+
+	if w := signIn(t, client); w.Code != http.StatusFound {
+		t.Fatalf("expected first sign in to succeed, got %d: %s", w.Code, w.Body)
+	}
+
+	provider.rotate()
+
+	if w := signIn(t, client); w.Code != http.StatusFound {
+		t.Errorf("expected sign in with the new key to succeed, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestClient_SignIn_KeysRefreshLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newFakeProvider(t)
+
+	users := mock.NewMockUserService(ctrl)
+	users.EXPECT().ProvisionUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&models.User{ID: 1, Login: "alice"}, nil)
+
+	client := newClient(t, provider, users)
+
+	if w := signIn(t, client); w.Code != http.StatusFound {
+		t.Fatalf("expected first sign in to succeed, got %d: %s", w.Code, w.Body)
+	}
+
+	// the key set was fetched a moment ago, so the unknown key is rejected without fetching it again.
+	provider.rotate()
+
+	for range 3 {
+		if w := signIn(t, client); w.Code == http.StatusFound {
+			t.Fatalf("expected sign in with unknown key to fail, got %d", w.Code)
+		}
+	}
+	if fetches := provider.keysFetches(); fetches != 1 {
+		t.Errorf("expected the key set to be fetched once, got %d", fetches)
+	}
+}
+
+func TestClient_Callback_InvalidIDToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newFakeProvider(t)
+	client := newClient(t, provider, mock.NewMockUserService(ctrl))
+
+	forger, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]func(claims map[string]any){
+		"issuer":   func(claims map[string]any) { claims["iss"] = "https://evil.example.com" },
+		"audience": func(claims map[string]any) { claims["aud"] = []string{"other"} },
+		"expired":  func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"nonce":    func(claims map[string]any) { claims["nonce"] = "replayed" },
+		"subject":  func(claims map[string]any) { delete(claims, "sub") },
+		"signature": func(map[string]any) {
+			provider.signer = forger
+		},
+	}
+
+	for name, mutate := range cases {
+		provider.mutate = mutate
+		provider.signer = nil
+
+		w := signIn(t, client)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status %d, got %d: %s", name, http.StatusUnauthorized, w.Code, w.Body)
+		}
+	}
+}
+
+func TestClient_Callback_StateMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newClient(t, newFakeProvider(t), mock.NewMockUserService(ctrl))
+
+	login := httptest.NewRecorder()
+	client.Login(login, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+
+	location, err := url.Parse(login.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := location.Query()
+	if query.Get("code_challenge") == "" || query.Get("nonce") == "" || query.Get("scope") != "openid profile email" {
+		t.Errorf("unexpected authorization request %s", location)
+	}
+
+	// callback of another browser has no state cookie.
+	w := httptest.NewRecorder()
+	client.Callback(w, httptest.NewRequest(http.MethodGet, "/auth/callback?code=code-1&state="+query.Get("state"), nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
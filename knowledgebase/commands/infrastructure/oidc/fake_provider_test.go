@@ -0,0 +1,205 @@
+package oidc_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "neurography"
+	testClientSecret = "secret"
+	testRedirectURL  = "https://kb.example.com/auth/callback"
+)
+
+// grant represents authorization code issued by the fake provider.
+type grant struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+}
+
+// fakeProvider type is in-process OpenID Connect provider signing in the same subject every time.
+// Claims of the issued ID tokens can be changed by mutate to test their verification.
+type fakeProvider struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu      sync.Mutex
+	key     *rsa.PrivateKey
+	kid     string
+	codes   map[string]*grant
+	subject string
+	mutate  func(claims map[string]any)
+	// signer signs ID tokens instead of the published key when it is set.
+	signer *rsa.PrivateKey
+	// fetches counts requests of the key set.
+	fetches int
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+
+	p := &fakeProvider{t: t, codes: make(map[string]*grant), subject: "248289761001"}
+	p.rotate()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// rotate function replaces the signing key of the provider.
+func (p *fakeProvider) rotate() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.key = key
+	p.kid = "key-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func (p *fakeProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"jwks_uri":               p.server.URL + "/jwks",
+	})
+}
+
+func (p *fakeProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fetches++
+	writeJSON(w, map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": p.kid,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+	}}})
+}
+
+func (p *fakeProvider) keysFetches() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.fetches
+}
+
+// authorize function signs the user in at once and redirects back with the code.
+func (p *fakeProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != testClientID || q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	code := "code-" + strconv.Itoa(len(p.codes)+1)
+	p.codes[code] = &grant{
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		redirectURI: q.Get("redirect_uri"),
+	}
+	p.mu.Unlock()
+
+	http.Redirect(w, r, q.Get("redirect_uri")+"?"+url.Values{"code": {code}, "state": {q.Get("state")}}.Encode(),
+		http.StatusFound)
+}
+
+// token function redeems the code once, the PKCE verifier has to match the challenge of the code.
+func (p *fakeProvider) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != testClientID || secret != testClientSecret {
+		http.Error(w, "invalid_client", http.StatusUnauthorized)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	g, ok := p.codes[r.PostFormValue("code")]
+	delete(p.codes, r.PostFormValue("code"))
+
+	sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge || r.PostFormValue("redirect_uri") != g.redirectURI {
+		http.Error(w, "invalid_grant", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":                p.server.URL,
+		"sub":                p.subject,
+		"aud":                testClientID,
+		"exp":                now.Add(time.Hour).Unix(),
+		"iat":                now.Unix(),
+		"nonce":              g.nonce,
+		"name":               "Alice Smith",
+		"email":              "alice@example.com",
+		"preferred_username": "alice",
+	}
+	if p.mutate != nil {
+		p.mutate(claims)
+	}
+
+	writeJSON(w, map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": p.sign(claims)})
+}
+
+func (p *fakeProvider) sign(claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": p.kid})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	key := p.key
+	if p.signer != nil {
+		key = p.signer
+	}
+
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		p.t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
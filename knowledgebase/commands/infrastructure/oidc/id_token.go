@@ -0,0 +1,141 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clockSkew is the allowed difference between clocks of the provider and the server.
+const clockSkew = time.Minute
+
+// ErrInvalidIDToken is returned when the ID token isn't issued by the provider for the client.
+var ErrInvalidIDToken = errors.New("id token is invalid")
+
+// audience represents the aud claim which is either a string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+// claims represents claims of the ID token used by the client.
+type claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Name              string   `json:"name"`
+	Email             string   `json:"email"`
+	PreferredUsername string   `json:"preferred_username"`
+}
+
+// verifier type checks ID tokens issued by the provider for the client.
+// Only RS256 signatures are accepted, unsigned tokens and other algorithms are rejected.
+type verifier struct {
+	issuer   string
+	clientID string
+	keys     *keySet
+	now      func() time.Time
+}
+
+// verify function checks signature and claims of the raw ID token and returns its claims.
+// The nonce has to be the one sent with the authorization request of the sign in.
+func (v *verifier) verify(ctx context.Context, raw, nonce string) (*claims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidIDToken)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: algorithm %q is not supported", ErrInvalidIDToken, header.Alg)
+	}
+
+	key, err := v.keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidIDToken)
+	}
+
+	var c claims
+	if err = decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidIDToken)
+	}
+
+	if err = v.check(&c, nonce); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+
+	return &c, nil
+}
+
+func (v *verifier) check(c *claims, nonce string) error {
+	now := v.now()
+
+	switch {
+	case c.Issuer != v.issuer:
+		return fmt.Errorf("issuer %q is unexpected", c.Issuer)
+	case !slices.Contains(c.Audience, v.clientID):
+		return errors.New("token is issued for another client")
+	case len(c.Audience) > 1 && c.AuthorizedParty != v.clientID:
+		return errors.New("token is authorized for another party")
+	case c.Subject == "":
+		return errors.New("subject is missing")
+	case !now.Before(time.Unix(c.Expiry, 0).Add(clockSkew)):
+		return errors.New("token is expired")
+	case time.Unix(c.IssuedAt, 0).After(now.Add(clockSkew)):
+		return errors.New("token is issued in the future")
+	case nonce == "" || c.Nonce != nonce:
+		return errors.New("nonce mismatch")
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,138 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jsonWebKey represents RSA key of the provider key set, other key types are ignored.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// keySet type keeps signing keys of the provider by their IDs.
+// Keys are fetched again when a token is signed by unknown key, so rotation of the keys is followed.
+// Fetches are made one at a time and at most once per the interval, so tokens with made up key IDs
+// can't make the client flood the provider, and the known keys are served while the keys are fetched.
+type keySet struct {
+	client   *http.Client
+	uri      string
+	interval time.Duration
+	now      func() time.Time
+
+	// fetchMu serializes fetches of the key set and guards fetchedAt.
+	fetchMu   sync.Mutex
+	fetchedAt time.Time
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+func newKeySet(client *http.Client, uri string, interval time.Duration, now func() time.Time) *keySet {
+	return &keySet{
+		client:   client,
+		uri:      uri,
+		interval: interval,
+		now:      now,
+	}
+}
+
+// key function returns the key with the ID.
+func (s *keySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, ok := s.known(kid); ok {
+		return key, nil
+	}
+
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	// the keys might have been fetched while waiting for the lock.
+	if key, ok := s.known(kid); ok {
+		return key, nil
+	}
+
+	now := s.now()
+	if !s.fetchedAt.IsZero() && now.Before(s.fetchedAt.Add(s.interval)) {
+		return nil, fmt.Errorf("signing key %q is unknown", kid)
+	}
+
+	s.fetchedAt = now
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	key, ok := s.known(kid)
+	if !ok {
+		return nil, fmt.Errorf("signing key %q is unknown", kid)
+	}
+
+	return key, nil
+}
+
+func (s *keySet) known(kid string) (*rsa.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
+
+func (s *keySet) refresh(ctx context.Context) error {
+	var doc struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, s.client, s.uri, &doc); err != nil {
+		return fmt.Errorf("key set fetch failed: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return err
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (k *jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("modulus of key %q is invalid: %w", k.Kid, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("exponent of key %q is invalid: %w", k.Kid, err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("key " + k.Kid + " is invalid")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
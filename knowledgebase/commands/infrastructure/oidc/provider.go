@@ -0,0 +1,82 @@
+// Package oidc contains OpenID Connect sign in of the web API users with the authorization code flow and PKCE.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Config represents settings of the client registered at the OpenID Connect provider.
+type Config struct {
+	// Issuer is the URL of the provider, the discovery document is read from it.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the URL the Callback handler is served at.
+	RedirectURL string
+	// Scopes requested in addition to openid, profile and email are requested when it is empty.
+	Scopes []string
+	// SessionTTL is the lifetime of the session started by the sign in, it is 24 hours by default.
+	SessionTTL time.Duration
+	// KeysRefreshInterval is the minimal time between fetches of the provider key set caused by tokens
+	// signed with unknown keys, it is one minute by default.
+	KeysRefreshInterval time.Duration
+	// SecureCookies marks cookies to be sent over HTTPS only.
+	SecureCookies bool
+	// HTTPClient is used to talk to the provider, http.DefaultClient is used by default.
+	HTTPClient *http.Client
+	// ErrorLog receives failures of the provider and the storage, they are dropped when it is nil.
+	ErrorLog *log.Logger
+}
+
+// metadata represents the part of the provider discovery document used by the client.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// discover function reads the discovery document of the issuer.
+// The issuer of the document has to be the configured one, otherwise ID tokens couldn't be trusted.
+func discover(ctx context.Context, client *http.Client, issuer string) (*metadata, error) {
+	var meta metadata
+	if err := getJSON(ctx, client, strings.TrimSuffix(issuer, "/")+discoveryPath, &meta); err != nil {
+		return nil, fmt.Errorf("discovery of %s failed: %w", issuer, err)
+	}
+
+	if meta.Issuer != issuer {
+		return nil, fmt.Errorf("discovery document of %s has issuer %q", issuer, meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document of %s has no endpoints", issuer)
+	}
+
+	return &meta, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s responded with %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,127 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// loginTTL is the time the user has to sign in at the provider.
+const loginTTL = 10 * time.Minute
+
+// maxPendingLogins caps sign ins waiting for the Callback, anybody can start them.
+const maxPendingLogins = 10000
+
+// pendingLogin represents sign in started by the Login handler and waiting for the Callback.
+type pendingLogin struct {
+	nonce     string
+	verifier  string
+	expiresAt time.Time
+}
+
+// session represents signed in user of the browser.
+type session struct {
+	login     string
+	expiresAt time.Time
+}
+
+// store type keeps pending sign ins by their state and sessions by their IDs in memory,
+// so users sign in again when the server restarts. Expired entries are dropped when they are met
+// and whenever new entries are added, so abandoned sign ins and sessions don't pile up.
+// Pending sign ins are capped by maxPendingLogins as well, the one expiring first gives way to the new one.
+type store struct {
+	mu       sync.Mutex
+	logins   map[string]*pendingLogin
+	sessions map[string]*session
+}
+
+func newStore() *store {
+	return &store{
+		logins:   make(map[string]*pendingLogin),
+		sessions: make(map[string]*session),
+	}
+}
+
+func (s *store) addLogin(state string, login *pendingLogin, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweepLogins(now)
+
+	if len(s.logins) >= maxPendingLogins {
+		var oldest string
+		for key, pending := range s.logins {
+			if oldest == "" || pending.expiresAt.Before(s.logins[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		delete(s.logins, oldest)
+	}
+
+	s.logins[state] = login
+}
+
+// takeLogin function returns the pending sign in of the state and forgets it, so the state can't be replayed.
+func (s *store) takeLogin(state string, now time.Time) (*pendingLogin, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweepLogins(now)
+
+	login, ok := s.logins[state]
+	delete(s.logins, state)
+
+	return login, ok
+}
+
+func (s *store) addSession(id string, sess *session, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, existing := range s.sessions {
+		if !now.Before(existing.expiresAt) {
+			delete(s.sessions, key)
+		}
+	}
+
+	s.sessions[id] = sess
+}
+
+func (s *store) session(id string, now time.Time) (*session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if ok && !now.Before(sess.expiresAt) {
+		delete(s.sessions, id)
+		return nil, false
+	}
+
+	return sess, ok
+}
+
+func (s *store) removeSession(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+}
+
+func (s *store) sweepLogins(now time.Time) {
+	for key, login := range s.logins {
+		if !now.Before(login.expiresAt) {
+			delete(s.logins, key)
+		}
+	}
+}
+
+// randomString function returns URL safe string of n random bytes.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
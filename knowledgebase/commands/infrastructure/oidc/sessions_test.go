@@ -0,0 +1,46 @@
+package oidc
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStore_SweepsExpiredEntries(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	s := newStore()
+	s.addLogin("abandoned", &pendingLogin{expiresAt: now.Add(loginTTL)}, now)
+	s.addSession("expired", &session{login: "alice", expiresAt: now.Add(time.Hour)}, now)
+
+	later := now.Add(2 * time.Hour)
+	s.addLogin("fresh", &pendingLogin{expiresAt: later.Add(loginTTL)}, later)
+	s.addSession("active", &session{login: "bob", expiresAt: later.Add(time.Hour)}, later)
+
+	if _, ok := s.logins["abandoned"]; ok || len(s.logins) != 1 {
+		t.Errorf("expected abandoned sign in to be dropped, got %v", s.logins)
+	}
+	if _, ok := s.sessions["expired"]; ok || len(s.sessions) != 1 {
+		t.Errorf("expected expired session to be dropped, got %v", s.sessions)
+	}
+}
+
+func TestStore_CapsPendingLogins(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	s := newStore()
+	for i := range maxPendingLogins + 1 {
+		at := now.Add(time.Duration(i) * time.Millisecond)
+		s.addLogin(strconv.Itoa(i), &pendingLogin{expiresAt: at.Add(loginTTL)}, at)
+	}
+
+	if len(s.logins) != maxPendingLogins {
+		t.Errorf("expected %d pending sign ins, got %d", maxPendingLogins, len(s.logins))
+	}
+	if _, ok := s.logins["0"]; ok {
+		t.Error("expected the sign in expiring first to give way")
+	}
+	if _, ok := s.takeLogin(strconv.Itoa(maxPendingLogins), now); !ok {
+		t.Error("expected the latest sign in to be kept")
+	}
+}
@@ -0,0 +1,75 @@
+package search
+
+import (
+	"errors"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// CategoriesRepo type decorates repositories.CategoriesRepo and reindexes the items of every category
+// renamed, moved or deleted through it, so the category filters and facets of the Index follow the tree.
+// Reading methods are passed to the decorated repository.
+type CategoriesRepo struct {
+	repositories.CategoriesRepo
+	items repositories.KnowledgeItemsRepo
+	index *Index
+}
+
+// NewCategoriesRepo function builds new instance of CategoriesRepo, the items are read back from itemsRepo.
+func NewCategoriesRepo(
+	repo repositories.CategoriesRepo,
+	itemsRepo repositories.KnowledgeItemsRepo,
+	index *Index,
+) *CategoriesRepo {
+	return &CategoriesRepo{
+		CategoriesRepo: repo,
+		items:          itemsRepo,
+		index:          index,
+	}
+}
+
+// Save function stores the category and reindexes its items under the new name.
+func (r *CategoriesRepo) Save(category *models.Category) error {
+	if err := r.CategoriesRepo.Save(category); err != nil {
+		return err
+	}
+
+	items, err := r.items.FindByCategoryIDs([]int64{category.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		r.index.Add(item)
+	}
+
+	return nil
+}
+
+// Delete function deletes the category and reindexes the items it was taken away from.
+func (r *CategoriesRepo) Delete(category *models.Category) error {
+	items, err := r.items.FindByCategoryIDs([]int64{category.ID})
+	if err != nil {
+		return err
+	}
+
+	if err = r.CategoriesRepo.Delete(category); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		updated, err := r.items.FindByID(item.ID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			r.index.Remove(item.ID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		r.index.Add(updated)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package search_test
+
+import (
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/search"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoriesRepo_MoveThenSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := newTestIndex()
+
+	moved := &models.Category{ID: concurrency.ID, Name: "Golang/Concurrency", ParentID: golang.ID}
+	goroutine := testItems()[0]
+	goroutine.Categories = []*models.Category{golang, moved}
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	repo.EXPECT().Save(moved).Return(nil)
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	itemsRepo.EXPECT().FindByCategoryIDs([]int64{concurrency.ID}).Return([]*models.KnowledgeItem{goroutine}, nil)
+
+	if err := search.NewCategoriesRepo(repo, itemsRepo, index).Save(moved); err != nil {
+		t.Fatal(err)
+	}
+
+	query := &appmodels.SearchKnowledgeItemsQuery{Query: "goroutine", Categories: []string{"Golang/Concurrency"}}
+	if ids := searchIDs(t, index, query); !equalIDs(ids, []int64{1}) {
+		t.Errorf("expected item to be found in the moved category, got %v", ids)
+	}
+
+	query.Categories = []string{"Concurrency"}
+	if ids := searchIDs(t, index, query); len(ids) != 0 {
+		t.Errorf("expected previous name of the category to be dropped, got %v", ids)
+	}
+}
+
+func TestCategoriesRepo_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := newTestIndex()
+
+	dbIndex := testItems()[2]
+	updated := testItems()[2]
+	updated.Categories = nil
+
+	repo := mock.NewMockCategoriesRepo(ctrl)
+	repo.EXPECT().Delete(databases).Return(nil)
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	itemsRepo.EXPECT().FindByCategoryIDs([]int64{databases.ID}).Return([]*models.KnowledgeItem{dbIndex}, nil)
+	itemsRepo.EXPECT().FindByID(dbIndex.ID).Return(updated, nil)
+
+	if err := search.NewCategoriesRepo(repo, itemsRepo, index).Delete(databases); err != nil {
+		t.Fatal(err)
+	}
+
+	query := &appmodels.SearchKnowledgeItemsQuery{Query: "index", Categories: []string{databases.Name}}
+	if ids := searchIDs(t, index, query); len(ids) != 0 {
+		t.Errorf("expected item to leave the deleted category, got %v", ids)
+	}
+
+	query.Categories = nil
+	if ids := searchIDs(t, index, query); !equalIDs(ids, []int64{3}) {
+		t.Errorf("expected item to stay searchable, got %v", ids)
+	}
+}
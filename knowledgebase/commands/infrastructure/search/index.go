@@ -0,0 +1,404 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+)
+
+// BM25 parameters, k1 saturates the term frequency and b normalizes it by the field length.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+const (
+	fieldTitle = iota
+	fieldAnchor
+	fieldData
+	fieldTags
+	numFields
+)
+
+// fieldNames and fieldWeights describe indexed fields, matches in the title count more than in the data.
+var (
+	fieldNames = [numFields]string{
+		appmodels.SearchFieldTitle,
+		appmodels.SearchFieldAnchor,
+		appmodels.SearchFieldData,
+		appmodels.SearchFieldTags,
+	}
+	fieldWeights = [numFields]float64{3, 2, 1, 2}
+)
+
+// frequencies keeps number of the term occurrences per field.
+type frequencies [numFields]int
+
+// document represents indexed knowledge item.
+type document struct {
+	item    *models.KnowledgeItem
+	lengths [numFields]int
+	terms   []string
+}
+
+// Index type is an in-memory inverted index of the knowledge items ranked with BM25F.
+// Terms are stemmed English words, stop words are not indexed. It implements models.KnowledgeItemsIndex.
+//
+// The index doesn't persist anything, it is filled by Reindex on start and kept up to date
+// by the KnowledgeItemsRepo and CategoriesRepo decorators. Items changed outside of the repositories,
+// like notes edited in the vault, are passed to Add and Remove.
+type Index struct {
+	mu           sync.RWMutex
+	docs         map[int64]*document
+	postings     map[string]map[int64]*frequencies
+	totalLengths [numFields]int
+	// sortedTerms is built lazily for prefix matching and dropped when the set of terms changes.
+	sortedTerms []string
+}
+
+// NewIndex function builds new empty instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[int64]*document),
+		postings: make(map[string]map[int64]*frequencies),
+	}
+}
+
+// Reindex function replaces content of the index with all the items of the repository.
+func (ix *Index) Reindex(repo repositories.KnowledgeItemsRepo) error {
+	items, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.docs = make(map[int64]*document, len(items))
+	ix.postings = make(map[string]map[int64]*frequencies)
+	ix.totalLengths = [numFields]int{}
+	ix.sortedTerms = nil
+
+	for _, item := range items {
+		ix.add(item)
+	}
+
+	return nil
+}
+
+// Add function indexes the item, previous version of the item is replaced.
+func (ix *Index) Add(item *models.KnowledgeItem) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(item.ID)
+	ix.add(item)
+}
+
+// Remove function drops the item from the index.
+func (ix *Index) Remove(id int64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+func (ix *Index) add(item *models.KnowledgeItem) {
+	doc := &document{item: cloneItem(item)}
+
+	for field, text := range fieldTexts(item) {
+		fieldTerms := terms(text)
+		doc.lengths[field] = len(fieldTerms)
+		ix.totalLengths[field] += len(fieldTerms)
+
+		for _, term := range fieldTerms {
+			docs, ok := ix.postings[term]
+			if !ok {
+				docs = make(map[int64]*frequencies)
+				ix.postings[term] = docs
+				ix.sortedTerms = nil
+			}
+
+			freq, ok := docs[item.ID]
+			if !ok {
+				freq = &frequencies{}
+				docs[item.ID] = freq
+				doc.terms = append(doc.terms, term)
+			}
+			freq[field]++
+		}
+	}
+
+	ix.docs[item.ID] = doc
+}
+
+func (ix *Index) remove(id int64) {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.terms {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+			ix.sortedTerms = nil
+		}
+	}
+
+	for field, length := range doc.lengths {
+		ix.totalLengths[field] -= length
+	}
+
+	delete(ix.docs, id)
+}
+
+// fieldTexts function returns searchable texts of the item in the order of the field constants.
+func fieldTexts(item *models.KnowledgeItem) [numFields]string {
+	return [numFields]string{item.Title, item.Anchor, item.Data, strings.Join(item.Tags, ", ")}
+}
+
+// clause represents one query term, it matches a document containing any of its index terms.
+type clause []string
+
+// Search function finds items matching every term of the query, ranks them and builds snippets and facets.
+// The query without terms matches all the items ordered by ID.
+func (ix *Index) Search(query *appmodels.SearchKnowledgeItemsQuery) (*appmodels.SearchResult, error) {
+	ix.mu.RLock()
+	for ix.sortedTerms == nil {
+		ix.mu.RUnlock()
+
+		ix.mu.Lock()
+		if ix.sortedTerms == nil {
+			ix.buildSortedTerms()
+		}
+		ix.mu.Unlock()
+
+		ix.mu.RLock()
+	}
+	defer ix.mu.RUnlock()
+
+	clauses := ix.parseQuery(query.Query)
+
+	var hits []*appmodels.SearchHit
+	for id, doc := range ix.docs {
+		if !ix.matches(id, clauses) || !matchesFilters(doc.item, query) {
+			continue
+		}
+
+		hits = append(hits, &appmodels.SearchHit{Item: doc.item, Score: ix.score(id, clauses)})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+
+		return hits[i].Item.ID < hits[j].Item.ID
+	})
+
+	result := &appmodels.SearchResult{
+		Total:  len(hits),
+		Facets: facets(hits),
+	}
+
+	hits = hits[min(query.Offset, len(hits)):]
+	if query.Limit > 0 {
+		hits = hits[:min(query.Limit, len(hits))]
+	}
+
+	matched := make(map[string]bool)
+	for _, c := range clauses {
+		for _, term := range c {
+			matched[term] = true
+		}
+	}
+
+	for _, hit := range hits {
+		hit.Snippets = snippets(hit.Item, matched)
+		hit.Item = cloneItem(hit.Item)
+	}
+	result.Hits = hits
+
+	return result, nil
+}
+
+func (ix *Index) buildSortedTerms() {
+	ix.sortedTerms = make([]string, 0, len(ix.postings))
+	for term := range ix.postings {
+		ix.sortedTerms = append(ix.sortedTerms, term)
+	}
+	sort.Strings(ix.sortedTerms)
+}
+
+// parseQuery function turns every word of the query into a clause.
+// A word ending with "*" matches index terms starting with the word or its stem.
+func (ix *Index) parseQuery(query string) []clause {
+	var clauses []clause
+	for _, chunk := range strings.Fields(query) {
+		prefix := strings.HasSuffix(chunk, "*")
+
+		tokens := tokenize(chunk)
+		for i, t := range tokens {
+			if prefix && i == len(tokens)-1 {
+				clauses = append(clauses, ix.expandPrefix(t.word))
+				continue
+			}
+
+			if t.term != "" {
+				clauses = append(clauses, clause{t.term})
+			}
+		}
+	}
+
+	return clauses
+}
+
+func (ix *Index) expandPrefix(word string) clause {
+	var expanded clause
+	for _, prefix := range []string{stem(word), word} {
+		i := sort.SearchStrings(ix.sortedTerms, prefix)
+		for ; i < len(ix.sortedTerms) && strings.HasPrefix(ix.sortedTerms[i], prefix); i++ {
+			if !slices.Contains(expanded, ix.sortedTerms[i]) {
+				expanded = append(expanded, ix.sortedTerms[i])
+			}
+		}
+	}
+
+	// a prefix without any match still has to fail the query.
+	if len(expanded) == 0 {
+		expanded = clause{word}
+	}
+
+	return expanded
+}
+
+func (ix *Index) matches(id int64, clauses []clause) bool {
+	for _, c := range clauses {
+		found := false
+		for _, term := range c {
+			if _, ok := ix.postings[term][id]; ok {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// score function calculates BM25F: term frequencies of the fields are normalized by the field lengths,
+// weighted and saturated together.
+func (ix *Index) score(id int64, clauses []clause) float64 {
+	n := float64(len(ix.docs))
+	doc := ix.docs[id]
+
+	var total float64
+	for _, c := range clauses {
+		for _, term := range c {
+			freq, ok := ix.postings[term][id]
+			if !ok {
+				continue
+			}
+
+			var weighted float64
+			for field := range freq {
+				if freq[field] == 0 {
+					continue
+				}
+
+				avgLength := float64(ix.totalLengths[field]) / n
+				norm := 1 - b + b*float64(doc.lengths[field])/avgLength
+				weighted += fieldWeights[field] * float64(freq[field]) / norm
+			}
+
+			df := float64(len(ix.postings[term]))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			total += idf * weighted / (k1 + weighted)
+		}
+	}
+
+	return total
+}
+
+// matchesFilters function reports whether the item belongs to the query owner, has any of the query categories,
+// or their descendants, and all the query tags.
+func matchesFilters(item *models.KnowledgeItem, query *appmodels.SearchKnowledgeItemsQuery) bool {
+	if item.Owner != query.Owner {
+		return false
+	}
+
+	if len(query.Categories) > 0 && !slices.ContainsFunc(item.Categories, func(c *models.Category) bool {
+		return slices.ContainsFunc(query.Categories, c.Within)
+	}) {
+		return false
+	}
+
+	for _, tag := range query.Tags {
+		if !slices.ContainsFunc(item.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// facets function counts matching items per category and tag, the most frequent values go first.
+func facets(hits []*appmodels.SearchHit) *appmodels.SearchFacets {
+	categories := make(map[string]int)
+	tags := make(map[string]int)
+	for _, hit := range hits {
+		for _, category := range hit.Item.Categories {
+			categories[category.Name]++
+		}
+		for _, tag := range hit.Item.Tags {
+			tags[tag]++
+		}
+	}
+
+	return &appmodels.SearchFacets{
+		Categories: facetCounts(categories),
+		Tags:       facetCounts(tags),
+	}
+}
+
+func facetCounts(counts map[string]int) []*appmodels.FacetCount {
+	result := make([]*appmodels.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, &appmodels.FacetCount{Value: value, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+
+		return result[i].Value < result[j].Value
+	})
+
+	return result
+}
+
+// cloneItem function copies the item, so the index never shares memory with callers.
+func cloneItem(item *models.KnowledgeItem) *models.KnowledgeItem {
+	clone := *item
+	clone.Tags = slices.Clone(item.Tags)
+
+	clone.Categories = nil
+	for _, category := range item.Categories {
+		c := *category
+		clone.Categories = append(clone.Categories, &c)
+	}
+
+	return &clone
+}
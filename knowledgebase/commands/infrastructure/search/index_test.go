@@ -0,0 +1,273 @@
+package search_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/search"
+)
+
+var (
+	golang      = &models.Category{ID: 1, Name: "Golang"}
+	databases   = &models.Category{ID: 2, Name: "Databases"}
+	concurrency = &models.Category{ID: 3, Name: "Concurrency"}
+)
+
+func testItems() []*models.KnowledgeItem {
+	return []*models.KnowledgeItem{
+		{
+			ID:         1,
+			Title:      "What is a goroutine?",
+			Anchor:     "goroutine",
+			Data:       "Lightweight thread managed by the Go runtime. Goroutines are multiplexed onto threads.",
+			Tags:       []string{"go", "concurrency"},
+			Categories: []*models.Category{golang, concurrency},
+		},
+		{
+			ID:         2,
+			Title:      "What is a channel?",
+			Anchor:     "channel",
+			Data:       "Typed conduit which connects concurrent goroutines.",
+			Tags:       []string{"go"},
+			Categories: []*models.Category{golang},
+		},
+		{
+			ID:         3,
+			Title:      "Database index",
+			Anchor:     "index",
+			Data:       "Additional structure derived from the primary data which speeds up reads.",
+			Tags:       []string{"storage"},
+			Categories: []*models.Category{databases},
+		},
+	}
+}
+
+func newTestIndex() *search.Index {
+	index := search.NewIndex()
+	for _, item := range testItems() {
+		index.Add(item)
+	}
+
+	return index
+}
+
+func hitIDs(result *appmodels.SearchResult) []int64 {
+	var ids []int64
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.Item.ID)
+	}
+
+	return ids
+}
+
+func searchIDs(t *testing.T, index *search.Index, query *appmodels.SearchKnowledgeItemsQuery) []int64 {
+	t.Helper()
+
+	result, err := index.Search(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hitIDs(result)
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestIndex_Search_Ranking(t *testing.T) {
+	index := newTestIndex()
+
+	cases := []struct {
+		query    string
+		expected []int64
+	}{
+		// the title match outweighs the data match, stemming joins "goroutines" and "goroutine".
+		{"goroutines", []int64{1, 2}},
+		{"the goroutine", []int64{1, 2}},
+		{"goroutine channel", []int64{2}},
+		{"connect", []int64{2}},
+		{"read", []int64{3}},
+		{"mutex", nil},
+		{"", []int64{1, 2, 3}},
+	}
+
+	for _, c := range cases {
+		ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: c.query})
+		if !equalIDs(ids, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.query, c.expected, ids)
+		}
+	}
+}
+
+func TestIndex_Search_Prefix(t *testing.T) {
+	index := newTestIndex()
+
+	cases := []struct {
+		query    string
+		expected []int64
+	}{
+		{"gorou*", []int64{1, 2}},
+		{"conc*", []int64{1, 2}},
+		{"databases*", []int64{3}},
+		{"xyz*", nil},
+	}
+
+	for _, c := range cases {
+		ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: c.query})
+		if !equalIDs(ids, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.query, c.expected, ids)
+		}
+	}
+}
+
+func TestIndex_Search_FiltersAndFacets(t *testing.T) {
+	index := newTestIndex()
+
+	result, err := index.Search(&appmodels.SearchKnowledgeItemsQuery{Query: "go*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 2 {
+		t.Errorf("expected 2 hits, got %d", result.Total)
+	}
+
+	var categories []string
+	for _, facet := range result.Facets.Categories {
+		categories = append(categories, fmt.Sprintf("%s:%d", facet.Value, facet.Count))
+	}
+	if strings.Join(categories, ",") != "Golang:2,Concurrency:1" {
+		t.Errorf("unexpected category facets %v", categories)
+	}
+
+	var tags []string
+	for _, facet := range result.Facets.Tags {
+		tags = append(tags, fmt.Sprintf("%s:%d", facet.Value, facet.Count))
+	}
+	if strings.Join(tags, ",") != "go:2,concurrency:1" {
+		t.Errorf("unexpected tag facets %v", tags)
+	}
+
+	ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "go*", Tags: []string{"Concurrency"}})
+	if !equalIDs(ids, []int64{1}) {
+		t.Errorf("expected tag filter to keep item 1, got %v", ids)
+	}
+
+	ids = searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Categories: []string{"Databases", "Concurrency"}})
+	if !equalIDs(ids, []int64{1, 3}) {
+		t.Errorf("expected category filter to keep items 1 and 3, got %v", ids)
+	}
+}
+
+func TestIndex_Search_Paging(t *testing.T) {
+	index := newTestIndex()
+
+	result, err := index.Search(&appmodels.SearchKnowledgeItemsQuery{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 3 || !equalIDs(hitIDs(result), []int64{2, 3}) {
+		t.Errorf("unexpected page %d %v", result.Total, hitIDs(result))
+	}
+
+	result, err = index.Search(&appmodels.SearchKnowledgeItemsQuery{Limit: 2, Offset: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 3 || len(result.Hits) != 0 {
+		t.Errorf("expected empty page, got %v", hitIDs(result))
+	}
+}
+
+func TestIndex_Search_Snippets(t *testing.T) {
+	index := search.NewIndex()
+	words := strings.Repeat("filler ", 40)
+	index.Add(&models.KnowledgeItem{
+		ID:     1,
+		Title:  "Scheduler",
+		Anchor: "scheduler",
+		Data:   words + "The scheduler parks blocked goroutines. " + words,
+		Tags:   []string{"go"},
+	})
+
+	result, err := index.Search(&appmodels.SearchKnowledgeItemsQuery{Query: "blocking goroutine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d", len(result.Hits))
+	}
+
+	snippets := result.Hits[0].Snippets
+	if len(snippets) != 1 || snippets[0].Field != appmodels.SearchFieldData {
+		t.Fatalf("expected data snippet, got %+v", snippets)
+	}
+
+	snippet := snippets[0]
+	if !strings.HasPrefix(snippet.Text, "…blocked goroutines.") || !strings.HasSuffix(snippet.Text, "…") {
+		t.Errorf("unexpected snippet %q", snippet.Text)
+	}
+
+	var highlighted []string
+	for _, h := range snippet.Highlights {
+		highlighted = append(highlighted, snippet.Text[h.Start:h.End])
+	}
+	if strings.Join(highlighted, ",") != "blocked,goroutines" {
+		t.Errorf("unexpected highlights %v", highlighted)
+	}
+}
+
+func TestIndex_AddAndRemove(t *testing.T) {
+	index := newTestIndex()
+
+	updated := testItems()[2]
+	updated.Title = "B-tree"
+	updated.Data = "Balanced tree which keeps data sorted."
+	index.Add(updated)
+
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "reads"}); len(ids) != 0 {
+		t.Errorf("expected old content to be dropped, got %v", ids)
+	}
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "balanced"}); !equalIDs(ids, []int64{3}) {
+		t.Errorf("expected new content to be found, got %v", ids)
+	}
+
+	index.Remove(3)
+	index.Remove(100)
+
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "bal*"}); len(ids) != 0 {
+		t.Errorf("expected removed item not to be found, got %v", ids)
+	}
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{}); !equalIDs(ids, []int64{1, 2}) {
+		t.Errorf("unexpected items %v", ids)
+	}
+}
+
+func TestIndex_Search_Owner(t *testing.T) {
+	index := newTestIndex()
+	index.Add(&models.KnowledgeItem{ID: 4, Owner: "bob", Title: "Goroutine leak", Data: "Goroutine which never exits."})
+
+	ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Owner: "bob", Query: "goroutine"})
+	if !equalIDs(ids, []int64{4}) {
+		t.Errorf("expected only items of the owner, got %v", ids)
+	}
+
+	ids = searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Owner: "carol", Query: "goroutine"})
+	if len(ids) != 0 {
+		t.Errorf("expected nothing for other owner, got %v", ids)
+	}
+}
@@ -0,0 +1,98 @@
+package search_test
+
+import (
+	"errors"
+	"testing"
+
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/search"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKnowledgeItemsRepo_KeepsIndexUpToDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := search.NewIndex()
+	items := testItems()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+	repo.EXPECT().CreateBatch(gomock.Any()).Return([]int64{2, 3}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(gomock.Any()).Return(nil)
+
+	indexed := search.NewKnowledgeItemsRepo(repo, index)
+
+	for _, item := range items {
+		item.ID = 0
+	}
+
+	if _, err := indexed.Create(items[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := indexed.CreateBatch(items[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{}); !equalIDs(ids, []int64{1, 2, 3}) {
+		t.Errorf("expected created items to be indexed, got %v", ids)
+	}
+
+	items[1].ID = 2
+	items[1].Data = "Typed conduit, unbuffered by default."
+	if err := indexed.Save(items[1]); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "unbuffered"}); !equalIDs(ids, []int64{2}) {
+		t.Errorf("expected saved item to be reindexed, got %v", ids)
+	}
+
+	if err := indexed.Delete(&models.KnowledgeItem{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{}); !equalIDs(ids, []int64{2, 3}) {
+		t.Errorf("expected deleted item to be dropped, got %v", ids)
+	}
+}
+
+func TestKnowledgeItemsRepo_StorageError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("expected error")
+	index := newTestIndex()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Delete(gomock.Any()).Return(expectedError)
+
+	err := search.NewKnowledgeItemsRepo(repo, index).Delete(&models.KnowledgeItem{ID: 1})
+	if !errors.Is(err, expectedError) {
+		t.Errorf("expected error %s, got %s", expectedError, err)
+	}
+
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{Query: "goroutine"}); len(ids) == 0 {
+		t.Error("expected item to stay indexed when storage fails")
+	}
+}
+
+func TestIndex_Reindex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := search.NewIndex()
+	index.Add(&models.KnowledgeItem{ID: 10, Title: "Stale item"})
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().FindAll().Return(testItems(), nil)
+
+	if err := index.Reindex(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := searchIDs(t, index, &appmodels.SearchKnowledgeItemsQuery{}); !equalIDs(ids, []int64{1, 2, 3}) {
+		t.Errorf("expected index to be replaced, got %v", ids)
+	}
+}
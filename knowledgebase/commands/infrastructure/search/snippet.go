@@ -0,0 +1,95 @@
+package search
+
+import (
+	appmodels "github.com/96solutions/neurography/knowledgebase/commands/application/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+)
+
+const (
+	snippetWords    = 30
+	snippetEllipsis = "…"
+)
+
+// snippets function builds snippet for every field of the item containing matched terms.
+// Short fields are returned whole, the data is cut to the window of words with the most matches.
+func snippets(item *models.KnowledgeItem, matched map[string]bool) []*appmodels.SearchSnippet {
+	var result []*appmodels.SearchSnippet
+	for field, text := range fieldTexts(item) {
+		tokens := tokenize(text)
+
+		var positions []int
+		for i, t := range tokens {
+			if t.term != "" && matched[t.term] {
+				positions = append(positions, i)
+			}
+		}
+		if len(positions) == 0 {
+			continue
+		}
+
+		first, last := 0, len(tokens)-1
+		if field == fieldData {
+			first, last = bestWindow(positions, len(tokens))
+		}
+
+		snippet := &appmodels.SearchSnippet{Field: fieldNames[field]}
+
+		start, end := 0, len(text)
+		if first > 0 {
+			start = tokens[first].start
+			snippet.Text = snippetEllipsis
+		}
+		if last < len(tokens)-1 {
+			end = tokens[last].end
+		}
+
+		shift := len(snippet.Text) - start
+		snippet.Text += text[start:end]
+		if end < len(text) {
+			snippet.Text += snippetEllipsis
+		}
+
+		for _, i := range positions {
+			if i < first || i > last {
+				continue
+			}
+
+			snippet.Highlights = append(snippet.Highlights, &appmodels.TextRange{
+				Start: tokens[i].start + shift,
+				End:   tokens[i].end + shift,
+			})
+		}
+
+		result = append(result, snippet)
+	}
+
+	return result
+}
+
+// bestWindow function finds the window of snippetWords tokens which contains the most matched positions.
+// The window starts at the first matched position of the best group, so the snippet begins with a match.
+func bestWindow(positions []int, count int) (int, int) {
+	bestFirst, bestMatches := positions[0], 0
+	for i, first := range positions {
+		matches := 0
+		for _, p := range positions[i:] {
+			if p >= first+snippetWords {
+				break
+			}
+			matches++
+		}
+
+		if matches > bestMatches {
+			bestFirst, bestMatches = first, matches
+		}
+	}
+
+	// short texts are shown whole instead of being cut at the first match.
+	if count <= snippetWords {
+		return 0, count - 1
+	}
+
+	first := min(bestFirst, count-snippetWords)
+
+	return first, first + snippetWords - 1
+}
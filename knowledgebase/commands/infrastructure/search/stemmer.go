@@ -0,0 +1,287 @@
+package search
+
+// stem function reduces the lower case English word to its stem with the Porter algorithm.
+// Words with characters other than latin letters and short words are returned as is.
+func stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word), k: len(word) - 1}
+	s.step1ab()
+	if s.k > 0 {
+		s.step1c()
+		s.step2()
+		s.step3()
+		s.step4()
+		s.step5()
+	}
+
+	return string(s.b[:s.k+1])
+}
+
+// stemmer keeps the word being stemmed in b[0..k], j marks the end of the stem before the matched suffix.
+type stemmer struct {
+	b []byte
+	k int
+	j int
+}
+
+// cons function reports whether b[i] is a consonant.
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	default:
+		return true
+	}
+}
+
+// m function measures the number of consonant sequences between 0 and j.
+// With c for consonants and v for vowels any word is [C](VC){m}[V].
+func (s *stemmer) m() int {
+	n, i := 0, 0
+	for ; ; i++ {
+		if i > s.j {
+			return n
+		}
+		if !s.cons(i) {
+			break
+		}
+	}
+	i++
+
+	for {
+		for ; ; i++ {
+			if i > s.j {
+				return n
+			}
+			if s.cons(i) {
+				break
+			}
+		}
+		i++
+		n++
+
+		for ; ; i++ {
+			if i > s.j {
+				return n
+			}
+			if !s.cons(i) {
+				break
+			}
+		}
+		i++
+	}
+}
+
+// vowelInStem function reports whether 0..j contains a vowel.
+func (s *stemmer) vowelInStem() bool {
+	for i := 0; i <= s.j; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// doubleCons function reports whether j-1, j contain a double consonant.
+func (s *stemmer) doubleCons(j int) bool {
+	return j >= 1 && s.b[j] == s.b[j-1] && s.cons(j)
+}
+
+// cvc function reports whether i-2, i-1, i is consonant - vowel - consonant
+// and the last consonant isn't w, x or y. It restores an e at the end of short words like hop(e), cav(e).
+func (s *stemmer) cvc(i int) bool {
+	if i < 2 || !s.cons(i) || s.cons(i-1) || !s.cons(i-2) {
+		return false
+	}
+
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+
+	return true
+}
+
+// ends function reports whether 0..k ends with the suffix and moves j before it.
+func (s *stemmer) ends(suffix string) bool {
+	l := len(suffix)
+	if l > s.k+1 || string(s.b[s.k+1-l:s.k+1]) != suffix {
+		return false
+	}
+
+	s.j = s.k - l
+
+	return true
+}
+
+// setTo function replaces j+1..k with the value.
+func (s *stemmer) setTo(value string) {
+	s.b = append(s.b[:s.j+1], value...)
+	s.k = s.j + len(value)
+}
+
+// replace function replaces the matched suffix when the stem has at least one consonant sequence.
+func (s *stemmer) replace(value string) {
+	if s.m() > 0 {
+		s.setTo(value)
+	}
+}
+
+// step1ab function removes plurals, -ed and -ing.
+func (s *stemmer) step1ab() {
+	if s.b[s.k] == 's' {
+		switch {
+		case s.ends("sses"):
+			s.k -= 2
+		case s.ends("ies"):
+			s.setTo("i")
+		case s.b[s.k-1] != 's':
+			s.k--
+		}
+	}
+
+	if s.ends("eed") {
+		if s.m() > 0 {
+			s.k--
+		}
+		return
+	}
+
+	if (s.ends("ed") || s.ends("ing")) && s.vowelInStem() {
+		s.k = s.j
+
+		switch {
+		case s.ends("at"):
+			s.setTo("ate")
+		case s.ends("bl"):
+			s.setTo("ble")
+		case s.ends("iz"):
+			s.setTo("ize")
+		case s.doubleCons(s.k):
+			s.k--
+			switch s.b[s.k] {
+			case 'l', 's', 'z':
+				s.k++
+			}
+		default:
+			s.j = s.k
+			if s.m() == 1 && s.cvc(s.k) {
+				s.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c function turns terminal y to i when there is another vowel in the stem.
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.vowelInStem() {
+		s.b[s.k] = 'i'
+	}
+}
+
+// step2 function maps double suffixes to single ones, like -ization to -ize.
+func (s *stemmer) step2() {
+	s.replaceFirst(step2Suffixes[s.b[s.k-1]])
+}
+
+// step3 function deals with -ic-, -full, -ness etc.
+func (s *stemmer) step3() {
+	s.replaceFirst(step3Suffixes[s.b[s.k]])
+}
+
+// replaceFirst function replaces the first matching suffix of the list, the list is ordered by priority.
+func (s *stemmer) replaceFirst(suffixes [][2]string) {
+	for _, suffix := range suffixes {
+		if s.ends(suffix[0]) {
+			s.replace(suffix[1])
+			return
+		}
+	}
+}
+
+// step4 function takes off -ant, -ence etc. in context <c>vcvc<v>.
+func (s *stemmer) step4() {
+	if s.k < 1 {
+		return
+	}
+
+	matched := false
+	for _, suffix := range step4Suffixes[s.b[s.k-1]] {
+		if !s.ends(suffix) {
+			continue
+		}
+
+		if suffix == "ion" && (s.j < 0 || (s.b[s.j] != 's' && s.b[s.j] != 't')) {
+			continue
+		}
+
+		matched = true
+		break
+	}
+
+	if matched && s.m() > 1 {
+		s.k = s.j
+	}
+}
+
+// step5 function removes final -e and changes -ll to -l when the stem is long enough.
+func (s *stemmer) step5() {
+	s.j = s.k
+	if s.b[s.k] == 'e' {
+		a := s.m()
+		if a > 1 || (a == 1 && !s.cvc(s.k-1)) {
+			s.k--
+		}
+	}
+
+	if s.b[s.k] == 'l' && s.doubleCons(s.k) && s.m() > 1 {
+		s.k--
+	}
+}
+
+// step2Suffixes are keyed by the penultimate letter of the word.
+var step2Suffixes = map[byte][][2]string{
+	'a': {{"ational", "ate"}, {"tional", "tion"}},
+	'c': {{"enci", "ence"}, {"anci", "ance"}},
+	'e': {{"izer", "ize"}},
+	'l': {{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}},
+	'o': {{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}},
+	's': {{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"}},
+	't': {{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}},
+	'g': {{"logi", "log"}},
+}
+
+// step3Suffixes are keyed by the last letter of the word.
+var step3Suffixes = map[byte][][2]string{
+	'e': {{"icate", "ic"}, {"ative", ""}, {"alize", "al"}},
+	'i': {{"iciti", "ic"}},
+	'l': {{"ical", "ic"}, {"ful", ""}},
+	's': {{"ness", ""}},
+}
+
+// step4Suffixes are keyed by the penultimate letter of the word.
+var step4Suffixes = map[byte][]string{
+	'a': {"al"},
+	'c': {"ance", "ence"},
+	'e': {"er"},
+	'i': {"ic"},
+	'l': {"able", "ible"},
+	'n': {"ant", "ement", "ment", "ent"},
+	'o': {"ion", "ou"},
+	's': {"ism"},
+	't': {"ate", "iti"},
+	'u': {"ous"},
+	'v': {"ive"},
+	'z': {"ize"},
+}
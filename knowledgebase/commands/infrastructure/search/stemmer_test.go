@@ -0,0 +1,78 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	// examples from the description of the Porter algorithm.
+	cases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"troubled":       "troubl",
+		"sized":          "size",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"generalization": "gener",
+		"connections":    "connect",
+		"connected":      "connect",
+		"electrical":     "electr",
+		"hopefulness":    "hope",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controlling":    "control",
+		"goroutines":     "goroutin",
+		"go":             "go",
+		"naïve":          "naïve",
+	}
+
+	for word, expected := range cases {
+		if actual := stem(word); actual != expected {
+			t.Errorf("%s: expected %q, got %q", word, expected, actual)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	text := "The Scheduler runs goroutines, 2 at a time!"
+	tokens := tokenize(text)
+
+	expected := []struct {
+		word string
+		term string
+	}{
+		{"the", ""},
+		{"scheduler", "schedul"},
+		{"runs", "run"},
+		{"goroutines", "goroutin"},
+		{"2", "2"},
+		{"at", ""},
+		{"a", ""},
+		{"time", "time"},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, ex := range expected {
+		tok := tokens[i]
+		if tok.word != ex.word || tok.term != ex.term {
+			t.Errorf("token %d: expected %+v, got %+v", i, ex, tok)
+		}
+		if !strings.EqualFold(text[tok.start:tok.end], ex.word) {
+			t.Errorf("token %d: unexpected offsets %d-%d", i, tok.start, tok.end)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package search contains embedded full-text index of the knowledge items.
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopWords are frequent English words which don't help ranking, they are not indexed.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "if": true, "in": true, "into": true, "is": true, "it": true, "no": true,
+	"not": true, "of": true, "on": true, "or": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true, "this": true, "to": true,
+	"was": true, "will": true, "with": true,
+}
+
+// token represents one word of the text.
+// Start and end are byte offsets of the word, term is its stem or empty for stop words.
+type token struct {
+	start int
+	end   int
+	word  string
+	term  string
+}
+
+// tokenize function splits text into words of letters and digits.
+func tokenize(text string) []*token {
+	var tokens []*token
+
+	start := -1
+	for i := 0; i <= len(text); {
+		r, size := utf8.RuneError, 1
+		if i < len(text) {
+			r, size = utf8.DecodeRuneInString(text[i:])
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			tokens = append(tokens, newToken(text, start, i))
+			start = -1
+		}
+
+		i += size
+	}
+
+	return tokens
+}
+
+func newToken(text string, start, end int) *token {
+	word := strings.ToLower(text[start:end])
+
+	t := &token{start: start, end: end, word: word}
+	if !stopWords[word] {
+		t.term = stem(word)
+	}
+
+	return t
+}
+
+// terms function returns indexed terms of the text.
+func terms(text string) []string {
+	var result []string
+	for _, t := range tokenize(text) {
+		if t.term != "" {
+			result = append(result, t.term)
+		}
+	}
+
+	return result
+}
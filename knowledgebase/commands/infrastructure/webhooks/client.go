@@ -0,0 +1,48 @@
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/netguard"
+)
+
+// defaultTimeout limits every delivery attempt, receivers are expected to answer at once and process later.
+const defaultTimeout = 10 * time.Second
+
+// NewClient function builds the HTTP client delivering the webhooks with the default timeout.
+// Its dialer checks every address it connects to with the guard, so the receiver can't be moved
+// to the internal network after its URL was checked, neither by DNS nor by redirects.
+// Proxies aren't used, since they would connect on behalf of the client.
+func NewClient(guard *netguard.Guard) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: defaultTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !guard.Allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", services.ErrHostBlocked, address)
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: defaultTimeout,
+		},
+		Timeout: defaultTimeout,
+	}
+}
@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// KnowledgeItemsRepo type decorates repositories.KnowledgeItemsRepo and publishes the webhook events
+// for every item created, saved or deleted through it. Reading methods are passed to the decorated repository.
+// The change is kept when the event can't be published, the error is returned though.
+type KnowledgeItemsRepo struct {
+	repositories.KnowledgeItemsRepo
+	service services.WebhookService
+}
+
+// NewKnowledgeItemsRepo function builds new instance of KnowledgeItemsRepo.
+func NewKnowledgeItemsRepo(repo repositories.KnowledgeItemsRepo, service services.WebhookService) *KnowledgeItemsRepo {
+	return &KnowledgeItemsRepo{
+		KnowledgeItemsRepo: repo,
+		service:            service,
+	}
+}
+
+// Create function stores the item and publishes models.EventItemCreated.
+func (r *KnowledgeItemsRepo) Create(item *models.KnowledgeItem) (int64, error) {
+	id, err := r.KnowledgeItemsRepo.Create(item)
+	if err != nil {
+		return 0, err
+	}
+
+	created := *item
+	created.ID = id
+
+	return id, r.publish(models.EventItemCreated, &created)
+}
+
+// CreateBatch function stores the items and publishes models.EventItemCreated for each of them.
+func (r *KnowledgeItemsRepo) CreateBatch(items []*models.KnowledgeItem) ([]int64, error) {
+	ids, err := r.KnowledgeItemsRepo.CreateBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		created := *item
+		created.ID = ids[i]
+		if err = r.publish(models.EventItemCreated, &created); err != nil {
+			return ids, err
+		}
+	}
+
+	return ids, nil
+}
+
+// Save function stores the item and publishes models.EventItemUpdated.
+func (r *KnowledgeItemsRepo) Save(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Save(item); err != nil {
+		return err
+	}
+
+	return r.publish(models.EventItemUpdated, item)
+}
+
+// Delete function deletes the item and publishes models.EventItemDeleted.
+func (r *KnowledgeItemsRepo) Delete(item *models.KnowledgeItem) error {
+	if err := r.KnowledgeItemsRepo.Delete(item); err != nil {
+		return err
+	}
+
+	return r.publish(models.EventItemDeleted, item)
+}
+
+func (r *KnowledgeItemsRepo) publish(event string, item *models.KnowledgeItem) error {
+	return r.service.Publish(&models.WebhookEvent{
+		Event: event,
+		Owner: item.Owner,
+		Item:  item,
+	})
+}
@@ -0,0 +1,71 @@
+package webhooks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/webhooks"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKnowledgeItemsRepo_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Create(gomock.Any()).Return(int64(1), nil)
+	repo.EXPECT().CreateBatch(gomock.Any()).Return([]int64{2, 3}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(gomock.Any()).Return(nil)
+
+	var published []string
+	service := mock.NewMockWebhookService(ctrl)
+	service.EXPECT().Publish(gomock.Any()).DoAndReturn(func(event *models.WebhookEvent) error {
+		if event.Owner != "alice" || event.Item.ID == 0 {
+			t.Errorf("expected the stored item of alice, got %+v", event)
+		}
+		published = append(published, event.Event)
+		return nil
+	}).Times(5)
+
+	items := webhooks.NewKnowledgeItemsRepo(repo, service)
+
+	if _, err := items.Create(&models.KnowledgeItem{Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := items.CreateBatch([]*models.KnowledgeItem{{Owner: "alice"}, {Owner: "alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := items.Save(&models.KnowledgeItem{ID: 1, Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := items.Delete(&models.KnowledgeItem{ID: 1, Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		models.EventItemCreated, models.EventItemCreated, models.EventItemCreated,
+		models.EventItemUpdated, models.EventItemDeleted,
+	}
+	for i, event := range expected {
+		if published[i] != event {
+			t.Errorf("expected %v, got %v", expected, published)
+		}
+	}
+}
+
+func TestKnowledgeItemsRepo_FailedChangeIsNotPublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	repo.EXPECT().Save(gomock.Any()).Return(errors.New("disk full"))
+
+	items := webhooks.NewKnowledgeItemsRepo(repo, mock.NewMockWebhookService(ctrl))
+
+	if err := items.Save(&models.KnowledgeItem{ID: 1, Owner: "alice"}); err == nil {
+		t.Error("expected the error of the storage")
+	}
+}
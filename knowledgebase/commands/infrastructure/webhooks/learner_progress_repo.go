@@ -0,0 +1,59 @@
+package webhooks
+
+import (
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/repositories"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+)
+
+// LearnerProgressRepo type decorates repositories.LearnerProgressRepo and publishes models.EventItemReviewed
+// to the owner of the item every time the progress is saved with one more review.
+// Other methods are passed to the decorated repository.
+type LearnerProgressRepo struct {
+	repositories.LearnerProgressRepo
+	itemsRepo repositories.KnowledgeItemsRepo
+	service   services.WebhookService
+}
+
+// NewLearnerProgressRepo function builds new instance of LearnerProgressRepo.
+func NewLearnerProgressRepo(
+	repo repositories.LearnerProgressRepo,
+	itemsRepo repositories.KnowledgeItemsRepo,
+	service services.WebhookService,
+) *LearnerProgressRepo {
+	return &LearnerProgressRepo{
+		LearnerProgressRepo: repo,
+		itemsRepo:           itemsRepo,
+		service:             service,
+	}
+}
+
+// Save function stores the progress and publishes models.EventItemReviewed when the item was reviewed.
+func (r *LearnerProgressRepo) Save(progress *models.LearnerProgress) error {
+	previous, err := r.LearnerProgressRepo.Find(progress.Learner, progress.ItemID)
+	if err != nil {
+		return err
+	}
+
+	if err = r.LearnerProgressRepo.Save(progress); err != nil {
+		return err
+	}
+
+	if previous != nil && previous.Reviews >= progress.Reviews {
+		return nil
+	}
+
+	item, err := r.itemsRepo.FindByID(progress.ItemID)
+	if err != nil {
+		return err
+	}
+
+	reviewed := *progress
+
+	return r.service.Publish(&models.WebhookEvent{
+		Event:    models.EventItemReviewed,
+		Owner:    item.Owner,
+		Item:     item,
+		Progress: &reviewed,
+	})
+}
@@ -0,0 +1,48 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/webhooks"
+	"github.com/96solutions/neurography/knowledgebase/commands/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLearnerProgressRepo_PublishesReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	item := &models.KnowledgeItem{ID: 7, Owner: "workspace:5"}
+
+	repo := mock.NewMockLearnerProgressRepo(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().Find("bob", int64(7)).Return(nil, nil),
+		repo.EXPECT().Save(gomock.Any()).Return(nil),
+		repo.EXPECT().Find("bob", int64(7)).Return(&models.LearnerProgress{ItemID: 7, Learner: "bob", Reviews: 1}, nil),
+		repo.EXPECT().Save(gomock.Any()).Return(nil),
+	)
+
+	itemsRepo := mock.NewMockKnowledgeItemsRepo(ctrl)
+	itemsRepo.EXPECT().FindByID(int64(7)).Return(item, nil)
+
+	service := mock.NewMockWebhookService(ctrl)
+	service.EXPECT().Publish(gomock.Any()).DoAndReturn(func(event *models.WebhookEvent) error {
+		if event.Event != models.EventItemReviewed || event.Owner != "workspace:5" ||
+			event.Item != item || event.Progress.Learner != "bob" {
+			t.Errorf("expected review of bob published to the workspace, got %+v", event)
+		}
+		return nil
+	})
+
+	progress := webhooks.NewLearnerProgressRepo(repo, itemsRepo, service)
+
+	if err := progress.Save(&models.LearnerProgress{ItemID: 7, Learner: "bob", Reviews: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// saving the progress without new review, e.g. on reset of the score, publishes nothing.
+	if err := progress.Save(&models.LearnerProgress{ItemID: 7, Learner: "bob", Reviews: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,41 @@
+// Package webhooks contains the delivery of the knowledge base events to the webhook endpoints.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of the webhook requests.
+const (
+	HeaderEvent     = "X-Neurography-Event"
+	HeaderDelivery  = "X-Neurography-Delivery"
+	HeaderTimestamp = "X-Neurography-Timestamp"
+	HeaderSignature = "X-Neurography-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign function returns the signature of the request body sent at the unix timestamp.
+// It is HMAC-SHA256 of the timestamp, a dot and the body keyed with the webhook secret,
+// so receivers can reject replayed requests by the timestamp they can trust.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify function reports whether the signature of the request body is valid,
+// timestamp is the value of the HeaderTimestamp header.
+func Verify(secret, timestamp, signature string, body []byte) bool {
+	at, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, at, body)), []byte(signature))
+}
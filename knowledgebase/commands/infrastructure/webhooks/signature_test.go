@@ -0,0 +1,31 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/webhooks"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"item.created"}`)
+
+	signature := webhooks.Sign("whs_secret", 1717243200, body)
+	if signature != webhooks.Sign("whs_secret", 1717243200, body) {
+		t.Fatal("expected the same signature for the same input")
+	}
+
+	if !webhooks.Verify("whs_secret", "1717243200", signature, body) {
+		t.Errorf("expected valid signature %s", signature)
+	}
+
+	for name, valid := range map[string]bool{
+		"other secret":    webhooks.Verify("whs_other", "1717243200", signature, body),
+		"other timestamp": webhooks.Verify("whs_secret", "1717243201", signature, body),
+		"other body":      webhooks.Verify("whs_secret", "1717243200", signature, []byte(`{}`)),
+		"bad timestamp":   webhooks.Verify("whs_secret", "now", signature, body),
+	} {
+		if valid {
+			t.Errorf("expected invalid signature with %s", name)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/netguard"
+)
+
+// Worker type sends the due webhook deliveries in background and records the outcome of every attempt,
+// so failed deliveries are retried with exponential backoff by services.WebhookService.
+type Worker struct {
+	service services.WebhookService
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewWorker function builds new instance of Worker. Nil client uses NewClient with netguard.DefaultGuard(),
+// the client without timeout is copied with the default one, so a stalled receiver can't stop the worker.
+// Nil now uses time.Now.
+func NewWorker(service services.WebhookService, client *http.Client, now func() time.Time) *Worker {
+	if client == nil {
+		client = NewClient(netguard.DefaultGuard())
+	}
+	if client.Timeout <= 0 {
+		withTimeout := *client
+		withTimeout.Timeout = defaultTimeout
+		client = &withTimeout
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Worker{
+		service: service,
+		client:  client,
+		now:     now,
+	}
+}
+
+// Run function delivers the due deliveries every interval until the context is cancelled.
+// Errors which don't stop the worker are passed to onError which may be nil.
+func (w *Worker) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.DeliverDue(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue function sends every delivery which is due now once.
+func (w *Worker) DeliverDue(ctx context.Context) error {
+	due, err := w.service.DueDeliveries(w.now())
+	if err != nil {
+		return err
+	}
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		status, failure := w.send(ctx, d.Webhook, d.Delivery)
+		if err = w.service.RecordAttempt(d.Delivery, status, failure, w.now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send function posts the signed payload and returns the response status and the failure if any.
+func (w *Worker) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := w.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// the body is drained so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
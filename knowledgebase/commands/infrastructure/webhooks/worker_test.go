@@ -0,0 +1,284 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/models"
+	"github.com/96solutions/neurography/knowledgebase/commands/domain/services"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/filesystem"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/netguard"
+	"github.com/96solutions/neurography/knowledgebase/commands/infrastructure/webhooks"
+)
+
+// receiver type is the local endpoint which verifies the signatures of the received requests.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	requests []*models.WebhookEvent
+	invalid  int
+}
+
+func (rv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if !webhooks.Verify(rv.secret, r.Header.Get(webhooks.HeaderTimestamp), r.Header.Get(webhooks.HeaderSignature), body) {
+		rv.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var event models.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Event != r.Header.Get(webhooks.HeaderEvent) {
+		rv.invalid++
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rv.requests = append(rv.requests, &event)
+	w.WriteHeader(rv.status)
+}
+
+// loopback allows the local receivers, the webhooks can't reach them by default.
+var loopback = netguard.NewGuard(nil, netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128"))
+
+func newService(t *testing.T) services.WebhookService {
+	t.Helper()
+
+	store, err := filesystem.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return services.NewWebhookService(store.WebhooksRepo(), store.WebhookDeliveriesRepo(), loopback)
+}
+
+func TestWorker_DeliverDue(t *testing.T) {
+	service := newService(t)
+	rv := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(rv)
+	defer server.Close()
+
+	webhook, secret, err := service.NewWebhook("alice", "alice", server.URL, []string{models.EventItemCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv.secret = secret
+
+	for _, event := range []string{models.EventItemCreated, models.EventItemDeleted} {
+		err = service.Publish(&models.WebhookEvent{Event: event, Owner: "alice", Item: &models.KnowledgeItem{ID: 7}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	worker := webhooks.NewWorker(service, webhooks.NewClient(loopback), nil)
+	if err = worker.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if rv.invalid != 0 || len(rv.requests) != 1 || rv.requests[0].Item.ID != 7 {
+		t.Fatalf("expected one signed item.created request, got %+v and %d invalid", rv.requests, rv.invalid)
+	}
+
+	deliveries, err := service.ListDeliveries("alice", webhook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != models.DeliveryDelivered ||
+		deliveries[0].ResponseStatus != http.StatusNoContent || deliveries[0].Attempts != 1 {
+		t.Fatalf("expected delivered delivery, got %+v", deliveries)
+	}
+
+	replay, err := service.ReplayDelivery("alice", deliveries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = worker.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rv.requests) != 2 {
+		t.Errorf("expected replayed delivery %d to be sent, got %d requests", replay.ID, len(rv.requests))
+	}
+}
+
+func TestWorker_RetriesAndDisables(t *testing.T) {
+	service := newService(t)
+	rv := &receiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(rv)
+	defer server.Close()
+
+	webhook, secret, err := service.NewWebhook("alice", "alice", server.URL, []string{models.EventItemUpdated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv.secret = secret
+
+	for i := 0; i < 2; i++ {
+		event := &models.WebhookEvent{Event: models.EventItemUpdated, Owner: "alice", Item: &models.KnowledgeItem{ID: 7}}
+		if err = service.Publish(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock := time.Now()
+	worker := webhooks.NewWorker(service, webhooks.NewClient(loopback), func() time.Time { return clock })
+
+	var delays []time.Duration
+	for attempt := 1; attempt <= 5; attempt++ {
+		if err = worker.DeliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		deliveries, err := service.ListDeliveries("alice", webhook.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deliveries[0].Attempts != attempt || deliveries[0].LastError != "endpoint responded 500" {
+			t.Fatalf("expected failed attempt %d, got %+v", attempt, deliveries[0])
+		}
+
+		if deliveries[0].NextAttemptAt == nil {
+			break
+		}
+
+		// nothing is sent again before the backoff passes.
+		sent := len(rv.requests)
+		if err = worker.DeliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if len(rv.requests) != sent {
+			t.Fatalf("expected no requests before the retry, got %d", len(rv.requests)-sent)
+		}
+
+		delays = append(delays, deliveries[0].NextAttemptAt.Sub(clock))
+		clock = *deliveries[0].NextAttemptAt
+	}
+
+	for i := 1; i < len(delays); i++ {
+		if delays[i] != 2*delays[i-1] {
+			t.Errorf("expected exponential backoff, got %v", delays)
+		}
+	}
+
+	list, err := service.ListWebhooks("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !list[0].Disabled() || list[0].Failures != 10 {
+		t.Fatalf("expected the webhook to be disabled after 10 failures, got %+v", list[0])
+	}
+
+	sent := len(rv.requests)
+	clock = clock.Add(24 * time.Hour)
+	if err = worker.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rv.requests) != sent {
+		t.Errorf("expected no requests to the disabled webhook, got %d", len(rv.requests)-sent)
+	}
+
+	rv.status = http.StatusOK
+	if _, err = service.EnableWebhook("alice", webhook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = worker.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := service.ListDeliveries("alice", webhook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, delivery := range deliveries {
+		if delivery.Status != models.DeliveryDelivered {
+			t.Errorf("expected delivery %d after enabling, got %s", delivery.ID, delivery.Status)
+		}
+	}
+}
+
+func TestWorker_Run(t *testing.T) {
+	service := newService(t)
+	rv := &receiver{status: http.StatusOK}
+	server := httptest.NewServer(rv)
+	defer server.Close()
+
+	_, secret, err := service.NewWebhook("alice", "alice", server.URL, []string{models.EventItemCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv.secret = secret
+
+	event := &models.WebhookEvent{Event: models.EventItemCreated, Owner: "alice", Item: &models.KnowledgeItem{ID: 7}}
+	if err = service.Publish(event); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- webhooks.NewWorker(service, webhooks.NewClient(loopback), nil).Run(ctx, 10*time.Millisecond, nil)
+	}()
+
+	for {
+		rv.mu.Lock()
+		received := len(rv.requests)
+		rv.mu.Unlock()
+
+		if received == 1 {
+			cancel()
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("expected the worker to deliver the event")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorker_DefaultClientBlocksInternalReceivers(t *testing.T) {
+	service := newService(t)
+	rv := &receiver{status: http.StatusOK}
+	server := httptest.NewServer(rv)
+	defer server.Close()
+
+	webhook, _, err := service.NewWebhook("alice", "alice", server.URL, []string{models.EventItemCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := &models.WebhookEvent{Event: models.EventItemCreated, Owner: "alice", Item: &models.KnowledgeItem{ID: 7}}
+	if err = service.Publish(event); err != nil {
+		t.Fatal(err)
+	}
+
+	// the URL was allowed on creation, the client still refuses to connect to the loopback address.
+	if err = webhooks.NewWorker(service, nil, nil).DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := service.ListDeliveries("alice", webhook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rv.requests) != 0 || deliveries[0].Attempts != 1 || !strings.Contains(deliveries[0].LastError, "not allowed") {
+		t.Errorf("expected the delivery to be refused, got %d requests and %+v", len(rv.requests), deliveries[0])
+	}
+}